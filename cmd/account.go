@@ -1,17 +1,30 @@
 package cmd
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/jonandersen/public-cli/internal/analytics"
 	"github.com/jonandersen/public-cli/internal/api"
 	"github.com/jonandersen/public-cli/internal/config"
 	"github.com/jonandersen/public-cli/internal/keyring"
+	"github.com/jonandersen/public-cli/internal/money"
 	"github.com/jonandersen/public-cli/internal/output"
 	"github.com/jonandersen/public-cli/pkg/publicapi"
 )
@@ -21,8 +34,133 @@ type accountOptions struct {
 	baseURL          string
 	authToken        string
 	jsonMode         bool
+	wide             bool // add price, cost, and allocation columns to the portfolio table
 	defaultAccountID string
 	tokenRefresher   api.TokenRefresher
+	fxRateURL        string // fx_rate_url from config; empty uses the default source
+	fxCachePath      string // where fetched exchange rates are cached; empty disables caching
+}
+
+// stdinAccount is the --account value that reads the account ID from stdin.
+const stdinAccount = "-"
+
+// resolveAccountID returns the account a command acts on: the --account flag
+// value, or defaultID when the flag is empty. With --account - the ID is read
+// from the first line of in, so an earlier command in a pipeline can pick the
+// account, e.g. pub account --json | jq -r '.[0]."Account ID"' | pub history --account -.
+func resolveAccountID(flagValue, defaultID string, in io.Reader) (string, error) {
+	if flagValue == "" {
+		return defaultID, nil
+	}
+	if flagValue != stdinAccount {
+		return flagValue, nil
+	}
+
+	line, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("failed to read account ID from stdin: %w", err)
+	}
+	accountID := strings.TrimSpace(line)
+	if accountID == "" {
+		return "", fmt.Errorf("--account -: no account ID on stdin")
+	}
+	if !uuidRegex.MatchString(accountID) {
+		return "", fmt.Errorf("--account -: %q from stdin is not an account UUID", accountID)
+	}
+	return accountID, nil
+}
+
+// portfolioParams holds display options for the portfolio command.
+type portfolioParams struct {
+	only          string
+	groupBy       string
+	refreshQuotes bool
+	watch         bool
+	interval      time.Duration
+	logCSV        string  // append a value row to this file on each fetch
+	minValue      float64 // hide positions worth less than this from the table
+	saveSnapshot  bool    // write positions to a snapshot file after fetching
+	diff          string  // snapshot to compare against; diffLatest picks the newest
+	sort          string  // one of publicapi.PositionSortKeys; empty keeps API order
+	desc          bool
+	benchmark     string // symbol to compare the portfolio's day change against
+	// includePending joins open orders to positions to project exposure.
+	includePending bool
+	summary        bool   // add a portfolioSummary footer
+	currency       string // also show values converted from USD to this currency
+}
+
+// addPortfolioFlags registers the display flags shared by the portfolio command builders.
+func addPortfolioFlags(cmd *cobra.Command, params *portfolioParams) {
+	cmd.Flags().StringVar(&params.only, "only", "", "Filter JSON output to one section: buying-power, positions, equity")
+	cmd.Flags().StringVar(&params.groupBy, "group-by", "", "Group positions: underlying")
+	cmd.Flags().BoolVar(&params.refreshQuotes, "refresh-quotes", false, "Revalue positions using live quotes")
+	cmd.Flags().BoolVar(&params.watch, "watch", false, "Refresh the portfolio every --interval until interrupted")
+	cmd.Flags().DurationVar(&params.interval, "interval", time.Minute, "Refresh interval for --watch")
+	cmd.Flags().StringVar(&params.logCSV, "log-csv", "", "Append a timestamped value row to this CSV file on each refresh")
+	cmd.Flags().Float64Var(&params.minValue, "min-value", 0, "Hide positions worth less than this many dollars (totals still include them)")
+	cmd.Flags().BoolVar(&params.saveSnapshot, "save-snapshot", false, "Save current positions to a timestamped snapshot file")
+	cmd.Flags().StringVar(&params.diff, "diff", "", "Compare positions to a snapshot (--diff=FILE, default: the latest saved)")
+	cmd.Flags().Lookup("diff").NoOptDefVal = diffLatest
+	cmd.Flags().StringVar(&params.sort, "sort", "", "Sort positions by symbol, value, day-pct, total-pct, or quantity")
+	cmd.Flags().BoolVar(&params.desc, "desc", false, "Sort in descending order")
+	cmd.Flags().StringVar(&params.benchmark, "benchmark", "", "Compare the portfolio's day change with this symbol's (e.g. SPY)")
+	cmd.Flags().BoolVar(&params.includePending, "include-pending", false, "Show pending buy/sell quantity from open orders and the projected position")
+	cmd.Flags().BoolVar(&params.summary, "summary", false, "Show the position count, total value, and total unrealized and day P/L")
+	cmd.Flags().StringVar(&params.currency, "currency", "", "Also show values converted from USD to this currency (e.g. EUR)")
+}
+
+// minPortfolioInterval keeps --watch from hammering the API.
+const minPortfolioInterval = 5 * time.Second
+
+// validatePortfolioParams checks flag combinations for the portfolio command.
+func validatePortfolioParams(params portfolioParams, jsonMode bool) error {
+	if params.only != "" {
+		if !jsonMode {
+			return fmt.Errorf("--only requires --json flag")
+		}
+		if !validPortfolioFilters[params.only] {
+			return fmt.Errorf("invalid --only value %q: must be one of buying-power, positions, equity", params.only)
+		}
+	}
+	if params.groupBy != "" && params.groupBy != "underlying" {
+		return fmt.Errorf("invalid --group-by value %q: must be underlying", params.groupBy)
+	}
+	if params.minValue < 0 {
+		return fmt.Errorf("invalid --min-value %v: must not be negative", params.minValue)
+	}
+	if params.sort != "" && !slices.Contains(publicapi.PositionSortKeys, params.sort) {
+		return fmt.Errorf("invalid --sort value %q: must be one of %s", params.sort, strings.Join(publicapi.PositionSortKeys, ", "))
+	}
+	if params.desc && params.sort == "" {
+		return fmt.Errorf("--desc requires --sort")
+	}
+	if params.diff != "" && (params.watch || params.only != "" || params.groupBy != "") {
+		return fmt.Errorf("--diff cannot be combined with --watch, --only, or --group-by")
+	}
+	if params.benchmark != "" && (params.only != "" || params.diff != "") {
+		return fmt.Errorf("--benchmark cannot be combined with --only or --diff")
+	}
+	if params.benchmark != "" && jsonMode && params.groupBy != "" {
+		return fmt.Errorf("--benchmark cannot be combined with --group-by in JSON output")
+	}
+	if params.includePending && (params.only != "" || params.diff != "" || params.groupBy != "" || params.benchmark != "") {
+		return fmt.Errorf("--include-pending cannot be combined with --only, --diff, --group-by, or --benchmark")
+	}
+	if params.summary && (params.only != "" || params.diff != "" || params.groupBy != "" || params.includePending) {
+		return fmt.Errorf("--summary cannot be combined with --only, --diff, --group-by, or --include-pending")
+	}
+	currency, err := parseCurrency(params.currency)
+	if err != nil {
+		return err
+	}
+	if currency != "" && (params.only != "" || params.diff != "" || params.groupBy != "" || params.includePending) {
+		return fmt.Errorf("--currency cannot be combined with --only, --diff, --group-by, or --include-pending")
+	}
+	if params.watch && params.interval < minPortfolioInterval {
+		return fmt.Errorf("invalid --interval %s: must be at least %s", params.interval, minPortfolioInterval)
+	}
+	return nil
 }
 
 // portfolioFilter defines valid values for the --only flag.
@@ -73,8 +211,8 @@ func runAccountList(cmd *cobra.Command, opts accountOptions) error {
 	}
 
 	var accountsResp api.AccountsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&accountsResp); err != nil {
-		return fmt.Errorf("failed to decode response: %w", err)
+	if err := api.DecodeJSON(resp, &accountsResp); err != nil {
+		return err
 	}
 
 	if len(accountsResp.Accounts) == 0 {
@@ -101,7 +239,7 @@ func runAccountList(cmd *cobra.Command, opts accountOptions) error {
 
 func newPortfolioCmd(opts accountOptions) *cobra.Command {
 	var flagAccountID string
-	var flagOnly string
+	var params portfolioParams
 
 	cmd := &cobra.Command{
 		Use:   "portfolio",
@@ -115,36 +253,65 @@ Examples:
   pub account portfolio --account YOUR_ACCOUNT_ID
   pub account portfolio --json --only buying-power  # Just buying power
   pub account portfolio --json --only positions     # Just positions array
-  pub account portfolio --json --only equity        # Just equity array`,
+  pub account portfolio --json --only equity        # Just equity array
+  pub account portfolio --group-by underlying       # Options grouped with their stock
+  pub account portfolio --refresh-quotes            # Revalue positions at live prices
+  pub account portfolio --min-value 10             # Hide positions worth under $10
+  pub account portfolio --sort value --desc         # Largest positions first
+  pub account portfolio --watch --interval 5m --log-csv equity.csv  # Log an equity curve
+  pub account portfolio --save-snapshot             # Save positions for a later --diff
+  pub account portfolio --diff                      # Changes since the latest snapshot
+  pub account portfolio --benchmark SPY             # Day change vs SPY
+  pub account portfolio --include-pending           # Held plus open-order quantity
+  pub account portfolio --summary                   # Position count and total P/L
+  pub account portfolio --currency EUR              # Values also shown in euros
+
+--benchmark compares the portfolio's day change (today's gain over the value
+at the previous close) with the benchmark's. Quotes carry no previous close,
+so the benchmark's day change is taken from a position in that symbol; when
+it is not held the comparison shows - and says why.
+
+--include-pending lists, per symbol, the quantity held, the unfilled
+quantity of open buy and sell orders, and the projected position if every
+open order filled in full.
+
+--summary ends the table with the number of positions, their total value, and
+the total unrealized (cost basis) and day P/L, counting positions hidden by
+--min-value. With --json these are in a summary object.
+
+--currency converts buying power, the account summary, and position values
+from USD at a rate fetched from fx_rate_url (default: frankfurter.app), shown
+with its date. Rates are cached for 10 minutes. If the rate cannot be
+fetched, values are shown in USD with a warning. With --json the USD figures
+are unchanged and the converted ones are in a converted object, with the rate
+in fx.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			accountID := flagAccountID
-			if accountID == "" {
-				accountID = opts.defaultAccountID
+			accountID, err := resolveAccountID(flagAccountID, opts.defaultAccountID, cmd.InOrStdin())
+			if err != nil {
+				return err
 			}
 			if accountID == "" {
 				return fmt.Errorf("account ID is required (use --account flag or set default with 'pub configure')")
 			}
-			// Validate --only flag
-			if flagOnly != "" {
-				if !opts.jsonMode {
-					return fmt.Errorf("--only requires --json flag")
-				}
-				if !validPortfolioFilters[flagOnly] {
-					return fmt.Errorf("invalid --only value %q: must be one of buying-power, positions, equity", flagOnly)
-				}
+			if err := validatePortfolioParams(params, opts.jsonMode); err != nil {
+				return err
+			}
+			if params.watch {
+				streamOutput(cmd)
+				return runPortfolioWatch(cmd, opts, accountID, params)
 			}
-			return runPortfolio(cmd, opts, accountID, flagOnly)
+			return runPortfolio(cmd, opts, accountID, params)
 		},
 	}
 
-	cmd.Flags().StringVarP(&flagAccountID, "account", "a", "", "Account ID (uses default if configured)")
-	cmd.Flags().StringVar(&flagOnly, "only", "", "Filter JSON output to one section: buying-power, positions, equity")
+	cmd.Flags().StringVarP(&flagAccountID, "account", "a", "", "Account ID (uses default if configured; - reads it from stdin)")
+	addPortfolioFlags(cmd, &params)
 	cmd.SilenceUsage = true
 
 	return cmd
 }
 
-func runPortfolio(cmd *cobra.Command, opts accountOptions, accountID string, only string) error {
+func runPortfolio(cmd *cobra.Command, opts accountOptions, accountID string, params portfolioParams) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
@@ -162,15 +329,66 @@ func runPortfolio(cmd *cobra.Command, opts accountOptions, accountID string, onl
 	}
 
 	var portfolio api.Portfolio
-	if err := json.NewDecoder(resp.Body).Decode(&portfolio); err != nil {
-		return fmt.Errorf("failed to decode response: %w", err)
+	if err := api.DecodeJSON(resp, &portfolio); err != nil {
+		return err
+	}
+
+	var refreshed map[string]bool
+	if params.refreshQuotes && len(portfolio.Positions) > 0 {
+		refreshed, err = refreshPositionQuotes(ctx, client, accountID, portfolio.Positions)
+		if err != nil {
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: could not refresh quotes: %s\n", err)
+		}
+	}
+
+	if params.logCSV != "" {
+		if err := appendPortfolioCSV(params.logCSV, time.Now(), &portfolio); err != nil {
+			return fmt.Errorf("failed to write %s: %w", params.logCSV, err)
+		}
+	}
+
+	// Diff before saving, or --diff's "latest" would be the snapshot just written
+	if params.diff != "" {
+		if err := runPortfolioDiff(cmd, opts, accountID, params.diff, portfolio.Positions); err != nil {
+			return err
+		}
+		return saveSnapshotIfRequested(cmd, params, accountID, portfolio.Positions)
+	}
+	if err := saveSnapshotIfRequested(cmd, params, accountID, portfolio.Positions); err != nil {
+		return err
+	}
+
+	if params.includePending {
+		orders, err := fetchOpenOrders(ctx, client, accountID)
+		if err != nil {
+			return err
+		}
+		return printPendingExposure(cmd, opts.jsonMode, joinPendingOrders(portfolio.Positions, orders))
+	}
+
+	if err := publicapi.SortPositions(portfolio.Positions, params.sort, params.desc); err != nil {
+		return err
+	}
+
+	var benchmark *benchmarkComparison
+	if params.benchmark != "" {
+		benchmark = compareToBenchmark(ctx, client, accountID, &portfolio, params.benchmark)
+	}
+
+	var fx *fxRate
+	if currency, _ := parseCurrency(params.currency); currency != "" {
+		fx, err = fetchFXRate(ctx, opts.fxRateURL, currency, opts.fxCachePath, time.Now())
+		if err != nil {
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: could not fetch the USD/%s exchange rate (%s); showing USD values\n", currency, err)
+			fx = nil
+		}
 	}
 
 	formatter := output.New(cmd.OutOrStdout(), opts.jsonMode)
 
 	// Handle --only flag for JSON output
-	if opts.jsonMode && only != "" {
-		switch only {
+	if opts.jsonMode && params.only != "" {
+		switch params.only {
 		case "buying-power":
 			return formatter.Print(map[string]any{
 				"buyingPower":         portfolio.BuyingPower.BuyingPower,
@@ -186,43 +404,94 @@ func runPortfolio(cmd *cobra.Command, opts accountOptions, accountID string, onl
 
 	// Print buying power summary
 	if !opts.jsonMode {
-		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Buying Power: $%s\n", portfolio.BuyingPower.BuyingPower)
-		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Options Buying Power: $%s\n\n", portfolio.BuyingPower.OptionsBuyingPower)
+		if fx != nil {
+			printFXRate(cmd.OutOrStdout(), fx)
+		}
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Buying Power: $%s%s\n", portfolio.BuyingPower.BuyingPower, fxSuffix(fx, portfolio.BuyingPower.BuyingPower))
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Options Buying Power: $%s%s\n\n", portfolio.BuyingPower.OptionsBuyingPower, fxSuffix(fx, portfolio.BuyingPower.OptionsBuyingPower))
 
 		// Print equity summary if available
 		if len(portfolio.Equity) > 0 {
 			_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Account Summary:")
 			for _, eq := range portfolio.Equity {
-				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  %s: $%s (%s%%)\n", eq.Type, eq.Value, eq.PercentageOfPortfolio)
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  %s: $%s (%s%%)%s\n", eq.Type, eq.Value, eq.PercentageOfPortfolio, fxSuffix(fx, eq.Value))
 			}
 			_, _ = fmt.Fprintln(cmd.OutOrStdout())
 		}
+
+		if benchmark != nil {
+			printBenchmarkComparison(cmd.OutOrStdout(), benchmark)
+		}
 	}
 
 	if len(portfolio.Positions) == 0 {
 		if opts.jsonMode {
-			return formatter.Print(map[string]any{
+			result := map[string]any{
 				"buyingPower": portfolio.BuyingPower,
 				"equity":      portfolio.Equity,
 				"positions":   []any{},
-			})
+			}
+			if benchmark != nil {
+				result["benchmark"] = benchmark
+			}
+			if params.summary {
+				result["summary"] = summarizePositions(portfolio.Positions)
+			}
+			if fx != nil {
+				result["fx"] = fx
+				result["converted"] = convertPortfolio(&portfolio, nil, fx)
+			}
+			return formatter.Print(result)
 		}
 		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No positions")
 		return nil
 	}
 
+	if params.groupBy == "underlying" {
+		groups, hidden := hideSmallGroupedPositions(groupByUnderlying(portfolio.Positions), params.minValue)
+		if err := printPositionGroups(formatter, opts.jsonMode, groups); err != nil {
+			return err
+		}
+		if !opts.jsonMode {
+			printHiddenPositionsNote(cmd.OutOrStdout(), hidden, params.minValue)
+		}
+		return nil
+	}
+
+	positions, hidden := publicapi.FilterPositionsByValue(portfolio.Positions, params.minValue)
+
 	if opts.jsonMode {
-		return formatter.Print(map[string]any{
+		result := map[string]any{
 			"buyingPower": portfolio.BuyingPower,
 			"equity":      portfolio.Equity,
-			"positions":   portfolio.Positions,
-		})
+			"positions":   positions,
+		}
+		if params.refreshQuotes {
+			result["refreshed"] = sortedKeys(refreshed)
+		}
+		if params.minValue > 0 {
+			result["hiddenPositions"] = hidden
+		}
+		if benchmark != nil {
+			result["benchmark"] = benchmark
+		}
+		if params.summary {
+			result["summary"] = summarizePositions(portfolio.Positions)
+		}
+		if fx != nil {
+			result["fx"] = fx
+			result["converted"] = convertPortfolio(&portfolio, positions, fx)
+		}
+		return formatter.Print(result)
 	}
 
 	// Format positions as table
-	headers := []string{"Symbol", "Qty", "Value", "Daily G/L", "Daily %", "Total G/L", "Total %"}
-	rows := make([][]string, 0, len(portfolio.Positions))
-	for _, pos := range portfolio.Positions {
+	headers := []string{"Symbol", "Qty", "Last", "Avg Cost", "Cost Basis", "Value", "% of Port", "Daily G/L", "Daily %", "Total G/L", "Total %"}
+	if fx != nil {
+		headers = slices.Insert(headers, 6, "Value ("+fx.Currency+")")
+	}
+	rows := make([][]string, 0, len(positions))
+	for _, pos := range positions {
 		// Use costBasis for total gain (more accurate than instrumentGain)
 		totalGainValue := pos.CostBasis.GainValue
 		totalGainPct := pos.CostBasis.GainPercentage
@@ -230,14 +499,735 @@ func runPortfolio(cmd *cobra.Command, opts accountOptions, accountID string, onl
 			totalGainValue = "0"
 			totalGainPct = "0"
 		}
-		rows = append(rows, []string{
-			pos.Instrument.Symbol,
+		symbol := pos.Instrument.Symbol
+		if refreshed[symbol] {
+			symbol += " *"
+		}
+		row := []string{
+			symbol,
 			pos.Quantity,
+			dollarsOrDash(pos.LastPrice.LastPrice),
+			dollarsOrDash(pos.CostBasis.UnitCost),
+			dollarsOrDash(pos.CostBasis.TotalCost),
 			"$" + pos.CurrentValue,
+			percentOrDash(pos.PercentOfPortfolio),
 			publicapi.FormatGainLoss(pos.PositionDailyGain.GainValue),
 			pos.PositionDailyGain.GainPercentage + "%",
 			publicapi.FormatGainLoss(totalGainValue),
 			totalGainPct + "%",
+		}
+		if fx != nil {
+			row = slices.Insert(row, 6, valueOrDash(fx.convert(pos.CurrentValue)))
+		}
+		rows = append(rows, row)
+	}
+
+	if err := formatter.WithWide(opts.wide).WideTable(headers, rows, "Last", "Avg Cost", "Cost Basis", "% of Port"); err != nil {
+		return err
+	}
+	if len(refreshed) > 0 {
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "\n* value recomputed from a live quote")
+	}
+	printHiddenPositionsNote(cmd.OutOrStdout(), hidden, params.minValue)
+	if params.summary {
+		printPortfolioSummary(cmd.OutOrStdout(), summarizePositions(portfolio.Positions))
+	}
+	return nil
+}
+
+// portfolioSummary totals the positions for --summary.
+type portfolioSummary struct {
+	Positions      int    `json:"positions"`
+	TotalValue     string `json:"totalValue"`
+	UnrealizedGain string `json:"unrealizedGain"` // sum of cost basis gains
+	DayGain        string `json:"dayGain"`
+}
+
+// summarizePositions sums value and gains across positions. Amounts the API
+// leaves empty count as zero.
+func summarizePositions(positions []api.Position) portfolioSummary {
+	value, unrealized, day := money.Zero, money.Zero, money.Zero
+	add := func(total money.Money, amount string) money.Money {
+		if m, err := money.Parse(amount); err == nil {
+			return total.Add(m)
+		}
+		return total
+	}
+	for _, pos := range positions {
+		value = add(value, pos.CurrentValue)
+		unrealized = add(unrealized, pos.CostBasis.GainValue)
+		day = add(day, pos.PositionDailyGain.GainValue)
+	}
+	return portfolioSummary{
+		Positions:      len(positions),
+		TotalValue:     value.String(),
+		UnrealizedGain: unrealized.String(),
+		DayGain:        day.String(),
+	}
+}
+
+// printPortfolioSummary prints the --summary footer.
+func printPortfolioSummary(w io.Writer, s portfolioSummary) {
+	noun := "positions"
+	if s.Positions == 1 {
+		noun = "position"
+	}
+	_, _ = fmt.Fprintf(w, "\nSummary: %d %s, value $%s, unrealized P/L %s, day P/L %s\n",
+		s.Positions, noun, s.TotalValue, publicapi.FormatGainLoss(s.UnrealizedGain), publicapi.FormatGainLoss(s.DayGain))
+}
+
+// fxSuffix formats a USD amount converted for --currency as " (920.00 EUR)",
+// or "" without a rate.
+func fxSuffix(fx *fxRate, usd string) string {
+	if fx == nil {
+		return ""
+	}
+	converted := fx.convert(usd)
+	if converted == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (%s %s)", converted, fx.Currency)
+}
+
+// dollarsOrDash formats an API amount as dollars, or "-" when it is empty.
+func dollarsOrDash(amount string) string {
+	if amount == "" {
+		return "-"
+	}
+	return "$" + amount
+}
+
+// percentOrDash formats an API percentage, or "-" when it is empty.
+func percentOrDash(pct string) string {
+	if pct == "" {
+		return "-"
+	}
+	return pct + "%"
+}
+
+// printHiddenPositionsNote prints a footer explaining how many positions
+// --min-value left out of the table.
+func printHiddenPositionsNote(w io.Writer, hidden int, minValue float64) {
+	if hidden == 0 {
+		return
+	}
+	noun := "positions"
+	if hidden == 1 {
+		noun = "position"
+	}
+	_, _ = fmt.Fprintf(w, "\n%d %s under $%.2f hidden (included in totals)\n", hidden, noun, minValue)
+}
+
+// runPortfolioWatch re-runs the portfolio command every interval until
+// interrupted. Fetch errors are reported and retried on the next tick so a
+// transient failure doesn't end a long-running --log-csv session.
+func runPortfolioWatch(cmd *cobra.Command, opts accountOptions, accountID string, params portfolioParams) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	ticker := time.NewTicker(params.interval)
+	defer ticker.Stop()
+
+	for {
+		if !opts.jsonMode {
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "--- %s ---\n", time.Now().Format("2006-01-02 15:04:05"))
+		}
+		if err := runPortfolio(cmd, opts, accountID, params); err != nil {
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %s\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// portfolioTotals sums the account value and cash from the equity breakdown
+// and the day gain across positions.
+func portfolioTotals(portfolio *api.Portfolio) (total, cash, dayGain float64) {
+	for _, eq := range portfolio.Equity {
+		v, _ := strconv.ParseFloat(eq.Value, 64)
+		total += v
+		if eq.Type == "CASH" {
+			cash += v
+		}
+	}
+	for _, pos := range portfolio.Positions {
+		v, _ := strconv.ParseFloat(pos.PositionDailyGain.GainValue, 64)
+		dayGain += v
+	}
+	return total, cash, dayGain
+}
+
+// benchmarkComparison is the --benchmark result. Percentages are nil when
+// they cannot be determined; Note says why.
+type benchmarkComparison struct {
+	Symbol          string   `json:"symbol"`
+	PortfolioDayPct *float64 `json:"portfolioDayPct"`
+	BenchmarkDayPct *float64 `json:"benchmarkDayPct"`
+	Difference      *float64 `json:"difference"` // portfolio minus benchmark, in percentage points
+	BenchmarkLast   string   `json:"benchmarkLast,omitempty"`
+	Note            string   `json:"note,omitempty"`
+}
+
+// portfolioDayPct returns the day change as a percentage of the value at the
+// previous close (total value minus today's gain), or nil without a base.
+func portfolioDayPct(portfolio *api.Portfolio) *float64 {
+	total, _, dayGain := portfolioTotals(portfolio)
+	base := total - dayGain
+	if base <= 0 {
+		return nil
+	}
+	pct := dayGain / base * 100
+	return &pct
+}
+
+// compareToBenchmark compares the portfolio's day change with symbol's.
+// Quotes carry no previous close, so the benchmark's day change comes from a
+// position in the same symbol; the quote supplies its last price. Failures
+// are reported in Note rather than as errors so the portfolio still prints.
+func compareToBenchmark(ctx context.Context, client *api.Client, accountID string, portfolio *api.Portfolio, symbol string) *benchmarkComparison {
+	symbol = strings.ToUpper(strings.TrimSpace(symbol))
+	cmp := &benchmarkComparison{Symbol: symbol, PortfolioDayPct: portfolioDayPct(portfolio)}
+
+	for _, pos := range portfolio.Positions {
+		if strings.EqualFold(pos.Instrument.Symbol, symbol) {
+			if pct, err := strconv.ParseFloat(pos.PositionDailyGain.GainPercentage, 64); err == nil {
+				cmp.BenchmarkDayPct = &pct
+			}
+			break
+		}
+	}
+
+	quotes, err := client.GetQuotes(ctx, accountID, []api.QuoteInstrument{{Symbol: symbol, Type: "EQUITY"}})
+	switch {
+	case err != nil:
+		cmp.Note = fmt.Sprintf("benchmark quote unavailable: %s", err)
+	case len(quotes) == 0 || quotes[0].Outcome != "SUCCESS":
+		cmp.Note = "benchmark quote unavailable"
+	default:
+		cmp.BenchmarkLast = quotes[0].Last
+	}
+
+	if cmp.BenchmarkDayPct == nil && cmp.Note == "" {
+		cmp.Note = fmt.Sprintf("day change for %s is only available when it is held in this account", symbol)
+	}
+	if cmp.PortfolioDayPct != nil && cmp.BenchmarkDayPct != nil {
+		diff := *cmp.PortfolioDayPct - *cmp.BenchmarkDayPct
+		cmp.Difference = &diff
+	}
+	return cmp
+}
+
+// printBenchmarkComparison prints the --benchmark section of the portfolio.
+func printBenchmarkComparison(w io.Writer, cmp *benchmarkComparison) {
+	format := func(v *float64, unit string) string {
+		if v == nil {
+			return "-"
+		}
+		return fmt.Sprintf("%+.2f%s", *v, unit)
+	}
+
+	last := ""
+	if cmp.BenchmarkLast != "" {
+		last = fmt.Sprintf(" (last $%s)", cmp.BenchmarkLast)
+	}
+	_, _ = fmt.Fprintf(w, "Benchmark (%s):\n", cmp.Symbol)
+	_, _ = fmt.Fprintf(w, "  Portfolio day: %s\n", format(cmp.PortfolioDayPct, "%"))
+	_, _ = fmt.Fprintf(w, "  %s day: %s%s\n", cmp.Symbol, format(cmp.BenchmarkDayPct, "%"), last)
+	_, _ = fmt.Fprintf(w, "  Difference: %s\n", format(cmp.Difference, " pts"))
+	if cmp.Note != "" {
+		_, _ = fmt.Fprintf(w, "  Note: %s\n", cmp.Note)
+	}
+	_, _ = fmt.Fprintln(w)
+}
+
+// portfolioCSVHeader is the header row written to new --log-csv files.
+var portfolioCSVHeader = []string{"timestamp", "total_value", "cash", "day_gain"}
+
+// appendPortfolioCSV appends one row of portfolio totals to path, writing the
+// header first if the file is new or empty. Each call opens the file in append
+// mode and writes the whole row with a single write, so spreadsheets reading
+// the file concurrently never see a partial line.
+func appendPortfolioCSV(path string, now time.Time, portfolio *api.Portfolio) error {
+	total, cash, dayGain := portfolioTotals(portfolio)
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if info.Size() == 0 {
+		_ = w.Write(portfolioCSVHeader)
+	}
+	_ = w.Write([]string{
+		now.UTC().Format(time.RFC3339),
+		fmt.Sprintf("%.2f", total),
+		fmt.Sprintf("%.2f", cash),
+		fmt.Sprintf("%.2f", dayGain),
+	})
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return err
+	}
+
+	_, err = f.Write(buf.Bytes())
+	return err
+}
+
+// refreshPositionQuotes fetches live quotes for all positions and overlays the
+// last price and current value (quantity x last, x100 for options). It returns
+// the set of symbols that were updated.
+func refreshPositionQuotes(ctx context.Context, client *api.Client, accountID string, positions []api.Position) (map[string]bool, error) {
+	instruments := make([]api.QuoteInstrument, 0, len(positions))
+	for _, pos := range positions {
+		instruments = append(instruments, api.QuoteInstrument{
+			Symbol: pos.Instrument.Symbol,
+			Type:   pos.Instrument.Type,
+		})
+	}
+
+	quotes, err := client.GetQuotes(ctx, accountID, instruments)
+	if err != nil {
+		return nil, err
+	}
+
+	bySymbol := make(map[string]api.Quote, len(quotes))
+	for _, q := range quotes {
+		if q.Outcome == "SUCCESS" && q.Last != "" {
+			bySymbol[q.Instrument.Symbol] = q
+		}
+	}
+
+	refreshed := make(map[string]bool)
+	for i := range positions {
+		pos := &positions[i]
+		q, ok := bySymbol[pos.Instrument.Symbol]
+		if !ok {
+			continue
+		}
+		last, err := strconv.ParseFloat(q.Last, 64)
+		if err != nil {
+			continue
+		}
+		qty, err := strconv.ParseFloat(pos.Quantity, 64)
+		if err != nil {
+			continue
+		}
+		multiplier := 1.0
+		if pos.Instrument.Type == "OPTION" {
+			multiplier = 100
+		}
+		pos.LastPrice.LastPrice = q.Last
+		if q.LastTimestamp != "" {
+			pos.LastPrice.Timestamp = q.LastTimestamp
+		}
+		pos.CurrentValue = fmt.Sprintf("%.2f", qty*last*multiplier)
+		refreshed[pos.Instrument.Symbol] = true
+	}
+
+	return refreshed, nil
+}
+
+// sortedKeys returns the keys of a set in sorted order.
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// diffLatest is the --diff value used when no snapshot file is given.
+const diffLatest = "latest"
+
+// snapshotTimeFormat names snapshot files so they sort chronologically.
+const snapshotTimeFormat = "20060102T150405Z"
+
+// portfolioSnapshot is the on-disk form written by --save-snapshot.
+type portfolioSnapshot struct {
+	AccountID string         `json:"accountId"`
+	TakenAt   time.Time      `json:"takenAt"`
+	Positions []api.Position `json:"positions"`
+}
+
+// positionDelta describes how one position changed between a snapshot and now.
+type positionDelta struct {
+	Symbol         string  `json:"symbol"`
+	Status         string  `json:"status"` // added, removed, changed, or unchanged
+	OldQuantity    float64 `json:"oldQuantity"`
+	NewQuantity    float64 `json:"newQuantity"`
+	QuantityChange float64 `json:"quantityChange"`
+	OldValue       float64 `json:"oldValue"`
+	NewValue       float64 `json:"newValue"`
+	ValueChange    float64 `json:"valueChange"`
+}
+
+// portfolioSnapshotDir returns the directory --save-snapshot writes to.
+func portfolioSnapshotDir() string {
+	return filepath.Join(config.ConfigDir(), "snapshots")
+}
+
+// saveSnapshotIfRequested saves a snapshot of positions for --save-snapshot.
+func saveSnapshotIfRequested(cmd *cobra.Command, params portfolioParams, accountID string, positions []api.Position) error {
+	if !params.saveSnapshot {
+		return nil
+	}
+	path, err := savePortfolioSnapshot(portfolioSnapshotDir(), accountID, time.Now(), positions)
+	if err != nil {
+		return fmt.Errorf("failed to save snapshot: %w", err)
+	}
+	_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Snapshot saved to %s\n", path)
+	return nil
+}
+
+// savePortfolioSnapshot writes positions to a new timestamped file in dir and
+// returns its path.
+func savePortfolioSnapshot(dir, accountID string, now time.Time, positions []api.Position) (string, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+
+	data, err := json.MarshalIndent(portfolioSnapshot{
+		AccountID: accountID,
+		TakenAt:   now.UTC(),
+		Positions: positions,
+	}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("portfolio-%s-%s.json", accountID, now.UTC().Format(snapshotTimeFormat)))
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// latestPortfolioSnapshot returns the newest snapshot file for accountID in dir.
+func latestPortfolioSnapshot(dir, accountID string) (string, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, fmt.Sprintf("portfolio-%s-*.json", accountID)))
+	if err != nil {
+		return "", err
+	}
+	if len(matches) == 0 {
+		return "", fmt.Errorf("no portfolio snapshots found in %s (save one with --save-snapshot)", dir)
+	}
+	sort.Strings(matches)
+	return matches[len(matches)-1], nil
+}
+
+// loadPortfolioSnapshot reads a snapshot written by savePortfolioSnapshot.
+func loadPortfolioSnapshot(path string) (*portfolioSnapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var snap portfolioSnapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("invalid snapshot %s: %w", path, err)
+	}
+	return &snap, nil
+}
+
+// diffPositions compares two position lists keyed by symbol, sorted by symbol.
+func diffPositions(before, after []api.Position) []positionDelta {
+	type qtyValue struct{ qty, value float64 }
+	index := func(positions []api.Position) map[string]qtyValue {
+		m := make(map[string]qtyValue, len(positions))
+		for _, pos := range positions {
+			qty, _ := strconv.ParseFloat(pos.Quantity, 64)
+			value, _ := strconv.ParseFloat(pos.CurrentValue, 64)
+			m[pos.Instrument.Symbol] = qtyValue{qty, value}
+		}
+		return m
+	}
+	old, cur := index(before), index(after)
+
+	symbols := make(map[string]bool, len(old)+len(cur))
+	for s := range old {
+		symbols[s] = true
+	}
+	for s := range cur {
+		symbols[s] = true
+	}
+
+	deltas := make([]positionDelta, 0, len(symbols))
+	for _, symbol := range sortedKeys(symbols) {
+		o, inOld := old[symbol]
+		n, inNew := cur[symbol]
+		d := positionDelta{
+			Symbol:         symbol,
+			OldQuantity:    o.qty,
+			NewQuantity:    n.qty,
+			QuantityChange: n.qty - o.qty,
+			OldValue:       o.value,
+			NewValue:       n.value,
+			ValueChange:    n.value - o.value,
+		}
+		switch {
+		case !inOld:
+			d.Status = "added"
+		case !inNew:
+			d.Status = "removed"
+		case d.QuantityChange != 0:
+			d.Status = "changed"
+		default:
+			d.Status = "unchanged"
+		}
+		deltas = append(deltas, d)
+	}
+	return deltas
+}
+
+// runPortfolioDiff prints how positions changed since a saved snapshot.
+func runPortfolioDiff(cmd *cobra.Command, opts accountOptions, accountID, snapshotPath string, positions []api.Position) error {
+	if snapshotPath == diffLatest {
+		latest, err := latestPortfolioSnapshot(portfolioSnapshotDir(), accountID)
+		if err != nil {
+			return err
+		}
+		snapshotPath = latest
+	}
+
+	snap, err := loadPortfolioSnapshot(snapshotPath)
+	if err != nil {
+		return fmt.Errorf("failed to load snapshot: %w", err)
+	}
+
+	deltas := diffPositions(snap.Positions, positions)
+
+	formatter := output.New(cmd.OutOrStdout(), opts.jsonMode)
+	if opts.jsonMode {
+		return formatter.Print(map[string]any{
+			"snapshot":  snapshotPath,
+			"since":     snap.TakenAt,
+			"positions": deltas,
+		})
+	}
+
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Changes since %s\n\n", snap.TakenAt.Local().Format("2006-01-02 15:04:05"))
+	headers := []string{"Symbol", "Status", "Qty", "Qty Chg", "Value", "Value Chg"}
+	rows := make([][]string, 0, len(deltas))
+	for _, d := range deltas {
+		rows = append(rows, []string{
+			d.Symbol,
+			d.Status,
+			strconv.FormatFloat(d.NewQuantity, 'f', -1, 64),
+			fmt.Sprintf("%+g", d.QuantityChange),
+			fmt.Sprintf("$%.2f", d.NewValue),
+			publicapi.FormatGainLoss(fmt.Sprintf("%.2f", d.ValueChange)),
+		})
+	}
+	return formatter.Table(headers, rows)
+}
+
+// pendingExposure is one symbol's held quantity next to its open orders.
+type pendingExposure struct {
+	Symbol      string  `json:"symbol"`
+	Held        float64 `json:"held"`
+	PendingBuy  float64 `json:"pendingBuy"`
+	PendingSell float64 `json:"pendingSell"`
+	Projected   float64 `json:"projected"` // held if every open order fills in full
+	OpenOrders  int     `json:"openOrders"`
+}
+
+// joinPendingOrders joins open orders to positions by symbol, sorted by
+// symbol. Only the unfilled part of an order is pending; the filled part is
+// already in the position.
+func joinPendingOrders(positions []api.Position, orders []api.Order) []pendingExposure {
+	bySymbol := make(map[string]*pendingExposure)
+	entry := func(symbol string) *pendingExposure {
+		e, ok := bySymbol[symbol]
+		if !ok {
+			e = &pendingExposure{Symbol: symbol}
+			bySymbol[symbol] = e
+		}
+		return e
+	}
+
+	for _, pos := range positions {
+		qty, _ := strconv.ParseFloat(pos.Quantity, 64)
+		entry(pos.Instrument.Symbol).Held += qty
+	}
+	for _, o := range orders {
+		if orderState(o.Status) != "OPEN" {
+			continue
+		}
+		qty, _ := strconv.ParseFloat(o.Quantity, 64)
+		filled, _ := strconv.ParseFloat(o.FilledQuantity, 64)
+		remaining := max(qty-filled, 0)
+
+		e := entry(o.Instrument.Symbol)
+		e.OpenOrders++
+		switch strings.ToUpper(o.Side) {
+		case "BUY":
+			e.PendingBuy += remaining
+		case "SELL":
+			e.PendingSell += remaining
+		}
+	}
+
+	exposures := make([]pendingExposure, 0, len(bySymbol))
+	for _, e := range bySymbol {
+		e.Projected = e.Held + e.PendingBuy - e.PendingSell
+		exposures = append(exposures, *e)
+	}
+	sort.Slice(exposures, func(i, j int) bool { return exposures[i].Symbol < exposures[j].Symbol })
+	return exposures
+}
+
+// printPendingExposure prints the --include-pending view of the portfolio.
+func printPendingExposure(cmd *cobra.Command, jsonMode bool, exposures []pendingExposure) error {
+	formatter := output.New(cmd.OutOrStdout(), jsonMode)
+	if jsonMode {
+		return formatter.Print(map[string]any{"positions": exposures})
+	}
+	if len(exposures) == 0 {
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No positions or open orders")
+		return nil
+	}
+
+	qty := func(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) }
+	headers := []string{"Symbol", "Held", "Pending Buy", "Pending Sell", "Projected", "Open Orders"}
+	rows := make([][]string, 0, len(exposures))
+	for _, e := range exposures {
+		rows = append(rows, []string{
+			e.Symbol,
+			qty(e.Held),
+			qty(e.PendingBuy),
+			qty(e.PendingSell),
+			qty(e.Projected),
+			strconv.Itoa(e.OpenOrders),
+		})
+	}
+	if err := formatter.Table(headers, rows); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintln(cmd.OutOrStdout(), "\nProjected assumes every open order fills in full.")
+	return nil
+}
+
+// positionGroup is a set of positions sharing an underlying symbol.
+type positionGroup struct {
+	Underlying    string         `json:"underlying"`
+	Value         float64        `json:"value"`
+	PercentOfBook float64        `json:"percentOfBook"`
+	Positions     []api.Position `json:"positions"`
+}
+
+// positionUnderlying returns the underlying symbol of a position, parsing
+// OSI symbols for options.
+func positionUnderlying(pos api.Position) string {
+	if pos.Instrument.Type == "OPTION" {
+		if osi, err := analytics.ParseOSI(pos.Instrument.Symbol); err == nil {
+			return osi.Underlying
+		}
+	}
+	return pos.Instrument.Symbol
+}
+
+// groupByUnderlying groups positions by underlying symbol, largest first,
+// with each group's share of the total position value.
+func groupByUnderlying(positions []api.Position) []positionGroup {
+	var order []string
+	groups := make(map[string]*positionGroup)
+	var total float64
+
+	for _, pos := range positions {
+		underlying := positionUnderlying(pos)
+		g, ok := groups[underlying]
+		if !ok {
+			g = &positionGroup{Underlying: underlying}
+			groups[underlying] = g
+			order = append(order, underlying)
+		}
+		value, _ := strconv.ParseFloat(pos.CurrentValue, 64)
+		g.Value += value
+		g.Positions = append(g.Positions, pos)
+		total += value
+	}
+
+	result := make([]positionGroup, 0, len(order))
+	for _, u := range order {
+		g := groups[u]
+		if total != 0 {
+			g.PercentOfBook = g.Value / total * 100
+		}
+		result = append(result, *g)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Value > result[j].Value
+	})
+
+	return result
+}
+
+// hideSmallGroupedPositions applies --min-value to each group's positions.
+// Group values and percentages are left untouched so subtotals still include
+// hidden positions; groups with nothing left to show are dropped.
+func hideSmallGroupedPositions(groups []positionGroup, minValue float64) ([]positionGroup, int) {
+	if minValue <= 0 {
+		return groups, 0
+	}
+
+	var total int
+	result := make([]positionGroup, 0, len(groups))
+	for _, g := range groups {
+		var hidden int
+		g.Positions, hidden = publicapi.FilterPositionsByValue(g.Positions, minValue)
+		total += hidden
+		if len(g.Positions) > 0 {
+			result = append(result, g)
+		}
+	}
+	return result, total
+}
+
+// printPositionGroups prints grouped positions with a subtotal per underlying.
+func printPositionGroups(formatter *output.Formatter, jsonMode bool, groups []positionGroup) error {
+	if jsonMode {
+		return formatter.Print(map[string]any{
+			"groups": groups,
+		})
+	}
+
+	headers := []string{"Underlying", "Symbol", "Type", "Qty", "Value", "% of Book"}
+	var rows [][]string
+	for _, g := range groups {
+		for i, pos := range g.Positions {
+			underlying := ""
+			if i == 0 {
+				underlying = g.Underlying
+			}
+			rows = append(rows, []string{
+				underlying,
+				pos.Instrument.Symbol,
+				pos.Instrument.Type,
+				pos.Quantity,
+				"$" + pos.CurrentValue,
+				"",
+			})
+		}
+		rows = append(rows, []string{
+			"",
+			"Subtotal",
+			"",
+			"",
+			fmt.Sprintf("$%.2f", g.Value),
+			fmt.Sprintf("%.1f%%", g.PercentOfBook),
 		})
 	}
 
@@ -273,7 +1263,10 @@ Examples:
 			opts.baseURL = cfg.APIBaseURL
 			opts.authToken = token
 			opts.jsonMode = GetJSONMode()
+			opts.wide = GetWideMode()
 			opts.defaultAccountID = cfg.AccountUUID
+			opts.fxRateURL = cfg.FXRateURL
+			opts.fxCachePath = fxCachePath()
 			// Create token refresher for 401 retry
 			opts.tokenRefresher = func() (string, error) {
 				return api.GetAuthToken(store, cfg.APIBaseURL, true)
@@ -289,7 +1282,7 @@ Examples:
 
 	// Add portfolio subcommand
 	var portfolioAccountID string
-	var portfolioOnly string
+	var portfolioFlags portfolioParams
 	portfolioCmd := &cobra.Command{
 		Use:   "portfolio",
 		Short: "View portfolio positions and balances",
@@ -302,29 +1295,57 @@ Examples:
   pub account portfolio --account YOUR_ACCOUNT_ID
   pub account portfolio --json --only buying-power  # Just buying power
   pub account portfolio --json --only positions     # Just positions array
-  pub account portfolio --json --only equity        # Just equity array`,
+  pub account portfolio --json --only equity        # Just equity array
+  pub account portfolio --group-by underlying       # Options grouped with their stock
+  pub account portfolio --refresh-quotes            # Revalue positions at live prices
+  pub account portfolio --min-value 10             # Hide positions worth under $10
+  pub account portfolio --sort value --desc         # Largest positions first
+  pub account portfolio --watch --interval 5m --log-csv equity.csv  # Log an equity curve
+  pub account portfolio --save-snapshot             # Save positions for a later --diff
+  pub account portfolio --diff                      # Changes since the latest snapshot
+  pub account portfolio --benchmark SPY             # Day change vs SPY
+  pub account portfolio --include-pending           # Held plus open-order quantity
+  pub account portfolio --summary                   # Position count and total P/L
+  pub account portfolio --currency EUR              # Values also shown in euros
+
+--benchmark compares the portfolio's day change (today's gain over the value
+at the previous close) with the benchmark's. Quotes carry no previous close,
+so the benchmark's day change is taken from a position in that symbol; when
+it is not held the comparison shows - and says why.
+
+--include-pending lists, per symbol, the quantity held, the unfilled
+quantity of open buy and sell orders, and the projected position if every
+open order filled in full.
+
+--summary ends the table with the number of positions, their total value, and
+the total unrealized (cost basis) and day P/L, counting positions hidden by
+--min-value. With --json these are in a summary object.
+
+--currency converts buying power, the account summary, and position values
+from USD at a rate fetched from fx_rate_url (default: frankfurter.app), shown
+with its date. Rates are cached for 10 minutes. If the rate cannot be
+fetched, values are shown in USD with a warning. With --json the USD figures
+are unchanged and the converted ones are in a converted object, with the rate
+in fx.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			accountID := portfolioAccountID
-			if accountID == "" {
-				accountID = opts.defaultAccountID
+			accountID, err := resolveAccountID(portfolioAccountID, opts.defaultAccountID, cmd.InOrStdin())
+			if err != nil {
+				return err
 			}
 			if accountID == "" {
 				return fmt.Errorf("account ID is required (use --account flag or set default with 'pub configure')")
 			}
-			// Validate --only flag
-			if portfolioOnly != "" {
-				if !opts.jsonMode {
-					return fmt.Errorf("--only requires --json flag")
-				}
-				if !validPortfolioFilters[portfolioOnly] {
-					return fmt.Errorf("invalid --only value %q: must be one of buying-power, positions, equity", portfolioOnly)
-				}
+			if err := validatePortfolioParams(portfolioFlags, opts.jsonMode); err != nil {
+				return err
+			}
+			if portfolioFlags.watch {
+				return runPortfolioWatch(cmd, opts, accountID, portfolioFlags)
 			}
-			return runPortfolio(cmd, opts, accountID, portfolioOnly)
+			return runPortfolio(cmd, opts, accountID, portfolioFlags)
 		},
 	}
-	portfolioCmd.Flags().StringVarP(&portfolioAccountID, "account", "a", "", "Account ID (uses default if configured)")
-	portfolioCmd.Flags().StringVar(&portfolioOnly, "only", "", "Filter JSON output to one section: buying-power, positions, equity")
+	portfolioCmd.Flags().StringVarP(&portfolioAccountID, "account", "a", "", "Account ID (uses default if configured; - reads it from stdin)")
+	addPortfolioFlags(portfolioCmd, &portfolioFlags)
 	portfolioCmd.SilenceUsage = true
 
 	accountCmd.AddCommand(portfolioCmd)
@@ -5,10 +5,16 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/jonandersen/public-cli/internal/api"
 )
 
 func TestAccountListCmd_Success(t *testing.T) {
@@ -793,3 +799,776 @@ func TestAccountPortfolioCmd_OnlyRequiresJSON(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "--only requires --json")
 }
+
+func TestAccountPortfolioCmd_GroupByUnderlying(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := map[string]any{
+			"accountId": "abc123",
+			"positions": []map[string]any{
+				{
+					"instrument":   map[string]any{"symbol": "MSFT", "type": "EQUITY"},
+					"quantity":     "1",
+					"currentValue": "400.00",
+				},
+				{
+					"instrument":   map[string]any{"symbol": "AAPL", "type": "EQUITY"},
+					"quantity":     "10",
+					"currentValue": "1750.00",
+				},
+				{
+					"instrument":   map[string]any{"symbol": "AAPL250117C00200000", "type": "OPTION"},
+					"quantity":     "1",
+					"currentValue": "250.00",
+				},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	t.Run("table", func(t *testing.T) {
+		cmd := newAccountCmd(accountOptions{baseURL: server.URL, authToken: "test-token"})
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetArgs([]string{"portfolio", "--account", "abc123", "--group-by", "underlying"})
+
+		require.NoError(t, cmd.Execute())
+
+		output := out.String()
+		assert.Contains(t, output, "AAPL250117C00200000")
+		assert.Contains(t, output, "Subtotal")
+		assert.Contains(t, output, "$2000.00")
+		assert.Contains(t, output, "83.3%")
+		assert.Less(t, strings.Index(output, "AAPL"), strings.Index(output, "MSFT"))
+	})
+
+	t.Run("json", func(t *testing.T) {
+		cmd := newAccountCmd(accountOptions{baseURL: server.URL, authToken: "test-token", jsonMode: true})
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetArgs([]string{"portfolio", "--account", "abc123", "--group-by", "underlying"})
+
+		require.NoError(t, cmd.Execute())
+
+		var result struct {
+			Groups []positionGroup `json:"groups"`
+		}
+		require.NoError(t, json.Unmarshal(out.Bytes(), &result))
+		require.Len(t, result.Groups, 2)
+		assert.Equal(t, "AAPL", result.Groups[0].Underlying)
+		assert.InDelta(t, 2000.0, result.Groups[0].Value, 0.001)
+		assert.Len(t, result.Groups[0].Positions, 2)
+		assert.Equal(t, "MSFT", result.Groups[1].Underlying)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		cmd := newAccountCmd(accountOptions{baseURL: server.URL, authToken: "test-token"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs([]string{"portfolio", "--account", "abc123", "--group-by", "sector"})
+
+		err := cmd.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid --group-by value")
+	})
+}
+
+func TestAccountPortfolioCmd_RefreshQuotes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/userapigateway/trading/abc123/portfolio/v2":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"accountId": "abc123",
+				"positions": []map[string]any{
+					{
+						"instrument":   map[string]any{"symbol": "AAPL", "type": "EQUITY"},
+						"quantity":     "10",
+						"currentValue": "1700.00",
+						"lastPrice":    map[string]any{"lastPrice": "170.00"},
+					},
+					{
+						"instrument":   map[string]any{"symbol": "AAPL250117C00200000", "type": "OPTION"},
+						"quantity":     "2",
+						"currentValue": "300.00",
+					},
+					{
+						"instrument":   map[string]any{"symbol": "MSFT", "type": "EQUITY"},
+						"quantity":     "1",
+						"currentValue": "400.00",
+					},
+				},
+			})
+		case "/userapigateway/marketdata/abc123/quotes":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"quotes": []map[string]any{
+					{"instrument": map[string]any{"symbol": "AAPL", "type": "EQUITY"}, "outcome": "SUCCESS", "last": "175.50"},
+					{"instrument": map[string]any{"symbol": "AAPL250117C00200000", "type": "OPTION"}, "outcome": "SUCCESS", "last": "1.25"},
+					{"instrument": map[string]any{"symbol": "MSFT", "type": "EQUITY"}, "outcome": "UNKNOWN"},
+				},
+			})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	cmd := newAccountCmd(accountOptions{baseURL: server.URL, authToken: "test-token", jsonMode: true})
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"portfolio", "--account", "abc123", "--refresh-quotes"})
+
+	require.NoError(t, cmd.Execute())
+
+	var result struct {
+		Positions []api.Position `json:"positions"`
+		Refreshed []string       `json:"refreshed"`
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &result))
+	require.Len(t, result.Positions, 3)
+	assert.Equal(t, "1755.00", result.Positions[0].CurrentValue)
+	assert.Equal(t, "175.50", result.Positions[0].LastPrice.LastPrice)
+	assert.Equal(t, "250.00", result.Positions[1].CurrentValue)
+	assert.Equal(t, "400.00", result.Positions[2].CurrentValue)
+	assert.Equal(t, []string{"AAPL", "AAPL250117C00200000"}, result.Refreshed)
+}
+
+func TestAppendPortfolioCSV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "equity.csv")
+	portfolio := &api.Portfolio{
+		Equity: []api.Equity{
+			{Type: "CASH", Value: "1000.00"},
+			{Type: "STOCK", Value: "4000.50"},
+		},
+		Positions: []api.Position{
+			{PositionDailyGain: api.Gain{GainValue: "25.25"}},
+			{PositionDailyGain: api.Gain{GainValue: "-5.00"}},
+		},
+	}
+
+	now := time.Date(2025, 1, 15, 14, 30, 0, 0, time.UTC)
+	require.NoError(t, appendPortfolioCSV(path, now, portfolio))
+	require.NoError(t, appendPortfolioCSV(path, now.Add(5*time.Minute), portfolio))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t,
+		"timestamp,total_value,cash,day_gain\n"+
+			"2025-01-15T14:30:00Z,5000.50,1000.00,20.25\n"+
+			"2025-01-15T14:35:00Z,5000.50,1000.00,20.25\n",
+		string(data))
+}
+
+func TestAccountPortfolioCmd_Benchmark(t *testing.T) {
+	quoteOutcome := "SUCCESS"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/userapigateway/trading/abc123/portfolio/v2":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"accountId": "abc123",
+				"equity": []map[string]any{
+					{"type": "CASH", "value": "1000.00"},
+					{"type": "STOCK", "value": "9200.00"},
+				},
+				"positions": []map[string]any{
+					{
+						"instrument":        map[string]any{"symbol": "AAPL", "type": "EQUITY"},
+						"quantity":          "20",
+						"currentValue":      "3600.00",
+						"positionDailyGain": map[string]any{"gainValue": "150.00", "gainPercentage": "4.35"},
+					},
+					{
+						"instrument":        map[string]any{"symbol": "SPY", "type": "EQUITY"},
+						"quantity":          "10",
+						"currentValue":      "5600.00",
+						"positionDailyGain": map[string]any{"gainValue": "50.00", "gainPercentage": "0.90"},
+					},
+				},
+			})
+		case "/userapigateway/marketdata/abc123/quotes":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"quotes": []map[string]any{
+					{"instrument": map[string]any{"symbol": "SPY", "type": "EQUITY"}, "outcome": quoteOutcome, "last": "560.00"},
+				},
+			})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	run := func(jsonMode bool, benchmark string) string {
+		cmd := newAccountCmd(accountOptions{baseURL: server.URL, authToken: "test-token", jsonMode: jsonMode})
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetArgs([]string{"portfolio", "--account", "abc123", "--benchmark", benchmark})
+		require.NoError(t, cmd.Execute())
+		return out.String()
+	}
+
+	// Day gain 200 on a 10000 previous close is +2.00%, 1.10 points ahead of SPY
+	output := run(false, "spy")
+	assert.Contains(t, output, "Benchmark (SPY):")
+	assert.Contains(t, output, "Portfolio day: +2.00%")
+	assert.Contains(t, output, "SPY day: +0.90% (last $560.00)")
+	assert.Contains(t, output, "Difference: +1.10 pts")
+
+	var result struct {
+		Benchmark benchmarkComparison `json:"benchmark"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(run(true, "SPY")), &result))
+	require.NotNil(t, result.Benchmark.Difference)
+	assert.InDelta(t, 1.10, *result.Benchmark.Difference, 0.0001)
+	assert.Equal(t, "560.00", result.Benchmark.BenchmarkLast)
+	assert.Empty(t, result.Benchmark.Note)
+
+	// Not held: the portfolio still prints, with the benchmark side unknown
+	output = run(false, "QQQ")
+	assert.Contains(t, output, "QQQ day: - (last $560.00)")
+	assert.Contains(t, output, "Difference: -")
+	assert.Contains(t, output, "only available when it is held")
+	assert.Contains(t, output, "AAPL")
+
+	quoteOutcome = "UNKNOWN"
+	output = run(false, "SPY")
+	assert.Contains(t, output, "SPY day: +0.90%\n")
+	assert.Contains(t, output, "Note: benchmark quote unavailable")
+}
+
+func TestValidatePortfolioParams_Benchmark(t *testing.T) {
+	assert.NoError(t, validatePortfolioParams(portfolioParams{benchmark: "SPY", groupBy: "underlying"}, false))
+	assert.ErrorContains(t, validatePortfolioParams(portfolioParams{benchmark: "SPY", only: "positions"}, true), "--benchmark cannot be combined")
+	assert.ErrorContains(t, validatePortfolioParams(portfolioParams{benchmark: "SPY", diff: diffLatest}, false), "--benchmark cannot be combined")
+	assert.ErrorContains(t, validatePortfolioParams(portfolioParams{benchmark: "SPY", groupBy: "underlying"}, true), "--group-by")
+}
+
+func TestJoinPendingOrders(t *testing.T) {
+	positions := []api.Position{
+		{Instrument: api.Instrument{Symbol: "AAPL", Type: "EQUITY"}, Quantity: "100"},
+		{Instrument: api.Instrument{Symbol: "MSFT", Type: "EQUITY"}, Quantity: "5"},
+	}
+	orders := []api.Order{
+		{Instrument: api.Instrument{Symbol: "AAPL"}, Side: "SELL", Status: "NEW", Quantity: "40"},
+		{Instrument: api.Instrument{Symbol: "AAPL"}, Side: "BUY", Status: "PARTIALLY_FILLED", Quantity: "10", FilledQuantity: "4"},
+		{Instrument: api.Instrument{Symbol: "TSLA"}, Side: "BUY", Status: "NEW", Quantity: "2.5"},
+		{Instrument: api.Instrument{Symbol: "MSFT"}, Side: "BUY", Status: "FILLED", Quantity: "5", FilledQuantity: "5"},
+	}
+
+	got := joinPendingOrders(positions, orders)
+	assert.Equal(t, []pendingExposure{
+		{Symbol: "AAPL", Held: 100, PendingBuy: 6, PendingSell: 40, Projected: 66, OpenOrders: 2},
+		{Symbol: "MSFT", Held: 5, Projected: 5},
+		{Symbol: "TSLA", PendingBuy: 2.5, Projected: 2.5, OpenOrders: 1},
+	}, got)
+}
+
+func TestAccountPortfolioCmd_IncludePending(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/userapigateway/trading/abc123/portfolio/v2", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"accountId": "abc123",
+			"positions": []map[string]any{
+				{"instrument": map[string]any{"symbol": "AAPL", "type": "EQUITY"}, "quantity": "100", "currentValue": "17500.00"},
+			},
+			"orders": []map[string]any{
+				{"orderId": "o1", "instrument": map[string]any{"symbol": "AAPL", "type": "EQUITY"}, "side": "SELL", "status": "NEW", "quantity": "40", "limitPrice": "190.00"},
+			},
+		})
+	}))
+	defer server.Close()
+
+	run := func(jsonMode bool) string {
+		cmd := newAccountCmd(accountOptions{baseURL: server.URL, authToken: "test-token", jsonMode: jsonMode})
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetArgs([]string{"portfolio", "--account", "abc123", "--include-pending"})
+		require.NoError(t, cmd.Execute())
+		return out.String()
+	}
+
+	output := run(false)
+	assert.Contains(t, output, "Pending Sell")
+	assert.Regexp(t, `AAPL\s+100\s+0\s+40\s+60\s+1`, output)
+	assert.Contains(t, output, "Projected assumes every open order fills in full.")
+
+	var result struct {
+		Positions []pendingExposure `json:"positions"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(run(true)), &result))
+	require.Len(t, result.Positions, 1)
+	assert.Equal(t, 60.0, result.Positions[0].Projected)
+}
+
+func TestValidatePortfolioParams_IncludePending(t *testing.T) {
+	assert.NoError(t, validatePortfolioParams(portfolioParams{includePending: true}, true))
+	assert.ErrorContains(t, validatePortfolioParams(portfolioParams{includePending: true, diff: diffLatest}, false), "--include-pending cannot be combined")
+	assert.ErrorContains(t, validatePortfolioParams(portfolioParams{includePending: true, groupBy: "underlying"}, false), "--include-pending cannot be combined")
+}
+
+func TestValidatePortfolioParams_Interval(t *testing.T) {
+	assert.NoError(t, validatePortfolioParams(portfolioParams{watch: true, interval: time.Minute}, false))
+	err := validatePortfolioParams(portfolioParams{watch: true, interval: time.Second}, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid --interval")
+}
+
+func TestAccountPortfolioCmd_Sort(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := map[string]any{
+			"accountId": "abc123",
+			"positions": []map[string]any{
+				{"instrument": map[string]any{"symbol": "AAPL", "type": "EQUITY"}, "quantity": "10", "currentValue": "1750.00"},
+				{"instrument": map[string]any{"symbol": "MSFT", "type": "EQUITY"}, "quantity": "5", "currentValue": "2000.00"},
+				{"instrument": map[string]any{"symbol": "F", "type": "EQUITY"}, "quantity": "100", "currentValue": ""},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	t.Run("json value desc", func(t *testing.T) {
+		cmd := newAccountCmd(accountOptions{baseURL: server.URL, authToken: "test-token", jsonMode: true})
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetArgs([]string{"portfolio", "--account", "abc123", "--sort", "value", "--desc"})
+
+		require.NoError(t, cmd.Execute())
+
+		var result struct {
+			Positions []api.Position `json:"positions"`
+		}
+		require.NoError(t, json.Unmarshal(out.Bytes(), &result))
+		require.Len(t, result.Positions, 3)
+		assert.Equal(t, "MSFT", result.Positions[0].Instrument.Symbol)
+		assert.Equal(t, "AAPL", result.Positions[1].Instrument.Symbol)
+		assert.Equal(t, "F", result.Positions[2].Instrument.Symbol, "missing value sorts last")
+	})
+
+	t.Run("table quantity", func(t *testing.T) {
+		cmd := newAccountCmd(accountOptions{baseURL: server.URL, authToken: "test-token"})
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetArgs([]string{"portfolio", "--account", "abc123", "--sort", "quantity"})
+
+		require.NoError(t, cmd.Execute())
+
+		output := out.String()
+		assert.Less(t, strings.Index(output, "MSFT"), strings.Index(output, "AAPL"))
+		assert.Less(t, strings.Index(output, "AAPL"), strings.Index(output, "F "))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, args := range [][]string{{"--sort", "price"}, {"--desc"}} {
+			cmd := newAccountCmd(accountOptions{baseURL: server.URL, authToken: "test-token"})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+			cmd.SetArgs(append([]string{"portfolio", "--account", "abc123"}, args...))
+			assert.Error(t, cmd.Execute(), args)
+		}
+	})
+}
+
+func TestAccountPortfolioCmd_MinValue(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := map[string]any{
+			"accountId": "abc123",
+			"positions": []map[string]any{
+				{
+					"instrument":   map[string]any{"symbol": "AAPL", "type": "EQUITY"},
+					"quantity":     "10",
+					"currentValue": "1750.00",
+				},
+				{
+					"instrument":   map[string]any{"symbol": "DUST", "type": "EQUITY"},
+					"quantity":     "0.01",
+					"currentValue": "0.42",
+				},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	t.Run("table", func(t *testing.T) {
+		cmd := newAccountCmd(accountOptions{baseURL: server.URL, authToken: "test-token"})
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetArgs([]string{"portfolio", "--account", "abc123", "--min-value", "1"})
+
+		require.NoError(t, cmd.Execute())
+
+		output := out.String()
+		assert.Contains(t, output, "AAPL")
+		assert.NotContains(t, output, "DUST")
+		assert.Contains(t, output, "1 position under $1.00 hidden")
+	})
+
+	t.Run("grouped subtotal keeps hidden value", func(t *testing.T) {
+		groups, hidden := hideSmallGroupedPositions([]positionGroup{
+			{Underlying: "AAPL", Value: 1751, Positions: []api.Position{
+				{Instrument: api.Instrument{Symbol: "AAPL"}, CurrentValue: "1750.00"},
+				{Instrument: api.Instrument{Symbol: "AAPL250117C00200000"}, CurrentValue: "1.00"},
+			}},
+			{Underlying: "DUST", Value: 0.42, Positions: []api.Position{
+				{Instrument: api.Instrument{Symbol: "DUST"}, CurrentValue: "0.42"},
+			}},
+		}, 5)
+		assert.Equal(t, 2, hidden)
+		require.Len(t, groups, 1)
+		assert.InDelta(t, 1751.0, groups[0].Value, 0.001)
+		assert.Len(t, groups[0].Positions, 1)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		cmd := newAccountCmd(accountOptions{baseURL: server.URL, authToken: "test-token", jsonMode: true})
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetArgs([]string{"portfolio", "--account", "abc123", "--min-value", "1"})
+
+		require.NoError(t, cmd.Execute())
+
+		var result struct {
+			Positions       []api.Position `json:"positions"`
+			HiddenPositions int            `json:"hiddenPositions"`
+		}
+		require.NoError(t, json.Unmarshal(out.Bytes(), &result))
+		assert.Len(t, result.Positions, 1)
+		assert.Equal(t, 1, result.HiddenPositions)
+	})
+}
+
+func TestDiffPositions(t *testing.T) {
+	before := []api.Position{
+		{Instrument: api.Instrument{Symbol: "AAPL"}, Quantity: "10", CurrentValue: "1750.00"},
+		{Instrument: api.Instrument{Symbol: "MSFT"}, Quantity: "5", CurrentValue: "2000.00"},
+		{Instrument: api.Instrument{Symbol: "TSLA"}, Quantity: "2", CurrentValue: "500.00"},
+	}
+	after := []api.Position{
+		{Instrument: api.Instrument{Symbol: "AAPL"}, Quantity: "15", CurrentValue: "2700.00"},
+		{Instrument: api.Instrument{Symbol: "MSFT"}, Quantity: "5", CurrentValue: "2050.00"},
+		{Instrument: api.Instrument{Symbol: "NVDA"}, Quantity: "1", CurrentValue: "130.00"},
+	}
+
+	deltas := diffPositions(before, after)
+	require.Len(t, deltas, 4)
+
+	assert.Equal(t, positionDelta{Symbol: "AAPL", Status: "changed", OldQuantity: 10, NewQuantity: 15, QuantityChange: 5,
+		OldValue: 1750, NewValue: 2700, ValueChange: 950}, deltas[0])
+	assert.Equal(t, "MSFT", deltas[1].Symbol)
+	assert.Equal(t, "unchanged", deltas[1].Status)
+	assert.InDelta(t, 50.0, deltas[1].ValueChange, 0.001)
+	assert.Equal(t, "NVDA", deltas[2].Symbol)
+	assert.Equal(t, "added", deltas[2].Status)
+	assert.Equal(t, "TSLA", deltas[3].Symbol)
+	assert.Equal(t, "removed", deltas[3].Status)
+	assert.InDelta(t, -2.0, deltas[3].QuantityChange, 0.001)
+}
+
+func TestAccountPortfolioCmd_SnapshotDiff(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	quantity := "10"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"accountId": "abc123",
+			"positions": []map[string]any{
+				{"instrument": map[string]any{"symbol": "AAPL", "type": "EQUITY"}, "quantity": quantity, "currentValue": "1750.00"},
+			},
+		})
+	}))
+	defer server.Close()
+
+	run := func(jsonMode bool, args ...string) (string, error) {
+		cmd := newAccountCmd(accountOptions{baseURL: server.URL, authToken: "test-token", jsonMode: jsonMode})
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs(append([]string{"portfolio", "--account", "abc123"}, args...))
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	_, err := run(false, "--diff")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no portfolio snapshots found")
+
+	_, err = run(false, "--save-snapshot")
+	require.NoError(t, err)
+	matches, _ := filepath.Glob(filepath.Join(portfolioSnapshotDir(), "portfolio-abc123-*.json"))
+	require.Len(t, matches, 1)
+
+	quantity = "12"
+	output, err := run(false, "--diff")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Changes since")
+	assert.Contains(t, output, "changed")
+	assert.Contains(t, output, "+2")
+
+	output, err = run(true, "--diff="+matches[0])
+	require.NoError(t, err)
+	var result struct {
+		Snapshot  string          `json:"snapshot"`
+		Positions []positionDelta `json:"positions"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(output), &result))
+	assert.Equal(t, matches[0], result.Snapshot)
+	require.Len(t, result.Positions, 1)
+	assert.InDelta(t, 2.0, result.Positions[0].QuantityChange, 0.001)
+
+	// --save-snapshot --diff compares with the earlier snapshot, then saves
+	quantity = "15"
+	output, err = run(false, "--save-snapshot", "--diff")
+	require.NoError(t, err)
+	assert.Contains(t, output, "+5")
+	output, err = run(false, "--diff")
+	require.NoError(t, err)
+	assert.NotContains(t, output, "+5", "the new snapshot is the latest now")
+}
+
+func TestResolveAccountID(t *testing.T) {
+	const piped = "12345678-1234-1234-1234-123456789012"
+
+	id, err := resolveAccountID("", "default-account", strings.NewReader(""))
+	require.NoError(t, err)
+	assert.Equal(t, "default-account", id)
+
+	id, err = resolveAccountID("flag-account", "default-account", strings.NewReader(piped))
+	require.NoError(t, err)
+	assert.Equal(t, "flag-account", id)
+
+	id, err = resolveAccountID("-", "default-account", strings.NewReader(piped+"\nignored\n"))
+	require.NoError(t, err)
+	assert.Equal(t, piped, id)
+
+	id, err = resolveAccountID("-", "", strings.NewReader("  "+piped+"\r\n"))
+	require.NoError(t, err)
+	assert.Equal(t, piped, id)
+
+	_, err = resolveAccountID("-", "default-account", strings.NewReader(""))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no account ID on stdin")
+
+	_, err = resolveAccountID("-", "", strings.NewReader("null\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not an account UUID")
+}
+
+func TestAccountPortfolioCmd_AccountFromStdin(t *testing.T) {
+	const piped = "12345678-1234-1234-1234-123456789012"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/userapigateway/trading/"+piped+"/portfolio/v2", r.URL.Path)
+		_ = json.NewEncoder(w).Encode(map[string]any{"accountId": piped})
+	}))
+	defer server.Close()
+
+	cmd := newAccountCmd(accountOptions{
+		baseURL:          server.URL,
+		authToken:        "test-token",
+		jsonMode:         true,
+		defaultAccountID: "default-account",
+	})
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetIn(strings.NewReader(piped + "\n"))
+	cmd.SetArgs([]string{"portfolio", "--account", "-"})
+
+	require.NoError(t, cmd.Execute())
+}
+
+func TestAccountPortfolioCmd_Wide(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"accountId": "abc123",
+			"positions": []map[string]any{
+				{
+					"instrument":         map[string]any{"symbol": "AAPL", "type": "EQUITY"},
+					"quantity":           "10",
+					"currentValue":       "1750.00",
+					"percentOfPortfolio": "35.00",
+					"lastPrice":          map[string]any{"lastPrice": "175.00"},
+					"costBasis":          map[string]any{"totalCost": "1500.00", "unitCost": "150.00"},
+				},
+			},
+		})
+	}))
+	defer server.Close()
+
+	run := func(wide bool) string {
+		cmd := newAccountCmd(accountOptions{baseURL: server.URL, authToken: "test-token", wide: wide})
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetArgs([]string{"portfolio", "--account", "abc123"})
+		require.NoError(t, cmd.Execute())
+		return out.String()
+	}
+
+	narrow := run(false)
+	assert.NotContains(t, narrow, "Avg Cost")
+	assert.NotContains(t, narrow, "$150.00")
+
+	wide := run(true)
+	assert.Contains(t, wide, "Avg Cost")
+	assert.Contains(t, wide, "$150.00")
+	assert.Contains(t, wide, "$1500.00")
+	assert.Contains(t, wide, "$175.00")
+	assert.Contains(t, wide, "35.00%")
+}
+
+func TestAccountPortfolioCmd_Summary(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"buyingPower": map[string]any{"buyingPower": "1000.00", "optionsBuyingPower": "500.00"},
+			"positions": []map[string]any{
+				{
+					"instrument":        map[string]any{"symbol": "AAPL", "type": "EQUITY"},
+					"quantity":          "10",
+					"currentValue":      "1750.00",
+					"positionDailyGain": map[string]any{"gainValue": "50.00", "gainPercentage": "2.94"},
+					"costBasis":         map[string]any{"totalCost": "1500.00", "gainValue": "250.00", "gainPercentage": "16.67"},
+				},
+				{
+					"instrument":        map[string]any{"symbol": "TSLA", "type": "EQUITY"},
+					"quantity":          "1",
+					"currentValue":      "5.00",
+					"positionDailyGain": map[string]any{"gainValue": "-80.25", "gainPercentage": "-3.10"},
+					"costBasis":         map[string]any{"totalCost": "400.00", "gainValue": "-395.00", "gainPercentage": "-98.75"},
+				},
+			},
+		})
+	}))
+	defer server.Close()
+
+	cmd := newAccountCmd(accountOptions{baseURL: server.URL, authToken: "test-token"})
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"portfolio", "--account", "abc123", "--summary", "--min-value", "10"})
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), "Summary: 2 positions, value $1755.00, unrealized P/L -$145.00, day P/L -$30.25")
+
+	cmd = newAccountCmd(accountOptions{baseURL: server.URL, authToken: "test-token", jsonMode: true})
+	out.Reset()
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"portfolio", "--account", "abc123", "--summary"})
+	require.NoError(t, cmd.Execute())
+
+	var result struct {
+		Summary portfolioSummary `json:"summary"`
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &result))
+	assert.Equal(t, portfolioSummary{Positions: 2, TotalValue: "1755.00", UnrealizedGain: "-145.00", DayGain: "-30.25"}, result.Summary)
+}
+
+func TestValidatePortfolioParams_Summary(t *testing.T) {
+	assert.NoError(t, validatePortfolioParams(portfolioParams{summary: true, minValue: 5}, false))
+	assert.ErrorContains(t, validatePortfolioParams(portfolioParams{summary: true, groupBy: "underlying"}, false), "--summary cannot be combined")
+	assert.ErrorContains(t, validatePortfolioParams(portfolioParams{summary: true, includePending: true}, false), "--summary cannot be combined")
+}
+
+func TestAccountPortfolioCmd_Currency(t *testing.T) {
+	fxServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "EUR", r.URL.Query().Get("to"))
+		assert.Empty(t, r.Header.Get("Authorization"), "credentials must not reach the rate source")
+		_, _ = w.Write([]byte(`{"amount":1.0,"base":"USD","date":"2026-10-15","rates":{"EUR":0.9}}`))
+	}))
+	defer fxServer.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"buyingPower": map[string]any{"buyingPower": "1000.00", "optionsBuyingPower": "500.00"},
+			"equity": []map[string]any{
+				{"type": "CASH", "value": "1000.00", "percentageOfPortfolio": "36.36"},
+				{"type": "STOCK", "value": "1750.00", "percentageOfPortfolio": "63.64"},
+			},
+			"positions": []map[string]any{
+				{
+					"instrument":   map[string]any{"symbol": "AAPL", "type": "EQUITY"},
+					"quantity":     "10",
+					"currentValue": "1750.00",
+				},
+			},
+		})
+	}))
+	defer server.Close()
+
+	opts := accountOptions{
+		baseURL:   server.URL,
+		authToken: "test-token",
+		fxRateURL: fxServer.URL + "/latest?from=USD&to={currency}",
+	}
+
+	cmd := newAccountCmd(opts)
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"portfolio", "--account", "abc123", "--currency", "eur"})
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), "Currency: EUR at 0.9000 per USD (rate for 2026-10-15")
+	assert.Contains(t, out.String(), "Buying Power: $1000.00 (900.00 EUR)")
+	assert.Contains(t, out.String(), "STOCK: $1750.00 (63.64%) (1575.00 EUR)")
+	assert.Contains(t, out.String(), "Value (EUR)")
+	assert.Contains(t, out.String(), "1575.00")
+
+	opts.jsonMode = true
+	cmd = newAccountCmd(opts)
+	out.Reset()
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"portfolio", "--account", "abc123", "--currency", "EUR"})
+	require.NoError(t, cmd.Execute())
+
+	var result struct {
+		BuyingPower api.BuyingPower    `json:"buyingPower"`
+		FX          fxRate             `json:"fx"`
+		Converted   convertedPortfolio `json:"converted"`
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &result))
+	assert.Equal(t, "1000.00", result.BuyingPower.BuyingPower)
+	assert.Equal(t, 0.9, result.FX.Rate)
+	assert.Equal(t, "2026-10-15", result.FX.AsOf)
+	assert.Equal(t, "EUR", result.Converted.Currency)
+	assert.Equal(t, "900.00", result.Converted.BuyingPower)
+	assert.Equal(t, "2475.00", result.Converted.TotalValue)
+	assert.Equal(t, []convertedPosition{{Symbol: "AAPL", Value: "1575.00"}}, result.Converted.Positions)
+}
+
+func TestAccountPortfolioCmd_CurrencyFallsBackToUSD(t *testing.T) {
+	fxServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer fxServer.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"buyingPower": map[string]any{"buyingPower": "1000.00", "optionsBuyingPower": "500.00"},
+		})
+	}))
+	defer server.Close()
+
+	cmd := newAccountCmd(accountOptions{baseURL: server.URL, authToken: "test-token", fxRateURL: fxServer.URL})
+	var out, errOut bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&errOut)
+	cmd.SetArgs([]string{"portfolio", "--account", "abc123", "--currency", "EUR"})
+	require.NoError(t, cmd.Execute())
+
+	assert.Contains(t, errOut.String(), "Warning: could not fetch the USD/EUR exchange rate")
+	assert.Contains(t, errOut.String(), "showing USD values")
+	assert.Contains(t, out.String(), "Buying Power: $1000.00\n")
+	assert.NotContains(t, out.String(), "EUR")
+}
+
+func TestValidatePortfolioParams_Currency(t *testing.T) {
+	assert.NoError(t, validatePortfolioParams(portfolioParams{currency: "eur", summary: true}, false))
+	assert.NoError(t, validatePortfolioParams(portfolioParams{currency: "USD", groupBy: "underlying"}, false))
+	assert.ErrorContains(t, validatePortfolioParams(portfolioParams{currency: "euro"}, false), "invalid --currency")
+	assert.ErrorContains(t, validatePortfolioParams(portfolioParams{currency: "EUR", groupBy: "underlying"}, false), "--currency cannot be combined")
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// quantityCap is a fat-finger limit on order size that holds regardless of
+// price: max_shares or max_contracts from config, or the matching flag for a
+// single order. Callers skip the check when --force is set.
+type quantityCap struct {
+	limit  float64 // 0 disables the cap
+	source string  // the flag or config key that set the cap
+	unit   string  // "shares" or "contracts"
+}
+
+// newQuantityCap returns the cap given by the flag when it is positive, and
+// the configured cap otherwise.
+func newQuantityCap(unit, flagName string, flagValue float64, configKey string, configValue float64) quantityCap {
+	if flagValue > 0 {
+		return quantityCap{limit: flagValue, source: flagName, unit: unit}
+	}
+	return quantityCap{limit: configValue, source: configKey, unit: unit}
+}
+
+// check rejects qty when it exceeds the cap. what names the quantity being
+// checked, e.g. "order" or "leg AAPL250117C00175000".
+func (c quantityCap) check(what string, qty float64) error {
+	if c.limit <= 0 || qty <= c.limit {
+		return nil
+	}
+	return fmt.Errorf("%s of %s %s exceeds %s %s (use --force to override)",
+		what, strconv.FormatFloat(qty, 'f', -1, 64), c.unit, c.source, strconv.FormatFloat(c.limit, 'f', -1, 64))
+}
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewQuantityCap(t *testing.T) {
+	assert.Equal(t, quantityCap{limit: 50, source: "--max-shares", unit: "shares"},
+		newQuantityCap("shares", "--max-shares", 50, "max_shares", 100))
+	assert.Equal(t, quantityCap{limit: 100, source: "max_shares", unit: "shares"},
+		newQuantityCap("shares", "--max-shares", 0, "max_shares", 100))
+}
+
+func TestQuantityCapCheck(t *testing.T) {
+	shares := quantityCap{limit: 100, source: "max_shares", unit: "shares"}
+	assert.NoError(t, shares.check("order", 100))
+	assert.NoError(t, shares.check("order", 99.5))
+	assert.EqualError(t, shares.check("order", 1000), "order of 1000 shares exceeds max_shares 100 (use --force to override)")
+
+	contracts := quantityCap{limit: 10, source: "--max-contracts", unit: "contracts"}
+	assert.EqualError(t, contracts.check("leg AAPL250117C00175000", 20),
+		"leg AAPL250117C00175000 of 20 contracts exceeds --max-contracts 10 (use --force to override)")
+
+	assert.NoError(t, quantityCap{unit: "shares"}.check("order", 1e9), "zero limit disables the cap")
+}
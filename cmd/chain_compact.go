@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/jonandersen/public-cli/internal/api"
+)
+
+// chainCompactFormat lays out a --compact row: call open interest, volume,
+// bid, and ask, the strike, then the put columns mirrored around it.
+const chainCompactFormat = "%10s  %10s  %8s  %8s | %8s | %8s  %8s  %10s  %10s\n"
+
+// chainCompactWidth is the width of a --compact row. Narrower terminals get
+// the stacked layout instead.
+var chainCompactWidth = len(fmt.Sprintf(chainCompactFormat, "", "", "", "", "", "", "", "", "")) - 1
+
+// addChainCompactFlags registers --compact and its --combined alias.
+func addChainCompactFlags(cmd *cobra.Command, compact *bool) {
+	cmd.Flags().BoolVar(compact, "compact", false, "Show calls and puts in one table, aligned by strike")
+	cmd.Flags().BoolVar(compact, "combined", false, "Alias for --compact")
+}
+
+// validateChainCompact checks --compact against the other chain flags. The
+// compact table has a fixed set of columns and needs both sides of the chain
+// ordered by strike, so flags that change either are rejected.
+func validateChainCompact(view chainView, jsonMode, oneSide, expirationRange bool) error {
+	if !view.compact {
+		return nil
+	}
+	switch {
+	case jsonMode || view.csv:
+		return fmt.Errorf("--compact is a table layout and cannot be combined with --json or --csv")
+	case oneSide:
+		return fmt.Errorf("--compact shows calls and puts side by side and cannot be combined with --calls-only or --puts-only")
+	case view.breakeven || view.deltaHint || view.spreadWidth > 0:
+		return fmt.Errorf("--compact cannot be combined with --breakeven, --delta-neutral-hint, or --spread-width")
+	case view.sortBy != "" && view.sortBy != "strike":
+		return fmt.Errorf("--compact lines calls and puts up by strike; use --sort strike (or --desc)")
+	case expirationRange:
+		return fmt.Errorf("--compact is not supported with --expiration-range")
+	}
+	return nil
+}
+
+// chainStrikeRow is one strike of the compact chain. A side is nil when the
+// chain has no contract at that strike.
+type chainStrikeRow struct {
+	strike float64
+	call   *api.OptionQuote
+	put    *api.OptionQuote
+}
+
+// pairChainByStrike lines calls and puts up by strike, ascending or, with
+// desc, descending.
+func pairChainByStrike(calls, puts []api.OptionQuote, desc bool) []chainStrikeRow {
+	byStrike := make(map[float64]*chainStrikeRow)
+	row := func(symbol string) *chainStrikeRow {
+		strike := parseStrikeFloat(symbol)
+		r, ok := byStrike[strike]
+		if !ok {
+			r = &chainStrikeRow{strike: strike}
+			byStrike[strike] = r
+		}
+		return r
+	}
+	for i := range calls {
+		row(calls[i].Instrument.Symbol).call = &calls[i]
+	}
+	for i := range puts {
+		row(puts[i].Instrument.Symbol).put = &puts[i]
+	}
+
+	rows := make([]chainStrikeRow, 0, len(byStrike))
+	for _, r := range byStrike {
+		rows = append(rows, *r)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if desc {
+			return rows[i].strike > rows[j].strike
+		}
+		return rows[i].strike < rows[j].strike
+	})
+	return rows
+}
+
+// printChainCompact prints calls and puts in one table with the strike in the
+// middle. Cells on a side with no contract at that strike are left blank.
+func printChainCompact(w io.Writer, rows []chainStrikeRow) {
+	_, _ = fmt.Fprintf(w, "%-42s   %8s   %s\n", "CALLS", "", "PUTS")
+	_, _ = fmt.Fprintf(w, chainCompactFormat, "OI", "Volume", "Bid", "Ask", "Strike", "Bid", "Ask", "Volume", "OI")
+	_, _ = fmt.Fprintf(w, chainCompactFormat, "------", "------", "------", "------", "------", "------", "------", "------", "------")
+	for _, r := range rows {
+		var callOI, callVol, callBid, callAsk, putBid, putAsk, putVol, putOI string
+		strike := strconv.FormatFloat(r.strike, 'f', -1, 64)
+		if r.call != nil {
+			strike = parseStrikeFromSymbol(r.call.Instrument.Symbol)
+			callOI, callVol = strconv.Itoa(r.call.OpenInterest), strconv.Itoa(r.call.Volume)
+			callBid, callAsk = r.call.Bid, r.call.Ask
+		}
+		if r.put != nil {
+			strike = parseStrikeFromSymbol(r.put.Instrument.Symbol)
+			putBid, putAsk = r.put.Bid, r.put.Ask
+			putVol, putOI = strconv.Itoa(r.put.Volume), strconv.Itoa(r.put.OpenInterest)
+		}
+		line := fmt.Sprintf(chainCompactFormat, callOI, callVol, callBid, callAsk, strike, putBid, putAsk, putVol, putOI)
+		_, _ = fmt.Fprintln(w, strings.TrimRight(line, " \n"))
+	}
+}
+
+// writerWidth returns the width of the terminal w writes to, or 0 when w is
+// not a terminal (piped, redirected, or a buffer).
+func writerWidth(w io.Writer) int {
+	f, ok := w.(*os.File)
+	if !ok || !term.IsTerminal(int(f.Fd())) {
+		return 0
+	}
+	width, _, err := term.GetSize(int(f.Fd()))
+	if err != nil {
+		return 0
+	}
+	return width
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jonandersen/public-cli/internal/api"
+)
+
+// newChainCompactServer serves a chain whose 170 strike has only a call and
+// whose 172.50 strike has only a put.
+func newChainCompactServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"baseSymbol": "AAPL",
+			"calls": []map[string]any{
+				{"instrument": map[string]any{"symbol": "AAPL250117C00175000", "type": "OPTION"}, "bid": "2.40", "ask": "2.50", "volume": 1200, "openInterest": 5400},
+				{"instrument": map[string]any{"symbol": "AAPL250117C00170000", "type": "OPTION"}, "bid": "6.00", "ask": "6.20", "volume": 300, "openInterest": 900},
+			},
+			"puts": []map[string]any{
+				{"instrument": map[string]any{"symbol": "AAPL250117P00175000", "type": "OPTION"}, "bid": "1.10", "ask": "1.20", "volume": 800, "openInterest": 3100},
+				{"instrument": map[string]any{"symbol": "AAPL250117P00172500", "type": "OPTION"}, "bid": "0.60", "ask": "0.65", "volume": 50, "openInterest": 220},
+			},
+		})
+	}))
+}
+
+func TestRunOptionsChain_Compact(t *testing.T) {
+	server := newChainCompactServer(t)
+	defer server.Close()
+	opts := optionsOptions{baseURL: server.URL, authToken: "test-token", accountID: "test-account"}
+
+	cmd := newTestCmd()
+	require.NoError(t, runOptionsChain(cmd, opts, "AAPL", "2025-01-17", chainFilter{}, chainView{compact: true}))
+	output := cmd.OutOrStdout().(*bytes.Buffer).String()
+
+	assert.NotContains(t, output, "CALLS\n")
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	rows := lines[len(lines)-3:]
+	assert.Equal(t, "       900         300      6.00      6.20 |      170 |", rows[0])
+	assert.Regexp(t, `^\s+\|\s+172\.50 \|\s+0\.60\s+0\.65\s+50\s+220$`, rows[1])
+	assert.Regexp(t, `^\s+5400\s+1200\s+2\.40\s+2\.50 \|\s+175 \|\s+1\.10\s+1\.20\s+800\s+3100$`, rows[2])
+	for _, line := range lines[2:] {
+		assert.LessOrEqual(t, len(line), chainCompactWidth)
+	}
+
+	cmd = newTestCmd()
+	require.NoError(t, runOptionsChain(cmd, opts, "AAPL", "2025-01-17", chainFilter{}, chainView{compact: true, desc: true}))
+	output = cmd.OutOrStdout().(*bytes.Buffer).String()
+	assert.Less(t, strings.Index(output, "|      175 |"), strings.Index(output, "|      170 |"))
+}
+
+func TestRunOptionsChain_CompactFallsBackWhenNarrow(t *testing.T) {
+	server := newChainCompactServer(t)
+	defer server.Close()
+	opts := optionsOptions{baseURL: server.URL, authToken: "test-token", accountID: "test-account"}
+
+	cmd := newTestCmd()
+	var errOut bytes.Buffer
+	cmd.SetErr(&errOut)
+	require.NoError(t, runOptionsChain(cmd, opts, "AAPL", "2025-01-17", chainFilter{}, chainView{compact: true, termWidth: 80}))
+
+	output := cmd.OutOrStdout().(*bytes.Buffer).String()
+	assert.Contains(t, output, "CALLS\n")
+	assert.Contains(t, output, "PUTS\n")
+	assert.Contains(t, errOut.String(), "Terminal is 80 columns wide")
+}
+
+func TestPairChainByStrike(t *testing.T) {
+	opt := func(symbol string) api.OptionQuote {
+		return api.OptionQuote{Instrument: api.OptionInstrument{Symbol: symbol}}
+	}
+	calls := []api.OptionQuote{opt("AAPL250117C00180000"), opt("AAPL250117C00175000")}
+	puts := []api.OptionQuote{opt("AAPL250117P00175000"), opt("AAPL250117P00170000")}
+
+	rows := pairChainByStrike(calls, puts, false)
+	require.Len(t, rows, 3)
+	assert.Equal(t, 170.0, rows[0].strike)
+	assert.Nil(t, rows[0].call)
+	assert.Equal(t, "AAPL250117P00170000", rows[0].put.Instrument.Symbol)
+	assert.Equal(t, "AAPL250117C00175000", rows[1].call.Instrument.Symbol)
+	assert.Equal(t, "AAPL250117P00175000", rows[1].put.Instrument.Symbol)
+	assert.Nil(t, rows[2].put)
+}
+
+func TestValidateChainCompact(t *testing.T) {
+	assert.NoError(t, validateChainCompact(chainView{}, true, true, true))
+	assert.NoError(t, validateChainCompact(chainView{compact: true, sortBy: "strike", desc: true}, false, false, false))
+
+	tests := []struct {
+		name    string
+		view    chainView
+		json    bool
+		oneSide bool
+		rng     bool
+		wantErr string
+	}{
+		{"json", chainView{compact: true}, true, false, false, "--json or --csv"},
+		{"csv", chainView{compact: true, csv: true}, false, false, false, "--json or --csv"},
+		{"one side", chainView{compact: true}, false, true, false, "--calls-only or --puts-only"},
+		{"breakeven", chainView{compact: true, breakeven: true}, false, false, false, "--breakeven"},
+		{"sort", chainView{compact: true, sortBy: "volume"}, false, false, false, "use --sort strike"},
+		{"range", chainView{compact: true}, false, false, true, "--expiration-range"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorContains(t, validateChainCompact(tt.view, tt.json, tt.oneSide, tt.rng), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+
+	"github.com/jonandersen/public-cli/internal/analytics"
+	"github.com/jonandersen/public-cli/internal/api"
+)
+
+// chainCSVColumns is the options chain --csv header. Scripts read these
+// columns by position, so the order is fixed: new columns may only be
+// appended, never inserted or renamed.
+var chainCSVColumns = []string{
+	"symbol",        // OSI contract symbol
+	"underlying",    // underlying root from the OSI symbol
+	"type",          // CALL or PUT
+	"expiration",    // YYYY-MM-DD
+	"strike",        // plain decimal, e.g. 175 or 172.5
+	"bid",           // as reported; empty when there is no bid
+	"ask",           // as reported; empty when there is no ask
+	"last",          // as reported; empty when the contract has not traded
+	"bid_size",      // contracts
+	"ask_size",      // contracts
+	"volume",        // contracts traded today
+	"open_interest", // open contracts
+}
+
+// chainCSVGreeksColumns follow chainCSVColumns with --greeks. They are always
+// written with --greeks; a contract without greeks gets empty cells.
+var chainCSVGreeksColumns = []string{"delta", "gamma", "theta", "vega", "rho", "implied_volatility"}
+
+// validateChainCSV checks --csv and --greeks against the other chain flags.
+// The CSV schema does not change with display flags, so those are rejected
+// rather than silently ignored.
+func validateChainCSV(view chainView, jsonMode bool) error {
+	if view.greeks && !view.csv {
+		return fmt.Errorf("--greeks requires --csv")
+	}
+	if !view.csv {
+		return nil
+	}
+	if jsonMode {
+		return fmt.Errorf("--csv cannot be combined with --json")
+	}
+	if view.breakeven || view.deltaHint || view.spreadWidth > 0 {
+		return fmt.Errorf("--csv has a fixed column set and cannot be combined with --breakeven, --delta-neutral-hint, or --spread-width")
+	}
+	return nil
+}
+
+// printChainCSV writes calls then puts as CSV, one contract per row. With
+// --greeks it fetches greeks first; if that fails the greeks cells are left
+// empty and a warning goes to stderr, so the columns are still there.
+func printChainCSV(ctx context.Context, w, errW io.Writer, client *api.Client, accountID string, calls, puts []api.OptionQuote, withGreeks bool) error {
+	options := append(append([]api.OptionQuote{}, calls...), puts...)
+
+	var greeks map[string]api.GreeksData
+	if withGreeks && len(options) > 0 {
+		var err error
+		greeks, err = fetchChainGreeks(ctx, client, accountID, options)
+		if err != nil {
+			_, _ = fmt.Fprintf(errW, "Warning: %s; greeks columns are empty\n", err)
+		}
+	}
+	return writeChainCSV(w, options, greeks, withGreeks)
+}
+
+// fetchChainGreeks returns the greeks of each option by OSI symbol.
+func fetchChainGreeks(ctx context.Context, client *api.Client, accountID string, options []api.OptionQuote) (map[string]api.GreeksData, error) {
+	symbols := make([]string, 0, len(options))
+	for _, opt := range options {
+		symbols = append(symbols, opt.Instrument.Symbol)
+	}
+	greeksResp, err := client.GetOptionGreeks(ctx, accountID, symbols)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch greeks: %w", err)
+	}
+	greeks := make(map[string]api.GreeksData, len(greeksResp.Greeks))
+	for _, og := range greeksResp.Greeks {
+		greeks[og.Symbol] = og.Greeks
+	}
+	return greeks, nil
+}
+
+// writeChainCSV writes the header and one row per option. greeks may be nil
+// or missing symbols; withGreeks alone decides whether the columns appear.
+func writeChainCSV(w io.Writer, options []api.OptionQuote, greeks map[string]api.GreeksData, withGreeks bool) error {
+	header := chainCSVColumns
+	if withGreeks {
+		header = append(slices.Clone(header), chainCSVGreeksColumns...)
+	}
+
+	cw := csv.NewWriter(w)
+	_ = cw.Write(header)
+	for _, opt := range options {
+		row := chainCSVRow(opt)
+		if withGreeks {
+			g := greeks[opt.Instrument.Symbol]
+			row = append(row, g.Delta, g.Gamma, g.Theta, g.Vega, g.Rho, g.ImpliedVolatility)
+		}
+		_ = cw.Write(row)
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// chainCSVRow formats the chainCSVColumns cells for opt. Contract details
+// come from the OSI symbol and are empty if it does not parse.
+func chainCSVRow(opt api.OptionQuote) []string {
+	var underlying, optType, expiration, strike string
+	if osi, err := analytics.ParseOSI(opt.Instrument.Symbol); err == nil {
+		underlying = osi.Underlying
+		optType = osi.Type
+		expiration = osi.Expiration.Format("2006-01-02")
+		strike = strconv.FormatFloat(osi.Strike, 'f', -1, 64)
+	}
+	return []string{
+		opt.Instrument.Symbol,
+		underlying,
+		optType,
+		expiration,
+		strike,
+		opt.Bid,
+		opt.Ask,
+		opt.Last,
+		strconv.Itoa(opt.BidSize),
+		strconv.Itoa(opt.AskSize),
+		strconv.Itoa(opt.Volume),
+		strconv.Itoa(opt.OpenInterest),
+	}
+}
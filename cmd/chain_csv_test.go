@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newChainCSVServer serves a two-call, one-put chain. Greeks are returned for
+// the 175 call and put only; with failGreeks the greeks endpoint errors.
+func newChainCSVServer(t *testing.T, failGreeks bool) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/userapigateway/option-details/test-account/greeks":
+			if failGreeks {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(`{"error":"greeks down"}`))
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"greeks": []map[string]any{
+					{"symbol": "AAPL250117C00175000", "greeks": map[string]any{
+						"delta": "0.5200", "gamma": "0.0310", "theta": "-0.0850", "vega": "0.1900", "rho": "0.0450", "impliedVolatility": "0.2450",
+					}},
+					{"symbol": "AAPL250117P00172500", "greeks": map[string]any{
+						"delta": "-0.4100", "gamma": "0.0290", "theta": "-0.0790", "vega": "0.1800", "rho": "-0.0380", "impliedVolatility": "0.2610",
+					}},
+				},
+			})
+		default:
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"baseSymbol": "AAPL",
+				"calls": []map[string]any{
+					{"instrument": map[string]any{"symbol": "AAPL250117C00180000", "type": "OPTION"}},
+					{"instrument": map[string]any{"symbol": "AAPL250117C00175000", "type": "OPTION"},
+						"bid": "5.45", "ask": "5.55", "last": "5.50", "bidSize": 12, "askSize": 30, "volume": 1000, "openInterest": 5000},
+				},
+				"puts": []map[string]any{
+					{"instrument": map[string]any{"symbol": "AAPL250117P00172500", "type": "OPTION"},
+						"bid": "2.10", "ask": "2.20", "last": "2.15", "bidSize": 4, "askSize": 8, "volume": 300, "openInterest": 1200},
+				},
+			})
+		}
+	}))
+}
+
+func TestRunOptionsChain_CSVGreeksGolden(t *testing.T) {
+	server := newChainCSVServer(t, false)
+	defer server.Close()
+
+	opts := optionsOptions{baseURL: server.URL, authToken: "test-token", accountID: "test-account"}
+	cmd := newTestCmd()
+	require.NoError(t, runOptionsChain(cmd, opts, "AAPL", "2025-01-17", chainFilter{}, chainView{csv: true, greeks: true}))
+
+	golden, err := os.ReadFile("testdata/options_chain_greeks.golden.csv")
+	require.NoError(t, err)
+	assert.Equal(t, string(golden), cmd.OutOrStdout().(*bytes.Buffer).String())
+}
+
+func TestRunOptionsChain_CSVGreeksUnavailable(t *testing.T) {
+	server := newChainCSVServer(t, true)
+	defer server.Close()
+
+	opts := optionsOptions{baseURL: server.URL, authToken: "test-token", accountID: "test-account"}
+	cmd := newTestCmd()
+	var stderr bytes.Buffer
+	cmd.SetErr(&stderr)
+	require.NoError(t, runOptionsChain(cmd, opts, "AAPL", "2025-01-17", chainFilter{}, chainView{csv: true, greeks: true}))
+
+	lines := strings.Split(strings.TrimSpace(cmd.OutOrStdout().(*bytes.Buffer).String()), "\n")
+	require.Len(t, lines, 4)
+	assert.Equal(t, strings.Join(append(append([]string{}, chainCSVColumns...), chainCSVGreeksColumns...), ","), lines[0])
+	for _, line := range lines[1:] {
+		assert.Equal(t, len(chainCSVColumns)+len(chainCSVGreeksColumns), len(strings.Split(line, ",")), line)
+		assert.True(t, strings.HasSuffix(line, ",,,,,,"), line)
+	}
+	assert.Contains(t, stderr.String(), "greeks columns are empty")
+}
+
+func TestRunOptionsChain_CSVWithoutGreeks(t *testing.T) {
+	server := newChainCSVServer(t, false)
+	defer server.Close()
+
+	opts := optionsOptions{baseURL: server.URL, authToken: "test-token", accountID: "test-account"}
+	cmd := newTestCmd()
+	require.NoError(t, runOptionsChain(cmd, opts, "AAPL", "2025-01-17", chainFilter{}, chainView{csv: true}))
+
+	lines := strings.Split(strings.TrimSpace(cmd.OutOrStdout().(*bytes.Buffer).String()), "\n")
+	require.Len(t, lines, 4)
+	assert.Equal(t, strings.Join(chainCSVColumns, ","), lines[0])
+	assert.Equal(t, "AAPL250117C00175000,AAPL,CALL,2025-01-17,175,5.45,5.55,5.50,12,30,1000,5000", lines[1])
+}
+
+func TestRunOptionsChain_CSVEmptyKeepsHeader(t *testing.T) {
+	server := newChainCSVServer(t, false)
+	defer server.Close()
+
+	opts := optionsOptions{baseURL: server.URL, authToken: "test-token", accountID: "test-account"}
+	cmd := newTestCmd()
+	require.NoError(t, runOptionsChain(cmd, opts, "AAPL", "2025-01-17", chainFilter{minOI: 1000000}, chainView{csv: true, greeks: true}))
+
+	assert.Equal(t, strings.Join(append(append([]string{}, chainCSVColumns...), chainCSVGreeksColumns...), ",")+"\n",
+		cmd.OutOrStdout().(*bytes.Buffer).String())
+}
+
+func TestValidateChainCSV(t *testing.T) {
+	assert.NoError(t, validateChainCSV(chainView{}, true))
+	assert.NoError(t, validateChainCSV(chainView{csv: true, greeks: true, showSize: true}, false))
+	assert.ErrorContains(t, validateChainCSV(chainView{greeks: true}, false), "--greeks requires --csv")
+	assert.ErrorContains(t, validateChainCSV(chainView{csv: true}, true), "--csv cannot be combined with --json")
+	assert.ErrorContains(t, validateChainCSV(chainView{csv: true, breakeven: true}, false), "fixed column set")
+	assert.ErrorContains(t, validateChainCSV(chainView{csv: true, spreadWidth: 5}, false), "fixed column set")
+}
@@ -0,0 +1,353 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jonandersen/public-cli/internal/auth"
+	"github.com/jonandersen/public-cli/internal/config"
+	"github.com/jonandersen/public-cli/internal/keyring"
+)
+
+// configOptions holds dependencies for the config command.
+type configOptions struct {
+	configPath     string
+	tokenCachePath string
+	store          keyring.Store
+	jsonMode       bool
+}
+
+// saveConfig saves cfg to path. Commands run without a config file on the
+// defaults from config.Load, so the first save is what creates it; that is
+// noted once on w.
+func saveConfig(w io.Writer, path string, cfg *config.Config) error {
+	_, statErr := os.Stat(path)
+	if err := config.Save(path, cfg); err != nil {
+		return err
+	}
+	if os.IsNotExist(statErr) {
+		_, _ = fmt.Fprintf(w, "Created config at %s\n", path)
+	}
+	return nil
+}
+
+// configSecretKey is the pseudo-key that routes to the keyring instead of the
+// config file.
+const configSecretKey = "secret_key"
+
+// configKey describes one settable field of config.yaml, named as in the file.
+type configKey struct {
+	name string
+	get  func(cfg *config.Config) string
+	set  func(cfg *config.Config, value string) error
+}
+
+// configKeys lists the keys accepted by config get/set, in file order.
+var configKeys = []configKey{
+	{
+		name: "account_uuid",
+		get:  func(cfg *config.Config) string { return cfg.AccountUUID },
+		set: func(cfg *config.Config, value string) error {
+			cfg.AccountUUID = value
+			return nil
+		},
+	},
+	{
+		name: "api_base_url",
+		get:  func(cfg *config.Config) string { return cfg.APIBaseURL },
+		set: func(cfg *config.Config, value string) error {
+			cfg.APIBaseURL = strings.TrimRight(value, "/")
+			return nil
+		},
+	},
+	{
+		name: "token_validity_minutes",
+		get:  func(cfg *config.Config) string { return strconv.Itoa(cfg.TokenValidityMinutes) },
+		set: func(cfg *config.Config, value string) error {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("token_validity_minutes must be a whole number of minutes")
+			}
+			cfg.TokenValidityMinutes = n
+			return nil
+		},
+	},
+	{
+		name: "trading_enabled",
+		get:  func(cfg *config.Config) string { return cfg.Trading.String() },
+		set: func(cfg *config.Config, value string) error {
+			mode, err := config.ParseTradingMode(value)
+			if err != nil {
+				return err
+			}
+			cfg.Trading = mode
+			return nil
+		},
+	},
+	{
+		name: "max_shares",
+		get:  func(cfg *config.Config) string { return strconv.FormatFloat(cfg.MaxShares, 'f', -1, 64) },
+		set: func(cfg *config.Config, value string) error {
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return fmt.Errorf("max_shares must be a number of shares (0 disables the cap)")
+			}
+			cfg.MaxShares = n
+			return nil
+		},
+	},
+	{
+		name: "max_contracts",
+		get:  func(cfg *config.Config) string { return strconv.Itoa(cfg.MaxContracts) },
+		set: func(cfg *config.Config, value string) error {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("max_contracts must be a whole number of contracts (0 disables the cap)")
+			}
+			cfg.MaxContracts = n
+			return nil
+		},
+	},
+	{
+		name: "require_reason",
+		get:  func(cfg *config.Config) string { return strconv.FormatBool(cfg.RequireReason) },
+		set: func(cfg *config.Config, value string) error {
+			required, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("require_reason must be true or false")
+			}
+			cfg.RequireReason = required
+			return nil
+		},
+	},
+	{
+		name: "refresh_interval_seconds",
+		get:  func(cfg *config.Config) string { return strconv.Itoa(cfg.RefreshIntervalSeconds) },
+		set: func(cfg *config.Config, value string) error {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("refresh_interval_seconds must be a whole number of seconds (0 uses the default)")
+			}
+			cfg.RefreshIntervalSeconds = n
+			return nil
+		},
+	},
+	{
+		name: "confirm_prompt",
+		get:  func(cfg *config.Config) string { return cfg.ConfirmPrompt },
+		set: func(cfg *config.Config, value string) error {
+			cfg.ConfirmPrompt = value
+			return nil
+		},
+	},
+	{
+		name: "fx_rate_url",
+		get:  func(cfg *config.Config) string { return cfg.FXRateURL },
+		set: func(cfg *config.Config, value string) error {
+			cfg.FXRateURL = value
+			return nil
+		},
+	},
+}
+
+// configKeyNames returns every key accepted by config get/set.
+func configKeyNames() []string {
+	names := make([]string, 0, len(configKeys)+1)
+	for _, k := range configKeys {
+		names = append(names, k.name)
+	}
+	return append(names, configSecretKey)
+}
+
+// lookupConfigKey finds a config key by name, listing the valid keys when the
+// name is unknown.
+func lookupConfigKey(name string) (configKey, error) {
+	for _, k := range configKeys {
+		if k.name == name {
+			return k, nil
+		}
+	}
+	return configKey{}, fmt.Errorf("unknown config key %q (valid keys: %s)", name, strings.Join(configKeyNames(), ", "))
+}
+
+// newConfigCmd creates the config command with the given options.
+func newConfigCmd(opts configOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Get or set individual configuration values",
+		Long: `Get or set individual configuration values without editing config.yaml.
+
+Keys use the names from config.yaml: ` + strings.Join(configKeyNames(), ", ") + `.
+Values are validated before the file is written.
+
+secret_key is stored in the system keyring, never in the config file. Pass -
+as the value to read it from stdin so it stays out of your shell history.
+'config get secret_key' only reports whether a secret is configured.
+
+Examples:
+  pub config get account_uuid
+  pub config set trading_enabled confirm       # Trade, but confirm every order at a prompt
+  pub config set api_base_url https://api.public.com
+  pub config get --json                        # All values as JSON
+  echo "$SECRET" | pub config set secret_key -`,
+	}
+
+	getCmd := &cobra.Command{
+		Use:   "get [KEY]",
+		Short: "Print one configuration value, or all of them",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			key := ""
+			if len(args) == 1 {
+				key = args[0]
+			}
+			opts.jsonMode = opts.jsonMode || GetJSONMode()
+			return runConfigGet(cmd, opts, key)
+		},
+	}
+
+	setCmd := &cobra.Command{
+		Use:   "set KEY VALUE",
+		Short: "Validate and save one configuration value",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigSet(cmd, opts, args[0], args[1])
+		},
+	}
+
+	cmd.AddCommand(getCmd, setCmd)
+	cmd.SilenceUsage = true
+	getCmd.SilenceUsage = true
+	setCmd.SilenceUsage = true
+
+	return cmd
+}
+
+// secretStatus reports whether a secret key is stored, never the secret itself.
+func secretStatus(store keyring.Store) string {
+	if _, err := store.Get(keyring.ServiceName, keyring.KeySecretKey); err != nil {
+		return "not configured"
+	}
+	return "configured"
+}
+
+// runConfigGet prints the value of key, or every key when key is empty.
+func runConfigGet(cmd *cobra.Command, opts configOptions, key string) error {
+	cfg, err := config.Load(opts.configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	values := make(map[string]string)
+	var names []string
+	switch key {
+	case "":
+		for _, k := range configKeys {
+			values[k.name] = k.get(cfg)
+		}
+		values[configSecretKey] = secretStatus(opts.store)
+		names = configKeyNames()
+	case configSecretKey:
+		values[key] = secretStatus(opts.store)
+		names = []string{key}
+	default:
+		k, err := lookupConfigKey(key)
+		if err != nil {
+			return err
+		}
+		values[key] = k.get(cfg)
+		names = []string{key}
+	}
+
+	w := cmd.OutOrStdout()
+	if opts.jsonMode {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(values)
+	}
+
+	if key != "" {
+		_, _ = fmt.Fprintln(w, values[key])
+		return nil
+	}
+	for _, name := range names {
+		_, _ = fmt.Fprintf(w, "%s: %s\n", name, values[name])
+	}
+	return nil
+}
+
+// runConfigSet validates value for key and saves it. The secret key goes to
+// the keyring and invalidates the cached access token.
+func runConfigSet(cmd *cobra.Command, opts configOptions, key, value string) error {
+	if key == configSecretKey {
+		return runConfigSetSecret(cmd, opts, value)
+	}
+
+	k, err := lookupConfigKey(key)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load(opts.configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := k.set(cfg, strings.TrimSpace(value)); err != nil {
+		return fmt.Errorf("invalid %s: %w", key, err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid %s: %w", key, err)
+	}
+	if err := saveConfig(cmd.ErrOrStderr(), opts.configPath, cfg); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s set to %s\n", key, k.get(cfg))
+	return nil
+}
+
+// runConfigSetSecret stores the secret key in the keyring, reading it from
+// stdin when value is "-".
+func runConfigSetSecret(cmd *cobra.Command, opts configOptions, value string) error {
+	if value == "-" {
+		scanner := bufio.NewScanner(cmd.InOrStdin())
+		if !scanner.Scan() {
+			if err := scanner.Err(); err != nil {
+				return fmt.Errorf("failed to read secret key from stdin: %w", err)
+			}
+		}
+		value = scanner.Text()
+	}
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return fmt.Errorf("secret key cannot be empty")
+	}
+
+	if err := opts.store.Set(keyring.ServiceName, keyring.KeySecretKey, value); err != nil {
+		return fmt.Errorf("failed to store secret in keyring: %w", err)
+	}
+	// A token minted from the old secret must not outlive it
+	if opts.tokenCachePath != "" {
+		if err := auth.DeleteToken(opts.tokenCachePath); err != nil {
+			return fmt.Errorf("failed to clear token cache: %w", err)
+		}
+	}
+
+	_, _ = fmt.Fprintln(cmd.OutOrStdout(), "secret_key stored in the keyring")
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(newConfigCmd(configOptions{
+		configPath:     config.ConfigPath(),
+		tokenCachePath: auth.TokenCachePath(),
+		store:          keyring.NewEnvStore(keyring.NewSystemStore()),
+	}))
+}
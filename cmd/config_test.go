@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jonandersen/public-cli/internal/config"
+	"github.com/jonandersen/public-cli/internal/keyring"
+)
+
+func runConfigCmd(t *testing.T, opts configOptions, stdin string, args ...string) (string, error) {
+	t.Helper()
+	cmd := newConfigCmd(opts)
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetIn(strings.NewReader(stdin))
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestConfigCmd_SetAndGet(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	opts := configOptions{configPath: configPath, store: keyring.NewMockStore()}
+
+	out, err := runConfigCmd(t, opts, "", "set", "trading_enabled", "true")
+	require.NoError(t, err)
+	assert.Contains(t, out, "trading_enabled set to yolo", "true maps to yolo")
+
+	_, err = runConfigCmd(t, opts, "", "set", "account_uuid", "12345678-1234-1234-1234-123456789abc")
+	require.NoError(t, err)
+
+	cfg, err := config.Load(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, config.TradingYolo, cfg.Trading)
+	assert.Equal(t, "12345678-1234-1234-1234-123456789abc", cfg.AccountUUID)
+	assert.Equal(t, config.DefaultAPIBaseURL, cfg.APIBaseURL)
+
+	_, err = runConfigCmd(t, opts, "", "set", "max_shares", "500")
+	require.NoError(t, err)
+	_, err = runConfigCmd(t, opts, "", "set", "max_contracts", "10")
+	require.NoError(t, err)
+	_, err = runConfigCmd(t, opts, "", "set", "require_reason", "true")
+	require.NoError(t, err)
+	_, err = runConfigCmd(t, opts, "", "set", "confirm_prompt", "Reason logged? Place this order")
+	require.NoError(t, err)
+	_, err = runConfigCmd(t, opts, "", "set", "refresh_interval_seconds", "60")
+	require.NoError(t, err)
+	cfg, err = config.Load(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, 60, cfg.RefreshIntervalSeconds)
+	assert.Equal(t, "Reason logged? Place this order", cfg.ConfirmPrompt)
+	assert.Equal(t, 500.0, cfg.MaxShares)
+	assert.Equal(t, 10, cfg.MaxContracts)
+	assert.True(t, cfg.RequireReason)
+
+	out, err = runConfigCmd(t, opts, "", "get", "account_uuid")
+	require.NoError(t, err)
+	assert.Equal(t, "12345678-1234-1234-1234-123456789abc\n", out)
+
+	out, err = runConfigCmd(t, opts, "", "get")
+	require.NoError(t, err)
+	assert.Contains(t, out, "trading_enabled: yolo")
+	assert.Contains(t, out, "api_base_url: https://api.public.com")
+	assert.Contains(t, out, "secret_key: not configured")
+}
+
+func TestConfigCmd_GetJSON(t *testing.T) {
+	store := keyring.NewMockStore()
+	require.NoError(t, store.Set(keyring.ServiceName, keyring.KeySecretKey, "super-secret"))
+	opts := configOptions{configPath: filepath.Join(t.TempDir(), "config.yaml"), store: store, jsonMode: true}
+
+	out, err := runConfigCmd(t, opts, "", "get")
+	require.NoError(t, err)
+	assert.NotContains(t, out, "super-secret")
+
+	var values map[string]string
+	require.NoError(t, json.Unmarshal([]byte(out), &values))
+	assert.Equal(t, "60", values["token_validity_minutes"])
+	assert.Equal(t, "disabled", values["trading_enabled"])
+	assert.Equal(t, "configured", values["secret_key"])
+}
+
+func TestSaveConfig_NotesCreation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pub", "config.yaml")
+
+	var stderr bytes.Buffer
+	require.NoError(t, saveConfig(&stderr, path, config.DefaultConfig()))
+	assert.Equal(t, "Created config at "+path+"\n", stderr.String())
+	assert.FileExists(t, path)
+
+	stderr.Reset()
+	require.NoError(t, saveConfig(&stderr, path, config.DefaultConfig()))
+	assert.Empty(t, stderr.String(), "the notice is printed only when the file is created")
+}
+
+func TestConfigCmd_SetValidation(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	opts := configOptions{configPath: configPath, store: keyring.NewMockStore()}
+
+	tests := []struct {
+		args    []string
+		wantErr string
+	}{
+		{[]string{"set", "account_uuid", "not-a-uuid"}, "account_uuid must be a valid UUID"},
+		{[]string{"set", "api_base_url", "ftp://example.com"}, "must use http or https"},
+		{[]string{"set", "token_validity_minutes", "soon"}, "whole number"},
+		{[]string{"set", "token_validity_minutes", "0"}, "must be positive"},
+		{[]string{"set", "trading_enabled", "maybe"}, "trading_enabled must be disabled, confirm, or yolo"},
+		{[]string{"set", "max_shares", "lots"}, "number of shares"},
+		{[]string{"set", "max_contracts", "2.5"}, "whole number of contracts"},
+		{[]string{"set", "require_reason", "always"}, "require_reason must be true or false"},
+		{[]string{"set", "refresh_interval_seconds", "1m"}, "whole number of seconds"},
+		{[]string{"set", "--", "refresh_interval_seconds", "-5"}, "refresh_interval_seconds must not be negative"},
+		{[]string{"set", "fx_rate_url", "rates.example.com"}, "fx_rate_url must be a valid http or https URL"},
+		{[]string{"set", "refresh", "30"}, "valid keys: account_uuid, api_base_url"},
+		{[]string{"get", "refresh"}, "unknown config key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			_, err := runConfigCmd(t, opts, "", tt.args...)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+
+	_, err := os.Stat(configPath)
+	assert.True(t, os.IsNotExist(err), "invalid values must not be written")
+}
+
+func TestConfigCmd_SetSecretUsesKeyring(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+	tokenCachePath := filepath.Join(dir, ".token_cache")
+	require.NoError(t, os.WriteFile(tokenCachePath, []byte(`{"access_token":"old"}`), 0600))
+
+	store := keyring.NewMockStore()
+	opts := configOptions{configPath: configPath, tokenCachePath: tokenCachePath, store: store}
+
+	_, err := runConfigCmd(t, opts, "piped-secret\n", "set", "secret_key", "-")
+	require.NoError(t, err)
+
+	secret, err := store.Get(keyring.ServiceName, keyring.KeySecretKey)
+	require.NoError(t, err)
+	assert.Equal(t, "piped-secret", secret)
+
+	_, err = os.Stat(tokenCachePath)
+	assert.True(t, os.IsNotExist(err), "token cache should be cleared")
+	_, err = os.Stat(configPath)
+	assert.True(t, os.IsNotExist(err), "secret must never touch the config file")
+
+	out, err := runConfigCmd(t, opts, "", "get", "secret_key")
+	require.NoError(t, err)
+	assert.Equal(t, "configured\n", out)
+
+	_, err = runConfigCmd(t, opts, "", "set", "secret_key", "-")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be empty")
+}
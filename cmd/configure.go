@@ -250,7 +250,7 @@ func runInitialSetup(cmd *cobra.Command, opts configureOptions, accountUUID stri
 	}
 
 	// Save config
-	if err := config.Save(opts.configPath, cfg); err != nil {
+	if err := saveConfig(cmd.ErrOrStderr(), opts.configPath, cfg); err != nil {
 		return fmt.Errorf("failed to save configuration: %w", err)
 	}
 
@@ -345,7 +345,7 @@ func runSelectAccount(cmd *cobra.Command, opts configureOptions) error {
 
 	cfg.AccountUUID = selectedAccount
 
-	if err := config.Save(opts.configPath, cfg); err != nil {
+	if err := saveConfig(cmd.ErrOrStderr(), opts.configPath, cfg); err != nil {
 		return fmt.Errorf("failed to save configuration: %w", err)
 	}
 
@@ -381,7 +381,9 @@ func runViewConfiguration(cmd *cobra.Command, opts configureOptions) error {
 	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "API base URL: %s\n", cfg.APIBaseURL)
 	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Token validity: %d minutes\n", cfg.TokenValidityMinutes)
 
-	if cfg.TradingEnabled {
+	if cfg.Trading == config.TradingConfirm {
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Trading: ENABLED (every order confirmed at a prompt)")
+	} else if cfg.TradingEnabled() {
 		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Trading: ENABLED")
 	} else {
 		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Trading: DISABLED (orders blocked)")
@@ -407,17 +409,28 @@ func runToggleTrading(cmd *cobra.Command, opts configureOptions) error {
 		cfg = config.DefaultConfig()
 	}
 
-	// Toggle the current state
-	cfg.TradingEnabled = !cfg.TradingEnabled
+	// Toggle the current state; turning trading on allows --yes, as it
+	// always has, so a confirm mode is not restored by toggling back
+	previous := cfg.Trading
+	if cfg.TradingEnabled() {
+		cfg.Trading = config.TradingDisabled
+	} else {
+		cfg.Trading = config.TradingYolo
+	}
 
-	if err := config.Save(opts.configPath, cfg); err != nil {
+	if err := saveConfig(cmd.ErrOrStderr(), opts.configPath, cfg); err != nil {
 		return fmt.Errorf("failed to save configuration: %w", err)
 	}
 
-	if cfg.TradingEnabled {
+	if cfg.TradingEnabled() {
 		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Trading is now ENABLED. You can place orders.")
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "To confirm every order at a prompt, even with --yes: pub config set trading_enabled confirm")
 	} else {
 		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Trading is now DISABLED. Order commands will be blocked.")
+		if previous == config.TradingConfirm {
+			_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Confirm mode was turned off too; toggling trading back on allows --yes.")
+			_, _ = fmt.Fprintln(cmd.OutOrStdout(), "To trade with a prompt for every order again: pub config set trading_enabled confirm")
+		}
 	}
 
 	return nil
@@ -508,7 +508,7 @@ func TestConfigureCmd_ToggleTrading(t *testing.T) {
 	cfg := &config.Config{
 		APIBaseURL:           "https://api.public.com",
 		TokenValidityMinutes: 60,
-		TradingEnabled:       false,
+		Trading:              config.TradingDisabled,
 	}
 	require.NoError(t, config.Save(configPath, cfg))
 
@@ -539,7 +539,7 @@ func TestConfigureCmd_ToggleTrading(t *testing.T) {
 	// Verify config was updated
 	loaded, err := config.Load(configPath)
 	require.NoError(t, err)
-	assert.True(t, loaded.TradingEnabled)
+	assert.True(t, loaded.TradingEnabled())
 }
 
 func TestConfigureCmd_ToggleTradingOff(t *testing.T) {
@@ -550,7 +550,7 @@ func TestConfigureCmd_ToggleTradingOff(t *testing.T) {
 	cfg := &config.Config{
 		APIBaseURL:           "https://api.public.com",
 		TokenValidityMinutes: 60,
-		TradingEnabled:       true,
+		Trading:              config.TradingYolo,
 	}
 	require.NoError(t, config.Save(configPath, cfg))
 
@@ -581,7 +581,35 @@ func TestConfigureCmd_ToggleTradingOff(t *testing.T) {
 	// Verify config was updated
 	loaded, err := config.Load(configPath)
 	require.NoError(t, err)
-	assert.False(t, loaded.TradingEnabled)
+	assert.False(t, loaded.TradingEnabled())
+}
+
+func TestConfigureCmd_ToggleTradingOffFromConfirm(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, config.Save(configPath, &config.Config{
+		APIBaseURL:           "https://api.public.com",
+		TokenValidityMinutes: 60,
+		Trading:              config.TradingConfirm,
+	}))
+
+	store := keyring.NewMockStore()
+	_ = store.Set(keyring.ServiceName, keyring.KeySecretKey, "existing-secret")
+
+	cmd := newConfigureCmd(configureOptions{
+		configPath:     configPath,
+		baseURL:        "https://api.example.com",
+		store:          store,
+		passwordReader: newMockPasswordReader("", true),
+		prompt:         newMockPrompt(3), // Select "Toggle trading"
+	})
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{})
+
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), "Trading is now DISABLED")
+	assert.Contains(t, out.String(), "Confirm mode was turned off too")
+	assert.Contains(t, out.String(), "pub config set trading_enabled confirm")
 }
 
 func TestConfigureCmd_ViewConfiguration(t *testing.T) {
@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jonandersen/public-cli/internal/api"
+)
+
+// confirmTokenTTL is how long a --preview-json-then-confirm token can be
+// confirmed. Prices move, so a preview should not be placed much later.
+const confirmTokenTTL = 5 * time.Minute
+
+// confirmPayload is the order a confirm token commits to. The order request
+// is serialized whole, order ID included, so confirming places exactly what
+// was previewed.
+type confirmPayload struct {
+	AccountID string           `json:"accountId"`
+	Order     api.OrderRequest `json:"order"`
+	ExpiresAt int64            `json:"expiresAt"`
+}
+
+// signConfirmToken serializes p and signs it with HMAC-SHA256 under key. The
+// token is the base64url payload and signature joined by a dot.
+func signConfirmToken(key []byte, p confirmPayload) (string, error) {
+	if len(key) == 0 {
+		return "", fmt.Errorf("cannot sign a confirm token without a secret key (run: pub configure)")
+	}
+	data, err := json.Marshal(p)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode confirm token: %w", err)
+	}
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString(data) + "." + enc.EncodeToString(confirmSignature(key, data)), nil
+}
+
+// verifyConfirmToken checks token's signature under key and its expiry, and
+// returns the order it commits to.
+func verifyConfirmToken(key []byte, token string, now time.Time) (*confirmPayload, error) {
+	if len(key) == 0 {
+		return nil, fmt.Errorf("cannot verify --confirm-token without a secret key (run: pub configure)")
+	}
+	enc := base64.RawURLEncoding
+	payloadPart, sigPart, ok := strings.Cut(strings.TrimSpace(token), ".")
+	if !ok {
+		return nil, fmt.Errorf("invalid --confirm-token: malformed token")
+	}
+	data, err := enc.DecodeString(payloadPart)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --confirm-token: malformed token")
+	}
+	sig, err := enc.DecodeString(sigPart)
+	if err != nil || !hmac.Equal(sig, confirmSignature(key, data)) {
+		return nil, fmt.Errorf("invalid --confirm-token: signature does not match (the token was altered or signed with another secret key)")
+	}
+
+	var p confirmPayload
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("invalid --confirm-token: %w", err)
+	}
+	if expires := time.Unix(p.ExpiresAt, 0); now.After(expires) {
+		return nil, fmt.Errorf("--confirm-token expired at %s; preview the order again", expires.UTC().Format(time.RFC3339))
+	}
+	return &p, nil
+}
+
+func confirmSignature(key, data []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// printOrderPreviewJSON prints the previewed order and a token that places it
+// unchanged. Nothing is submitted.
+func printOrderPreviewJSON(cmd *cobra.Command, opts orderOptions, orderReq api.OrderRequest, reason string, preflight *api.PreflightResponse, preflightErr error) error {
+	expires := time.Now().Add(confirmTokenTTL)
+	token, err := signConfirmToken(opts.confirmKey, confirmPayload{
+		AccountID: opts.accountID,
+		Order:     orderReq,
+		ExpiresAt: expires.Unix(),
+	})
+	if err != nil {
+		return err
+	}
+
+	result := OrderPreviewResult{
+		Order:        orderReq,
+		Preflight:    preflight,
+		ConfirmToken: token,
+		ExpiresAt:    expires.UTC().Format(time.RFC3339),
+		Reason:       reason,
+	}
+	if preflightErr != nil {
+		result.PreflightError = extractErrorMessage(preflightErr)
+	}
+	enc := json.NewEncoder(cmd.OutOrStdout())
+	enc.SetIndent("", "  ")
+	return enc.Encode(result)
+}
+
+// runConfirmedOrder places the order a --confirm-token commits to. The
+// command's symbol and side must match it, and the order flags must be left
+// out: the token is the whole order, already checked against the share cap
+// and halts when it was previewed.
+func runConfirmedOrder(cmd *cobra.Command, opts orderOptions, symbol, side string, params orderParams) error {
+	if params.previewJSON {
+		return fmt.Errorf("--confirm-token cannot be combined with --preview-json-then-confirm")
+	}
+	if params.quantity != "" || params.limitPrice != "" || params.stopPrice != "" || params.risk != "" || params.peg.set() {
+		return fmt.Errorf("--confirm-token places the previewed order as is; drop --quantity, --limit, --stop, --risk, and --peg")
+	}
+
+	p, err := verifyConfirmToken(opts.confirmKey, params.confirmToken, time.Now())
+	if err != nil {
+		return err
+	}
+	order := p.Order
+	if p.AccountID != opts.accountID {
+		return fmt.Errorf("--confirm-token was issued for account %s, not %s", p.AccountID, opts.accountID)
+	}
+	if !strings.EqualFold(symbol, order.Instrument.Symbol) || side != order.OrderSide {
+		return fmt.Errorf("--confirm-token is for %s %s, not %s %s", order.OrderSide, order.Instrument.Symbol, side, strings.ToUpper(symbol))
+	}
+
+	// The token stands in for --yes, which confirm mode never accepts alone.
+	// Its order was already shown by --preview-json-then-confirm, so the
+	// prompt is allowed under --json.
+	if opts.promptConfirm {
+		question := fmt.Sprintf("Place the previewed order (%s %s %s)", order.OrderSide, order.Quantity, order.Instrument.Symbol)
+		if err := confirmTrade(cmd, true, true, false, "order", placeOrderQuestion(opts.confirmPrompt, question)); err != nil {
+			return err
+		}
+	}
+
+	client := api.NewClient(opts.baseURL, opts.authToken)
+	orderResp, err := placeEquityOrder(client, opts.accountID, order)
+	if err != nil {
+		return err
+	}
+	order.OrderID = orderResp.OrderID
+	recordJournal(cmd.ErrOrStderr(), opts.journalPath, equityJournalEntry(opts.accountID, order, params.reason), time.Now())
+
+	if opts.jsonMode {
+		result := OrderPlacedResult{
+			OrderID:    orderResp.OrderID,
+			Status:     statusPlaced,
+			Symbol:     order.Instrument.Symbol,
+			Side:       order.OrderSide,
+			Quantity:   order.Quantity,
+			OrderType:  order.OrderType,
+			LimitPrice: order.LimitPrice,
+			StopPrice:  order.StopPrice,
+			Reason:     params.reason,
+		}
+		if order.Instrument.Type != "EQUITY" {
+			result.InstrumentType = order.Instrument.Type
+		}
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	}
+
+	out := cmd.OutOrStdout()
+	_, _ = fmt.Fprintf(out, "Order placed successfully!\n")
+	_, _ = fmt.Fprintf(out, "  Order ID: %s\n", orderResp.OrderID)
+	_, _ = fmt.Fprintf(out, "  %s %s shares of %s (%s)\n", order.OrderSide, order.Quantity, order.Instrument.Symbol, order.OrderType)
+	if order.LimitPrice != "" {
+		_, _ = fmt.Fprintf(out, "  Limit: $%s\n", order.LimitPrice)
+	}
+	if order.StopPrice != "" {
+		_, _ = fmt.Fprintf(out, "  Stop: $%s\n", order.StopPrice)
+	}
+	if params.reason != "" {
+		_, _ = fmt.Fprintf(out, "  Reason: %s\n", params.reason)
+	}
+	return nil
+}
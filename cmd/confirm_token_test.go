@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jonandersen/public-cli/internal/api"
+)
+
+var testConfirmKey = []byte("test-secret")
+
+func testConfirmPayload(expires time.Time) confirmPayload {
+	return confirmPayload{
+		AccountID: "test-account",
+		Order: api.OrderRequest{
+			OrderID:    "912710f1-1a45-4ef0-88a7-cd513781933d",
+			Instrument: api.OrderInstrument{Symbol: "AAPL", Type: "EQUITY"},
+			OrderSide:  "BUY",
+			OrderType:  "LIMIT",
+			Expiration: api.OrderExpiration{TimeInForce: "DAY"},
+			Quantity:   "10",
+			LimitPrice: "175.00",
+		},
+		ExpiresAt: expires.Unix(),
+	}
+}
+
+func TestConfirmToken_RoundTrip(t *testing.T) {
+	now := time.Now()
+	want := testConfirmPayload(now.Add(time.Minute))
+
+	token, err := signConfirmToken(testConfirmKey, want)
+	require.NoError(t, err)
+
+	got, err := verifyConfirmToken(testConfirmKey, token, now)
+	require.NoError(t, err)
+	assert.Equal(t, want, *got)
+}
+
+func TestConfirmToken_Rejects(t *testing.T) {
+	now := time.Now()
+	token, err := signConfirmToken(testConfirmKey, testConfirmPayload(now.Add(time.Minute)))
+	require.NoError(t, err)
+
+	// Swap in a payload for a bigger order under the original signature
+	bigger := testConfirmPayload(now.Add(time.Minute))
+	bigger.Order.Quantity = "1000"
+	biggerToken, err := signConfirmToken(testConfirmKey, bigger)
+	require.NoError(t, err)
+	payload, _, _ := strings.Cut(biggerToken, ".")
+	_, sig, _ := strings.Cut(token, ".")
+
+	expired, err := signConfirmToken(testConfirmKey, testConfirmPayload(now.Add(-time.Second)))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		key   []byte
+		token string
+		want  string
+	}{
+		{"tampered", testConfirmKey, payload + "." + sig, "signature does not match"},
+		{"other key", []byte("other-secret"), token, "signature does not match"},
+		{"expired", testConfirmKey, expired, "expired at"},
+		{"malformed", testConfirmKey, "not-a-token", "malformed token"},
+		{"no key", nil, token, "without a secret key"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := verifyConfirmToken(tt.key, tt.token, now)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestOrderBuyCmd_PreviewThenConfirm(t *testing.T) {
+	var placed []api.OrderRequest
+	server := httptest.NewServer(stubQuotes(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(r.URL.Path, "preflight") {
+			_ = json.NewEncoder(w).Encode(api.PreflightResponse{OrderValue: "1750.00", EstimatedCost: "1750.00"})
+			return
+		}
+		var req api.OrderRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		placed = append(placed, req)
+		_ = json.NewEncoder(w).Encode(map[string]any{"orderId": req.OrderID})
+	}))
+	defer server.Close()
+
+	opts := orderOptions{
+		baseURL:        server.URL,
+		authToken:      "test-token",
+		accountID:      "test-account",
+		tradingEnabled: true,
+		confirmKey:     testConfirmKey,
+	}
+
+	cmd := newOrderBuyCmd(opts)
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"AAPL", "-q", "10", "--limit", "175.00", "--preview-json-then-confirm"})
+	require.NoError(t, cmd.Execute())
+	assert.Empty(t, placed, "preview must not place the order")
+
+	var preview OrderPreviewResult
+	require.NoError(t, json.Unmarshal(out.Bytes(), &preview))
+	assert.Equal(t, "AAPL", preview.Order.Instrument.Symbol)
+	assert.Equal(t, "175.00", preview.Order.LimitPrice)
+	assert.NotEmpty(t, preview.Order.OrderID)
+	require.NotNil(t, preview.Preflight)
+	assert.Equal(t, "1750.00", preview.Preflight.EstimatedCost)
+	assert.NotEmpty(t, preview.ConfirmToken)
+
+	cmd = newOrderBuyCmd(opts)
+	out.Reset()
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"AAPL", "--confirm-token", preview.ConfirmToken})
+	require.NoError(t, cmd.Execute())
+
+	require.Len(t, placed, 1)
+	assert.Equal(t, preview.Order, placed[0])
+	assert.Contains(t, out.String(), "Order placed successfully!")
+	assert.Contains(t, out.String(), preview.Order.OrderID)
+}
+
+func TestOrderBuyCmd_ConfirmTokenMismatch(t *testing.T) {
+	token, err := signConfirmToken(testConfirmKey, testConfirmPayload(time.Now().Add(time.Minute)))
+	require.NoError(t, err)
+
+	opts := orderOptions{
+		baseURL:        "http://localhost",
+		authToken:      "test-token",
+		accountID:      "test-account",
+		tradingEnabled: true,
+		confirmKey:     testConfirmKey,
+	}
+
+	tests := []struct {
+		name string
+		cmd  func(orderOptions) *cobra.Command
+		args []string
+		want string
+	}{
+		{"other symbol", newOrderBuyCmd, []string{"MSFT", "--confirm-token", token}, "--confirm-token is for BUY AAPL, not BUY MSFT"},
+		{"other side", newOrderSellCmd, []string{"AAPL", "--confirm-token", token}, "--confirm-token is for BUY AAPL, not SELL AAPL"},
+		{"order flags", newOrderBuyCmd, []string{"AAPL", "-q", "5", "--confirm-token", token}, "places the previewed order as is"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := tt.cmd(opts)
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+			cmd.SetArgs(tt.args)
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+
+	opts.accountID = "other-account"
+	cmd := newOrderBuyCmd(opts)
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"AAPL", "--confirm-token", token})
+	err = cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "issued for account test-account, not other-account")
+}
+
+func TestOrderBuyCmd_PreviewRejectsRetry(t *testing.T) {
+	cmd := newOrderBuyCmd(orderOptions{
+		baseURL:        "http://localhost",
+		authToken:      "test-token",
+		accountID:      "test-account",
+		tradingEnabled: true,
+		confirmKey:     testConfirmKey,
+	})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"AAPL", "-q", "10", "--retry-on-reject", "2", "--preview-json-then-confirm"})
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be combined with --retry-on-reject")
+}
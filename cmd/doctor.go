@@ -0,0 +1,323 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jonandersen/public-cli/internal/auth"
+	"github.com/jonandersen/public-cli/internal/config"
+	"github.com/jonandersen/public-cli/internal/keyring"
+)
+
+// doctorOptions holds dependencies for the doctor command.
+type doctorOptions struct {
+	configPath     string
+	tokenCachePath string
+	store          keyring.Store
+	jsonMode       bool
+}
+
+// Doctor check statuses.
+const (
+	doctorOK      = "ok"
+	doctorProblem = "problem"
+	doctorFixed   = "fixed"
+	doctorSkipped = "skipped"
+)
+
+// doctorCheck is the outcome of one doctor check. Fix describes the change
+// --fix would make; it is empty when the problem cannot be fixed
+// automatically, in which case Advice says what to do instead.
+type doctorCheck struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail"`
+	Fix    string `json:"fix,omitempty"`
+	Advice string `json:"advice,omitempty"`
+
+	apply func() error
+}
+
+// newDoctorCmd creates the doctor command with the given options.
+func newDoctorCmd(opts doctorOptions) *cobra.Command {
+	var fix, skipConfirm bool
+
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Check the local setup for common problems",
+		Long: `Check the local setup for common problems: the config directory and its
+permissions, the config file and its API base URL, the token cache, and
+whether a secret key is stored.
+
+With --fix, problems that are safe to repair are fixed after a y/N prompt
+for each one (--yes skips the prompts):
+  - a missing config directory is created with 0700 permissions
+  - a config directory readable by others is restricted to 0700
+  - an api_base_url without a scheme is rewritten with https://
+  - a corrupt token cache is removed (a new token is fetched on next use)
+
+The secret key is never changed. If none is stored, doctor tells you to run
+pub configure. Exits non-zero while any problem remains.
+
+Examples:
+  pub doctor              # Report problems
+  pub doctor --fix        # Fix problems, confirming each one
+  pub doctor --fix --yes  # Fix problems without prompting
+  pub doctor --json       # JSON report`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDoctor(cmd, opts, fix, skipConfirm)
+		},
+	}
+
+	cmd.Flags().BoolVar(&fix, "fix", false, "Fix problems that can be repaired automatically")
+	cmd.Flags().BoolVarP(&skipConfirm, "yes", "y", false, "Skip confirmation prompts with --fix")
+
+	cmd.SilenceUsage = true
+
+	return cmd
+}
+
+func runDoctor(cmd *cobra.Command, opts doctorOptions, fix, skipConfirm bool) error {
+	if skipConfirm && !fix {
+		return fmt.Errorf("--yes requires --fix")
+	}
+
+	checks := []*doctorCheck{
+		checkConfigDir(filepath.Dir(opts.configPath)),
+		checkConfigFile(opts.configPath),
+		checkTokenCache(opts.tokenCachePath),
+		checkSecret(opts.store),
+	}
+
+	if fix {
+		// Prompts go to stderr so stdout stays a clean report
+		in := bufio.NewReader(cmd.InOrStdin())
+		for _, c := range checks {
+			if c.Status != doctorProblem || c.apply == nil {
+				continue
+			}
+			if !skipConfirm && !askYesNo(in, cmd.ErrOrStderr(), c.Fix) {
+				c.Status = doctorSkipped
+				continue
+			}
+			if err := c.apply(); err != nil {
+				return fmt.Errorf("failed to fix %s: %w", c.Name, err)
+			}
+			c.Status = doctorFixed
+		}
+	}
+
+	remaining := 0
+	for _, c := range checks {
+		if c.Status == doctorProblem || c.Status == doctorSkipped {
+			remaining++
+		}
+	}
+
+	if opts.jsonMode {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(map[string]any{"checks": checks}); err != nil {
+			return err
+		}
+	} else {
+		printDoctorChecks(cmd.OutOrStdout(), checks, fix)
+	}
+
+	if remaining > 0 {
+		return fmt.Errorf("%d problem(s) remaining", remaining)
+	}
+	return nil
+}
+
+// askYesNo asks question on w and reads the answer from in. Anything but y
+// or yes, including end of input, declines.
+func askYesNo(in *bufio.Reader, w io.Writer, question string) bool {
+	_, _ = fmt.Fprintf(w, "%s? [y/N] ", question)
+	line, _ := in.ReadString('\n')
+	answer := strings.ToLower(strings.TrimSpace(line))
+	return answer == "y" || answer == "yes"
+}
+
+func printDoctorChecks(w io.Writer, checks []*doctorCheck, fix bool) {
+	for _, c := range checks {
+		switch c.Status {
+		case doctorOK:
+			_, _ = fmt.Fprintf(w, "[ok]      %s: %s\n", c.Name, c.Detail)
+		case doctorFixed:
+			_, _ = fmt.Fprintf(w, "[fixed]   %s: %s\n", c.Name, c.Fix)
+		case doctorSkipped:
+			_, _ = fmt.Fprintf(w, "[skipped] %s: %s\n", c.Name, c.Detail)
+		default:
+			_, _ = fmt.Fprintf(w, "[problem] %s: %s\n", c.Name, c.Detail)
+			if c.Fix != "" && !fix {
+				_, _ = fmt.Fprintf(w, "          run pub doctor --fix to: %s\n", c.Fix)
+			}
+		}
+		if c.Advice != "" && c.Status != doctorOK && c.Status != doctorFixed {
+			_, _ = fmt.Fprintf(w, "          %s\n", c.Advice)
+		}
+	}
+}
+
+// checkConfigDir checks that dir exists and is private to the user.
+func checkConfigDir(dir string) *doctorCheck {
+	c := &doctorCheck{Name: "config_dir"}
+
+	info, err := os.Stat(dir)
+	switch {
+	case os.IsNotExist(err):
+		c.Status = doctorProblem
+		c.Detail = fmt.Sprintf("%s does not exist", dir)
+		c.Fix = fmt.Sprintf("create %s with 0700 permissions", dir)
+		c.apply = func() error { return os.MkdirAll(dir, 0700) }
+	case err != nil:
+		c.Status = doctorProblem
+		c.Detail = err.Error()
+	case !info.IsDir():
+		c.Status = doctorProblem
+		c.Detail = fmt.Sprintf("%s is not a directory", dir)
+		c.Advice = "move or remove it so the config directory can be created"
+	case runtime.GOOS != "windows" && info.Mode().Perm()&0077 != 0:
+		c.Status = doctorProblem
+		c.Detail = fmt.Sprintf("%s has permissions %04o, want 0700", dir, info.Mode().Perm())
+		c.Fix = fmt.Sprintf("restrict %s to 0700", dir)
+		c.apply = func() error { return os.Chmod(dir, 0700) }
+	default:
+		c.Status = doctorOK
+		c.Detail = dir
+	}
+	return c
+}
+
+// checkConfigFile checks that the config file parses and validates. The only
+// validation failure it repairs is an api_base_url missing its scheme.
+func checkConfigFile(path string) *doctorCheck {
+	c := &doctorCheck{Name: "config"}
+
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		c.Status = doctorOK
+		c.Detail = fmt.Sprintf("%s not found, using defaults", path)
+		return c
+	}
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		c.Status = doctorProblem
+		c.Detail = fmt.Sprintf("cannot read %s: %v", path, err)
+		c.Advice = "fix the file by hand or run: pub configure"
+		return c
+	}
+
+	if fixed, ok := fixBaseURLScheme(cfg.APIBaseURL); ok {
+		c.Status = doctorProblem
+		c.Detail = fmt.Sprintf("api_base_url %q has no scheme", cfg.APIBaseURL)
+		c.Fix = fmt.Sprintf("rewrite api_base_url as %s", fixed)
+		c.apply = func() error {
+			cfg.APIBaseURL = fixed
+			if err := cfg.Validate(); err != nil {
+				return err
+			}
+			return config.Save(path, cfg)
+		}
+		return c
+	}
+
+	if err := cfg.Validate(); err != nil {
+		c.Status = doctorProblem
+		c.Detail = strings.ReplaceAll(err.Error(), "\n", "; ")
+		c.Advice = "fix with: pub config set <key> <value>"
+		return c
+	}
+
+	c.Status = doctorOK
+	c.Detail = path
+	return c
+}
+
+// fixBaseURLScheme returns raw with https:// prepended when raw has no scheme
+// and doing so yields a valid URL. ok is false when raw needs no fix or
+// cannot be fixed this way.
+func fixBaseURLScheme(raw string) (string, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" || strings.Contains(raw, "://") {
+		return "", false
+	}
+	fixed := "https://" + strings.TrimPrefix(raw, "//")
+	parsed, err := url.Parse(fixed)
+	if err != nil || parsed.Host == "" {
+		return "", false
+	}
+	return fixed, true
+}
+
+// checkTokenCache checks that the token cache, if present, is readable.
+func checkTokenCache(path string) *doctorCheck {
+	c := &doctorCheck{Name: "token_cache"}
+
+	token, err := auth.LoadToken(path)
+	switch {
+	case os.IsNotExist(err):
+		c.Status = doctorOK
+		c.Detail = "none cached (fetched on next command)"
+	case errors.Is(err, auth.ErrInvalidCache):
+		c.Status = doctorProblem
+		c.Detail = fmt.Sprintf("%s is corrupt", path)
+		c.Fix = fmt.Sprintf("remove %s", path)
+		c.apply = func() error { return auth.DeleteToken(path) }
+	case err != nil:
+		c.Status = doctorProblem
+		c.Detail = fmt.Sprintf("cannot read %s: %v", path, err)
+	case !token.IsValid():
+		c.Status = doctorOK
+		c.Detail = "expired (refreshed on next command)"
+	default:
+		c.Status = doctorOK
+		c.Detail = "valid"
+	}
+	return c
+}
+
+// checkSecret checks that a secret key is stored. doctor never writes the
+// secret, so a missing one is only ever reported.
+func checkSecret(store keyring.Store) *doctorCheck {
+	c := &doctorCheck{Name: "secret"}
+	if _, err := store.Get(keyring.ServiceName, keyring.KeySecretKey); err != nil {
+		c.Status = doctorProblem
+		c.Detail = "no secret key stored"
+		c.Advice = "run: pub configure"
+		return c
+	}
+	c.Status = doctorOK
+	c.Detail = "configured"
+	return c
+}
+
+func init() {
+	opts := doctorOptions{
+		configPath:     config.ConfigPath(),
+		tokenCachePath: auth.TokenCachePath(),
+		store:          keyring.NewEnvStore(keyring.NewSystemStore()),
+	}
+	doctorCmd := newDoctorCmd(opts)
+	doctorCmd.PreRun = func(cmd *cobra.Command, args []string) {
+		opts.jsonMode = GetJSONMode()
+	}
+	doctorCmd.RunE = func(cmd *cobra.Command, args []string) error {
+		fix, _ := cmd.Flags().GetBool("fix")
+		skipConfirm, _ := cmd.Flags().GetBool("yes")
+		return runDoctor(cmd, opts, fix, skipConfirm)
+	}
+	rootCmd.AddCommand(doctorCmd)
+}
@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jonandersen/public-cli/internal/auth"
+	"github.com/jonandersen/public-cli/internal/config"
+	"github.com/jonandersen/public-cli/internal/keyring"
+)
+
+func runDoctorTest(t *testing.T, opts doctorOptions, stdin string, args ...string) (string, string, error) {
+	t.Helper()
+	cmd := newDoctorCmd(opts)
+	var out, errOut bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&errOut)
+	cmd.SetIn(strings.NewReader(stdin))
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), errOut.String(), err
+}
+
+func TestDoctorCmd_Healthy(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	tokenPath := filepath.Join(tmpDir, ".token_cache")
+	require.NoError(t, os.Chmod(tmpDir, 0700))
+	require.NoError(t, config.Save(configPath, config.DefaultConfig()))
+	require.NoError(t, auth.SaveToken(tokenPath, &auth.Token{
+		AccessToken: "tok",
+		ExpiresAt:   time.Now().Add(time.Hour).Unix(),
+	}))
+
+	out, _, err := runDoctorTest(t, doctorOptions{
+		configPath:     configPath,
+		tokenCachePath: tokenPath,
+		store:          keyring.NewMockStore().WithData(keyring.ServiceName, keyring.KeySecretKey, "secret"),
+	}, "")
+
+	require.NoError(t, err)
+	assert.NotContains(t, out, "[problem]")
+	assert.Contains(t, out, "[ok]      token_cache: valid")
+	assert.Contains(t, out, "[ok]      secret: configured")
+}
+
+func TestDoctorCmd_ReportsWithoutFixing(t *testing.T) {
+	tmpDir := t.TempDir()
+	configDir := filepath.Join(tmpDir, "pub")
+	configPath := filepath.Join(configDir, "config.yaml")
+
+	out, _, err := runDoctorTest(t, doctorOptions{
+		configPath:     configPath,
+		tokenCachePath: filepath.Join(configDir, ".token_cache"),
+		store:          keyring.NewMockStore(),
+	}, "")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "2 problem(s) remaining")
+	assert.Contains(t, out, "[problem] config_dir")
+	assert.Contains(t, out, "run pub doctor --fix to: create "+configDir)
+	assert.Contains(t, out, "run: pub configure")
+	assert.NoDirExists(t, configDir)
+}
+
+func TestDoctorCmd_FixWithYes(t *testing.T) {
+	tmpDir := t.TempDir()
+	configDir := filepath.Join(tmpDir, "pub")
+	configPath := filepath.Join(configDir, "config.yaml")
+	tokenPath := filepath.Join(configDir, ".token_cache")
+	require.NoError(t, os.MkdirAll(configDir, 0755))
+	if runtime.GOOS != "windows" {
+		require.NoError(t, os.Chmod(configDir, 0755))
+	}
+	require.NoError(t, os.WriteFile(configPath, []byte("api_base_url: api.public.com\n"), 0600))
+	require.NoError(t, os.WriteFile(tokenPath, []byte("{not json"), 0600))
+
+	store := keyring.NewMockStore().WithData(keyring.ServiceName, keyring.KeySecretKey, "secret")
+	out, _, err := runDoctorTest(t, doctorOptions{
+		configPath:     configPath,
+		tokenCachePath: tokenPath,
+		store:          store,
+	}, "", "--fix", "--yes")
+
+	require.NoError(t, err)
+	assert.Contains(t, out, "[fixed]   config: rewrite api_base_url as https://api.public.com")
+	assert.Contains(t, out, "[fixed]   token_cache: remove "+tokenPath)
+
+	cfg, err := config.Load(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.public.com", cfg.APIBaseURL)
+	assert.NoFileExists(t, tokenPath)
+
+	if runtime.GOOS != "windows" {
+		assert.Contains(t, out, "[fixed]   config_dir")
+		info, err := os.Stat(configDir)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0700), info.Mode().Perm())
+	}
+
+	secret, err := store.Get(keyring.ServiceName, keyring.KeySecretKey)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", secret)
+}
+
+func TestDoctorCmd_FixPromptsEachChange(t *testing.T) {
+	tmpDir := t.TempDir()
+	configDir := filepath.Join(tmpDir, "pub")
+	configPath := filepath.Join(configDir, "config.yaml")
+	tokenPath := filepath.Join(configDir, ".token_cache")
+	require.NoError(t, os.MkdirAll(configDir, 0700))
+	require.NoError(t, os.Chmod(configDir, 0700))
+	require.NoError(t, os.WriteFile(configPath, []byte("api_base_url: api.public.com\n"), 0600))
+	require.NoError(t, os.WriteFile(tokenPath, []byte("{}"), 0600))
+
+	// Accept the URL rewrite, decline the token cache removal
+	out, errOut, err := runDoctorTest(t, doctorOptions{
+		configPath:     configPath,
+		tokenCachePath: tokenPath,
+		store:          keyring.NewMockStore().WithData(keyring.ServiceName, keyring.KeySecretKey, "secret"),
+	}, "y\nn\n", "--fix")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 problem(s) remaining")
+	assert.Contains(t, errOut, "rewrite api_base_url as https://api.public.com? [y/N]")
+	assert.Contains(t, errOut, "remove "+tokenPath+"? [y/N]")
+	assert.Contains(t, out, "[fixed]   config")
+	assert.Contains(t, out, "[skipped] token_cache")
+	assert.FileExists(t, tokenPath)
+}
+
+func TestDoctorCmd_JSON(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	out, _, err := runDoctorTest(t, doctorOptions{
+		configPath:     filepath.Join(tmpDir, "config.yaml"),
+		tokenCachePath: filepath.Join(tmpDir, ".token_cache"),
+		store:          keyring.NewMockStore(),
+		jsonMode:       true,
+	}, "")
+	require.Error(t, err)
+
+	var result struct {
+		Checks []doctorCheck `json:"checks"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &result))
+	require.Len(t, result.Checks, 4)
+	assert.Equal(t, "secret", result.Checks[3].Name)
+	assert.Equal(t, doctorProblem, result.Checks[3].Status)
+	assert.Equal(t, "run: pub configure", result.Checks[3].Advice)
+}
+
+func TestDoctorCmd_YesRequiresFix(t *testing.T) {
+	_, _, err := runDoctorTest(t, doctorOptions{store: keyring.NewMockStore()}, "", "--yes")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--yes requires --fix")
+}
+
+func TestFixBaseURLScheme(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+		ok   bool
+	}{
+		{"api.public.com", "https://api.public.com", true},
+		{"api.public.com:443/v1", "https://api.public.com:443/v1", true},
+		{"//api.public.com", "https://api.public.com", true},
+		{"https://api.public.com", "", false},
+		{"ftp://api.public.com", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			got, ok := fixBaseURLScheme(tt.raw)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
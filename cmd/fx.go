@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jonandersen/public-cli/internal/api"
+	"github.com/jonandersen/public-cli/internal/config"
+	"github.com/jonandersen/public-cli/internal/money"
+)
+
+// fxCacheTTL is how long a fetched exchange rate is reused. Converted values
+// are indicative, so a rate a few minutes old is good enough, and --watch
+// does not hit the rate source on every refresh.
+const fxCacheTTL = 10 * time.Minute
+
+// currencyCode matches an ISO 4217 currency code.
+var currencyCode = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// parseCurrency validates --currency and returns it uppercased. Empty and
+// USD both mean no conversion and return "".
+func parseCurrency(s string) (string, error) {
+	code := strings.ToUpper(strings.TrimSpace(s))
+	if code == "" || code == "USD" {
+		return "", nil
+	}
+	if !currencyCode.MatchString(code) {
+		return "", fmt.Errorf("invalid --currency %q: use a three-letter currency code such as EUR", s)
+	}
+	return code, nil
+}
+
+// fxRate is a USD exchange rate: one dollar buys Rate units of Currency.
+type fxRate struct {
+	Currency  string    `json:"currency"`
+	Rate      float64   `json:"rate"`
+	AsOf      string    `json:"asOf,omitempty"` // the source's date for the rate
+	FetchedAt time.Time `json:"fetchedAt"`
+	Source    string    `json:"source"`
+}
+
+// convert returns a USD amount from the API in the rate's currency, or "" when
+// the amount is empty or not a number.
+func (r *fxRate) convert(usd string) string {
+	if usd == "" {
+		return ""
+	}
+	amount, err := money.Parse(usd)
+	if err != nil {
+		return ""
+	}
+	rate, err := money.Parse(strconv.FormatFloat(r.Rate, 'f', money.Scale, 64))
+	if err != nil {
+		return ""
+	}
+	return amount.Mul(rate).String()
+}
+
+// fxCachePath is where fetched exchange rates are cached between runs.
+func fxCachePath() string {
+	return filepath.Join(config.ConfigDir(), "fx_rates.json")
+}
+
+// fetchFXRate returns the USD rate for currency from sourceURL, reusing a
+// rate cached in cachePath when it is younger than fxCacheTTL and came from
+// the same source. An empty sourceURL uses config.DefaultFXRateURL and an
+// empty cachePath disables the cache.
+//
+// The source must answer with a JSON object holding a "rates" map keyed by
+// currency code and, optionally, the "date" the rates are for, as
+// frankfurter.app does. The request is a plain GET that carries no
+// credentials.
+func fetchFXRate(ctx context.Context, sourceURL, currency, cachePath string, now time.Time) (*fxRate, error) {
+	if sourceURL == "" {
+		sourceURL = config.DefaultFXRateURL
+	}
+
+	cache := loadFXCache(cachePath)
+	if cached, ok := cache[currency]; ok && cached.Source == sourceURL && now.Sub(cached.FetchedAt) < fxCacheTTL {
+		return &cached, nil
+	}
+
+	reqURL := strings.ReplaceAll(sourceURL, "{currency}", url.QueryEscape(currency))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid fx_rate_url: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("rate source error: %d - %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var rates struct {
+		Date  string             `json:"date"`
+		Rates map[string]float64 `json:"rates"`
+	}
+	if err := api.DecodeJSON(resp, &rates); err != nil {
+		return nil, err
+	}
+	value, ok := rates.Rates[currency]
+	if !ok || value <= 0 {
+		return nil, fmt.Errorf("rate source has no USD rate for %s", currency)
+	}
+
+	rate := fxRate{Currency: currency, Rate: value, AsOf: rates.Date, FetchedAt: now, Source: sourceURL}
+	if cachePath != "" {
+		cache[currency] = rate
+		_ = saveFXCache(cachePath, cache)
+	}
+	return &rate, nil
+}
+
+// loadFXCache reads the cached rates keyed by currency. A missing or corrupt
+// cache is treated as empty; it only saves a fetch.
+func loadFXCache(path string) map[string]fxRate {
+	cache := make(map[string]fxRate)
+	if path == "" {
+		return cache
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cache
+	}
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return make(map[string]fxRate)
+	}
+	return cache
+}
+
+func saveFXCache(path string, cache map[string]fxRate) error {
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// convertedEquity is one account summary line in the --currency currency.
+type convertedEquity struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// convertedPosition is a position's value in the --currency currency.
+type convertedPosition struct {
+	Symbol string `json:"symbol"`
+	Value  string `json:"value"`
+}
+
+// convertedPortfolio holds the portfolio figures converted for --currency,
+// alongside the USD ones in JSON output.
+type convertedPortfolio struct {
+	Currency           string              `json:"currency"`
+	BuyingPower        string              `json:"buyingPower"`
+	OptionsBuyingPower string              `json:"optionsBuyingPower"`
+	Equity             []convertedEquity   `json:"equity"`
+	Positions          []convertedPosition `json:"positions"`
+	TotalValue         string              `json:"totalValue"`
+}
+
+// convertPortfolio converts the portfolio's buying power, account summary,
+// and the values of positions with rate. TotalValue sums the account
+// summary.
+func convertPortfolio(portfolio *api.Portfolio, positions []api.Position, rate *fxRate) convertedPortfolio {
+	c := convertedPortfolio{
+		Currency:           rate.Currency,
+		BuyingPower:        rate.convert(portfolio.BuyingPower.BuyingPower),
+		OptionsBuyingPower: rate.convert(portfolio.BuyingPower.OptionsBuyingPower),
+		Equity:             make([]convertedEquity, 0, len(portfolio.Equity)),
+		Positions:          make([]convertedPosition, 0, len(positions)),
+	}
+	total := money.Zero
+	for _, eq := range portfolio.Equity {
+		c.Equity = append(c.Equity, convertedEquity{Type: eq.Type, Value: rate.convert(eq.Value)})
+		if m, err := money.Parse(eq.Value); err == nil {
+			total = total.Add(m)
+		}
+	}
+	c.TotalValue = rate.convert(total.String())
+	for _, pos := range positions {
+		c.Positions = append(c.Positions, convertedPosition{Symbol: pos.Instrument.Symbol, Value: rate.convert(pos.CurrentValue)})
+	}
+	return c
+}
+
+// printFXRate prints the rate --currency converted with and when it is from.
+func printFXRate(w io.Writer, rate *fxRate) {
+	asOf := rate.AsOf
+	if asOf == "" {
+		asOf = "unknown date"
+	}
+	_, _ = fmt.Fprintf(w, "Currency: %s at %.4f per USD (rate for %s, fetched %s)\n\n",
+		rate.Currency, rate.Rate, asOf, rate.FetchedAt.Local().Format("2006-01-02 15:04"))
+}
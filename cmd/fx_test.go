@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCurrency(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"", "", false},
+		{"usd", "", false},
+		{"eur", "EUR", false},
+		{" GBP ", "GBP", false},
+		{"EURO", "", true},
+		{"E1R", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseCurrency(tt.in)
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "invalid --currency")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFetchFXRate_Caches(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		assert.Equal(t, "JPY", r.URL.Query().Get("to"))
+		_, _ = w.Write([]byte(`{"date":"2026-10-15","rates":{"JPY":150.25}}`))
+	}))
+	defer server.Close()
+
+	source := server.URL + "/latest?from=USD&to={currency}"
+	cachePath := filepath.Join(t.TempDir(), "fx_rates.json")
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	rate, err := fetchFXRate(context.Background(), source, "JPY", cachePath, now)
+	require.NoError(t, err)
+	assert.Equal(t, 150.25, rate.Rate)
+	assert.Equal(t, "2026-10-15", rate.AsOf)
+	assert.Equal(t, 1, calls)
+
+	rate, err = fetchFXRate(context.Background(), source, "JPY", cachePath, now.Add(fxCacheTTL-time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, 150.25, rate.Rate)
+	assert.Equal(t, 1, calls, "a fresh cached rate is reused")
+
+	_, err = fetchFXRate(context.Background(), source, "JPY", cachePath, now.Add(fxCacheTTL))
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls, "an expired rate is fetched again")
+
+	_, err = fetchFXRate(context.Background(), source+"&v=2", "JPY", cachePath, now.Add(fxCacheTTL))
+	require.NoError(t, err)
+	assert.Equal(t, 3, calls, "a rate from another source is not reused")
+}
+
+func TestFetchFXRate_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/down" {
+			w.WriteHeader(http.StatusBadGateway)
+			_, _ = w.Write([]byte("bad gateway"))
+			return
+		}
+		_, _ = w.Write([]byte(`{"rates":{"GBP":0.79}}`))
+	}))
+	defer server.Close()
+
+	_, err := fetchFXRate(context.Background(), server.URL+"/down", "EUR", "", time.Now())
+	assert.ErrorContains(t, err, "rate source error: 502 - bad gateway")
+
+	_, err = fetchFXRate(context.Background(), server.URL, "EUR", "", time.Now())
+	assert.ErrorContains(t, err, "no USD rate for EUR")
+}
+
+func TestLoadFXCache_Corrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fx_rates.json")
+	require.NoError(t, os.WriteFile(path, []byte("{not json"), 0600))
+	assert.Empty(t, loadFXCache(path))
+	assert.Empty(t, loadFXCache(""))
+}
+
+func TestFXRateConvert(t *testing.T) {
+	rate := &fxRate{Currency: "EUR", Rate: 0.923456}
+	assert.Equal(t, "923.46", rate.convert("1000.00"))
+	assert.Equal(t, "-92.35", rate.convert("-100"))
+	assert.Equal(t, "", rate.convert(""))
+	assert.Equal(t, "", rate.convert("n/a"))
+	assert.Equal(t, " (923.46 EUR)", fxSuffix(rate, "1000"))
+	assert.Equal(t, "", fxSuffix(nil, "1000"))
+}
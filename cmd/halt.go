@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jonandersen/public-cli/internal/analytics"
+	"github.com/jonandersen/public-cli/internal/api"
+)
+
+// haltCheckSymbols returns symbols uppercased and deduplicated, with the
+// underlying of every option symbol added: a halt in the underlying stops
+// its options from trading too.
+func haltCheckSymbols(symbols []string) []string {
+	seen := make(map[string]bool)
+	var out []string
+	add := func(symbol string) {
+		if !seen[symbol] {
+			seen[symbol] = true
+			out = append(out, symbol)
+		}
+	}
+	for _, symbol := range symbols {
+		symbol = strings.ToUpper(symbol)
+		add(symbol)
+		if osi, err := analytics.ParseOSI(symbol); err == nil {
+			add(osi.Underlying)
+		}
+	}
+	return out
+}
+
+// checkNotHalted rejects an order when the quote for any of symbols (or an
+// option's underlying) reports a trading halt. Callers skip it when --force
+// is set. The check is best-effort: when quotes cannot be fetched the order
+// goes ahead and the venue has the final say.
+func checkNotHalted(ctx context.Context, client *api.Client, accountID string, symbols []string) error {
+	quotes, err := client.GetQuotes(ctx, accountID, api.ResolveInstruments(haltCheckSymbols(symbols)))
+	if err != nil {
+		return nil
+	}
+	return haltedIn(quotes)
+}
+
+// haltedIn returns the halt error for the first halted quote, or nil, for
+// callers that already have the quotes checkNotHalted would fetch.
+func haltedIn(quotes []api.Quote) error {
+	for _, q := range quotes {
+		if api.IsHalted(q) {
+			return fmt.Errorf("trading in %s is halted; the order would sit until trading resumes and may fill far from the last price (use --force to place it anyway)",
+				q.Instrument.Symbol)
+		}
+	}
+	return nil
+}
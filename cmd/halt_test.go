@@ -0,0 +1,246 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jonandersen/public-cli/internal/api"
+)
+
+// stubQuotes answers the pre-order halt check with no quotes and passes
+// every other request to next, for order tests that don't care about halts.
+func stubQuotes(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/quotes") {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"quotes":[]}`))
+			return
+		}
+		next(w, r)
+	}
+}
+
+// haltedQuoteServer serves quotes in which the given symbols are halted and
+// records every instrument it was asked for; any other request fails the
+// test, since nothing may be sent for a halted symbol.
+func haltedQuoteServer(t *testing.T, halted ...string) (*httptest.Server, *[]api.QuoteInstrument) {
+	t.Helper()
+	var requested []api.QuoteInstrument
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/quotes") {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		var req api.QuoteRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		requested = append(requested, req.Instruments...)
+		quotes := make([]map[string]any, 0, len(req.Instruments))
+		for _, inst := range req.Instruments {
+			q := map[string]any{"instrument": inst, "outcome": "SUCCESS", "last": "10.00"}
+			for _, h := range halted {
+				if inst.Symbol == h {
+					q["status"] = "HALTED"
+				}
+			}
+			quotes = append(quotes, q)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"quotes": quotes})
+	}))
+	t.Cleanup(server.Close)
+	return server, &requested
+}
+
+func TestHaltCheckSymbols(t *testing.T) {
+	got := haltCheckSymbols([]string{"aapl250117c00175000", "AAPL", "AAPL250117P00175000", "msft"})
+	assert.Equal(t, []string{"AAPL250117C00175000", "AAPL", "AAPL250117P00175000", "MSFT"}, got)
+}
+
+func TestCheckNotHalted(t *testing.T) {
+	server, requested := haltedQuoteServer(t, "AAPL")
+	client := api.NewClient(server.URL, "test-token")
+
+	err := checkNotHalted(context.Background(), client, "test-account", []string{"AAPL250117C00175000"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "trading in AAPL is halted")
+	assert.Contains(t, err.Error(), "--force")
+	assert.Equal(t, []api.QuoteInstrument{
+		{Symbol: "AAPL250117C00175000", Type: "OPTION"},
+		{Symbol: "AAPL", Type: "EQUITY"},
+	}, *requested)
+
+	assert.NoError(t, checkNotHalted(context.Background(), client, "test-account", []string{"MSFT"}))
+}
+
+func TestCheckNotHalted_QuoteFailureDoesNotBlock(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	err := checkNotHalted(context.Background(), api.NewClient(server.URL, "test-token"), "test-account", []string{"AAPL"})
+	assert.NoError(t, err)
+}
+
+func TestOrderBuyCmd_Halted(t *testing.T) {
+	server, _ := haltedQuoteServer(t, "AAPL")
+
+	cmd := newOrderBuyCmd(orderOptions{
+		baseURL:        server.URL,
+		authToken:      "test-token",
+		accountID:      "test-account",
+		tradingEnabled: true,
+	})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"aapl", "-q", "10", "--yes"})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "trading in AAPL is halted")
+}
+
+func TestOrderBuyCmd_HaltedForce(t *testing.T) {
+	var quoteRequests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/quotes") {
+			quoteRequests++
+		}
+		var req map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"orderId": req["orderId"]})
+	}))
+	defer server.Close()
+
+	cmd := newOrderBuyCmd(orderOptions{
+		baseURL:        server.URL,
+		authToken:      "test-token",
+		accountID:      "test-account",
+		tradingEnabled: true,
+	})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"AAPL", "-q", "10", "--no-preflight", "--force", "--yes"})
+
+	require.NoError(t, cmd.Execute())
+	assert.Zero(t, quoteRequests, "--force skips the halt check")
+}
+
+func TestRunSingleLegOrder_Halted(t *testing.T) {
+	server, requested := haltedQuoteServer(t, "AAPL")
+	opts := optionsOptions{baseURL: server.URL, authToken: "test-token", accountID: "test-account"}
+	params := singleLegParams{quantity: "1", limitPrice: "2.50", expiration: "DAY", openClose: "OPEN"}
+
+	err := runSingleLegOrder(newTestCmd(), opts, "AAPL250117C00175000", "BUY", params, true, true)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "trading in AAPL is halted")
+	assert.Len(t, *requested, 2)
+}
+
+func TestRunMultilegOrder_Halted(t *testing.T) {
+	server, _ := haltedQuoteServer(t, "AAPL250117C00180000")
+	opts := optionsOptions{baseURL: server.URL, authToken: "test-token", accountID: "test-account"}
+	legs := []string{
+		"BUY AAPL250117C00175000 OPEN",
+		"SELL AAPL250117C00180000 OPEN",
+	}
+
+	err := runMultilegOrder(newTestCmd(), opts, legs, "2.50", "", "1", "DAY", true, riskGuard{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "trading in AAPL250117C00180000 is halted")
+}
+
+func TestOrderBuyCmd_HaltedPegUsesOneQuote(t *testing.T) {
+	server, requested := haltedQuoteServer(t, "AAPL")
+
+	cmd := newOrderBuyCmd(orderOptions{
+		baseURL:        server.URL,
+		authToken:      "test-token",
+		accountID:      "test-account",
+		tradingEnabled: true,
+	})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"AAPL", "-q", "10", "--peg", "last", "--yes"})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "trading in AAPL is halted")
+	assert.Len(t, *requested, 1, "the peg quote doubles as the halt check")
+}
+
+func TestOrderBuyCmd_NoPreflightSkipsHaltCheck(t *testing.T) {
+	var quoteRequests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/quotes") {
+			quoteRequests++
+		}
+		var req map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"orderId": req["orderId"]})
+	}))
+	defer server.Close()
+
+	cmd := newOrderBuyCmd(orderOptions{
+		baseURL:        server.URL,
+		authToken:      "test-token",
+		accountID:      "test-account",
+		tradingEnabled: true,
+	})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"AAPL", "-q", "10", "--no-preflight", "--yes"})
+
+	require.NoError(t, cmd.Execute())
+	assert.Zero(t, quoteRequests, "--no-preflight sends only the order")
+}
+
+func TestOrderCloseCmd_Halted(t *testing.T) {
+	var placed bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/quotes"):
+			t.Errorf("a close does not check for a halt")
+		case strings.HasSuffix(r.URL.Path, "/portfolio/v2"):
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"accountId": "test-account",
+				"positions": []map[string]any{
+					{"instrument": map[string]any{"symbol": "AAPL", "type": "EQUITY"}, "quantity": "10"},
+				},
+			})
+		case strings.HasSuffix(r.URL.Path, "/order"):
+			var req map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			placed = true
+			_ = json.NewEncoder(w).Encode(map[string]any{"orderId": req["orderId"]})
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	cmd := newOrderCloseCmd(orderOptions{
+		baseURL:        server.URL,
+		authToken:      "test-token",
+		accountID:      "test-account",
+		tradingEnabled: true,
+	})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"AAPL", "--limit", "180.00", "--yes"})
+
+	require.NoError(t, cmd.Execute())
+	assert.True(t, placed)
+}
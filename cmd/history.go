@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"time"
@@ -12,6 +11,7 @@ import (
 	"github.com/jonandersen/public-cli/internal/api"
 	"github.com/jonandersen/public-cli/internal/config"
 	"github.com/jonandersen/public-cli/internal/keyring"
+	"github.com/jonandersen/public-cli/internal/marketdate"
 	"github.com/jonandersen/public-cli/internal/output"
 )
 
@@ -20,6 +20,7 @@ type historyOptions struct {
 	baseURL          string
 	authToken        string
 	jsonMode         bool
+	wide             bool // show timestamps, quantities, fees, and full descriptions
 	defaultAccountID string
 }
 
@@ -43,11 +44,12 @@ Examples:
   pub history                                    # Use default account
   pub history --account YOUR_ACCOUNT_ID          # Specific account
   pub history --start 2025-01-01T00:00:00Z       # Filter by start date
+  pub history --start -7d --end today            # Last week (US/Eastern days)
   pub history --limit 10                         # Limit results`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			accountID := flagAccountID
-			if accountID == "" {
-				accountID = opts.defaultAccountID
+			accountID, err := resolveAccountID(flagAccountID, opts.defaultAccountID, cmd.InOrStdin())
+			if err != nil {
+				return err
 			}
 			if accountID == "" {
 				return fmt.Errorf("account ID is required (use --account flag or set default with 'pub configure')")
@@ -56,9 +58,9 @@ Examples:
 		},
 	}
 
-	cmd.Flags().StringVarP(&flagAccountID, "account", "a", "", "Account ID (uses default if configured)")
-	cmd.Flags().StringVar(&flagStart, "start", "", "Start timestamp (ISO 8601 format, e.g., 2025-01-01T00:00:00Z)")
-	cmd.Flags().StringVar(&flagEnd, "end", "", "End timestamp (ISO 8601 format, e.g., 2025-01-31T23:59:59Z)")
+	cmd.Flags().StringVarP(&flagAccountID, "account", "a", "", "Account ID (uses default if configured; - reads it from stdin)")
+	cmd.Flags().StringVar(&flagStart, "start", "", "Start timestamp (ISO 8601) or date (YYYY-MM-DD, today, -Nd)")
+	cmd.Flags().StringVar(&flagEnd, "end", "", "End timestamp (ISO 8601) or date (YYYY-MM-DD, today, -Nd)")
 	cmd.Flags().IntVarP(&flagLimit, "limit", "l", 0, "Maximum number of transactions to return")
 	cmd.SilenceUsage = true
 
@@ -75,10 +77,18 @@ func runHistory(cmd *cobra.Command, opts historyOptions, accountID, start, end s
 	// Build query parameters
 	queryParams := make(map[string]string)
 	if start != "" {
-		queryParams["start"] = start
+		ts, err := parseHistoryBound(start, false, time.Now())
+		if err != nil {
+			return fmt.Errorf("invalid --start: %w", err)
+		}
+		queryParams["start"] = ts
 	}
 	if end != "" {
-		queryParams["end"] = end
+		ts, err := parseHistoryBound(end, true, time.Now())
+		if err != nil {
+			return fmt.Errorf("invalid --end: %w", err)
+		}
+		queryParams["end"] = ts
 	}
 	if limit > 0 {
 		queryParams["pageSize"] = fmt.Sprintf("%d", limit)
@@ -96,8 +106,8 @@ func runHistory(cmd *cobra.Command, opts historyOptions, accountID, start, end s
 	}
 
 	var historyResp api.HistoryResponse
-	if err := json.NewDecoder(resp.Body).Decode(&historyResp); err != nil {
-		return fmt.Errorf("failed to decode response: %w", err)
+	if err := api.DecodeJSON(resp, &historyResp); err != nil {
+		return err
 	}
 
 	if len(historyResp.Transactions) == 0 {
@@ -122,7 +132,7 @@ func runHistory(cmd *cobra.Command, opts historyOptions, accountID, start, end s
 	}
 
 	// Format as table
-	headers := []string{"ID", "Date", "Type", "Symbol", "Description", "Amount"}
+	headers := []string{"ID", "Date", "Timestamp", "Type", "Symbol", "Description", "Quantity", "Amount", "Fees"}
 	rows := make([][]string, 0, len(historyResp.Transactions))
 	for _, txn := range historyResp.Transactions {
 		// Format timestamp to just the date portion for readability
@@ -131,17 +141,46 @@ func runHistory(cmd *cobra.Command, opts historyOptions, accountID, start, end s
 		if txn.SubType != "" {
 			txnType = txn.SubType
 		}
+		description := txn.Description
+		if !opts.wide {
+			description = truncateString(description, 30)
+		}
+		fees := "-"
+		if txn.Fees != "" {
+			fees = formatAmount(txn.Fees)
+		}
 		rows = append(rows, []string{
 			txn.ID,
 			date,
+			txn.Timestamp,
 			txnType,
 			txn.Symbol,
-			truncateString(txn.Description, 30),
+			description,
+			valueOrDash(txn.Quantity),
 			formatAmount(txn.NetAmount),
+			fees,
 		})
 	}
 
-	return formatter.Table(headers, rows)
+	return formatter.WithWide(opts.wide).WideTable(headers, rows, "Timestamp", "Quantity", "Fees")
+}
+
+// parseHistoryBound converts a --start/--end style value into the RFC 3339
+// timestamp the history endpoint expects. Full timestamps pass through
+// unchanged; dates such as 2025-01-31, today, or -7d name a US/Eastern market
+// day and resolve to its first instant, or its last when endOfDay is set.
+func parseHistoryBound(value string, endOfDay bool, now time.Time) (string, error) {
+	if _, err := time.Parse(time.RFC3339, value); err == nil {
+		return value, nil
+	}
+	day, err := marketdate.Parse(value, now)
+	if err != nil {
+		return "", err
+	}
+	if endOfDay {
+		day = day.AddDate(0, 0, 1).Add(-time.Second)
+	}
+	return day.UTC().Format(time.RFC3339), nil
 }
 
 // formatTransactionDate formats an ISO timestamp to a readable date.
@@ -186,6 +225,7 @@ Examples:
   pub history                                    # Use default account
   pub history --account YOUR_ACCOUNT_ID          # Specific account
   pub history --start 2025-01-01T00:00:00Z       # Filter by start date
+  pub history --start -7d --end today            # Last week (US/Eastern days)
   pub history --limit 10                         # Limit results`,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			cfg, err := config.Load(config.ConfigPath())
@@ -202,6 +242,7 @@ Examples:
 			opts.baseURL = cfg.APIBaseURL
 			opts.authToken = token
 			opts.jsonMode = GetJSONMode()
+			opts.wide = GetWideMode()
 			opts.defaultAccountID = cfg.AccountUUID
 			return nil
 		},
@@ -216,15 +257,15 @@ Examples:
 		flagLimit     int
 	)
 
-	historyCmd.Flags().StringVarP(&flagAccountID, "account", "a", "", "Account ID (uses default if configured)")
-	historyCmd.Flags().StringVar(&flagStart, "start", "", "Start timestamp (ISO 8601 format, e.g., 2025-01-01T00:00:00Z)")
-	historyCmd.Flags().StringVar(&flagEnd, "end", "", "End timestamp (ISO 8601 format, e.g., 2025-01-31T23:59:59Z)")
+	historyCmd.Flags().StringVarP(&flagAccountID, "account", "a", "", "Account ID (uses default if configured; - reads it from stdin)")
+	historyCmd.Flags().StringVar(&flagStart, "start", "", "Start timestamp (ISO 8601) or date (YYYY-MM-DD, today, -Nd)")
+	historyCmd.Flags().StringVar(&flagEnd, "end", "", "End timestamp (ISO 8601) or date (YYYY-MM-DD, today, -Nd)")
 	historyCmd.Flags().IntVarP(&flagLimit, "limit", "l", 0, "Maximum number of transactions to return")
 
 	historyCmd.RunE = func(cmd *cobra.Command, args []string) error {
-		accountID := flagAccountID
-		if accountID == "" {
-			accountID = opts.defaultAccountID
+		accountID, err := resolveAccountID(flagAccountID, opts.defaultAccountID, cmd.InOrStdin())
+		if err != nil {
+			return err
 		}
 		if accountID == "" {
 			return fmt.Errorf("account ID is required (use --account flag or set default with 'pub configure')")
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -216,6 +217,69 @@ func TestHistoryCmd_WithDateFilters(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestHistoryCmd_WithMarketDates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Plain dates are whole US/Eastern days
+		assert.Equal(t, "2025-01-01T05:00:00Z", r.URL.Query().Get("start"))
+		assert.Equal(t, "2025-02-01T04:59:59Z", r.URL.Query().Get("end"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"transactions": []map[string]any{}})
+	}))
+	defer server.Close()
+
+	cmd := newHistoryCmd(historyOptions{
+		baseURL:   server.URL,
+		authToken: "test-token",
+	})
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--account", "abc123", "--start", "2025-01-01", "--end", "2025-01-31"})
+
+	require.NoError(t, cmd.Execute())
+}
+
+func TestHistoryCmd_InvalidDate(t *testing.T) {
+	cmd := newHistoryCmd(historyOptions{
+		baseURL:   "http://unused",
+		authToken: "test-token",
+	})
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs([]string{"--account", "abc123", "--start", "01/01/2025"})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid --start")
+}
+
+func TestParseHistoryBound(t *testing.T) {
+	// 02:30 UTC on the 16th is the evening of the 15th in New York
+	now := time.Date(2025, 1, 16, 2, 30, 0, 0, time.UTC)
+
+	got, err := parseHistoryBound("2025-01-01T00:00:00Z", false, now)
+	require.NoError(t, err)
+	assert.Equal(t, "2025-01-01T00:00:00Z", got)
+
+	got, err = parseHistoryBound("today", false, now)
+	require.NoError(t, err)
+	assert.Equal(t, "2025-01-15T05:00:00Z", got)
+
+	got, err = parseHistoryBound("today", true, now)
+	require.NoError(t, err)
+	assert.Equal(t, "2025-01-16T04:59:59Z", got)
+
+	got, err = parseHistoryBound("-7d", false, now)
+	require.NoError(t, err)
+	assert.Equal(t, "2025-01-08T05:00:00Z", got)
+
+	_, err = parseHistoryBound("last-week", false, now)
+	assert.Error(t, err)
+}
+
 func TestHistoryCmd_WithLimit(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "10", r.URL.Query().Get("pageSize"))
@@ -334,3 +398,45 @@ func TestHistoryCmd_FlagOverridesDefault(t *testing.T) {
 	err := cmd.Execute()
 	require.NoError(t, err)
 }
+
+func TestHistoryCmd_Wide(t *testing.T) {
+	longDescription := "Buy 10 shares of AAPL at 175.00 via limit order"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"transactions": []map[string]any{
+				{
+					"id":          "txn-001",
+					"timestamp":   "2025-01-15T10:30:00Z",
+					"type":        "TRADE",
+					"symbol":      "AAPL",
+					"description": longDescription,
+					"quantity":    "10",
+					"netAmount":   "-1750.02",
+					"fees":        "0.02",
+				},
+			},
+		})
+	}))
+	defer server.Close()
+
+	run := func(wide bool) string {
+		cmd := newHistoryCmd(historyOptions{baseURL: server.URL, authToken: "test-token", wide: wide})
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetArgs([]string{"--account", "abc123"})
+		require.NoError(t, cmd.Execute())
+		return out.String()
+	}
+
+	narrow := run(false)
+	assert.NotContains(t, narrow, "Fees")
+	assert.NotContains(t, narrow, "10:30:00")
+	assert.NotContains(t, narrow, longDescription)
+
+	wide := run(true)
+	assert.Contains(t, wide, "Fees")
+	assert.Contains(t, wide, "$0.02")
+	assert.Contains(t, wide, "2025-01-15T10:30:00Z")
+	assert.Contains(t, wide, longDescription)
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jonandersen/public-cli/internal/api"
+)
+
+// orderInstrumentTypes are the --instrument-type values accepted by order
+// buy, sell, and estimate, in the order auto-detection tries them.
+var orderInstrumentTypes = []string{"EQUITY", "ETF", "ADR", "CRYPTO"}
+
+// instrumentTypeAuto asks the instrument endpoint how it classifies a symbol.
+const instrumentTypeAuto = "AUTO"
+
+// parseInstrumentType validates --instrument-type and returns it uppercased.
+// Empty means EQUITY.
+func parseInstrumentType(s string) (string, error) {
+	t := strings.ToUpper(strings.TrimSpace(s))
+	if t == "" {
+		return "EQUITY", nil
+	}
+	if t == instrumentTypeAuto {
+		return t, nil
+	}
+	for _, valid := range orderInstrumentTypes {
+		if t == valid {
+			return t, nil
+		}
+	}
+	return "", fmt.Errorf("invalid --instrument-type %q: use %s, or auto", s, strings.Join(orderInstrumentTypes, ", "))
+}
+
+// resolveInstrumentType returns the instrument type to send for symbol. An
+// explicit type is used as given; auto looks symbol up under each supported
+// type in turn and takes the first the API knows, using the type the API
+// reports for it.
+func resolveInstrumentType(ctx context.Context, client *api.Client, symbol, flagValue string) (string, error) {
+	t, err := parseInstrumentType(flagValue)
+	if err != nil || t != instrumentTypeAuto {
+		return t, err
+	}
+
+	var lastErr error
+	for _, candidate := range orderInstrumentTypes {
+		inst, err := client.GetInstrument(ctx, symbol, candidate)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if inst.Instrument.Type != "" {
+			return strings.ToUpper(inst.Instrument.Type), nil
+		}
+		return candidate, nil
+	}
+	return "", fmt.Errorf("--instrument-type auto: %s not found as %s (%w); pass --instrument-type explicitly",
+		symbol, strings.Join(orderInstrumentTypes, ", "), lastErr)
+}
@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jonandersen/public-cli/internal/api"
+)
+
+func TestParseInstrumentType(t *testing.T) {
+	for input, want := range map[string]string{
+		"":       "EQUITY",
+		"equity": "EQUITY",
+		"ETF":    "ETF",
+		" adr ":  "ADR",
+		"crypto": "CRYPTO",
+		"auto":   instrumentTypeAuto,
+	} {
+		got, err := parseInstrumentType(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, got, input)
+	}
+
+	_, err := parseInstrumentType("OPTION")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid --instrument-type "OPTION": use EQUITY, ETF, ADR, CRYPTO, or auto`)
+}
+
+func TestResolveInstrumentType_Auto(t *testing.T) {
+	var tried []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tried = append(tried, r.URL.Path)
+		if !strings.HasSuffix(r.URL.Path, "/BTC/CRYPTO") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(api.InstrumentResponse{
+			Instrument: api.InstrumentIdentifier{Symbol: "BTC", Type: "CRYPTO"},
+		})
+	}))
+	defer server.Close()
+
+	got, err := resolveInstrumentType(context.Background(), api.NewClient(server.URL, "test-token"), "BTC", "auto")
+	require.NoError(t, err)
+	assert.Equal(t, "CRYPTO", got)
+	assert.Equal(t, []string{
+		"/userapigateway/trading/instruments/BTC/EQUITY",
+		"/userapigateway/trading/instruments/BTC/ETF",
+		"/userapigateway/trading/instruments/BTC/ADR",
+		"/userapigateway/trading/instruments/BTC/CRYPTO",
+	}, tried)
+}
+
+func TestResolveInstrumentType_AutoNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	_, err := resolveInstrumentType(context.Background(), api.NewClient(server.URL, "test-token"), "NOPE", "auto")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--instrument-type auto: NOPE not found")
+}
+
+func TestResolveInstrumentType_ExplicitSkipsLookup(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL.Path)
+	}))
+	defer server.Close()
+
+	got, err := resolveInstrumentType(context.Background(), api.NewClient(server.URL, "test-token"), "SPY", "etf")
+	require.NoError(t, err)
+	assert.Equal(t, "ETF", got)
+}
+
+func TestOrderBuyCmd_InstrumentType(t *testing.T) {
+	var orderType, preflightType string
+	server := httptest.NewServer(stubQuotes(func(w http.ResponseWriter, r *http.Request) {
+		var req api.OrderRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(r.URL.Path, "preflight") {
+			preflightType = req.Instrument.Type
+			_ = json.NewEncoder(w).Encode(api.PreflightResponse{})
+			return
+		}
+		orderType = req.Instrument.Type
+		_ = json.NewEncoder(w).Encode(map[string]any{"orderId": req.OrderID})
+	}))
+	defer server.Close()
+
+	cmd := newOrderBuyCmd(orderOptions{
+		baseURL:        server.URL,
+		authToken:      "test-token",
+		accountID:      "test-account",
+		tradingEnabled: true,
+		jsonMode:       true,
+	})
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"VOO", "--quantity", "1", "--instrument-type", "etf", "--yes"})
+
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, "ETF", orderType)
+	assert.Equal(t, "ETF", preflightType)
+
+	var result OrderPlacedResult
+	require.NoError(t, json.Unmarshal(out.Bytes(), &result))
+	assert.Equal(t, "ETF", result.InstrumentType)
+}
+
+func TestOrderBuyCmd_InvalidInstrumentType(t *testing.T) {
+	cmd := newOrderBuyCmd(orderOptions{
+		baseURL:        "http://localhost",
+		authToken:      "test-token",
+		accountID:      "test-account",
+		tradingEnabled: true,
+	})
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs([]string{"AAPL", "--quantity", "1", "--instrument-type", "bond", "--yes"})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid --instrument-type")
+}
@@ -2,11 +2,7 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/url"
-	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -61,34 +57,9 @@ func runInstruments(cmd *cobra.Command, opts instrumentsOptions, typeFilter, tra
 
 	client := api.NewClient(opts.baseURL, opts.authToken)
 
-	// Build query parameters
-	params := url.Values{}
-	if typeFilter != "" {
-		params.Set("typeFilter", strings.ToUpper(typeFilter))
-	}
-	if tradingFilter != "" {
-		params.Set("tradingFilter", strings.ToUpper(tradingFilter))
-	}
-
-	path := "/userapigateway/trading/instruments"
-	if len(params) > 0 {
-		path += "?" + params.Encode()
-	}
-
-	resp, err := client.Get(ctx, path)
+	instResp, err := client.GetInstruments(ctx, typeFilter, tradingFilter)
 	if err != nil {
-		return fmt.Errorf("failed to fetch instruments: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != 200 {
-		respBody, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API error: %d - %s", resp.StatusCode, string(respBody))
-	}
-
-	var instResp api.InstrumentsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&instResp); err != nil {
-		return fmt.Errorf("failed to decode response: %w", err)
+		return err
 	}
 
 	// Format output
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jonandersen/public-cli/internal/api"
+	"github.com/jonandersen/public-cli/internal/config"
+)
+
+// maxReasonLength bounds --reason so journal entries stay readable.
+const maxReasonLength = 500
+
+// parseReason validates --reason and returns it trimmed. A blank reason is
+// config.ErrReasonRequired when required is set (require_reason in config).
+func parseReason(reason string, required bool) (string, error) {
+	reason = strings.TrimSpace(reason)
+	switch {
+	case reason == "" && required:
+		return "", config.ErrReasonRequired
+	case strings.ContainsAny(reason, "\r\n"):
+		return "", fmt.Errorf("invalid --reason: must be a single line")
+	case len(reason) > maxReasonLength:
+		return "", fmt.Errorf("invalid --reason: must be at most %d characters", maxReasonLength)
+	}
+	return reason, nil
+}
+
+// journalPath is the local trade journal: one JSON object per line for each
+// order placed with a --reason.
+func journalPath() string {
+	return filepath.Join(config.ConfigDir(), "journal.jsonl")
+}
+
+// journalEntry is one placed order in the trade journal. Multi-leg orders
+// list their legs instead of a symbol and side.
+type journalEntry struct {
+	Time       string   `json:"time"`
+	AccountID  string   `json:"accountId"`
+	OrderID    string   `json:"orderId"`
+	Symbol     string   `json:"symbol,omitempty"`
+	Side       string   `json:"side,omitempty"`
+	Legs       []string `json:"legs,omitempty"`
+	Quantity   string   `json:"quantity"`
+	OrderType  string   `json:"orderType"`
+	LimitPrice string   `json:"limitPrice,omitempty"`
+	StopPrice  string   `json:"stopPrice,omitempty"`
+	Reason     string   `json:"reason"`
+}
+
+// equityJournalEntry describes a placed single-leg order. order.OrderID must
+// be the ID the API returned.
+func equityJournalEntry(accountID string, order api.OrderRequest, reason string) journalEntry {
+	quantity := order.Quantity
+	if quantity == "" && order.Amount != "" {
+		quantity = "$" + order.Amount
+	}
+	return journalEntry{
+		AccountID:  accountID,
+		OrderID:    order.OrderID,
+		Symbol:     order.Instrument.Symbol,
+		Side:       order.OrderSide,
+		Quantity:   quantity,
+		OrderType:  order.OrderType,
+		LimitPrice: order.LimitPrice,
+		StopPrice:  order.StopPrice,
+		Reason:     reason,
+	}
+}
+
+// recordJournal appends entry to the journal at path when it has a reason.
+// An empty path disables the journal. The order is already placed by now, so
+// a failed write is a warning on errW rather than an error.
+func recordJournal(errW io.Writer, path string, entry journalEntry, now time.Time) {
+	if path == "" || entry.Reason == "" {
+		return
+	}
+	entry.Time = now.UTC().Format(time.RFC3339)
+	if err := appendJournal(path, entry); err != nil {
+		_, _ = fmt.Fprintf(errW, "Warning: order %s was placed but not recorded in the journal: %s\n", entry.OrderID, err)
+	}
+}
+
+func appendJournal(path string, entry journalEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jonandersen/public-cli/internal/api"
+	"github.com/jonandersen/public-cli/internal/config"
+)
+
+func TestParseReason(t *testing.T) {
+	reason, err := parseReason("  earnings play  ", false)
+	require.NoError(t, err)
+	assert.Equal(t, "earnings play", reason)
+
+	reason, err = parseReason("", false)
+	require.NoError(t, err)
+	assert.Empty(t, reason)
+
+	_, err = parseReason("   ", true)
+	assert.ErrorIs(t, err, config.ErrReasonRequired)
+
+	_, err = parseReason("line one\nline two", false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid --reason: must be a single line")
+
+	_, err = parseReason(strings.Repeat("x", maxReasonLength+1), false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must be at most 500 characters")
+}
+
+func TestRecordJournal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pub", "journal.jsonl")
+	now := time.Date(2025, 1, 10, 15, 4, 5, 0, time.UTC)
+	order := api.OrderRequest{
+		OrderID:    "order-1",
+		Instrument: api.OrderInstrument{Symbol: "AAPL", Type: "EQUITY"},
+		OrderSide:  "BUY",
+		OrderType:  "LIMIT",
+		Quantity:   "10",
+		LimitPrice: "180.00",
+	}
+
+	var errOut bytes.Buffer
+	recordJournal(&errOut, path, equityJournalEntry("test-account", order, "earnings play"), now)
+	recordJournal(&errOut, path, equityJournalEntry("test-account", order, ""), now)
+	order.OrderID = "order-2"
+	order.Quantity = ""
+	order.Amount = "500"
+	recordJournal(&errOut, path, equityJournalEntry("test-account", order, "dip buy"), now)
+	assert.Empty(t, errOut.String())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2, "orders without a reason are not journaled")
+
+	var entry journalEntry
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+	assert.Equal(t, journalEntry{
+		Time: "2025-01-10T15:04:05Z", AccountID: "test-account", OrderID: "order-1", Symbol: "AAPL", Side: "BUY",
+		Quantity: "10", OrderType: "LIMIT", LimitPrice: "180.00", Reason: "earnings play",
+	}, entry)
+
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &entry))
+	assert.Equal(t, "$500", entry.Quantity)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestRecordJournal_WriteFailureWarns(t *testing.T) {
+	// The journal path is a directory, so the append fails
+	path := t.TempDir()
+
+	var errOut bytes.Buffer
+	recordJournal(&errOut, path, journalEntry{OrderID: "order-1", Reason: "earnings play"}, time.Now())
+	assert.Contains(t, errOut.String(), "Warning: order order-1 was placed but not recorded in the journal")
+
+	errOut.Reset()
+	recordJournal(&errOut, "", journalEntry{OrderID: "order-1", Reason: "earnings play"}, time.Now())
+	assert.Empty(t, errOut.String(), "an empty path disables the journal")
+}
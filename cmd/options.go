@@ -6,17 +6,22 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
-	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 
+	"github.com/jonandersen/public-cli/internal/analytics"
 	"github.com/jonandersen/public-cli/internal/api"
 	"github.com/jonandersen/public-cli/internal/config"
 	"github.com/jonandersen/public-cli/internal/keyring"
+	"github.com/jonandersen/public-cli/internal/marketdate"
+	"github.com/jonandersen/public-cli/internal/money"
+	"github.com/jonandersen/public-cli/pkg/publicapi"
 )
 
 // chainFilter holds filtering options for the options chain command.
@@ -25,9 +30,89 @@ type chainFilter struct {
 	maxStrike float64
 	minOI     int
 	minVolume int
+	minBid    float64 // drops options bidding below this, including unbid ones
 	callsOnly bool
 	putsOnly  bool
-	strikes   int // N strikes around ATM (requires underlying price)
+	strikes   int     // N strikes around ATM (requires underlying price)
+	near      int     // N strikes around center instead of ATM
+	center    float64 // price to center --near on
+
+	underlyingPrice float64 // overrides the fetched underlying price when > 0
+}
+
+// chainView holds display options for the chain table.
+type chainView struct {
+	showSize  bool   // include bid/ask size columns
+	breakeven bool   // include the breakeven-at-expiration column
+	sortBy    string // one of chainSortKeys; empty sorts by strike
+	desc      bool   // reverse the sort order
+
+	deltaHint bool               // include the delta-hedge share column
+	contracts int                // contracts the hedge is sized for
+	deltas    map[string]float64 // delta by OSI symbol, fetched for deltaHint
+
+	spreadWidth float64 // when > 0, show call verticals of this width instead of the chain
+
+	csv    bool // write chainCSVColumns as CSV instead of the table
+	greeks bool // append chainCSVGreeksColumns to the CSV
+
+	compact   bool // one table with calls and puts on either side of the strike
+	termWidth int  // overrides the detected terminal width for compact when > 0
+}
+
+// chainSortKeys are the accepted --sort values for the chain command.
+var chainSortKeys = []string{"strike", "volume", "oi", "spread"}
+
+// parseChainSort validates a --sort value.
+func parseChainSort(value string) (string, error) {
+	key := strings.ToLower(strings.TrimSpace(value))
+	for _, k := range chainSortKeys {
+		if key == k {
+			return key, nil
+		}
+	}
+	return "", fmt.Errorf("invalid --sort value: %s (use %s)", value, strings.Join(chainSortKeys, ", "))
+}
+
+// sortChainOptions sorts one side of a chain in place by the given key.
+// Ties fall back to strike ascending. Options without a parseable bid and
+// ask sort last for spread regardless of direction.
+func sortChainOptions(options []api.OptionQuote, key string, desc bool) {
+	spread := func(opt api.OptionQuote) (float64, bool) {
+		bid, err1 := strconv.ParseFloat(opt.Bid, 64)
+		ask, err2 := strconv.ParseFloat(opt.Ask, 64)
+		if err1 != nil || err2 != nil {
+			return 0, false
+		}
+		return ask - bid, true
+	}
+
+	sort.SliceStable(options, func(i, j int) bool {
+		a, b := options[i], options[j]
+		var va, vb float64
+		switch key {
+		case "volume":
+			va, vb = float64(a.Volume), float64(b.Volume)
+		case "oi":
+			va, vb = float64(a.OpenInterest), float64(b.OpenInterest)
+		case "spread":
+			sa, okA := spread(a)
+			sb, okB := spread(b)
+			if okA != okB {
+				return okA
+			}
+			va, vb = sa, sb
+		default:
+			va, vb = parseStrikeFloat(a.Instrument.Symbol), parseStrikeFloat(b.Instrument.Symbol)
+		}
+		if va != vb {
+			if desc {
+				return va > vb
+			}
+			return va < vb
+		}
+		return parseStrikeFloat(a.Instrument.Symbol) < parseStrikeFloat(b.Instrument.Symbol)
+	})
 }
 
 // filterOptions filters a slice of OptionQuote based on the given criteria.
@@ -58,12 +143,40 @@ func filterOptions(options []api.OptionQuote, filter chainFilter) []api.OptionQu
 			continue
 		}
 
+		// Bid filter: an empty or unparseable bid counts as no bid
+		if filter.minBid > 0 {
+			bid, _ := strconv.ParseFloat(opt.Bid, 64)
+			if bid < filter.minBid {
+				continue
+			}
+		}
+
 		filtered = append(filtered, opt)
 	}
 
 	return filtered
 }
 
+// validateNearFilter checks --near and --center, which only make sense together.
+func validateNearFilter(filter chainFilter, nearSet, centerSet bool) error {
+	if nearSet != centerSet {
+		return fmt.Errorf("--near and --center must be used together")
+	}
+	if !nearSet {
+		return nil
+	}
+	if filter.near <= 0 {
+		return fmt.Errorf("invalid --near: must be a positive number of strikes")
+	}
+	if filter.center <= 0 {
+		return fmt.Errorf("invalid --center: must be a positive price")
+	}
+	if filter.strikes > 0 {
+		return fmt.Errorf("cannot use both --strikes and --near")
+	}
+	return nil
+}
+
 // filterStrikesAroundATM filters options to N strikes centered around the ATM strike.
 // underlyingPrice is the current price of the underlying stock.
 func filterStrikesAroundATM(options []api.OptionQuote, n int, underlyingPrice float64) []api.OptionQuote {
@@ -116,6 +229,25 @@ func parseStrikeFloat(symbol string) float64 {
 	return float64(strike) / 1000.0
 }
 
+// optionBreakeven returns the breakeven at expiration for buying the option at
+// the ask: strike + ask for calls and strike - ask for puts. It returns "-" when
+// the ask or the option type is unknown.
+func optionBreakeven(opt api.OptionQuote) string {
+	ask, err := strconv.ParseFloat(opt.Ask, 64)
+	if err != nil || ask <= 0 {
+		return "-"
+	}
+	osi, err := analytics.ParseOSI(opt.Instrument.Symbol)
+	if err != nil {
+		return "-"
+	}
+	strike := parseStrikeFloat(opt.Instrument.Symbol)
+	if osi.Type == analytics.Put {
+		return fmt.Sprintf("%.2f", strike-ask)
+	}
+	return fmt.Sprintf("%.2f", strike+ask)
+}
+
 func abs(x float64) float64 {
 	if x < 0 {
 		return -x
@@ -125,14 +257,25 @@ func abs(x float64) float64 {
 
 // optionsOptions holds dependencies for options commands.
 type optionsOptions struct {
-	baseURL   string
-	authToken string
-	accountID string
-	jsonMode  bool
+	baseURL    string
+	authToken  string
+	accountID  string
+	jsonMode   bool
+	newOrderID orderIDGenerator // nil uses random UUIDs
+
+	maxContracts int // max_contracts from config; 0 disables the cap
+
+	promptConfirm bool   // trading_enabled: confirm; orders are confirmed at a prompt, even with --yes
+	confirmPrompt string // confirm_prompt from config: the question asked before placing an order; empty uses the default
+	reason        string // --reason for the order being placed
+	requireReason bool   // require_reason from config: orders need a --reason
+	journalPath   string // trade journal for orders with a --reason; empty disables it
 }
 
 // newOptionsExpirationsCmd creates the options expirations command with the given options.
 func newOptionsExpirationsCmd(opts optionsOptions) *cobra.Command {
+	var withATMIV bool
+
 	cmd := &cobra.Command{
 		Use:   "expirations SYMBOL",
 		Short: "List option expiration dates",
@@ -140,23 +283,34 @@ func newOptionsExpirationsCmd(opts optionsOptions) *cobra.Command {
 
 Examples:
   pub options expirations AAPL           # List expirations for Apple
-  pub options expirations AAPL --json    # Output in JSON format`,
+  pub options expirations AAPL --json    # Output in JSON format
+  pub options expirations AAPL --with-atm-iv   # Add the ATM implied volatility per expiration
+
+--with-atm-iv fetches the chain and greeks of the at-the-money call for every
+expiration (a few at a time) and shows its implied volatility next to the
+date and DTE, giving the term structure at a glance. Expirations whose IV
+cannot be fetched show "-".`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if opts.accountID == "" {
 				return fmt.Errorf("account ID is required (use --account flag or configure default account)")
 			}
-			return runOptionsExpirations(cmd, opts, args[0])
+			return runOptionsExpirations(cmd, opts, args[0], withATMIV)
 		},
 	}
 
+	cmd.Flags().BoolVar(&withATMIV, "with-atm-iv", false, "Show the ATM implied volatility for each expiration")
 	cmd.SilenceUsage = true
 
 	return cmd
 }
 
-func runOptionsExpirations(cmd *cobra.Command, opts optionsOptions, symbol string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+func runOptionsExpirations(cmd *cobra.Command, opts optionsOptions, symbol string, withATMIV bool) error {
+	timeout := 30 * time.Second
+	if withATMIV {
+		timeout = 60 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
 	client := api.NewClient(opts.baseURL, opts.authToken)
@@ -170,6 +324,18 @@ func runOptionsExpirations(cmd *cobra.Command, opts optionsOptions, symbol strin
 		return nil
 	}
 
+	if withATMIV {
+		price, err := fetchUnderlyingPrice(ctx, client, opts.accountID, symbol)
+		if err != nil {
+			return err
+		}
+		if price <= 0 {
+			return fmt.Errorf("no last price for %s; cannot pick ATM strikes", strings.ToUpper(symbol))
+		}
+		rows := fetchExpirationATMIVs(ctx, client, opts.accountID, symbol, expResp.Expirations, price, time.Now())
+		return printExpirationATMIVs(cmd.OutOrStdout(), expResp.BaseSymbol, price, rows, opts.jsonMode)
+	}
+
 	// Format output
 	if opts.jsonMode {
 		enc := json.NewEncoder(cmd.OutOrStdout())
@@ -186,66 +352,206 @@ func runOptionsExpirations(cmd *cobra.Command, opts optionsOptions, symbol strin
 	return nil
 }
 
+// expirationATMIV is one expiration with the implied volatility of its
+// at-the-money call, as shown by expirations --with-atm-iv.
+type expirationATMIV struct {
+	Expiration string  `json:"expiration"`
+	DTE        int     `json:"dte"`
+	Strike     float64 `json:"strike,omitempty"`
+	Symbol     string  `json:"symbol,omitempty"`
+	IV         string  `json:"impliedVolatility,omitempty"`
+	Error      string  `json:"error,omitempty"`
+}
+
+// fetchExpirationATMIVs looks up the ATM call and its IV for every
+// expiration, at most maxConcurrentChains at a time. A failed expiration is
+// reported on its row rather than aborting the others.
+func fetchExpirationATMIVs(ctx context.Context, client *api.Client, accountID, symbol string, expirations []string, price float64, now time.Time) []expirationATMIV {
+	rows := make([]expirationATMIV, len(expirations))
+	sem := make(chan struct{}, maxConcurrentChains)
+	var wg sync.WaitGroup
+
+	for i, exp := range expirations {
+		rows[i] = expirationATMIV{Expiration: exp, DTE: marketdate.DaysUntil(exp, now)}
+		wg.Add(1)
+		go func(row *expirationATMIV) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			chainResp, err := client.GetOptionChain(ctx, accountID, symbol, row.Expiration)
+			if err != nil {
+				row.Error = err.Error()
+				return
+			}
+			atm, ok := atmOption(chainResp.Calls, price)
+			if !ok {
+				if atm, ok = atmOption(chainResp.Puts, price); !ok {
+					row.Error = "empty chain"
+					return
+				}
+			}
+			row.Symbol = atm.Instrument.Symbol
+			row.Strike = parseStrikeFloat(atm.Instrument.Symbol)
+
+			greeksResp, err := client.GetOptionGreeks(ctx, accountID, []string{row.Symbol})
+			if err != nil {
+				row.Error = err.Error()
+				return
+			}
+			for _, og := range greeksResp.Greeks {
+				if og.Symbol == row.Symbol {
+					row.IV = og.Greeks.ImpliedVolatility
+				}
+			}
+			if row.IV == "" {
+				row.Error = "no implied volatility reported"
+			}
+		}(&rows[i])
+	}
+	wg.Wait()
+
+	return rows
+}
+
+// printExpirationATMIVs prints the expirations with their ATM strike and IV.
+func printExpirationATMIVs(w io.Writer, symbol string, price float64, rows []expirationATMIV, jsonMode bool) error {
+	if jsonMode {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(map[string]any{
+			"baseSymbol":  symbol,
+			"last":        price,
+			"expirations": rows,
+		})
+	}
+
+	_, _ = fmt.Fprintf(w, "Option Expirations for %s (last %.2f)\n\n", symbol, price)
+	_, _ = fmt.Fprintf(w, "  %-12s  %5s  %10s  %8s\n", "Expiration", "DTE", "ATM Strike", "ATM IV")
+	failed := 0
+	for _, row := range rows {
+		strike, iv := "-", "-"
+		if row.Strike > 0 {
+			strike = fmt.Sprintf("%.2f", row.Strike)
+		}
+		if v, err := strconv.ParseFloat(row.IV, 64); err == nil {
+			iv = fmt.Sprintf("%.1f%%", v*100)
+		}
+		if row.Error != "" {
+			failed++
+		}
+		_, _ = fmt.Fprintf(w, "  %-12s  %5d  %10s  %8s\n", row.Expiration, row.DTE, strike, iv)
+	}
+	if failed > 0 {
+		_, _ = fmt.Fprintf(w, "\nIV unavailable for %d expiration(s); use --json for details.\n", failed)
+	}
+	return nil
+}
+
 // newOptionsChainCmd creates the options chain command with the given options.
 // Note: This function is unused; the actual chain command is created inline in init().
 func newOptionsChainCmd(opts optionsOptions) *cobra.Command {
-	var expiration string
+	var expiration, asOf string
+	var view chainView
 
 	cmd := &cobra.Command{
 		Use:   "chain SYMBOL",
 		Short: "Display option chain",
 		Long: `Display the option chain for an underlying symbol and expiration date.
 
+CSV export:
+  --csv                Write the chain as CSV, calls then puts, with the columns
+                       symbol, underlying, type, expiration, strike, bid, ask,
+                       last, bid_size, ask_size, volume, open_interest
+  --greeks             Append delta, gamma, theta, vega, rho, implied_volatility
+                       (empty cells for contracts without greeks)
+
+Historical chains:
+  --as-of DATE         Request the chain as of a past date. The API only serves
+                       the live chain, so a past date is an error rather than
+                       live data; today returns the live chain.
+
+Compact layout:
+  --compact            One table with calls on the left, puts on the right, and
+                       the strike between them (alias --combined). Falls back to
+                       separate tables when the terminal is too narrow.
+
 Examples:
   pub options chain AAPL --expiration 2025-01-17        # Show chain for date
-  pub options chain AAPL --expiration 2025-01-17 --json # Output in JSON format`,
+  pub options chain AAPL -e 2025-01-17 --compact        # Calls | strike | puts
+  pub options chain AAPL --expiration 2025-01-17 --json # Output in JSON format
+  pub options chain AAPL -e 2025-01-17 --csv --greeks > chain.csv  # Export with greeks`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if opts.accountID == "" {
 				return fmt.Errorf("account ID is required (use --account flag or configure default account)")
 			}
+			if err := checkChainAsOf(asOf, time.Now()); err != nil {
+				return err
+			}
 			if expiration == "" {
 				return fmt.Errorf("expiration date is required (use --expiration flag)")
 			}
-			return runOptionsChain(cmd, opts, args[0], expiration, chainFilter{})
+			exp, err := marketdate.Normalize(expiration, time.Now())
+			if err != nil {
+				return fmt.Errorf("invalid --expiration: %w", err)
+			}
+			sortBy, err := parseChainSort(view.sortBy)
+			if err != nil {
+				return err
+			}
+			view.sortBy = sortBy
+			if err := validateDeltaHint(view.deltaHint, view.contracts, cmd.Flags().Changed("contracts"), opts.jsonMode); err != nil {
+				return err
+			}
+			if err := validateSpreadWidth(view, cmd.Flags().Changed("spread-width"), false); err != nil {
+				return err
+			}
+			if err := validateChainCSV(view, opts.jsonMode); err != nil {
+				return err
+			}
+			if err := validateChainCompact(view, opts.jsonMode, false, false); err != nil {
+				return err
+			}
+			return runOptionsChain(cmd, opts, args[0], exp, chainFilter{}, view)
 		},
 	}
 
-	cmd.Flags().StringVarP(&expiration, "expiration", "e", "", "Expiration date (YYYY-MM-DD)")
+	cmd.Flags().StringVarP(&expiration, "expiration", "e", "", "Expiration date (YYYY-MM-DD, today, +Nd, or next-<weekday>)")
+	cmd.Flags().BoolVar(&view.showSize, "size", false, "Show bid/ask size columns")
+	cmd.Flags().BoolVar(&view.breakeven, "breakeven", false, "Show each option's breakeven at expiration when bought at the ask")
+	cmd.Flags().BoolVar(&view.deltaHint, "delta-neutral-hint", false, "Show the shares to trade to delta-hedge each option")
+	cmd.Flags().IntVar(&view.contracts, "contracts", 1, "Contracts to size --delta-neutral-hint for")
+	cmd.Flags().Float64Var(&view.spreadWidth, "spread-width", 0, "Show call verticals with this strike-price width in dollars instead of the chain")
+	cmd.Flags().StringVar(&view.sortBy, "sort", "strike", "Sort each side by strike, volume, oi, or spread")
+	cmd.Flags().BoolVar(&view.desc, "desc", false, "Sort in descending order")
+	cmd.Flags().BoolVar(&view.csv, "csv", false, "Write the chain as CSV with a fixed column order")
+	cmd.Flags().BoolVar(&view.greeks, "greeks", false, "Append greeks columns to --csv output")
+	cmd.Flags().StringVar(&asOf, "as-of", "", "Chain as of a past date (not supported by the API; errors instead of returning live data)")
+	addChainCompactFlags(cmd, &view.compact)
 	cmd.SilenceUsage = true
 
 	return cmd
 }
 
-func runOptionsChain(cmd *cobra.Command, opts optionsOptions, symbol, expiration string, filter chainFilter) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	client := api.NewClient(opts.baseURL, opts.authToken)
-	chainResp, err := client.GetOptionChain(ctx, opts.accountID, symbol, expiration)
+// fetchUnderlyingPrice returns the last price of the underlying, used for ATM filtering.
+func fetchUnderlyingPrice(ctx context.Context, client *api.Client, accountID, symbol string) (float64, error) {
+	instruments := []api.QuoteInstrument{{Symbol: strings.ToUpper(symbol), Type: "EQUITY"}}
+	quotes, err := client.GetQuotes(ctx, accountID, instruments)
 	if err != nil {
-		return err
+		return 0, fmt.Errorf("failed to get underlying price for ATM filtering: %w", err)
 	}
-
-	// Get underlying price if we need to filter by strikes around ATM
-	var underlyingPrice float64
-	if filter.strikes > 0 {
-		instruments := []api.QuoteInstrument{{Symbol: strings.ToUpper(symbol), Type: "EQUITY"}}
-		quotes, err := client.GetQuotes(ctx, opts.accountID, instruments)
-		if err != nil {
-			return fmt.Errorf("failed to get underlying price for ATM filtering: %w", err)
-		}
-		if len(quotes) > 0 {
-			underlyingPrice, _ = strconv.ParseFloat(quotes[0].Last, 64)
-		}
+	var price float64
+	if len(quotes) > 0 {
+		price, _ = strconv.ParseFloat(quotes[0].Last, 64)
 	}
+	return price, nil
+}
 
-	// Apply filters
-	calls := chainResp.Calls
-	puts := chainResp.Puts
-
-	// First apply strike/OI/volume filters
-	if filter.minStrike > 0 || filter.maxStrike > 0 || filter.minOI > 0 || filter.minVolume > 0 {
+// applyChainFilter applies strike/OI/volume, ATM and side filters to a chain.
+func applyChainFilter(calls, puts []api.OptionQuote, filter chainFilter, underlyingPrice float64) ([]api.OptionQuote, []api.OptionQuote) {
+	// First apply strike/OI/volume/bid filters
+	if filter.minStrike > 0 || filter.maxStrike > 0 || filter.minOI > 0 || filter.minVolume > 0 || filter.minBid > 0 {
 		if !filter.putsOnly {
 			calls = filterOptions(calls, filter)
 		}
@@ -254,8 +560,12 @@ func runOptionsChain(cmd *cobra.Command, opts optionsOptions, symbol, expiration
 		}
 	}
 
-	// Then apply ATM strikes filter (if specified)
-	if filter.strikes > 0 && underlyingPrice > 0 {
+	// Then apply ATM strikes filter (if specified), or --near around a chosen price
+	n, around := filter.strikes, underlyingPrice
+	if filter.near > 0 {
+		n, around = filter.near, filter.center
+	}
+	if n > 0 && around > 0 {
 		// Sort by strike to ensure proper ordering
 		sort.Slice(calls, func(i, j int) bool {
 			return parseStrikeFloat(calls[i].Instrument.Symbol) < parseStrikeFloat(calls[j].Instrument.Symbol)
@@ -265,10 +575,10 @@ func runOptionsChain(cmd *cobra.Command, opts optionsOptions, symbol, expiration
 		})
 
 		if !filter.putsOnly {
-			calls = filterStrikesAroundATM(calls, filter.strikes, underlyingPrice)
+			calls = filterStrikesAroundATM(calls, n, around)
 		}
 		if !filter.callsOnly {
-			puts = filterStrikesAroundATM(puts, filter.strikes, underlyingPrice)
+			puts = filterStrikesAroundATM(puts, n, around)
 		}
 	}
 
@@ -280,11 +590,48 @@ func runOptionsChain(cmd *cobra.Command, opts optionsOptions, symbol, expiration
 		calls = nil
 	}
 
+	return calls, puts
+}
+
+func runOptionsChain(cmd *cobra.Command, opts optionsOptions, symbol, expiration string, filter chainFilter, view chainView) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	client := api.NewClient(opts.baseURL, opts.authToken)
+	chainResp, err := client.GetOptionChain(ctx, opts.accountID, symbol, expiration)
+	if err != nil {
+		return err
+	}
+
+	// Get underlying price if we need to filter by strikes around ATM
+	underlyingPrice := filter.underlyingPrice
+	if filter.strikes > 0 && underlyingPrice == 0 {
+		underlyingPrice, err = fetchUnderlyingPrice(ctx, client, opts.accountID, symbol)
+		if err != nil {
+			return err
+		}
+	}
+
+	calls, puts := applyChainFilter(chainResp.Calls, chainResp.Puts, filter, underlyingPrice)
+	sortChainOptions(calls, view.sortBy, view.desc)
+	sortChainOptions(puts, view.sortBy, view.desc)
+
+	// CSV keeps its header even when nothing matches, so parsers see the schema
+	if view.csv {
+		return printChainCSV(ctx, cmd.OutOrStdout(), cmd.ErrOrStderr(), client, opts.accountID, calls, puts, view.greeks)
+	}
+
 	if len(calls) == 0 && len(puts) == 0 {
 		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "No options available for %s expiring %s (after filtering)\n", chainResp.BaseSymbol, expiration)
 		return nil
 	}
 
+	if view.spreadWidth > 0 {
+		// Upper legs may fall outside the filtered strikes, so look them up in the full chain
+		rows := buildSpreadGrid(calls, chainResp.Calls, view.spreadWidth)
+		return printSpreadGrid(cmd.OutOrStdout(), opts.jsonMode, chainResp.BaseSymbol, expiration, view.spreadWidth, rows)
+	}
+
 	// Format output
 	if opts.jsonMode {
 		// Return filtered results in JSON
@@ -298,160 +645,1527 @@ func runOptionsChain(cmd *cobra.Command, opts optionsOptions, symbol, expiration
 		return enc.Encode(filteredResp)
 	}
 
+	if view.deltaHint {
+		view.deltas, err = fetchChainDeltas(ctx, client, opts.accountID, append(append([]api.OptionQuote{}, calls...), puts...))
+		if err != nil {
+			return err
+		}
+	}
+
 	// Table output
 	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Option Chain for %s - Expiration: %s\n\n", chainResp.BaseSymbol, expiration)
 
-	if len(calls) > 0 {
-		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "CALLS\n")
-		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%-8s  %8s  %8s  %10s  %10s\n", "Strike", "Bid", "Ask", "Volume", "OI")
-		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%-8s  %8s  %8s  %10s  %10s\n", "------", "------", "------", "------", "------")
-		for _, call := range calls {
-			strike := parseStrikeFromSymbol(call.Instrument.Symbol)
-			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%-8s  %8s  %8s  %10d  %10d\n",
-				strike, call.Bid, call.Ask, call.Volume, call.OpenInterest)
+	if view.compact {
+		width := view.termWidth
+		if width == 0 {
+			width = writerWidth(cmd.OutOrStdout())
+		}
+		if width == 0 || width >= chainCompactWidth {
+			printChainCompact(cmd.OutOrStdout(), pairChainByStrike(calls, puts, view.desc))
+			return nil
 		}
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Terminal is %d columns wide; --compact needs %d, showing calls and puts separately\n", width, chainCompactWidth)
+	}
+
+	if len(calls) > 0 {
+		printChainSide(cmd.OutOrStdout(), "CALLS", calls, view)
 		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "\n")
 	}
 
 	if len(puts) > 0 {
-		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "PUTS\n")
-		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%-8s  %8s  %8s  %10s  %10s\n", "Strike", "Bid", "Ask", "Volume", "OI")
-		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%-8s  %8s  %8s  %10s  %10s\n", "------", "------", "------", "------", "------")
-		for _, put := range puts {
-			strike := parseStrikeFromSymbol(put.Instrument.Symbol)
-			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%-8s  %8s  %8s  %10d  %10d\n",
-				strike, put.Bid, put.Ask, put.Volume, put.OpenInterest)
-		}
+		printChainSide(cmd.OutOrStdout(), "PUTS", puts, view)
+	}
+
+	if view.deltaHint {
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "\nHedge Shares: shares to trade to delta-neutralize %d long contract(s) (- sell, + buy); reverse the sign when short.\n", view.contracts)
 	}
 
 	return nil
 }
 
-// parseStrikeFromSymbol extracts the strike price from an OSI option symbol.
-// Example: AAPL250117C00175000 -> 175.00
-func parseStrikeFromSymbol(symbol string) string {
-	if len(symbol) < 8 {
-		return symbol
+// printChainSide prints one side (calls or puts) of the option chain.
+func printChainSide(w io.Writer, title string, options []api.OptionQuote, view chainView) {
+	_, _ = fmt.Fprintf(w, "%s\n", title)
+	if view.showSize {
+		_, _ = fmt.Fprintf(w, "%-8s  %8s  %8s  %8s  %8s  %10s  %10s", "Strike", "Bid", "Bid Size", "Ask", "Ask Size", "Volume", "OI")
+		printHedgeCell(w, view, "Hedge Shares")
+		printBreakevenCell(w, view, "Breakeven")
+		_, _ = fmt.Fprintf(w, "%-8s  %8s  %8s  %8s  %8s  %10s  %10s", "------", "------", "------", "------", "------", "------", "------")
+		printHedgeCell(w, view, "------------")
+		printBreakevenCell(w, view, "---------")
+	} else {
+		_, _ = fmt.Fprintf(w, "%-8s  %8s  %8s  %10s  %10s", "Strike", "Bid", "Ask", "Volume", "OI")
+		printHedgeCell(w, view, "Hedge Shares")
+		printBreakevenCell(w, view, "Breakeven")
+		_, _ = fmt.Fprintf(w, "%-8s  %8s  %8s  %10s  %10s", "------", "------", "------", "------", "------")
+		printHedgeCell(w, view, "------------")
+		printBreakevenCell(w, view, "---------")
 	}
-	// Last 8 characters are the strike price in format: SSSSSSSS (8 digits, price * 1000)
-	strikeStr := symbol[len(symbol)-8:]
-	// Parse as integer and convert to decimal
-	var strike int64
-	if _, err := fmt.Sscanf(strikeStr, "%d", &strike); err != nil {
-		return symbol
-	}
-	dollars := strike / 1000
-	cents := (strike % 1000) / 10
-	if cents == 0 {
-		return fmt.Sprintf("%d", dollars)
+	for _, opt := range options {
+		strike := parseStrikeFromSymbol(opt.Instrument.Symbol)
+		if view.showSize {
+			_, _ = fmt.Fprintf(w, "%-8s  %8s  %8s  %8s  %8s  %10d  %10d",
+				strike, opt.Bid, publicapi.FormatVolume(int64(opt.BidSize)),
+				opt.Ask, publicapi.FormatVolume(int64(opt.AskSize)),
+				opt.Volume, opt.OpenInterest)
+		} else {
+			_, _ = fmt.Fprintf(w, "%-8s  %8s  %8s  %10d  %10d",
+				strike, opt.Bid, opt.Ask, opt.Volume, opt.OpenInterest)
+		}
+		printHedgeCell(w, view, optionHedge(opt, view))
+		printBreakevenCell(w, view, optionBreakeven(opt))
 	}
-	return fmt.Sprintf("%d.%02d", dollars, cents)
 }
 
-func runOptionsGreeks(cmd *cobra.Command, opts optionsOptions, symbols []string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
+// validateDeltaHint checks the --delta-neutral-hint and --contracts flags.
+func validateDeltaHint(deltaHint bool, contracts int, contractsChanged, jsonMode bool) error {
+	if contractsChanged && !deltaHint {
+		return fmt.Errorf("--contracts requires --delta-neutral-hint")
+	}
+	if !deltaHint {
+		return nil
+	}
+	if contracts < 1 {
+		return fmt.Errorf("invalid --contracts: must be at least 1")
+	}
+	if jsonMode {
+		return fmt.Errorf("--delta-neutral-hint is not supported with --json (use 'pub options greeks' for deltas)")
+	}
+	return nil
+}
 
-	client := api.NewClient(opts.baseURL, opts.authToken)
-	greeksResp, err := client.GetOptionGreeks(ctx, opts.accountID, symbols)
+// checkChainAsOf checks --as-of. The API only serves the live chain, so any
+// date before today is an error rather than live data passed off as a
+// snapshot; today is the live chain itself.
+func checkChainAsOf(asOf string, now time.Time) error {
+	if asOf == "" {
+		return nil
+	}
+	date, err := marketdate.Parse(asOf, now)
 	if err != nil {
-		return err
+		return fmt.Errorf("invalid --as-of: %w", err)
 	}
+	today := marketdate.Today(now)
+	if date.After(today) {
+		return fmt.Errorf("invalid --as-of: %s is in the future", date.Format(marketdate.Layout))
+	}
+	if date.Before(today) {
+		return fmt.Errorf("historical option chains are not supported: the API only returns the live chain (--as-of %s)", date.Format(marketdate.Layout))
+	}
+	return nil
+}
 
-	if len(greeksResp.Greeks) == 0 {
-		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No greeks data available")
+// validateSpreadWidth checks --spread-width against the other display flags.
+func validateSpreadWidth(view chainView, changed, putsOnly bool) error {
+	if !changed {
 		return nil
 	}
-
-	// Format output
-	if opts.jsonMode {
-		enc := json.NewEncoder(cmd.OutOrStdout())
-		enc.SetIndent("", "  ")
-		return enc.Encode(greeksResp)
+	if view.spreadWidth <= 0 {
+		return fmt.Errorf("invalid --spread-width: must be positive")
 	}
+	if putsOnly {
+		return fmt.Errorf("--spread-width pairs call strikes and cannot be used with --puts-only")
+	}
+	if view.breakeven || view.deltaHint {
+		return fmt.Errorf("--spread-width cannot be combined with --breakeven or --delta-neutral-hint")
+	}
+	return nil
+}
 
-	// Table output
-	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "\n%-22s  %8s  %8s  %8s  %8s  %8s  %8s\n",
-		"SYMBOL", "DELTA", "GAMMA", "THETA", "VEGA", "RHO", "IV")
-	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s\n", strings.Repeat("-", 85))
+// chainOnlyFlags are the chain filter, display, and strategy flags that --scan
+// does not read. The scan always covers both sides of the full chain.
+var chainOnlyFlags = []string{
+	"strikes", "near", "center", "min-strike", "max-strike", "min-oi", "min-volume", "min-bid",
+	"calls-only", "puts-only", "underlying-price",
+	"size", "breakeven", "delta-neutral-hint", "contracts", "spread-width", "sort", "desc",
+	"csv", "greeks", "compact", "combined",
+	"export-strategy", "buy", "sell", "limit", "quantity", "execute", "yes", "reason",
+}
 
-	for _, og := range greeksResp.Greeks {
-		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%-22s  %8s  %8s  %8s  %8s  %8s  %8s\n",
-			og.Symbol,
-			og.Greeks.Delta,
-			og.Greeks.Gamma,
-			og.Greeks.Theta,
-			og.Greeks.Vega,
-			og.Greeks.Rho,
-			og.Greeks.ImpliedVolatility)
+// validateScanFlags rejects chain flags that --scan would otherwise ignore.
+func validateScanFlags(cmd *cobra.Command) error {
+	for _, name := range chainOnlyFlags {
+		if cmd.Flags().Changed(name) {
+			return fmt.Errorf("--%s is not supported with --scan", name)
+		}
 	}
-
 	return nil
 }
 
-// parseLeg parses a leg string in format "SIDE SYMBOL OPEN|CLOSE [RATIO]"
-// Example: "BUY AAPL250117C00175000 OPEN" or "SELL AAPL250117C00180000 OPEN 2"
-func parseLeg(legStr string) (api.MultilegLeg, error) {
-	parts := strings.Fields(legStr)
-	if len(parts) < 3 {
-		return api.MultilegLeg{}, fmt.Errorf("invalid leg format: expected 'SIDE SYMBOL OPEN|CLOSE [RATIO]', got %q", legStr)
+// fetchChainDeltas returns the delta of each option by OSI symbol. Options
+// without a parsable delta are left out.
+func fetchChainDeltas(ctx context.Context, client *api.Client, accountID string, options []api.OptionQuote) (map[string]float64, error) {
+	symbols := make([]string, 0, len(options))
+	for _, opt := range options {
+		symbols = append(symbols, opt.Instrument.Symbol)
 	}
-
-	side := strings.ToUpper(parts[0])
-	if side != "BUY" && side != "SELL" {
-		return api.MultilegLeg{}, fmt.Errorf("invalid side %q: must be BUY or SELL", parts[0])
+	greeksResp, err := client.GetOptionGreeks(ctx, accountID, symbols)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch greeks for --delta-neutral-hint: %w", err)
 	}
+	deltas := make(map[string]float64, len(greeksResp.Greeks))
+	for _, og := range greeksResp.Greeks {
+		if delta, err := strconv.ParseFloat(og.Greeks.Delta, 64); err == nil {
+			deltas[og.Symbol] = delta
+		}
+	}
+	return deltas, nil
+}
 
-	symbol := strings.ToUpper(parts[1])
-
-	openClose := strings.ToUpper(parts[2])
-	if openClose != "OPEN" && openClose != "CLOSE" {
-		return api.MultilegLeg{}, fmt.Errorf("invalid open/close %q: must be OPEN or CLOSE", parts[2])
+// optionHedge returns the signed share count that delta-hedges view.contracts
+// long contracts of opt, or "-" when its delta is unknown.
+func optionHedge(opt api.OptionQuote, view chainView) string {
+	delta, ok := view.deltas[opt.Instrument.Symbol]
+	if !ok {
+		return "-"
 	}
+	return fmt.Sprintf("%+d", analytics.HedgeShares(delta, float64(view.contracts)))
+}
 
-	ratio := 1
-	if len(parts) >= 4 {
-		if _, err := fmt.Sscanf(parts[3], "%d", &ratio); err != nil {
-			return api.MultilegLeg{}, fmt.Errorf("invalid ratio %q: must be an integer", parts[3])
-		}
+// printHedgeCell appends value as the Hedge Shares column when
+// --delta-neutral-hint is set.
+func printHedgeCell(w io.Writer, view chainView, value string) {
+	if view.deltaHint {
+		_, _ = fmt.Fprintf(w, "  %12s", value)
 	}
+}
 
-	// Determine instrument type from symbol (options have 21+ chars in OSI format)
-	instType := "OPTION"
-	if len(symbol) <= 5 {
-		instType = "EQUITY"
+// printBreakevenCell ends a chain table line, appending value as the
+// Breakeven column when --breakeven is set.
+func printBreakevenCell(w io.Writer, view chainView, value string) {
+	if view.breakeven {
+		_, _ = fmt.Fprintf(w, "  %10s", value)
 	}
+	_, _ = fmt.Fprintln(w)
+}
 
-	return api.MultilegLeg{
-		Instrument: api.MultilegInstrument{
-			Symbol: symbol,
-			Type:   instType,
-		},
-		Side:               side,
-		OpenCloseIndicator: openClose,
-		RatioQuantity:      ratio,
-	}, nil
+// strategySpec holds the --export-strategy leg selections and order settings.
+type strategySpec struct {
+	buys           []string // strike specs such as 175c or 172.5p
+	sells          []string
+	limit          string // empty uses the net mid from the chain
+	quantity       string
+	execute        bool
+	skipConfirm    bool
+	tradingEnabled bool
 }
 
-func runMultilegPreflight(cmd *cobra.Command, opts optionsOptions, legs []string, limitPrice, quantity, expiration string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
+// strategyLeg is one leg of a strategy exported from the chain.
+type strategyLeg struct {
+	Side   string  `json:"side"`
+	Symbol string  `json:"symbol"`
+	Type   string  `json:"type"`
+	Strike float64 `json:"strike"`
+	Bid    string  `json:"bid,omitempty"`
+	Ask    string  `json:"ask,omitempty"`
+}
 
-	// Parse legs
-	var parsedLegs []api.MultilegLeg
-	for _, legStr := range legs {
-		leg, err := parseLeg(legStr)
-		if err != nil {
-			return err
-		}
-		parsedLegs = append(parsedLegs, leg)
+// exportedStrategy is the --json output of --export-strategy.
+type exportedStrategy struct {
+	Underlying string        `json:"underlying"`
+	Expiration string        `json:"expiration"`
+	Quantity   string        `json:"quantity"`
+	LimitPrice string        `json:"limitPrice"`
+	Legs       []strategyLeg `json:"legs"`
+	Command    string        `json:"command"`
+}
+
+// parseStrikeSpec parses a strike with a call/put suffix, e.g. 175c or 172.5P.
+func parseStrikeSpec(spec string) (float64, string, error) {
+	s := strings.ToUpper(strings.TrimSpace(spec))
+	if len(s) < 2 {
+		return 0, "", fmt.Errorf("invalid strike %q: expected STRIKE followed by c or p (e.g. 175c)", spec)
 	}
 
-	if len(parsedLegs) < 2 {
-		return fmt.Errorf("multi-leg orders require at least 2 legs")
+	var optType string
+	switch s[len(s)-1] {
+	case 'C':
+		optType = analytics.Call
+	case 'P':
+		optType = analytics.Put
+	default:
+		return 0, "", fmt.Errorf("invalid strike %q: expected STRIKE followed by c or p (e.g. 175c)", spec)
 	}
-	if len(parsedLegs) > 6 {
-		return fmt.Errorf("multi-leg orders support at most 6 legs")
+
+	strike, err := strconv.ParseFloat(s[:len(s)-1], 64)
+	if err != nil || strike <= 0 {
+		return 0, "", fmt.Errorf("invalid strike %q: expected STRIKE followed by c or p (e.g. 175c)", spec)
+	}
+	return strike, optType, nil
+}
+
+// buildStrategyLegs turns --buy/--sell strike specs into legs with OSI symbols
+// and checks that each contract is listed in the chain.
+func buildStrategyLegs(underlying, expiration string, spec strategySpec, chain *api.OptionChainResponse) ([]strategyLeg, error) {
+	exp, err := time.Parse("2006-01-02", expiration)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expiration %q: expected YYYY-MM-DD", expiration)
+	}
+
+	quotes := make(map[string]api.OptionQuote)
+	for _, opt := range append(append([]api.OptionQuote{}, chain.Calls...), chain.Puts...) {
+		quotes[strings.ToUpper(opt.Instrument.Symbol)] = opt
+	}
+
+	var legs []strategyLeg
+	add := func(side string, specs []string) error {
+		for _, s := range specs {
+			strike, optType, err := parseStrikeSpec(s)
+			if err != nil {
+				return err
+			}
+			symbol := analytics.BuildOSI(underlying, exp, optType, strike)
+			quote, ok := quotes[symbol]
+			if !ok {
+				return fmt.Errorf("%s is not in the %s chain for %s", symbol, strings.ToUpper(underlying), expiration)
+			}
+			legs = append(legs, strategyLeg{
+				Side:   side,
+				Symbol: symbol,
+				Type:   optType,
+				Strike: strike,
+				Bid:    quote.Bid,
+				Ask:    quote.Ask,
+			})
+		}
+		return nil
+	}
+	if err := add("BUY", spec.buys); err != nil {
+		return nil, err
+	}
+	if err := add("SELL", spec.sells); err != nil {
+		return nil, err
+	}
+
+	if len(legs) < 2 {
+		return nil, fmt.Errorf("--export-strategy needs at least 2 legs (use --buy and --sell)")
+	}
+	if len(legs) > 6 {
+		return nil, fmt.Errorf("multi-leg orders support at most 6 legs")
+	}
+	return legs, nil
+}
+
+// strategyNetMid returns the absolute net mid price of the legs (buys minus
+// sells), which is the limit a multileg order expects for a debit or credit.
+func strategyNetMid(legs []strategyLeg) (string, error) {
+	var net float64
+	for _, leg := range legs {
+		bid, err1 := strconv.ParseFloat(leg.Bid, 64)
+		ask, err2 := strconv.ParseFloat(leg.Ask, 64)
+		if err1 != nil || err2 != nil {
+			return "", fmt.Errorf("no bid/ask for %s to price the strategy (use --limit)", leg.Symbol)
+		}
+		mid := (bid + ask) / 2
+		if leg.Side == "SELL" {
+			mid = -mid
+		}
+		net += mid
+	}
+	return fmt.Sprintf("%.2f", math.Abs(net)), nil
+}
+
+// strategyLegArgs formats legs as --leg values for the multileg order command.
+func strategyLegArgs(legs []strategyLeg) []string {
+	args := make([]string, len(legs))
+	for i, leg := range legs {
+		args[i] = fmt.Sprintf("%s %s OPEN", leg.Side, leg.Symbol)
+	}
+	return args
+}
+
+// multilegOrderCommand renders a copy-paste ready multileg order command.
+func multilegOrderCommand(legs []string, limit, quantity string) string {
+	var b strings.Builder
+	b.WriteString("pub options multileg order")
+	for _, leg := range legs {
+		b.WriteString(fmt.Sprintf(" \\\n  --leg %q", leg))
+	}
+	b.WriteString(fmt.Sprintf(" \\\n  --limit %s --quantity %s", limit, quantity))
+	return b.String()
+}
+
+// runOptionsExportStrategy builds a multileg order from strikes picked off the
+// chain and prints the matching command, or places it with --execute.
+func runOptionsExportStrategy(cmd *cobra.Command, opts optionsOptions, symbol, expiration string, spec strategySpec) error {
+	if spec.execute && !spec.tradingEnabled {
+		return config.ErrTradingDisabled
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	client := api.NewClient(opts.baseURL, opts.authToken)
+	chainResp, err := client.GetOptionChain(ctx, opts.accountID, symbol, expiration)
+	if err != nil {
+		return err
+	}
+
+	legs, err := buildStrategyLegs(symbol, expiration, spec, chainResp)
+	if err != nil {
+		return err
+	}
+
+	limit := spec.limit
+	if limit == "" {
+		limit, err = strategyNetMid(legs)
+		if err != nil {
+			return err
+		}
+	}
+	quantity := spec.quantity
+	if quantity == "" {
+		quantity = "1"
+	}
+
+	if spec.execute {
+		return runMultilegOrder(cmd, opts, strategyLegArgs(legs), limit, "", quantity, "DAY", spec.skipConfirm, riskGuard{})
+	}
+
+	command := multilegOrderCommand(strategyLegArgs(legs), limit, quantity)
+	if opts.jsonMode {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(exportedStrategy{
+			Underlying: strings.ToUpper(symbol),
+			Expiration: expiration,
+			Quantity:   quantity,
+			LimitPrice: limit,
+			Legs:       legs,
+			Command:    command,
+		})
+	}
+
+	out := cmd.OutOrStdout()
+	_, _ = fmt.Fprintf(out, "Strategy for %s - Expiration: %s\n\n", strings.ToUpper(symbol), expiration)
+	for _, leg := range legs {
+		_, _ = fmt.Fprintf(out, "  %-4s  %-22s  bid %-8s ask %s\n", leg.Side, leg.Symbol, leg.Bid, leg.Ask)
+	}
+	if spec.limit == "" {
+		_, _ = fmt.Fprintf(out, "\nNet mid: $%s\n", limit)
+	}
+	_, _ = fmt.Fprintf(out, "\n%s\n", command)
+	_, _ = fmt.Fprintln(out, "\nAdd --yes to place the order, or rerun with --execute --yes.")
+
+	return nil
+}
+
+// maxConcurrentChains bounds the number of chains fetched at once for
+// --expiration-range and expirations --with-atm-iv.
+const maxConcurrentChains = 4
+
+// expirationChain is one expiration's filtered chain in a multi-expiration view.
+type expirationChain struct {
+	Expiration string            `json:"expiration"`
+	DTE        int               `json:"dte"`
+	Calls      []api.OptionQuote `json:"calls"`
+	Puts       []api.OptionQuote `json:"puts"`
+}
+
+// parseExpirationRange parses a START:END range of dates, each either
+// YYYY-MM-DD or a relative form such as today or +30d resolved against now.
+// Either side may be empty to leave that end open.
+func parseExpirationRange(value string, now time.Time) (string, string, error) {
+	start, end, ok := strings.Cut(value, ":")
+	if !ok {
+		return "", "", fmt.Errorf("invalid --expiration-range %q: expected START:END (YYYY-MM-DD)", value)
+	}
+	for _, d := range []*string{&start, &end} {
+		if *d == "" {
+			continue
+		}
+		date, err := marketdate.Normalize(*d, now)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid --expiration-range: %w", err)
+		}
+		*d = date
+	}
+	if start != "" && end != "" && start > end {
+		return "", "", fmt.Errorf("invalid --expiration-range: start %s is after end %s", start, end)
+	}
+	return start, end, nil
+}
+
+// expirationsInRange returns the expirations within [start, end], sorted by date.
+func expirationsInRange(expirations []string, start, end string) []string {
+	var result []string
+	for _, exp := range expirations {
+		if start != "" && exp < start {
+			continue
+		}
+		if end != "" && exp > end {
+			continue
+		}
+		result = append(result, exp)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// runOptionsChainRange fetches the chains for every expiration in a range
+// concurrently and prints them stacked, grouped by strike.
+func runOptionsChainRange(cmd *cobra.Command, opts optionsOptions, symbol, start, end string, filter chainFilter, view chainView) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	client := api.NewClient(opts.baseURL, opts.authToken)
+	expResp, err := client.GetOptionExpirations(ctx, opts.accountID, symbol)
+	if err != nil {
+		return err
+	}
+
+	expirations := expirationsInRange(expResp.Expirations, start, end)
+	if len(expirations) == 0 {
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "No expirations for %s between %s and %s\n", strings.ToUpper(symbol), start, end)
+		return nil
+	}
+
+	underlyingPrice := filter.underlyingPrice
+	if filter.strikes > 0 && underlyingPrice == 0 {
+		underlyingPrice, err = fetchUnderlyingPrice(ctx, client, opts.accountID, symbol)
+		if err != nil {
+			return err
+		}
+	}
+
+	chains := make([]expirationChain, len(expirations))
+	errs := make([]error, len(expirations))
+	sem := make(chan struct{}, maxConcurrentChains)
+	var wg sync.WaitGroup
+	now := time.Now()
+
+	for i, exp := range expirations {
+		wg.Add(1)
+		go func(i int, exp string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			chainResp, err := client.GetOptionChain(ctx, opts.accountID, symbol, exp)
+			if err != nil {
+				errs[i] = fmt.Errorf("%s: %w", exp, err)
+				return
+			}
+			calls, puts := applyChainFilter(chainResp.Calls, chainResp.Puts, filter, underlyingPrice)
+			chains[i] = expirationChain{
+				Expiration: exp,
+				DTE:        marketdate.DaysUntil(exp, now),
+				Calls:      calls,
+				Puts:       puts,
+			}
+		}(i, exp)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	if opts.jsonMode {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(chains)
+	}
+
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Option Chains for %s - Expirations: %s to %s\n\n",
+		strings.ToUpper(symbol), expirations[0], expirations[len(expirations)-1])
+
+	printed := printChainRangeSide(cmd.OutOrStdout(), "CALLS", chains, func(c expirationChain) []api.OptionQuote { return c.Calls }, view)
+	if printed {
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "\n")
+	}
+	if !printChainRangeSide(cmd.OutOrStdout(), "PUTS", chains, func(c expirationChain) []api.OptionQuote { return c.Puts }, view) && !printed {
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No options available (after filtering)")
+	}
+
+	return nil
+}
+
+// printChainRangeSide prints one side of several chains, ordered by strike and
+// then expiration so the same strike lines up across expirations. It reports
+// whether anything was printed.
+func printChainRangeSide(w io.Writer, title string, chains []expirationChain, side func(expirationChain) []api.OptionQuote, view chainView) bool {
+	type row struct {
+		strike float64
+		chain  expirationChain
+		opt    api.OptionQuote
+	}
+	var rows []row
+	for _, c := range chains {
+		for _, opt := range side(c) {
+			rows = append(rows, row{strike: parseStrikeFloat(opt.Instrument.Symbol), chain: c, opt: opt})
+		}
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].strike != rows[j].strike {
+			return rows[i].strike < rows[j].strike
+		}
+		return rows[i].chain.Expiration < rows[j].chain.Expiration
+	})
+	if len(rows) == 0 {
+		return false
+	}
+
+	_, _ = fmt.Fprintf(w, "%s\n", title)
+	if view.showSize {
+		_, _ = fmt.Fprintf(w, "%-8s  %-10s  %4s  %8s  %8s  %8s  %8s  %10s  %10s", "Strike", "Expiration", "DTE", "Bid", "Bid Size", "Ask", "Ask Size", "Volume", "OI")
+	} else {
+		_, _ = fmt.Fprintf(w, "%-8s  %-10s  %4s  %8s  %8s  %10s  %10s", "Strike", "Expiration", "DTE", "Bid", "Ask", "Volume", "OI")
+	}
+	printBreakevenCell(w, view, "Breakeven")
+	for _, r := range rows {
+		strike := parseStrikeFromSymbol(r.opt.Instrument.Symbol)
+		if view.showSize {
+			_, _ = fmt.Fprintf(w, "%-8s  %-10s  %4d  %8s  %8s  %8s  %8s  %10d  %10d",
+				strike, r.chain.Expiration, r.chain.DTE,
+				r.opt.Bid, publicapi.FormatVolume(int64(r.opt.BidSize)),
+				r.opt.Ask, publicapi.FormatVolume(int64(r.opt.AskSize)),
+				r.opt.Volume, r.opt.OpenInterest)
+		} else {
+			_, _ = fmt.Fprintf(w, "%-8s  %-10s  %4d  %8s  %8s  %10d  %10d",
+				strike, r.chain.Expiration, r.chain.DTE, r.opt.Bid, r.opt.Ask, r.opt.Volume, r.opt.OpenInterest)
+		}
+		printBreakevenCell(w, view, optionBreakeven(r.opt))
+	}
+	return true
+}
+
+// verticalSpread is a candidate vertical credit spread found by the chain scanner.
+type verticalSpread struct {
+	Type        string  `json:"type"`
+	ShortSymbol string  `json:"shortSymbol"`
+	LongSymbol  string  `json:"longSymbol"`
+	ShortStrike float64 `json:"shortStrike"`
+	LongStrike  float64 `json:"longStrike"`
+	Net         float64 `json:"net"`
+	MaxRisk     float64 `json:"maxRisk"`
+	Ratio       float64 `json:"creditToWidth"`
+}
+
+// findVerticalSpreads enumerates vertical credit spreads of the given strike width.
+// Net is computed conservatively as short bid minus long ask; negative values are debits.
+// Spreads with a net below minCredit are dropped and the rest ranked by credit-to-width ratio.
+func findVerticalSpreads(chain *api.OptionChainResponse, width, minCredit float64) []verticalSpread {
+	var spreads []verticalSpread
+
+	scan := func(options []api.OptionQuote, optType string) {
+		byStrike := make(map[int64]api.OptionQuote, len(options))
+		for _, opt := range options {
+			byStrike[int64(math.Round(parseStrikeFloat(opt.Instrument.Symbol)*1000))] = opt
+		}
+
+		for _, short := range options {
+			shortStrike := parseStrikeFloat(short.Instrument.Symbol)
+			// Calls: sell lower strike, buy higher. Puts: sell higher strike, buy lower.
+			longStrike := shortStrike + width
+			if optType == "PUT" {
+				longStrike = shortStrike - width
+			}
+			long, ok := byStrike[int64(math.Round(longStrike*1000))]
+			if !ok || longStrike <= 0 {
+				continue
+			}
+
+			net, risk, ok := verticalCredit(short, long, width)
+			if !ok || net < minCredit {
+				continue
+			}
+
+			spreads = append(spreads, verticalSpread{
+				Type:        optType,
+				ShortSymbol: short.Instrument.Symbol,
+				LongSymbol:  long.Instrument.Symbol,
+				ShortStrike: shortStrike,
+				LongStrike:  longStrike,
+				Net:         net,
+				MaxRisk:     risk,
+				Ratio:       net / width,
+			})
+		}
+	}
+
+	scan(chain.Calls, "CALL")
+	scan(chain.Puts, "PUT")
+
+	sort.SliceStable(spreads, func(i, j int) bool {
+		return spreads[i].Ratio > spreads[j].Ratio
+	})
+
+	return spreads
+}
+
+// verticalCredit prices selling short and buying long, width apart, at the
+// conservative side of each quote: short bid minus long ask. Max risk is
+// (width - credit) x 100. ok is false when either quote is missing.
+func verticalCredit(short, long api.OptionQuote, width float64) (credit, maxRisk float64, ok bool) {
+	bid, err := strconv.ParseFloat(short.Bid, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	ask, err := strconv.ParseFloat(long.Ask, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	credit = bid - ask
+	return credit, (width - credit) * 100, true
+}
+
+// verticalDebit prices buying long and selling short: long ask minus short
+// bid. Max risk is the debit x 100.
+func verticalDebit(long, short api.OptionQuote) (debit, maxRisk float64, ok bool) {
+	ask, err := strconv.ParseFloat(long.Ask, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	bid, err := strconv.ParseFloat(short.Bid, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	debit = ask - bid
+	return debit, debit * 100, true
+}
+
+// spreadGridRow is the call vertical from one strike to strike + width, priced
+// both ways: selling the lower strike for a credit and buying it for a debit.
+// Nets are nil when a needed bid or ask is missing.
+type spreadGridRow struct {
+	LowerSymbol   string   `json:"lowerSymbol"`
+	UpperSymbol   string   `json:"upperSymbol"`
+	LowerStrike   float64  `json:"lowerStrike"`
+	UpperStrike   float64  `json:"upperStrike"`
+	Credit        *float64 `json:"credit"`        // lower bid - upper ask
+	CreditMaxRisk *float64 `json:"creditMaxRisk"` // (width - credit) x 100
+	Debit         *float64 `json:"debit"`         // lower ask - upper bid
+	DebitMaxRisk  *float64 `json:"debitMaxRisk"`  // debit x 100
+}
+
+// buildSpreadGrid pairs each call in rows with the call width above it in
+// chain. Net prices come from verticalCredit, shared with
+// findVerticalSpreads, and verticalDebit. Strikes without a partner are skipped.
+func buildSpreadGrid(rows, chain []api.OptionQuote, width float64) []spreadGridRow {
+	byStrike := make(map[int64]api.OptionQuote, len(chain))
+	for _, opt := range chain {
+		byStrike[int64(math.Round(parseStrikeFloat(opt.Instrument.Symbol)*1000))] = opt
+	}
+
+	grid := make([]spreadGridRow, 0, len(rows))
+	for _, lower := range rows {
+		lowerStrike := parseStrikeFloat(lower.Instrument.Symbol)
+		upper, ok := byStrike[int64(math.Round((lowerStrike+width)*1000))]
+		if !ok {
+			continue
+		}
+
+		row := spreadGridRow{
+			LowerSymbol: lower.Instrument.Symbol,
+			UpperSymbol: upper.Instrument.Symbol,
+			LowerStrike: lowerStrike,
+			UpperStrike: lowerStrike + width,
+		}
+		if credit, risk, ok := verticalCredit(lower, upper, width); ok {
+			row.Credit, row.CreditMaxRisk = &credit, &risk
+		}
+		if debit, risk, ok := verticalDebit(lower, upper); ok {
+			row.Debit, row.DebitMaxRisk = &debit, &risk
+		}
+		grid = append(grid, row)
+	}
+	return grid
+}
+
+// printSpreadGrid prints the --spread-width grid as a table or JSON.
+func printSpreadGrid(w io.Writer, jsonMode bool, symbol, expiration string, width float64, rows []spreadGridRow) error {
+	if jsonMode {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(rows)
+	}
+
+	widthStr := strconv.FormatFloat(width, 'f', -1, 64)
+	if len(rows) == 0 {
+		_, _ = fmt.Fprintf(w, "No call strikes %s apart for %s expiring %s\n", widthStr, symbol, expiration)
+		return nil
+	}
+
+	_, _ = fmt.Fprintf(w, "Call Verticals for %s - Expiration: %s - Width: %s\n\n", symbol, expiration, widthStr)
+	_, _ = fmt.Fprintf(w, "%-8s  %8s  %8s  %10s  %8s  %10s\n", "Lower", "Upper", "Credit", "Max Risk", "Debit", "Max Risk")
+	_, _ = fmt.Fprintf(w, "%-8s  %8s  %8s  %10s  %8s  %10s\n", "-----", "-----", "------", "--------", "-----", "--------")
+	for _, row := range rows {
+		_, _ = fmt.Fprintf(w, "%-8s  %8s  %8s  %10s  %8s  %10s\n",
+			parseStrikeFromSymbol(row.LowerSymbol),
+			parseStrikeFromSymbol(row.UpperSymbol),
+			formatSpreadNet(row.Credit),
+			formatSpreadNet(row.CreditMaxRisk),
+			formatSpreadNet(row.Debit),
+			formatSpreadNet(row.DebitMaxRisk))
+	}
+	_, _ = fmt.Fprintln(w, "\nCredit: sell the lower strike, buy the upper (bear call). Debit: buy the lower, sell the upper (bull call).")
+	return nil
+}
+
+// formatSpreadNet formats an optional net price or risk, "-" when unknown.
+func formatSpreadNet(v *float64) string {
+	if v == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f", *v)
+}
+
+// runOptionsScan scans the chain for vertical spreads and prints the ranked candidates.
+func runOptionsScan(cmd *cobra.Command, opts optionsOptions, symbol, expiration string, width, minCredit float64) error {
+	if width <= 0 {
+		return fmt.Errorf("spread width must be positive (use --width flag)")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	client := api.NewClient(opts.baseURL, opts.authToken)
+	chainResp, err := client.GetOptionChain(ctx, opts.accountID, symbol, expiration)
+	if err != nil {
+		return err
+	}
+
+	spreads := findVerticalSpreads(chainResp, width, minCredit)
+
+	if opts.jsonMode {
+		if spreads == nil {
+			spreads = []verticalSpread{}
+		}
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(spreads)
+	}
+
+	if len(spreads) == 0 {
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "No vertical spreads found for %s expiring %s\n", chainResp.BaseSymbol, expiration)
+		return nil
+	}
+
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Vertical Spreads for %s - Expiration: %s - Width: %s\n\n",
+		chainResp.BaseSymbol, expiration, strconv.FormatFloat(width, 'f', -1, 64))
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%-5s  %8s  %8s  %8s  %10s  %8s\n", "Type", "Short", "Long", "Net", "Max Risk", "Net/Wid")
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%-5s  %8s  %8s  %8s  %10s  %8s\n", "----", "------", "------", "------", "--------", "-------")
+	for _, sp := range spreads {
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%-5s  %8s  %8s  %8.2f  %10.2f  %7.1f%%\n",
+			sp.Type,
+			parseStrikeFromSymbol(sp.ShortSymbol),
+			parseStrikeFromSymbol(sp.LongSymbol),
+			sp.Net,
+			sp.MaxRisk,
+			sp.Ratio*100)
+	}
+
+	return nil
+}
+
+// parseStrikeFromSymbol extracts the strike price from an OSI option symbol.
+// Example: AAPL250117C00175000 -> 175.00
+func parseStrikeFromSymbol(symbol string) string {
+	if len(symbol) < 8 {
+		return symbol
+	}
+	// Last 8 characters are the strike price in format: SSSSSSSS (8 digits, price * 1000)
+	strikeStr := symbol[len(symbol)-8:]
+	// Parse as integer and convert to decimal
+	var strike int64
+	if _, err := fmt.Sscanf(strikeStr, "%d", &strike); err != nil {
+		return symbol
+	}
+	dollars := strike / 1000
+	cents := (strike % 1000) / 10
+	if cents == 0 {
+		return fmt.Sprintf("%d", dollars)
+	}
+	return fmt.Sprintf("%d.%02d", dollars, cents)
+}
+
+// maxGreeksPrecision bounds the --precision flag of the greeks command.
+const maxGreeksPrecision = 6
+
+// validateGreeksPrecision checks the --precision flag; 0 keeps API values as-is.
+func validateGreeksPrecision(precision int) error {
+	if precision < 0 || precision > maxGreeksPrecision {
+		return fmt.Errorf("invalid --precision %d: must be 1-%d, or 0 to keep the values as reported", precision, maxGreeksPrecision)
+	}
+	return nil
+}
+
+// formatGreekValue rounds a greek to the given number of decimals.
+// A precision of 0 returns the value as reported by the API.
+func formatGreekValue(value string, precision int) string {
+	if precision == 0 || value == "" {
+		return value
+	}
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return value
+	}
+	return fmt.Sprintf("%.*f", precision, v)
+}
+
+// greeksRow is one option's greeks, optionally with its underlying's last
+// price for moneyness calculations.
+type greeksRow struct {
+	api.OptionGreeks
+	Underlying      string `json:"underlying,omitempty"`
+	UnderlyingPrice string `json:"underlyingPrice,omitempty"`
+}
+
+// greeksUnderlying returns the underlying root of an OSI symbol, or "" when the
+// symbol does not parse.
+func greeksUnderlying(symbol string) string {
+	osi, err := analytics.ParseOSI(symbol)
+	if err != nil {
+		return ""
+	}
+	return osi.Underlying
+}
+
+// fetchUnderlyingPrices returns the last price of each distinct underlying of
+// the given OSI symbols, fetched in one quote request.
+func fetchUnderlyingPrices(ctx context.Context, client *api.Client, accountID string, symbols []string) (map[string]string, error) {
+	var instruments []api.QuoteInstrument
+	seen := make(map[string]bool)
+	for _, sym := range symbols {
+		root := greeksUnderlying(sym)
+		if root == "" || seen[root] {
+			continue
+		}
+		seen[root] = true
+		instruments = append(instruments, api.QuoteInstrument{Symbol: root, Type: "EQUITY"})
+	}
+	if len(instruments) == 0 {
+		return map[string]string{}, nil
+	}
+
+	quotes, err := client.GetQuotes(ctx, accountID, instruments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch underlying quotes: %w", err)
+	}
+	prices := make(map[string]string, len(quotes))
+	for _, q := range quotes {
+		if q.Outcome == "SUCCESS" {
+			prices[q.Instrument.Symbol] = q.Last
+		}
+	}
+	return prices, nil
+}
+
+func runOptionsGreeks(cmd *cobra.Command, opts optionsOptions, symbols []string, precision int, withUnderlying bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	client := api.NewClient(opts.baseURL, opts.authToken)
+	greeksResp, err := client.GetOptionGreeks(ctx, opts.accountID, symbols)
+	if err != nil {
+		return err
+	}
+
+	if len(greeksResp.Greeks) == 0 {
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No greeks data available")
+		return nil
+	}
+
+	rows := make([]greeksRow, len(greeksResp.Greeks))
+	for i, og := range greeksResp.Greeks {
+		rows[i] = greeksRow{OptionGreeks: og}
+	}
+	if withUnderlying {
+		prices, err := fetchUnderlyingPrices(ctx, client, opts.accountID, symbols)
+		if err != nil {
+			return err
+		}
+		for i := range rows {
+			rows[i].Underlying = greeksUnderlying(rows[i].Symbol)
+			rows[i].UnderlyingPrice = prices[rows[i].Underlying]
+		}
+	}
+
+	// Format output
+	if opts.jsonMode {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		if withUnderlying {
+			return enc.Encode(struct {
+				Greeks []greeksRow `json:"greeks"`
+			}{rows})
+		}
+		return enc.Encode(greeksResp)
+	}
+
+	// Table output
+	underlyingHeader, width := "", 85
+	if withUnderlying {
+		underlyingHeader, width = fmt.Sprintf("  %10s", "UNDERLYING"), 97
+	}
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "\n%-22s  %8s  %8s  %8s  %8s  %8s  %8s%s\n",
+		"SYMBOL", "DELTA", "GAMMA", "THETA", "VEGA", "RHO", "IV", underlyingHeader)
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s\n", strings.Repeat("-", width))
+
+	for _, row := range rows {
+		underlying := ""
+		if withUnderlying {
+			underlying = fmt.Sprintf("  %10s", valueOrDash(row.UnderlyingPrice))
+		}
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%-22s  %8s  %8s  %8s  %8s  %8s  %8s%s\n",
+			row.Symbol,
+			formatGreekValue(row.Greeks.Delta, precision),
+			formatGreekValue(row.Greeks.Gamma, precision),
+			formatGreekValue(row.Greeks.Theta, precision),
+			formatGreekValue(row.Greeks.Vega, precision),
+			formatGreekValue(row.Greeks.Rho, precision),
+			formatGreekValue(row.Greeks.ImpliedVolatility, precision),
+			underlying)
+	}
+
+	return nil
+}
+
+// greeksExposure holds position-weighted greeks for a group of option positions.
+type greeksExposure struct {
+	Underlying string  `json:"underlying,omitempty"`
+	Delta      float64 `json:"delta"`
+	Gamma      float64 `json:"gamma"`
+	Theta      float64 `json:"theta"`
+	Vega       float64 `json:"vega"`
+}
+
+// add accumulates greeks scaled by the given multiplier.
+func (e *greeksExposure) add(g api.GreeksData, multiplier float64) {
+	parse := func(v string) float64 {
+		f, _ := strconv.ParseFloat(v, 64)
+		return f
+	}
+	e.Delta += parse(g.Delta) * multiplier
+	e.Gamma += parse(g.Gamma) * multiplier
+	e.Theta += parse(g.Theta) * multiplier
+	e.Vega += parse(g.Vega) * multiplier
+}
+
+// greeksSymbolKey normalizes an option symbol for matching positions to
+// greeks, which may differ in case or OSI padding.
+func greeksSymbolKey(symbol string) string {
+	return strings.ToUpper(strings.ReplaceAll(symbol, " ", ""))
+}
+
+// aggregateGreeks weights each option position's greeks by its signed quantity
+// times the 100-share contract multiplier and sums them per underlying. It
+// also returns the symbols of positions left out of the sums, because they
+// have no greeks or an unreadable quantity.
+func aggregateGreeks(positions []api.Position, greeks []api.OptionGreeks) ([]greeksExposure, greeksExposure, []string) {
+	bySymbol := make(map[string]api.GreeksData, len(greeks))
+	for _, og := range greeks {
+		bySymbol[greeksSymbolKey(og.Symbol)] = og.Greeks
+	}
+
+	var underlyings []string
+	byUnderlying := make(map[string]*greeksExposure)
+	var total greeksExposure
+	var missing []string
+
+	for _, pos := range positions {
+		g, ok := bySymbol[greeksSymbolKey(pos.Instrument.Symbol)]
+		if !ok {
+			missing = append(missing, pos.Instrument.Symbol)
+			continue
+		}
+		qty, err := strconv.ParseFloat(pos.Quantity, 64)
+		if err != nil {
+			missing = append(missing, pos.Instrument.Symbol)
+			continue
+		}
+
+		underlying := pos.Instrument.Symbol
+		if osi, err := analytics.ParseOSI(pos.Instrument.Symbol); err == nil {
+			underlying = osi.Underlying
+		}
+		exp, ok := byUnderlying[underlying]
+		if !ok {
+			exp = &greeksExposure{Underlying: underlying}
+			byUnderlying[underlying] = exp
+			underlyings = append(underlyings, underlying)
+		}
+		exp.add(g, qty*100)
+		total.add(g, qty*100)
+	}
+
+	sort.Strings(underlyings)
+	result := make([]greeksExposure, 0, len(underlyings))
+	for _, u := range underlyings {
+		result = append(result, *byUnderlying[u])
+	}
+
+	return result, total, missing
+}
+
+// runPortfolioGreeks prints aggregate greeks across all option positions.
+func runPortfolioGreeks(cmd *cobra.Command, opts optionsOptions, precision int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	client := api.NewClient(opts.baseURL, opts.authToken)
+	portfolio, err := client.GetPortfolio(ctx, opts.accountID)
+	if err != nil {
+		return err
+	}
+
+	var positions []api.Position
+	var symbols []string
+	for _, pos := range portfolio.Positions {
+		if pos.Instrument.Type == "OPTION" {
+			positions = append(positions, pos)
+			symbols = append(symbols, pos.Instrument.Symbol)
+		}
+	}
+
+	if len(positions) == 0 {
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No option positions")
+		return nil
+	}
+
+	greeksResp, err := client.GetOptionGreeks(ctx, opts.accountID, symbols)
+	if err != nil {
+		return err
+	}
+
+	underlyings, total, missing := aggregateGreeks(positions, greeksResp.Greeks)
+
+	if opts.jsonMode {
+		result := map[string]any{
+			"underlyings": underlyings,
+			"total":       total,
+		}
+		if len(missing) > 0 {
+			result["missing"] = missing
+		}
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	}
+
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "\n%-10s  %10s  %10s  %10s  %10s\n",
+		"UNDERLYING", "DELTA", "GAMMA", "THETA", "VEGA")
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s\n", strings.Repeat("-", 58))
+	// Gamma is small per contract, so it gets two extra decimals by default
+	prec, gammaPrec := 2, 4
+	if precision > 0 {
+		prec, gammaPrec = precision, precision
+	}
+	for _, e := range underlyings {
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%-10s  %10.*f  %10.*f  %10.*f  %10.*f\n",
+			e.Underlying, prec, e.Delta, gammaPrec, e.Gamma, prec, e.Theta, prec, e.Vega)
+	}
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s\n", strings.Repeat("-", 58))
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%-10s  %10.*f  %10.*f  %10.*f  %10.*f\n",
+		"TOTAL", prec, total.Delta, gammaPrec, total.Gamma, prec, total.Theta, prec, total.Vega)
+	if len(missing) > 0 {
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: TOTAL leaves out %d position(s) without greeks: %s\n",
+			len(missing), strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// Assignment risk levels, highest first.
+const (
+	assignmentRiskHigh   = "HIGH"
+	assignmentRiskMedium = "MEDIUM"
+	assignmentRiskLow    = "LOW"
+)
+
+// lowExtrinsicThreshold is the time value per share below which an ITM short
+// option is considered likely to be exercised early.
+const lowExtrinsicThreshold = 0.10
+
+// assignmentRisk describes the early-assignment exposure of one short option position.
+type assignmentRisk struct {
+	Symbol          string  `json:"symbol"`
+	Underlying      string  `json:"underlying"`
+	Type            string  `json:"type"`
+	Strike          float64 `json:"strike"`
+	Expiration      string  `json:"expiration"`
+	DTE             int     `json:"dte"`
+	Quantity        float64 `json:"quantity"`
+	UnderlyingPrice float64 `json:"underlyingPrice"`
+	OptionPrice     float64 `json:"optionPrice"`
+	InTheMoney      bool    `json:"inTheMoney"`
+	Intrinsic       float64 `json:"intrinsic"`
+	Extrinsic       float64 `json:"extrinsic"`
+	ExDividend      string  `json:"exDividend,omitempty"`
+	Risk            string  `json:"risk"`
+}
+
+// parseExDividendDates parses --ex-div values of the form SYMBOL=YYYY-MM-DD.
+func parseExDividendDates(values []string) (map[string]string, error) {
+	dates := make(map[string]string, len(values))
+	for _, v := range values {
+		symbol, date, ok := strings.Cut(v, "=")
+		if !ok || symbol == "" {
+			return nil, fmt.Errorf("invalid --ex-div %q: expected SYMBOL=YYYY-MM-DD", v)
+		}
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			return nil, fmt.Errorf("invalid --ex-div date %q: expected YYYY-MM-DD", date)
+		}
+		dates[strings.ToUpper(symbol)] = date
+	}
+	return dates, nil
+}
+
+// quoteMidOrLast returns the bid/ask midpoint of a quote, falling back to the last price.
+func quoteMidOrLast(q api.Quote) float64 {
+	bid, errBid := strconv.ParseFloat(q.Bid, 64)
+	ask, errAsk := strconv.ParseFloat(q.Ask, 64)
+	if errBid == nil && errAsk == nil && ask > 0 {
+		return (bid + ask) / 2
+	}
+	last, _ := strconv.ParseFloat(q.Last, 64)
+	return last
+}
+
+// buildAssignmentRisks evaluates every short option position against current
+// quotes and ranks them by risk: ITM before OTM, then least extrinsic value,
+// then nearest expiration. A short ITM call whose underlying goes ex-dividend
+// before expiration, or any ITM short with little time value left, is HIGH.
+func buildAssignmentRisks(positions []api.Position, quotes map[string]api.Quote, exDiv map[string]string, now time.Time) []assignmentRisk {
+	var risks []assignmentRisk
+	for _, pos := range positions {
+		if pos.Instrument.Type != "OPTION" {
+			continue
+		}
+		qty, err := strconv.ParseFloat(pos.Quantity, 64)
+		if err != nil || qty >= 0 {
+			continue
+		}
+		osi, err := analytics.ParseOSI(pos.Instrument.Symbol)
+		if err != nil {
+			continue
+		}
+
+		expiration := osi.Expiration.Format("2006-01-02")
+		r := assignmentRisk{
+			Symbol:          pos.Instrument.Symbol,
+			Underlying:      osi.Underlying,
+			Type:            osi.Type,
+			Strike:          osi.Strike,
+			Expiration:      expiration,
+			DTE:             marketdate.DaysUntil(expiration, now),
+			Quantity:        qty,
+			UnderlyingPrice: quoteMidOrLast(quotes[osi.Underlying]),
+			OptionPrice:     quoteMidOrLast(quotes[pos.Instrument.Symbol]),
+		}
+
+		if osi.Type == analytics.Call {
+			r.Intrinsic = math.Max(0, r.UnderlyingPrice-r.Strike)
+		} else {
+			r.Intrinsic = math.Max(0, r.Strike-r.UnderlyingPrice)
+		}
+		r.InTheMoney = r.UnderlyingPrice > 0 && r.Intrinsic > 0
+		r.Extrinsic = math.Max(0, r.OptionPrice-r.Intrinsic)
+
+		if date, ok := exDiv[osi.Underlying]; ok && date >= now.Format("2006-01-02") && date <= expiration {
+			r.ExDividend = date
+		}
+
+		switch {
+		case r.InTheMoney && (r.Extrinsic < lowExtrinsicThreshold || (osi.Type == analytics.Call && r.ExDividend != "")):
+			r.Risk = assignmentRiskHigh
+		case r.InTheMoney:
+			r.Risk = assignmentRiskMedium
+		default:
+			r.Risk = assignmentRiskLow
+		}
+
+		risks = append(risks, r)
+	}
+
+	rank := map[string]int{assignmentRiskHigh: 0, assignmentRiskMedium: 1, assignmentRiskLow: 2}
+	sort.SliceStable(risks, func(i, j int) bool {
+		a, b := risks[i], risks[j]
+		if rank[a.Risk] != rank[b.Risk] {
+			return rank[a.Risk] < rank[b.Risk]
+		}
+		if a.Extrinsic != b.Extrinsic {
+			return a.Extrinsic < b.Extrinsic
+		}
+		return a.DTE < b.DTE
+	})
+
+	return risks
+}
+
+// runOptionsAssignmentRisk prints short option positions ranked by early-assignment risk.
+func runOptionsAssignmentRisk(cmd *cobra.Command, opts optionsOptions, exDiv map[string]string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	client := api.NewClient(opts.baseURL, opts.authToken)
+	portfolio, err := client.GetPortfolio(ctx, opts.accountID)
+	if err != nil {
+		return err
+	}
+
+	var shorts []api.Position
+	var instruments []api.QuoteInstrument
+	seen := make(map[string]bool)
+	for _, pos := range portfolio.Positions {
+		if pos.Instrument.Type != "OPTION" {
+			continue
+		}
+		if qty, err := strconv.ParseFloat(pos.Quantity, 64); err != nil || qty >= 0 {
+			continue
+		}
+		osi, err := analytics.ParseOSI(pos.Instrument.Symbol)
+		if err != nil {
+			continue
+		}
+		shorts = append(shorts, pos)
+		instruments = append(instruments, api.QuoteInstrument{Symbol: pos.Instrument.Symbol, Type: "OPTION"})
+		if !seen[osi.Underlying] {
+			seen[osi.Underlying] = true
+			instruments = append(instruments, api.QuoteInstrument{Symbol: osi.Underlying, Type: "EQUITY"})
+		}
+	}
+
+	if len(shorts) == 0 {
+		if opts.jsonMode {
+			_, _ = fmt.Fprintln(cmd.OutOrStdout(), "[]")
+			return nil
+		}
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No short option positions")
+		return nil
+	}
+
+	quoteList, err := client.GetQuotes(ctx, opts.accountID, instruments)
+	if err != nil {
+		return err
+	}
+	quotes := make(map[string]api.Quote, len(quoteList))
+	for _, q := range quoteList {
+		quotes[q.Instrument.Symbol] = q
+	}
+
+	risks := buildAssignmentRisks(shorts, quotes, exDiv, time.Now())
+
+	if opts.jsonMode {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(risks)
+	}
+
+	out := cmd.OutOrStdout()
+	_, _ = fmt.Fprintf(out, "%-6s  %-21s  %4s  %5s  %10s  %9s  %4s  %9s  %9s  %-10s\n",
+		"RISK", "SYMBOL", "QTY", "DTE", "UNDERLYING", "STRIKE", "ITM", "INTRINSIC", "EXTRINSIC", "EX-DIV")
+	_, _ = fmt.Fprintf(out, "%s\n", strings.Repeat("-", 103))
+	for _, r := range risks {
+		itm := "no"
+		if r.InTheMoney {
+			itm = "yes"
+		}
+		exDivDate := "-"
+		if r.ExDividend != "" {
+			exDivDate = r.ExDividend
+		}
+		_, _ = fmt.Fprintf(out, "%-6s  %-21s  %4s  %5d  %10.2f  %9.2f  %4s  %9.2f  %9.2f  %-10s\n",
+			r.Risk, r.Symbol, strconv.FormatFloat(r.Quantity, 'f', -1, 64), r.DTE,
+			r.UnderlyingPrice, r.Strike, itm, r.Intrinsic, r.Extrinsic, exDivDate)
+	}
+	_, _ = fmt.Fprintf(out, "\nHIGH: ITM with under $%.2f of time value, or an ITM call with an ex-dividend date before expiration.\n", lowExtrinsicThreshold)
+
+	return nil
+}
+
+// legGrammar is the --leg syntax shown in parseLeg errors.
+const legGrammar = "SIDE SYMBOL OPEN|CLOSE [RATIO]"
+
+// exampleOSI is the placeholder option symbol used in leg hints and templates.
+const exampleOSI = "AAPL250117C00175000"
+
+// legSideAliases maps side spellings people commonly type to BUY or SELL.
+var legSideAliases = map[string]string{
+	"BUY": "BUY", "SELL": "SELL", "LONG": "BUY", "SHORT": "SELL",
+	"BTO": "BUY", "BTC": "BUY", "STO": "SELL", "STC": "SELL",
+}
+
+// legOpenCloseAliases maps open/close spellings to OPEN or CLOSE.
+var legOpenCloseAliases = map[string]string{
+	"OPEN": "OPEN", "CLOSE": "CLOSE", "OPENING": "OPEN", "CLOSING": "CLOSE",
+	"BTO": "OPEN", "STO": "OPEN", "BTC": "CLOSE", "STC": "CLOSE",
+}
+
+// legError reports a malformed --leg with the expected grammar and a
+// corrected example guessed from what was typed.
+func legError(legStr, problem string) error {
+	return fmt.Errorf("invalid leg %q: %s\n  expected: %q\n  try:      %q", legStr, problem, legGrammar, suggestLeg(legStr))
+}
+
+// suggestLeg rebuilds a leg from its tokens in any order, translating
+// aliases such as STO or LONG and filling missing parts with defaults.
+func suggestLeg(legStr string) string {
+	var side, symbol, openClose, ratio string
+	for _, tok := range strings.Fields(strings.ToUpper(legStr)) {
+		if s, ok := legSideAliases[tok]; ok && side == "" {
+			side = s
+			if oc, ok := legOpenCloseAliases[tok]; ok && openClose == "" {
+				openClose = oc
+			}
+			continue
+		}
+		if oc, ok := legOpenCloseAliases[tok]; ok && openClose == "" {
+			openClose = oc
+			continue
+		}
+		if n, err := strconv.Atoi(tok); err == nil && n > 0 && ratio == "" {
+			ratio = tok
+			continue
+		}
+		if symbol == "" {
+			symbol = tok
+		}
+	}
+
+	if side == "" {
+		side = "BUY"
+	}
+	if symbol == "" {
+		symbol = exampleOSI
+	}
+	if openClose == "" {
+		openClose = "OPEN"
+	}
+	leg := fmt.Sprintf("%s %s %s", side, symbol, openClose)
+	if ratio != "" && ratio != "1" {
+		leg += " " + ratio
+	}
+	return leg
+}
+
+// parseLeg parses a leg string in format "SIDE SYMBOL OPEN|CLOSE [RATIO]"
+// Example: "BUY AAPL250117C00175000 OPEN" or "SELL AAPL250117C00180000 OPEN 2"
+func parseLeg(legStr string) (api.MultilegLeg, error) {
+	parts := strings.Fields(legStr)
+	if len(parts) < 3 {
+		return api.MultilegLeg{}, legError(legStr, fmt.Sprintf("expected at least 3 fields, got %d", len(parts)))
+	}
+	if len(parts) > 4 {
+		return api.MultilegLeg{}, legError(legStr, fmt.Sprintf("expected at most 4 fields, got %d", len(parts)))
+	}
+
+	side := strings.ToUpper(parts[0])
+	if side != "BUY" && side != "SELL" {
+		return api.MultilegLeg{}, legError(legStr, fmt.Sprintf("side must be BUY or SELL, got %q", parts[0]))
+	}
+
+	symbol := strings.ToUpper(parts[1])
+
+	// Determine instrument type from symbol (options have 16+ chars in OSI format)
+	instType := "OPTION"
+	if len(symbol) <= 5 {
+		instType = "EQUITY"
+	} else if _, err := analytics.ParseOSI(symbol); err != nil {
+		return api.MultilegLeg{}, legError(legStr, fmt.Sprintf(
+			"%s is not an OSI option symbol (ROOT + YYMMDD + C|P + strike x1000 as 8 digits, e.g. %s)", symbol, exampleOSI))
+	}
+
+	openClose := strings.ToUpper(parts[2])
+	if openClose != "OPEN" && openClose != "CLOSE" {
+		return api.MultilegLeg{}, legError(legStr, fmt.Sprintf("third field must be OPEN or CLOSE, got %q", parts[2]))
+	}
+
+	ratio := 1
+	if len(parts) == 4 {
+		n, err := strconv.Atoi(parts[3])
+		if err != nil || n < 1 {
+			return api.MultilegLeg{}, legError(legStr, fmt.Sprintf("ratio must be a positive whole number, got %q", parts[3]))
+		}
+		ratio = n
+	}
+
+	return api.MultilegLeg{
+		Instrument: api.MultilegInstrument{
+			Symbol: symbol,
+			Type:   instType,
+		},
+		Side:               side,
+		OpenCloseIndicator: openClose,
+		RatioQuantity:      ratio,
+	}, nil
+}
+
+// multilegTemplate is a common strategy printed by --help-examples.
+type multilegTemplate struct {
+	name  string
+	note  string
+	legs  []string
+	limit string
+}
+
+// multilegTemplates are ready-to-edit strategies for --help-examples. They
+// share one underlying and expiration so only strikes need changing.
+var multilegTemplates = []multilegTemplate{
+	{
+		name:  "Vertical call spread (debit)",
+		note:  "Buy the lower strike, sell the higher strike",
+		legs:  []string{"BUY AAPL250117C00175000 OPEN", "SELL AAPL250117C00180000 OPEN"},
+		limit: "2.50",
+	},
+	{
+		name:  "Vertical put spread (credit)",
+		note:  "Sell the higher strike, buy the lower strike",
+		legs:  []string{"SELL AAPL250117P00170000 OPEN", "BUY AAPL250117P00165000 OPEN"},
+		limit: "1.10",
+	},
+	{
+		name:  "Iron condor",
+		note:  "Short put spread below the price, short call spread above it",
+		legs:  []string{"SELL AAPL250117P00165000 OPEN", "BUY AAPL250117P00160000 OPEN", "SELL AAPL250117C00185000 OPEN", "BUY AAPL250117C00190000 OPEN"},
+		limit: "1.20",
+	},
+	{
+		name:  "Long straddle",
+		note:  "Buy a call and a put at the same strike",
+		legs:  []string{"BUY AAPL250117C00175000 OPEN", "BUY AAPL250117P00175000 OPEN"},
+		limit: "9.00",
+	},
+	{
+		name:  "Closing a spread",
+		note:  "Reverse each side and use CLOSE",
+		legs:  []string{"SELL AAPL250117C00175000 CLOSE", "BUY AAPL250117C00180000 CLOSE"},
+		limit: "3.00",
+	},
+}
+
+// printMultilegTemplates prints the --help-examples strategy templates.
+func printMultilegTemplates(w io.Writer) {
+	_, _ = fmt.Fprintf(w, "Leg format: %q\n", legGrammar)
+	_, _ = fmt.Fprintln(w, "Edit the root, expiration (YYMMDD), C/P, and strike x1000 in each symbol.")
+	for _, t := range multilegTemplates {
+		_, _ = fmt.Fprintf(w, "\n# %s: %s\n", t.name, t.note)
+		_, _ = fmt.Fprintln(w, multilegOrderCommand(t.legs, t.limit, "1"))
+	}
+}
+
+func runMultilegPreflight(cmd *cobra.Command, opts optionsOptions, legs []string, limitPrice, quantity, expiration string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// Parse legs
+	var parsedLegs []api.MultilegLeg
+	for _, legStr := range legs {
+		leg, err := parseLeg(legStr)
+		if err != nil {
+			return err
+		}
+		parsedLegs = append(parsedLegs, leg)
+	}
+
+	if len(parsedLegs) < 2 {
+		return fmt.Errorf("multi-leg orders require at least 2 legs")
+	}
+	if len(parsedLegs) > 6 {
+		return fmt.Errorf("multi-leg orders support at most 6 legs")
 	}
 
 	// Validate expiration
@@ -490,8 +2204,8 @@ func runMultilegPreflight(cmd *cobra.Command, opts optionsOptions, legs []string
 	}
 
 	var preflightResp api.MultilegPreflightResponse
-	if err := json.NewDecoder(resp.Body).Decode(&preflightResp); err != nil {
-		return fmt.Errorf("failed to decode response: %w", err)
+	if err := api.DecodeJSON(resp, &preflightResp); err != nil {
+		return err
 	}
 
 	// Format output
@@ -547,25 +2261,222 @@ func runMultilegPreflight(cmd *cobra.Command, opts optionsOptions, legs []string
 
 // sumMultilegFees calculates the total regulatory fees for multi-leg orders.
 func sumMultilegFees(fees api.MultilegRegulatoryFees) string {
+	return money.Sum(fees.SECFee, fees.TAFFee, fees.ORFFee, fees.ExchangeFee, fees.OCCFee, fees.CATFee).String()
+}
+
+// singleLegParams holds parameters for single-leg options orders.
+type singleLegParams struct {
+	quantity    string
+	limitPrice  string
+	expiration  string
+	openClose   string // "OPEN" or "CLOSE"
+	noPreflight bool
+	risk        riskGuard
+	peg         pegSpec // prices the limit from the contract's quote instead of --limit
+}
+
+// riskGuard caps the maximum loss and the contract count an options order
+// may carry.
+type riskGuard struct {
+	maxRisk      float64 // Dollars; 0 disables the check
+	maxContracts int     // Overrides max_contracts for this order; 0 keeps it
+	force        bool
+}
+
+// contractCap returns the contract cap for an order placed with opts.
+func (g riskGuard) contractCap(opts optionsOptions) quantityCap {
+	return newQuantityCap("contracts", "--max-contracts", float64(g.maxContracts), "max_contracts", float64(opts.maxContracts))
+}
+
+// checkLegContracts applies the contract cap to every leg of a multi-leg
+// order (quantity times the leg's ratio) and to the total across legs.
+func (g riskGuard) checkLegContracts(opts optionsOptions, legs []api.MultilegLeg, quantity string) error {
+	if g.force {
+		return nil
+	}
+	qty, err := strconv.ParseFloat(quantity, 64)
+	if err != nil {
+		return nil
+	}
+	contractCap := g.contractCap(opts)
 	var total float64
-	for _, feeStr := range []string{fees.SECFee, fees.TAFFee, fees.ORFFee, fees.ExchangeFee, fees.OCCFee, fees.CATFee} {
-		if feeStr == "" {
-			continue
+	for _, leg := range legs {
+		n := qty * float64(leg.RatioQuantity)
+		if err := contractCap.check("leg "+leg.Instrument.Symbol, n); err != nil {
+			return err
+		}
+		total += n
+	}
+	return contractCap.check("order total across legs", total)
+}
+
+// orderRisk is the computed maximum loss of an order in dollars.
+type orderRisk struct {
+	amount    float64
+	unlimited bool
+	known     bool
+}
+
+// String formats the risk for display.
+func (r orderRisk) String() string {
+	switch {
+	case !r.known:
+		return "unavailable"
+	case r.unlimited:
+		return "unlimited"
+	default:
+		return fmt.Sprintf("$%.2f", r.amount)
+	}
+}
+
+// check returns an error when the risk exceeds the cap, unless forced.
+func (g riskGuard) check(r orderRisk) error {
+	if g.maxRisk <= 0 || g.force {
+		return nil
+	}
+	if !r.known {
+		return fmt.Errorf("max risk could not be computed for this order (use --force to place it anyway)")
+	}
+	if r.unlimited || r.amount > g.maxRisk {
+		return fmt.Errorf("max risk %s exceeds --max-risk $%.2f (use --force to override)", r, g.maxRisk)
+	}
+	return nil
+}
+
+// singleLegRisk computes the maximum loss of a single-leg options order.
+// Buys risk the debit paid; closing sells add no risk; opening sells use the
+// short option's payoff against the credit received.
+func singleLegRisk(symbol, side, openClose, quantity, limitPrice string) orderRisk {
+	qty, err := strconv.ParseFloat(quantity, 64)
+	if err != nil {
+		return orderRisk{}
+	}
+	limit, err := strconv.ParseFloat(limitPrice, 64)
+	if err != nil {
+		return orderRisk{}
+	}
+
+	if side == "BUY" {
+		return orderRisk{amount: limit * qty * 100, known: true}
+	}
+	if openClose == "CLOSE" {
+		return orderRisk{known: true}
+	}
+
+	osi, err := analytics.ParseOSI(symbol)
+	if err != nil {
+		return orderRisk{}
+	}
+	loss, unlimited := analytics.MaxLoss([]analytics.Leg{{Type: osi.Type, Strike: osi.Strike, Quantity: -1}}, limit)
+	return orderRisk{amount: loss * qty * 100, unlimited: unlimited, known: true}
+}
+
+// multilegRisk computes the maximum loss of a multi-leg options order from
+// the payoff of its legs at expiration. Strategies with equity legs are not
+// supported and report an unknown risk.
+func multilegRisk(legs []api.MultilegLeg, limitPrice, quantity string) orderRisk {
+	qty, err := strconv.ParseFloat(quantity, 64)
+	if err != nil {
+		return orderRisk{}
+	}
+	limit, err := strconv.ParseFloat(limitPrice, 64)
+	if err != nil {
+		return orderRisk{}
+	}
+	payoffLegs, ok := multilegPayoffLegs(legs)
+	if !ok {
+		return orderRisk{}
+	}
+
+	premium := analytics.NetPremium(payoffLegs, limit)
+	loss, unlimited := analytics.MaxLoss(payoffLegs, premium)
+	return orderRisk{amount: loss * qty * 100, unlimited: unlimited, known: true}
+}
+
+// multilegPayoffLegs converts order legs to payoff legs, signed by side. It
+// reports false for equity legs and symbols that are not OSI options.
+func multilegPayoffLegs(legs []api.MultilegLeg) ([]analytics.Leg, bool) {
+	payoffLegs := make([]analytics.Leg, 0, len(legs))
+	for _, leg := range legs {
+		if leg.Instrument.Type != "OPTION" {
+			return nil, false
+		}
+		osi, err := analytics.ParseOSI(leg.Instrument.Symbol)
+		if err != nil {
+			return nil, false
 		}
-		var v float64
-		if _, err := fmt.Sscanf(feeStr, "%f", &v); err == nil {
-			total += v
+		ratio := float64(leg.RatioQuantity)
+		if leg.Side == "SELL" {
+			ratio = -ratio
 		}
+		payoffLegs = append(payoffLegs, analytics.Leg{Type: osi.Type, Strike: osi.Strike, Quantity: ratio})
 	}
-	return fmt.Sprintf("%.2f", total)
+	return payoffLegs, true
 }
 
-// singleLegParams holds parameters for single-leg options orders.
-type singleLegParams struct {
-	quantity   string
-	limitPrice string
-	expiration string
-	openClose  string // "OPEN" or "CLOSE"
+// multilegNetDirection returns analytics.Debit or analytics.Credit when the
+// legs can only be opened one way, or "" when it cannot be told from them.
+func multilegNetDirection(legs []api.MultilegLeg) string {
+	payoffLegs, ok := multilegPayoffLegs(legs)
+	if !ok {
+		return ""
+	}
+	return analytics.NetDirection(payoffLegs)
+}
+
+// resolveMultilegNet checks a --net-debit or --net-credit direction against
+// what the legs imply, so a credit spread is not entered at a debit price by
+// mistake. It returns the stated direction, or the inferred one when none was
+// stated.
+func resolveMultilegNet(legs []api.MultilegLeg, limitPrice, net string) (string, error) {
+	inferred := multilegNetDirection(legs)
+	if net != "" && inferred != "" && net != inferred {
+		return "", fmt.Errorf("--net-%s %s conflicts with the legs, which open for a %s (use --net-%s, or check the BUY/SELL side of each leg)",
+			strings.ToLower(net), limitPrice, strings.ToLower(inferred), strings.ToLower(inferred))
+	}
+	if net == "" {
+		return inferred, nil
+	}
+	return net, nil
+}
+
+// signedMultilegLimit returns the limit price as the API reads it: positive
+// for a debit and negative for a credit. NetPremium reads the sign the same
+// way, so the result also feeds the local risk check.
+func signedMultilegLimit(limitPrice, net string) string {
+	if net == analytics.Credit && !strings.HasPrefix(limitPrice, "-") {
+		return "-" + limitPrice
+	}
+	return limitPrice
+}
+
+// multilegLimit picks the multileg limit price from --limit, --net-debit, or
+// --net-credit, exactly one of which must be set. It also returns the
+// direction a --net flag asserts; --limit leaves it "".
+func multilegLimit(limit, netDebit, netCredit string) (string, string, error) {
+	set := 0
+	for _, v := range []string{limit, netDebit, netCredit} {
+		if v != "" {
+			set++
+		}
+	}
+	switch {
+	case set == 0:
+		return "", "", fmt.Errorf("limit price is required (use --limit, --net-debit, or --net-credit)")
+	case set > 1:
+		return "", "", fmt.Errorf("use only one of --limit, --net-debit, or --net-credit")
+	case limit != "":
+		return limit, "", nil
+	}
+
+	flag, price, net := "--net-debit", netDebit, analytics.Debit
+	if netCredit != "" {
+		flag, price, net = "--net-credit", netCredit, analytics.Credit
+	}
+	if v, err := strconv.ParseFloat(price, 64); err != nil || v <= 0 {
+		return "", "", fmt.Errorf("invalid %s %q: must be a positive price", flag, price)
+	}
+	return price, net, nil
 }
 
 func runSingleLegPreflight(opts optionsOptions, symbol, side string, params singleLegParams) (*api.OptionsPreflightResponse, error) {
@@ -590,33 +2501,11 @@ func runSingleLegPreflight(opts optionsOptions, symbol, side string, params sing
 		OpenCloseIndicator: strings.ToUpper(params.openClose),
 	}
 
-	body, err := json.Marshal(preflightReq)
-	if err != nil {
-		return nil, fmt.Errorf("failed to encode preflight request: %w", err)
-	}
-
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
 	client := api.NewClient(opts.baseURL, opts.authToken)
-	path := fmt.Sprintf("/userapigateway/trading/%s/preflight/single-leg", opts.accountID)
-	resp, err := client.Post(ctx, path, bytes.NewReader(body))
-	if err != nil {
-		return nil, fmt.Errorf("failed to call preflight: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != 200 {
-		respBody, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("preflight API error: %d - %s", resp.StatusCode, string(respBody))
-	}
-
-	var preflightResp api.OptionsPreflightResponse
-	if err := json.NewDecoder(resp.Body).Decode(&preflightResp); err != nil {
-		return nil, fmt.Errorf("failed to decode preflight response: %w", err)
-	}
-
-	return &preflightResp, nil
+	return client.PreflightSingleLeg(ctx, opts.accountID, preflightReq)
 }
 
 func runSingleLegOrder(cmd *cobra.Command, opts optionsOptions, symbol, side string, params singleLegParams, skipConfirm, tradingEnabled bool) error {
@@ -629,13 +2518,20 @@ func runSingleLegOrder(cmd *cobra.Command, opts optionsOptions, symbol, side str
 	if opts.accountID == "" {
 		return fmt.Errorf("account ID is required (use --account flag or configure default account)")
 	}
+	reason, err := parseReason(opts.reason, opts.requireReason)
+	if err != nil {
+		return err
+	}
 
 	if params.quantity == "" {
 		return fmt.Errorf("quantity is required (use --quantity flag)")
 	}
 
-	if params.limitPrice == "" {
-		return fmt.Errorf("limit price is required for options orders (use --limit flag)")
+	if params.limitPrice == "" && !params.peg.set() {
+		return fmt.Errorf("limit price is required for options orders (use --limit or --peg)")
+	}
+	if err := params.peg.validate(params.limitPrice); err != nil {
+		return err
 	}
 
 	openClose := strings.ToUpper(params.openClose)
@@ -643,18 +2539,60 @@ func runSingleLegOrder(cmd *cobra.Command, opts optionsOptions, symbol, side str
 		return fmt.Errorf("open/close indicator is required (use --open or --close flag)")
 	}
 
-	symbol = strings.ToUpper(symbol)
-	orderID := uuid.New().String()
+	symbol = strings.ToUpper(symbol)
+	orderID := generateOrderID(opts.newOrderID)
+
+	// Validate expiration
+	expiration := strings.ToUpper(params.expiration)
+	if expiration != "DAY" && expiration != "GTC" {
+		return fmt.Errorf("invalid expiration: %s (use DAY or GTC)", params.expiration)
+	}
+
+	if !params.risk.force {
+		qty, _ := strconv.ParseFloat(params.quantity, 64)
+		if err := params.risk.contractCap(opts).check("order", qty); err != nil {
+			return err
+		}
+	}
+
+	// One quote request serves the halt check, --peg, and the preview's
+	// market block. --no-preflight drops the market block, so with --force
+	// and no --peg nothing is fetched.
+	showMarket := !opts.jsonMode && !params.noPreflight
+	var quote *api.Quote
+	var quoteErr error
+	if !params.risk.force || params.peg.set() || showMarket {
+		quotes, err := fetchOrderQuotes(opts, symbol)
+		if !params.risk.force && err == nil {
+			if err := haltedIn(quotes); err != nil {
+				return err
+			}
+		}
+		quote, quoteErr = findOptionQuote(quotes, err, symbol)
+	}
+
+	var pegged *peggedLimit
+	if params.peg.set() {
+		if quoteErr != nil {
+			return fmt.Errorf("--peg: %w", quoteErr)
+		}
+		resolved, err := params.peg.resolve(*quote, side, optionTick)
+		if err != nil {
+			return err
+		}
+		pegged = &resolved
+		params.limitPrice = resolved.price()
+	}
+
+	risk := singleLegRisk(symbol, side, openClose, params.quantity, params.limitPrice)
 
-	// Validate expiration
-	expiration := strings.ToUpper(params.expiration)
-	if expiration != "DAY" && expiration != "GTC" {
-		return fmt.Errorf("invalid expiration: %s (use DAY or GTC)", params.expiration)
+	// Call preflight to get estimated costs unless explicitly skipped
+	var preflight *api.OptionsPreflightResponse
+	var preflightErr error
+	if !params.noPreflight {
+		preflight, preflightErr = runSingleLegPreflight(opts, symbol, side, params)
 	}
 
-	// Call preflight to get estimated costs
-	preflight, preflightErr := runSingleLegPreflight(opts, symbol, side, params)
-
 	// Show order preview (not in JSON mode)
 	if !opts.jsonMode {
 		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "\nOptions Order Preview:\n")
@@ -662,7 +2600,34 @@ func runSingleLegOrder(cmd *cobra.Command, opts optionsOptions, symbol, side str
 		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  Symbol:     %s\n", symbol)
 		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  Quantity:   %s contract(s)\n", params.quantity)
 		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  Limit:      $%s\n", params.limitPrice)
+		if pegged != nil {
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  Peg:        %s\n", pegged.describe())
+		}
 		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  Expires:    %s\n", expiration)
+		if reason != "" {
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  Reason:     %s\n", reason)
+		}
+
+		// Show the current market for the contract so the limit can be
+		// judged; under --no-preflight only the order parameters are shown
+		if showMarket {
+			if quoteErr == nil {
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "\n  Market:\n")
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "    Bid:          $%s\n", quote.Bid)
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "    Ask:          $%s\n", quote.Ask)
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "    Last:         $%s\n", quote.Last)
+				bid, bidErr := strconv.ParseFloat(quote.Bid, 64)
+				ask, askErr := strconv.ParseFloat(quote.Ask, 64)
+				if bidErr == nil && askErr == nil {
+					_, _ = fmt.Fprintf(cmd.OutOrStdout(), "    Spread:       $%.2f\n", ask-bid)
+				}
+				if warning := limitThroughMarket(side, params.limitPrice, *quote); warning != "" {
+					_, _ = fmt.Fprintf(cmd.OutOrStdout(), "\n  Warning: %s\n", warning)
+				}
+			} else {
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "\n  Market: quote unavailable (%s)\n", extractOptionsErrorMessage(quoteErr))
+			}
+		}
 
 		// Show preflight cost estimates if available
 		if preflightErr == nil && preflight != nil {
@@ -682,12 +2647,17 @@ func runSingleLegOrder(cmd *cobra.Command, opts optionsOptions, symbol, side str
 			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "\n  Cost Estimate: unavailable (%s)\n", extractOptionsErrorMessage(preflightErr))
 		}
 
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "\n  Max Risk:   %s\n", risk)
 		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "\n  Order ID: %s\n\n", orderID)
 	}
 
+	if err := params.risk.check(risk); err != nil {
+		return err
+	}
+
 	// Require confirmation unless --yes flag is set
-	if !skipConfirm {
-		return fmt.Errorf("order requires confirmation (use --yes to confirm)")
+	if err := confirmTrade(cmd, opts.promptConfirm, skipConfirm, opts.jsonMode, "order", placeOrderQuestion(opts.confirmPrompt, defaultPlaceQuestion)); err != nil {
+		return err
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -710,39 +2680,33 @@ func runSingleLegOrder(cmd *cobra.Command, opts optionsOptions, symbol, side str
 		OpenCloseIndicator: openClose,
 	}
 
-	body, err := json.Marshal(orderReq)
-	if err != nil {
-		return fmt.Errorf("failed to encode request: %w", err)
-	}
-
 	client := api.NewClient(opts.baseURL, opts.authToken)
-	path := fmt.Sprintf("/userapigateway/trading/%s/order", opts.accountID)
-	resp, err := client.Post(ctx, path, bytes.NewReader(body))
+	orderResp, err := client.PlaceOptionsOrder(ctx, opts.accountID, orderReq)
 	if err != nil {
-		return fmt.Errorf("failed to place order: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != 200 {
-		respBody, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API error: %d - %s", resp.StatusCode, string(respBody))
-	}
-
-	var orderResp api.OrderResponse
-	if err := json.NewDecoder(resp.Body).Decode(&orderResp); err != nil {
-		return fmt.Errorf("failed to decode response: %w", err)
+		return err
 	}
+	recordJournal(cmd.ErrOrStderr(), opts.journalPath, journalEntry{
+		AccountID:  opts.accountID,
+		OrderID:    orderResp.OrderID,
+		Symbol:     symbol,
+		Side:       side + " to " + openClose,
+		Quantity:   params.quantity,
+		OrderType:  "LIMIT",
+		LimitPrice: params.limitPrice,
+		Reason:     reason,
+	}, time.Now())
 
 	// Output result
 	if opts.jsonMode {
-		result := map[string]any{
-			"orderId":    orderResp.OrderID,
-			"status":     "placed",
-			"symbol":     symbol,
-			"side":       side,
-			"quantity":   params.quantity,
-			"limitPrice": params.limitPrice,
-			"openClose":  openClose,
+		result := OptionOrderPlacedResult{
+			OrderID:    orderResp.OrderID,
+			Status:     statusPlaced,
+			Symbol:     symbol,
+			Side:       side,
+			Quantity:   params.quantity,
+			LimitPrice: params.limitPrice,
+			OpenClose:  openClose,
+			Reason:     reason,
 		}
 		enc := json.NewEncoder(cmd.OutOrStdout())
 		enc.SetIndent("", "  ")
@@ -752,24 +2716,66 @@ func runSingleLegOrder(cmd *cobra.Command, opts optionsOptions, symbol, side str
 	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Order placed successfully!\n")
 	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  Order ID: %s\n", orderResp.OrderID)
 	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  %s to %s %s contract(s) of %s at $%s\n", side, openClose, params.quantity, symbol, params.limitPrice)
+	if reason != "" {
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  Reason: %s\n", reason)
+	}
 	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "\nNote: Order placement is asynchronous. Use 'pub order status %s' to check execution status.\n", orderResp.OrderID)
 
 	return nil
 }
 
-// sumOptionsFees calculates the total regulatory fees for single-leg options orders.
-func sumOptionsFees(fees api.OptionsRegulatoryFees) string {
-	var total float64
-	for _, feeStr := range []string{fees.SECFee, fees.TAFFee, fees.ORFFee, fees.ExchangeFee, fees.OCCFee, fees.CATFee} {
-		if feeStr == "" {
+// fetchOrderQuotes retrieves the quotes of an option contract and its
+// underlying in one request, enough for both the halt check and pricing.
+func fetchOrderQuotes(opts optionsOptions, symbol string) ([]api.Quote, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	client := api.NewClient(opts.baseURL, opts.authToken)
+	return client.GetQuotes(ctx, opts.accountID, api.ResolveInstruments(haltCheckSymbols([]string{symbol})))
+}
+
+// findOptionQuote picks the contract's quote out of a fetchOrderQuotes
+// result, or says why there is none; err is the fetch error, if any.
+func findOptionQuote(quotes []api.Quote, err error, symbol string) (*api.Quote, error) {
+	if err != nil {
+		return nil, err
+	}
+	for i := range quotes {
+		if !strings.EqualFold(quotes[i].Instrument.Symbol, symbol) {
 			continue
 		}
-		var v float64
-		if _, err := fmt.Sscanf(feeStr, "%f", &v); err == nil {
-			total += v
+		if quotes[i].Outcome != "SUCCESS" {
+			return nil, fmt.Errorf("quote outcome %s", quotes[i].Outcome)
+		}
+		return &quotes[i], nil
+	}
+	return nil, fmt.Errorf("no quote returned for %s", symbol)
+}
+
+// limitThroughMarket returns a warning when a limit price crosses the current
+// market: above the ask for a buy, or below the bid for a sell.
+func limitThroughMarket(side, limitPrice string, quote api.Quote) string {
+	limit, err := strconv.ParseFloat(limitPrice, 64)
+	if err != nil {
+		return ""
+	}
+
+	switch side {
+	case "BUY":
+		if ask, err := strconv.ParseFloat(quote.Ask, 64); err == nil && ask > 0 && limit > ask {
+			return fmt.Sprintf("limit $%s is above the ask ($%s)", limitPrice, quote.Ask)
+		}
+	case "SELL":
+		if bid, err := strconv.ParseFloat(quote.Bid, 64); err == nil && bid > 0 && limit < bid {
+			return fmt.Sprintf("limit $%s is below the bid ($%s)", limitPrice, quote.Bid)
 		}
 	}
-	return fmt.Sprintf("%.2f", total)
+	return ""
+}
+
+// sumOptionsFees calculates the total regulatory fees for single-leg options orders.
+func sumOptionsFees(fees api.OptionsRegulatoryFees) string {
+	return money.Sum(fees.SECFee, fees.TAFFee, fees.ORFFee, fees.ExchangeFee, fees.OCCFee, fees.CATFee).String()
 }
 
 // extractOptionsErrorMessage extracts a human-readable message from an API error.
@@ -804,7 +2810,12 @@ func extractOptionsErrorMessage(err error) string {
 	return errStr
 }
 
-func runMultilegOrder(cmd *cobra.Command, opts optionsOptions, legs []string, limitPrice, quantity, expiration string, skipConfirm bool) error {
+func runMultilegOrder(cmd *cobra.Command, opts optionsOptions, legs []string, limitPrice, net, quantity, expiration string, skipConfirm bool, guard riskGuard) error {
+	reason, err := parseReason(opts.reason, opts.requireReason)
+	if err != nil {
+		return err
+	}
+
 	// Parse legs
 	var parsedLegs []api.MultilegLeg
 	for _, legStr := range legs {
@@ -828,20 +2839,42 @@ func runMultilegOrder(cmd *cobra.Command, opts optionsOptions, legs []string, li
 		return fmt.Errorf("invalid expiration: %s (use DAY or GTC)", expiration)
 	}
 
+	if err := guard.checkLegContracts(opts, parsedLegs, quantity); err != nil {
+		return err
+	}
+
+	net, err = resolveMultilegNet(parsedLegs, limitPrice, net)
+	if err != nil {
+		return err
+	}
+	apiLimit := signedMultilegLimit(limitPrice, net)
+
 	// Generate order ID
-	orderID := uuid.New().String()
+	orderID := generateOrderID(opts.newOrderID)
+	risk := multilegRisk(parsedLegs, apiLimit, quantity)
 
 	// Call preflight to get cost estimate
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
+	client := api.NewClient(opts.baseURL, opts.authToken)
+	if !guard.force {
+		legSymbols := make([]string, 0, len(parsedLegs))
+		for _, leg := range parsedLegs {
+			legSymbols = append(legSymbols, leg.Instrument.Symbol)
+		}
+		if err := checkNotHalted(ctx, client, opts.accountID, legSymbols); err != nil {
+			return err
+		}
+	}
+
 	preflightReq := api.MultilegPreflightRequest{
 		OrderType: "LIMIT",
 		Expiration: api.MultilegExpiration{
 			TimeInForce: exp,
 		},
 		Quantity:   quantity,
-		LimitPrice: limitPrice,
+		LimitPrice: apiLimit,
 		Legs:       parsedLegs,
 	}
 
@@ -850,7 +2883,6 @@ func runMultilegOrder(cmd *cobra.Command, opts optionsOptions, legs []string, li
 		return fmt.Errorf("failed to encode preflight request: %w", err)
 	}
 
-	client := api.NewClient(opts.baseURL, opts.authToken)
 	preflightPath := fmt.Sprintf("/userapigateway/trading/%s/preflight/multi-leg", opts.accountID)
 	preflightResp, err := client.Post(ctx, preflightPath, bytes.NewReader(preflightBody))
 	if err != nil {
@@ -860,8 +2892,8 @@ func runMultilegOrder(cmd *cobra.Command, opts optionsOptions, legs []string, li
 
 	var preflight api.MultilegPreflightResponse
 	if preflightResp.StatusCode == 200 {
-		if err := json.NewDecoder(preflightResp.Body).Decode(&preflight); err != nil {
-			return fmt.Errorf("failed to decode preflight response: %w", err)
+		if err := api.DecodeJSON(preflightResp, &preflight); err != nil {
+			return err
 		}
 	}
 
@@ -875,13 +2907,23 @@ func runMultilegOrder(cmd *cobra.Command, opts optionsOptions, legs []string, li
 			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Underlying:  %s\n", preflight.BaseSymbol)
 		}
 		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Quantity:    %s\n", quantity)
-		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Limit:       $%s\n\n", limitPrice)
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Limit:       $%s\n", limitPrice)
+		switch net {
+		case analytics.Debit:
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Net:         DEBIT $%s (you pay)\n", limitPrice)
+		case analytics.Credit:
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Net:         CREDIT $%s (you receive)\n", limitPrice)
+		}
+		_, _ = fmt.Fprintln(cmd.OutOrStdout())
 
 		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Legs:\n")
 		for _, leg := range parsedLegs {
 			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  %s %dx %s (%s)\n",
 				leg.Side, leg.RatioQuantity, leg.Instrument.Symbol, leg.OpenCloseIndicator)
 		}
+		if reason != "" {
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "\nReason:      %s\n", reason)
+		}
 
 		if preflightResp.StatusCode == 200 {
 			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "\nEstimated Costs:\n")
@@ -898,12 +2940,17 @@ func runMultilegOrder(cmd *cobra.Command, opts optionsOptions, legs []string, li
 			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "\nBuying Power Required: $%s\n", preflight.BuyingPowerRequirement)
 		}
 
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "\nMax Risk:    %s\n", risk)
 		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "\n  Order ID: %s\n\n", orderID)
 	}
 
+	if err := guard.check(risk); err != nil {
+		return err
+	}
+
 	// Require confirmation unless --yes flag is set
-	if !skipConfirm {
-		return fmt.Errorf("order requires confirmation (use --yes to confirm)")
+	if err := confirmTrade(cmd, opts.promptConfirm, skipConfirm, opts.jsonMode, "order", placeOrderQuestion(opts.confirmPrompt, defaultPlaceQuestion)); err != nil {
+		return err
 	}
 
 	// Place the order
@@ -917,7 +2964,7 @@ func runMultilegOrder(cmd *cobra.Command, opts optionsOptions, legs []string, li
 			TimeInForce: exp,
 		},
 		Quantity:   quantity,
-		LimitPrice: limitPrice,
+		LimitPrice: apiLimit,
 		Legs:       parsedLegs,
 	}
 
@@ -939,20 +2986,35 @@ func runMultilegOrder(cmd *cobra.Command, opts optionsOptions, legs []string, li
 	}
 
 	var orderResult api.MultilegOrderResponse
-	if err := json.NewDecoder(orderResp.Body).Decode(&orderResult); err != nil {
-		return fmt.Errorf("failed to decode order response: %w", err)
+	if err := api.DecodeJSON(orderResp, &orderResult); err != nil {
+		return err
+	}
+	journalLegs := make([]string, len(parsedLegs))
+	for i, leg := range parsedLegs {
+		journalLegs[i] = fmt.Sprintf("%s %dx %s %s", leg.Side, leg.RatioQuantity, leg.Instrument.Symbol, leg.OpenCloseIndicator)
 	}
+	recordJournal(cmd.ErrOrStderr(), opts.journalPath, journalEntry{
+		AccountID:  opts.accountID,
+		OrderID:    orderResult.OrderID,
+		Legs:       journalLegs,
+		Quantity:   quantity,
+		OrderType:  "LIMIT",
+		LimitPrice: apiLimit,
+		Reason:     reason,
+	}, time.Now())
 
 	// Output result
 	if opts.jsonMode {
-		result := map[string]any{
-			"orderId":    orderResult.OrderID,
-			"status":     "placed",
-			"strategy":   preflight.StrategyName,
-			"underlying": preflight.BaseSymbol,
-			"quantity":   quantity,
-			"limitPrice": limitPrice,
-			"legs":       len(parsedLegs),
+		result := MultilegPlacedResult{
+			OrderID:    orderResult.OrderID,
+			Status:     statusPlaced,
+			Strategy:   preflight.StrategyName,
+			Underlying: preflight.BaseSymbol,
+			Quantity:   quantity,
+			LimitPrice: limitPrice,
+			Net:        net,
+			Legs:       len(parsedLegs),
+			Reason:     reason,
 		}
 		enc := json.NewEncoder(cmd.OutOrStdout())
 		enc.SetIndent("", "  ")
@@ -965,6 +3027,9 @@ func runMultilegOrder(cmd *cobra.Command, opts optionsOptions, legs []string, li
 		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  Strategy: %s on %s\n", preflight.StrategyName, preflight.BaseSymbol)
 	}
 	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  %s spread(s) at $%s limit\n", quantity, limitPrice)
+	if reason != "" {
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  Reason: %s\n", reason)
+	}
 	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "\nNote: Order placement is asynchronous. Use 'pub order status %s' to check execution status.\n", orderResult.OrderID)
 
 	return nil
@@ -980,6 +3045,7 @@ func init() {
 		Long:  `Commands for options trading including expirations and chains.`,
 	}
 
+	var expirationsWithATMIV bool
 	expirationsCmd := &cobra.Command{
 		Use:   "expirations SYMBOL",
 		Short: "List option expiration dates",
@@ -987,7 +3053,13 @@ func init() {
 
 Examples:
   pub options expirations AAPL           # List expirations for Apple
-  pub options expirations AAPL --json    # Output in JSON format`,
+  pub options expirations AAPL --json    # Output in JSON format
+  pub options expirations AAPL --with-atm-iv   # Add the ATM implied volatility per expiration
+
+--with-atm-iv fetches the chain and greeks of the at-the-money call for every
+expiration (a few at a time) and shows its implied volatility next to the
+date and DTE, giving the term structure at a glance. Expirations whose IV
+cannot be fetched show "-".`,
 		Args: cobra.ExactArgs(1),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			// Load config
@@ -1004,8 +3076,9 @@ Examples:
 			}
 
 			// Use flag value or default from config
-			if accountID == "" {
-				accountID = cfg.AccountUUID
+			accountID, err = resolveAccountID(accountID, cfg.AccountUUID, cmd.InOrStdin())
+			if err != nil {
+				return err
 			}
 
 			opts.baseURL = cfg.APIBaseURL
@@ -1018,11 +3091,12 @@ Examples:
 			if opts.accountID == "" {
 				return fmt.Errorf("account ID is required (use --account flag or configure default account)")
 			}
-			return runOptionsExpirations(cmd, opts, args[0])
+			return runOptionsExpirations(cmd, opts, args[0], expirationsWithATMIV)
 		},
 	}
 
-	expirationsCmd.Flags().StringVarP(&accountID, "account", "a", "", "Account ID (uses default if not specified)")
+	expirationsCmd.Flags().StringVarP(&accountID, "account", "a", "", "Account ID (uses default if not specified; - reads it from stdin)")
+	expirationsCmd.Flags().BoolVar(&expirationsWithATMIV, "with-atm-iv", false, "Show the ATM implied volatility for each expiration")
 	expirationsCmd.SilenceUsage = true
 
 	var chainAccountID string
@@ -1031,9 +3105,31 @@ Examples:
 	var chainMaxStrike string
 	var chainMinOI int
 	var chainMinVolume int
+	var chainMinBid float64
+	var chainNear int
+	var chainCenter float64
 	var chainCallsOnly bool
 	var chainPutsOnly bool
 	var chainStrikes int
+	var chainScan string
+	var chainWidth float64
+	var chainMinCredit float64
+	var chainSize bool
+	var chainBreakeven bool
+	var chainDeltaHint bool
+	var chainContracts int
+	var chainSpreadWidth float64
+	var chainExpirationRange string
+	var chainAsOf string
+	var chainUnderlyingPrice float64
+	var chainSort string
+	var chainDesc bool
+	var chainCSV bool
+	var chainGreeks bool
+	var chainCompact bool
+	var chainExportStrategy bool
+	var chainStrategy strategySpec
+	var chainReason string
 
 	chainCmd := &cobra.Command{
 		Use:   "chain SYMBOL",
@@ -1042,16 +3138,93 @@ Examples:
 
 Filtering options:
   --strikes N          Show N strikes centered around ATM (e.g., --strikes 10 shows 5 above, 5 below)
+  --underlying-price P Center --strikes on P instead of fetching the underlying quote
+  --center P --near N  Show N strikes centered on an arbitrary price P, such as a
+                       technical level, instead of ATM
   --min-strike/--max-strike  Filter by strike price range
   --calls-only/--puts-only   Show only one side of the chain
   --min-oi N           Minimum open interest
   --min-volume N       Minimum daily volume
+  --min-bid P          Drop options bidding below P, including those with no bid
+                       (e.g. --min-bid 0.05 hides worthless far-OTM wings)
+
+Display options:
+  --size               Show bid/ask size columns
+  --breakeven          Show the breakeven at expiration when buying at the ask
+                       (strike + ask for calls, strike - ask for puts; - without an ask)
+  --delta-neutral-hint Show the shares to trade to delta-hedge each option:
+                       delta x 100 x contracts from the option's greeks, negated
+                       (-52 means sell 52 shares against a long call). Reverse
+                       the sign when you are short the option.
+  --contracts N        Contracts to size the hedge for (default 1)
+  --spread-width N     Show each call strike paired with the strike $N above it
+                       instead of the chain: the credit for selling the spread
+                       (lower bid - upper ask) and the debit for buying it
+                       (lower ask - upper bid), each with its max risk.
+                       Strike filters pick the lower strikes.
+  --sort KEY           Sort calls and puts by strike (default), volume, oi, or spread
+  --desc               Reverse the sort order (e.g. --sort volume --desc for most liquid first)
+
+CSV export:
+  --csv                Write the chain as CSV, calls then puts, with the columns
+                       symbol, underlying, type, expiration, strike, bid, ask,
+                       last, bid_size, ask_size, volume, open_interest.
+                       The column order is fixed; new columns are only appended.
+                       Filters and --sort apply; an empty result is just the header.
+  --greeks             Append delta, gamma, theta, vega, rho, implied_volatility.
+                       Contracts without greeks get empty cells, never missing columns.
+
+Multiple expirations:
+  --expiration-range START:END  Fetch every expiration in the range (either side
+                       may be empty) and list strikes across expirations, ordered
+                       by strike then days to expiration. Useful for calendars.
+
+Historical chains:
+  --as-of DATE         Request the chain as of a past date. The API only serves
+                       the live chain, so a past date is an error rather than
+                       live data; today returns the live chain.
+
+Compact layout:
+  --compact            One table with calls on the left, puts on the right, and
+                       the strike between them (alias --combined), for picking
+                       straddles and spreads. Strikes listed on one side only
+                       leave the other blank. Falls back to separate tables when
+                       the terminal is too narrow. Sizes are not shown.
+
+Dates may be YYYY-MM-DD or relative: today, tomorrow, +30d, +2w, next-friday.
+Relative dates and days to expiration use the US/Eastern market date.
+
+Scanning:
+  --scan vertical      List vertical credit spreads instead of the chain
+  --width N            Strike width of the spreads to scan for
+  --min-credit N       Minimum net credit per share (short bid - long ask)
+
+Strategy export:
+  --export-strategy    Print the 'pub options multileg order' command for the
+                       strikes picked with --buy and --sell (e.g. 175c, 172.5p).
+                       Each contract is checked against the chain.
+  --limit P            Limit price (default: net mid of the legs)
+  --quantity N         Number of strategies (default 1)
+  --execute            Place the order instead of printing it (requires --yes)
 
 Examples:
   pub options chain AAPL --expiration 2025-01-17                    # Full chain
   pub options chain AAPL -e 2025-01-17 --strikes 10                 # 10 strikes around ATM
+  pub options chain AAPL -e 2025-01-17 --strikes 10 --compact       # Calls | strike | puts
   pub options chain AAPL -e 2025-01-17 --calls-only --min-oi 100    # Liquid calls only
-  pub options chain AAPL -e 2025-01-17 --min-strike 170 --max-strike 190  # Strike range`,
+  pub options chain AAPL -e 2025-01-17 --puts-only --center 160 --near 6  # Puts around a support level
+  pub options chain AAPL -e 2025-01-17 --puts-only --min-bid 0.10   # Puts worth selling
+  pub options chain AAPL -e 2025-01-17 --min-strike 170 --max-strike 190  # Strike range
+  pub options chain AAPL -e 2025-01-17 --sort oi --desc           # Highest open interest first
+  pub options chain AAPL -e 2025-01-17 --strikes 6 --breakeven     # Move needed to profit
+  pub options chain AAPL -e 2025-01-17 --strikes 6 --delta-neutral-hint --contracts 5  # Hedge sizes
+  pub options chain AAPL -e 2025-01-17 --strikes 10 --spread-width 5  # $5-wide call verticals
+  pub options chain AAPL -e 2025-01-17 --csv --greeks > chain.csv    # Export with greeks
+  pub options chain AAPL -e 2025-01-17 --scan vertical --width 5 --min-credit 1.00  # Spread scanner
+  pub options chain AAPL --expiration-range 2025-01-01:2025-03-31 --strikes 4       # Compare expirations
+  pub options chain AAPL -e next-friday --strikes 10                 # This week's expiration
+  pub options chain AAPL --expiration-range today:+45d --strikes 4  # Next 45 days
+  pub options chain AAPL -e 2025-01-17 --export-strategy --buy 175c --sell 180c    # Scaffold a spread`,
 		Args: cobra.ExactArgs(1),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			// Load config
@@ -1068,34 +3241,94 @@ Examples:
 			}
 
 			// Use flag value or default from config
-			if chainAccountID == "" {
-				chainAccountID = cfg.AccountUUID
+			chainAccountID, err = resolveAccountID(chainAccountID, cfg.AccountUUID, cmd.InOrStdin())
+			if err != nil {
+				return err
 			}
 
 			opts.baseURL = cfg.APIBaseURL
 			opts.authToken = token
 			opts.accountID = chainAccountID
 			opts.jsonMode = GetJSONMode()
+			opts.reason = chainReason
+			opts.requireReason = cfg.RequireReason
+			opts.promptConfirm = cfg.Trading == config.TradingConfirm
+			opts.confirmPrompt = cfg.ConfirmPrompt
+			opts.journalPath = journalPath()
+			chainStrategy.tradingEnabled = cfg.TradingEnabled()
+			opts.maxContracts = cfg.MaxContracts
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if opts.accountID == "" {
 				return fmt.Errorf("account ID is required (use --account flag or configure default account)")
 			}
-			if chainExpiration == "" {
+			if err := checkChainAsOf(chainAsOf, time.Now()); err != nil {
+				return err
+			}
+			if chainExpiration == "" && chainExpirationRange == "" {
 				return fmt.Errorf("expiration date is required (use --expiration flag)")
 			}
+			if chainExpiration != "" && chainExpirationRange != "" {
+				return fmt.Errorf("cannot use both --expiration and --expiration-range")
+			}
 			if chainCallsOnly && chainPutsOnly {
 				return fmt.Errorf("cannot use both --calls-only and --puts-only")
 			}
+			if chainExpiration != "" {
+				exp, err := marketdate.Normalize(chainExpiration, time.Now())
+				if err != nil {
+					return fmt.Errorf("invalid --expiration: %w", err)
+				}
+				chainExpiration = exp
+			}
+			if chainScan != "" {
+				if chainExpirationRange != "" {
+					return fmt.Errorf("--scan does not support --expiration-range")
+				}
+				if !strings.EqualFold(chainScan, "vertical") {
+					return fmt.Errorf("invalid --scan value: %s (use vertical)", chainScan)
+				}
+				if err := validateScanFlags(cmd); err != nil {
+					return err
+				}
+				return runOptionsScan(cmd, opts, args[0], chainExpiration, chainWidth, chainMinCredit)
+			}
+
+			if !chainExportStrategy && (len(chainStrategy.buys) > 0 || len(chainStrategy.sells) > 0 || chainStrategy.execute) {
+				return fmt.Errorf("--buy, --sell, and --execute require --export-strategy")
+			}
+			if chainExportStrategy {
+				if chainExpirationRange != "" {
+					return fmt.Errorf("--export-strategy does not support --expiration-range")
+				}
+				return runOptionsExportStrategy(cmd, opts, args[0], chainExpiration, chainStrategy)
+			}
+
+			if cmd.Flags().Changed("underlying-price") && chainUnderlyingPrice <= 0 {
+				return fmt.Errorf("invalid --underlying-price: must be positive")
+			}
+			if err := validateDeltaHint(chainDeltaHint, chainContracts, cmd.Flags().Changed("contracts"), opts.jsonMode); err != nil {
+				return err
+			}
+			if chainMinBid < 0 {
+				return fmt.Errorf("invalid --min-bid: must not be negative")
+			}
 
 			// Build filter
 			filter := chainFilter{
-				minOI:     chainMinOI,
-				minVolume: chainMinVolume,
-				callsOnly: chainCallsOnly,
-				putsOnly:  chainPutsOnly,
-				strikes:   chainStrikes,
+				minOI:           chainMinOI,
+				minVolume:       chainMinVolume,
+				minBid:          chainMinBid,
+				callsOnly:       chainCallsOnly,
+				putsOnly:        chainPutsOnly,
+				strikes:         chainStrikes,
+				near:            chainNear,
+				center:          chainCenter,
+				underlyingPrice: chainUnderlyingPrice,
+			}
+			if err := validateNearFilter(filter, cmd.Flags().Changed("near"), cmd.Flags().Changed("center")); err != nil {
+				return err
 			}
 			if chainMinStrike != "" {
 				if v, err := strconv.ParseFloat(chainMinStrike, 64); err == nil {
@@ -1112,22 +3345,100 @@ Examples:
 				}
 			}
 
-			return runOptionsChain(cmd, opts, args[0], chainExpiration, filter)
+			sortBy, err := parseChainSort(chainSort)
+			if err != nil {
+				return err
+			}
+			view := chainView{
+				showSize:  chainSize || GetWideMode(),
+				breakeven: chainBreakeven,
+				sortBy:    sortBy,
+				desc:      chainDesc,
+				deltaHint: chainDeltaHint,
+				contracts: chainContracts,
+
+				spreadWidth: chainSpreadWidth,
+
+				csv:    chainCSV,
+				greeks: chainGreeks,
+
+				compact: chainCompact,
+			}
+			if err := validateSpreadWidth(view, cmd.Flags().Changed("spread-width"), chainPutsOnly); err != nil {
+				return err
+			}
+			if err := validateChainCSV(view, opts.jsonMode); err != nil {
+				return err
+			}
+			if err := validateChainCompact(view, opts.jsonMode, chainCallsOnly || chainPutsOnly, chainExpirationRange != ""); err != nil {
+				return err
+			}
+			if chainExpirationRange != "" {
+				if cmd.Flags().Changed("sort") || chainDesc {
+					return fmt.Errorf("--sort and --desc are not supported with --expiration-range")
+				}
+				if chainDeltaHint {
+					return fmt.Errorf("--delta-neutral-hint is not supported with --expiration-range")
+				}
+				if chainSpreadWidth > 0 {
+					return fmt.Errorf("--spread-width is not supported with --expiration-range")
+				}
+				if chainCSV {
+					return fmt.Errorf("--csv is not supported with --expiration-range")
+				}
+				start, end, err := parseExpirationRange(chainExpirationRange, time.Now())
+				if err != nil {
+					return err
+				}
+				return runOptionsChainRange(cmd, opts, args[0], start, end, filter, view)
+			}
+
+			return runOptionsChain(cmd, opts, args[0], chainExpiration, filter, view)
 		},
 	}
 
-	chainCmd.Flags().StringVarP(&chainAccountID, "account", "a", "", "Account ID (uses default if not specified)")
-	chainCmd.Flags().StringVarP(&chainExpiration, "expiration", "e", "", "Expiration date (YYYY-MM-DD)")
+	chainCmd.Flags().StringVarP(&chainAccountID, "account", "a", "", "Account ID (uses default if not specified; - reads it from stdin)")
+	chainCmd.Flags().StringVarP(&chainExpiration, "expiration", "e", "", "Expiration date (YYYY-MM-DD, today, +Nd, or next-<weekday>)")
 	chainCmd.Flags().IntVar(&chainStrikes, "strikes", 0, "Limit to N strikes around ATM (e.g., 10 shows 5 above, 5 below)")
+	chainCmd.Flags().IntVar(&chainNear, "near", 0, "Limit to N strikes around --center instead of ATM")
+	chainCmd.Flags().Float64Var(&chainCenter, "center", 0, "Price to center --near on (e.g. a support level)")
 	chainCmd.Flags().StringVar(&chainMinStrike, "min-strike", "", "Minimum strike price")
 	chainCmd.Flags().StringVar(&chainMaxStrike, "max-strike", "", "Maximum strike price")
 	chainCmd.Flags().IntVar(&chainMinOI, "min-oi", 0, "Minimum open interest")
 	chainCmd.Flags().IntVar(&chainMinVolume, "min-volume", 0, "Minimum daily volume")
+	chainCmd.Flags().Float64Var(&chainMinBid, "min-bid", 0, "Minimum bid price; drops options with no bid")
 	chainCmd.Flags().BoolVar(&chainCallsOnly, "calls-only", false, "Show only calls")
 	chainCmd.Flags().BoolVar(&chainPutsOnly, "puts-only", false, "Show only puts")
+	chainCmd.Flags().StringVar(&chainScan, "scan", "", "Scan for spreads instead of listing the chain (vertical)")
+	chainCmd.Flags().Float64Var(&chainWidth, "width", 5, "Strike width for --scan")
+	chainCmd.Flags().Float64Var(&chainMinCredit, "min-credit", 0, "Minimum net credit for --scan")
+	chainCmd.Flags().BoolVar(&chainSize, "size", false, "Show bid/ask size columns")
+	chainCmd.Flags().BoolVar(&chainBreakeven, "breakeven", false, "Show each option's breakeven at expiration when bought at the ask")
+	chainCmd.Flags().BoolVar(&chainDeltaHint, "delta-neutral-hint", false, "Show the shares to trade to delta-hedge each option")
+	chainCmd.Flags().IntVar(&chainContracts, "contracts", 1, "Contracts to size --delta-neutral-hint for")
+	chainCmd.Flags().Float64Var(&chainSpreadWidth, "spread-width", 0, "Show call verticals with this strike-price width in dollars instead of the chain")
+	chainCmd.Flags().StringVar(&chainSort, "sort", "strike", "Sort each side by strike, volume, oi, or spread")
+	addChainCompactFlags(chainCmd, &chainCompact)
+	chainCmd.Flags().BoolVar(&chainDesc, "desc", false, "Sort in descending order")
+	chainCmd.Flags().BoolVar(&chainCSV, "csv", false, "Write the chain as CSV with a fixed column order")
+	chainCmd.Flags().BoolVar(&chainGreeks, "greeks", false, "Append greeks columns to --csv output")
+	chainCmd.Flags().Float64Var(&chainUnderlyingPrice, "underlying-price", 0, "Underlying price for ATM filtering (skips the quote fetch)")
+	chainCmd.Flags().StringVar(&chainExpirationRange, "expiration-range", "", "Fetch all expirations in START:END (YYYY-MM-DD)")
+	chainCmd.Flags().StringVar(&chainAsOf, "as-of", "", "Chain as of a past date (not supported by the API; errors instead of returning live data)")
+	chainCmd.Flags().BoolVar(&chainExportStrategy, "export-strategy", false, "Print a multileg order command for the --buy/--sell strikes")
+	chainCmd.Flags().StringArrayVar(&chainStrategy.buys, "buy", nil, "Strike to buy for --export-strategy, e.g. 175c (repeatable)")
+	chainCmd.Flags().StringArrayVar(&chainStrategy.sells, "sell", nil, "Strike to sell for --export-strategy, e.g. 180c (repeatable)")
+	chainCmd.Flags().StringVar(&chainStrategy.limit, "limit", "", "Limit price for --export-strategy (default: net mid)")
+	chainCmd.Flags().StringVar(&chainStrategy.quantity, "quantity", "1", "Number of strategies for --export-strategy")
+	chainCmd.Flags().BoolVar(&chainStrategy.execute, "execute", false, "Place the exported strategy instead of printing it")
+	chainCmd.Flags().BoolVar(&chainStrategy.skipConfirm, "yes", false, "Confirm order placement with --execute")
+	chainCmd.Flags().StringVar(&chainReason, "reason", "", "Why you are placing the --execute order; recorded in the trade journal")
 	chainCmd.SilenceUsage = true
 
 	var greeksAccountID string
+	var greeksPortfolio bool
+	var greeksPrecision int
+	var greeksWithUnderlying bool
 	greeksCmd := &cobra.Command{
 		Use:   "greeks SYMBOL [SYMBOL...]",
 		Short: "Display option greeks",
@@ -1135,11 +3446,30 @@ Examples:
 
 Symbols should be in OSI format (e.g., AAPL250117C00175000).
 
+With --portfolio, greeks are fetched for every open option position and
+weighted by signed quantity x 100 shares per contract, then summed per
+underlying and in total.
+
+Use --precision to control the decimals shown, e.g. to see far-OTM deltas
+that would otherwise round to 0.00.
+
+Use --with-underlying to add each option's underlying last price (an
+Underlying column, or underlying/underlyingPrice in JSON). Quotes for all
+distinct underlyings are fetched in one request.
+
 Examples:
   pub options greeks AAPL250117C00175000                    # Single option
   pub options greeks AAPL250117C00175000 AAPL250117P00175000  # Multiple options
-  pub options greeks AAPL250117C00175000 --json             # Output as JSON`,
-		Args: cobra.MinimumNArgs(1),
+  pub options greeks AAPL250117C00175000 --json             # Output as JSON
+  pub options greeks --portfolio                            # Net greeks across positions
+  pub options greeks AAPL250117C00250000 --precision 4      # More decimals
+  pub options greeks AAPL250117C00175000 --with-underlying --json  # Greeks plus spot`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if greeksPortfolio {
+				return cobra.NoArgs(cmd, args)
+			}
+			return cobra.MinimumNArgs(1)(cmd, args)
+		},
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			// Load config
 			cfg, err := config.Load(config.ConfigPath())
@@ -1155,8 +3485,9 @@ Examples:
 			}
 
 			// Use flag value or default from config
-			if greeksAccountID == "" {
-				greeksAccountID = cfg.AccountUUID
+			greeksAccountID, err = resolveAccountID(greeksAccountID, cfg.AccountUUID, cmd.InOrStdin())
+			if err != nil {
+				return err
 			}
 
 			opts.baseURL = cfg.APIBaseURL
@@ -1169,19 +3500,177 @@ Examples:
 			if opts.accountID == "" {
 				return fmt.Errorf("account ID is required (use --account flag or configure default account)")
 			}
-			return runOptionsGreeks(cmd, opts, args)
+			if err := validateGreeksPrecision(greeksPrecision); err != nil {
+				return err
+			}
+			if greeksPortfolio {
+				if greeksWithUnderlying {
+					return fmt.Errorf("--with-underlying is not supported with --portfolio")
+				}
+				return runPortfolioGreeks(cmd, opts, greeksPrecision)
+			}
+			return runOptionsGreeks(cmd, opts, args, greeksPrecision, greeksWithUnderlying)
 		},
 	}
 
-	greeksCmd.Flags().StringVarP(&greeksAccountID, "account", "a", "", "Account ID (uses default if not specified)")
+	greeksCmd.Flags().StringVarP(&greeksAccountID, "account", "a", "", "Account ID (uses default if not specified; - reads it from stdin)")
+	greeksCmd.Flags().BoolVar(&greeksPortfolio, "portfolio", false, "Aggregate greeks across all option positions")
+	greeksCmd.Flags().IntVar(&greeksPrecision, "precision", 0, "Decimals for greeks and IV (1-6; 0 keeps the values as reported)")
+	greeksCmd.Flags().BoolVar(&greeksWithUnderlying, "with-underlying", false, "Include each option's underlying last price")
 	greeksCmd.SilenceUsage = true
 
+	var assignmentAccountID string
+	var assignmentExDiv []string
+	assignmentCmd := &cobra.Command{
+		Use:   "assignment-risk",
+		Short: "Rank short option positions by early-assignment risk",
+		Long: `Scan short option positions for early-assignment risk.
+
+Each short option is priced against current quotes for the option and its
+underlying. The report shows whether it is in the money, its intrinsic value,
+and its extrinsic (time) value per share. Little time value left on an ITM
+short is the main signal that the holder may exercise early.
+
+The API does not provide dividend data, so pass known ex-dividend dates with
+--ex-div. An ITM short call whose underlying goes ex-dividend before
+expiration is flagged HIGH.
+
+Examples:
+  pub options assignment-risk                               # Ranked table
+  pub options assignment-risk --ex-div AAPL=2025-02-07      # Include an ex-dividend date
+  pub options assignment-risk --json                        # Output as JSON`,
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			// Load config
+			cfg, err := config.Load(config.ConfigPath())
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			// Get auth token
+			store := keyring.NewEnvStore(keyring.NewSystemStore())
+			token, err := api.GetAuthToken(store, cfg.APIBaseURL, false)
+			if err != nil {
+				return err
+			}
+
+			// Use flag value or default from config
+			assignmentAccountID, err = resolveAccountID(assignmentAccountID, cfg.AccountUUID, cmd.InOrStdin())
+			if err != nil {
+				return err
+			}
+
+			opts.baseURL = cfg.APIBaseURL
+			opts.authToken = token
+			opts.accountID = assignmentAccountID
+			opts.jsonMode = GetJSONMode()
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.accountID == "" {
+				return fmt.Errorf("account ID is required (use --account flag or configure default account)")
+			}
+			exDiv, err := parseExDividendDates(assignmentExDiv)
+			if err != nil {
+				return err
+			}
+			return runOptionsAssignmentRisk(cmd, opts, exDiv)
+		},
+	}
+
+	assignmentCmd.Flags().StringVarP(&assignmentAccountID, "account", "a", "", "Account ID (uses default if not specified; - reads it from stdin)")
+	assignmentCmd.Flags().StringArrayVar(&assignmentExDiv, "ex-div", nil, "Known ex-dividend date as SYMBOL=YYYY-MM-DD (repeatable)")
+	assignmentCmd.SilenceUsage = true
+
+	var analyzeAccountID string
+	var analyzeFlags analyzeParams
+	analyzeCmd := &cobra.Command{
+		Use:   "analyze",
+		Short: "Analyze the payoff of an options strategy",
+		Long: `Analyze an options strategy at expiration: max profit, max loss, and
+breakevens. Legs use the multileg order format, so a strategy can be checked
+here and then placed with 'pub options multileg order'. Nothing is submitted.
+
+Price the strategy with one of --limit, --net-debit, or --net-credit, as for
+a multileg order.
+
+--probability fetches each leg's delta and uses it as the chance that leg
+finishes in the money, then estimates the probability of profit for the
+whole strategy. This is an approximation: delta is only a proxy for the
+risk-neutral probability, and prices beyond the outermost strikes are
+extrapolated from implied volatility. It is not a forecast.
+
+Examples:
+  pub options analyze \
+    --leg "BUY AAPL250117C00175000 OPEN" \
+    --leg "SELL AAPL250117C00180000 OPEN" \
+    --net-debit 2.00
+  pub options analyze \
+    --leg "BUY AAPL250117P00160000 OPEN" --leg "SELL AAPL250117P00165000 OPEN" \
+    --leg "SELL AAPL250117C00185000 OPEN" --leg "BUY AAPL250117C00190000 OPEN" \
+    --net-credit 1.20 --probability`,
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			opts.jsonMode = GetJSONMode()
+			// The payoff is computed locally; only --probability calls the API
+			if !analyzeFlags.probability {
+				return nil
+			}
+
+			// Load config
+			cfg, err := config.Load(config.ConfigPath())
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			// Get auth token
+			store := keyring.NewEnvStore(keyring.NewSystemStore())
+			token, err := api.GetAuthToken(store, cfg.APIBaseURL, false)
+			if err != nil {
+				return err
+			}
+
+			// Use flag value or default from config
+			analyzeAccountID, err = resolveAccountID(analyzeAccountID, cfg.AccountUUID, cmd.InOrStdin())
+			if err != nil {
+				return err
+			}
+
+			opts.baseURL = cfg.APIBaseURL
+			opts.authToken = token
+			opts.accountID = analyzeAccountID
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if analyzeFlags.probability && opts.accountID == "" {
+				return fmt.Errorf("account ID is required for --probability (use --account flag or configure default account)")
+			}
+			return runOptionsAnalyze(cmd, opts, analyzeFlags)
+		},
+	}
+
+	analyzeCmd.Flags().StringVarP(&analyzeAccountID, "account", "a", "", "Account ID for --probability (uses default if not specified; - reads it from stdin)")
+	addOptionsAnalyzeFlags(analyzeCmd, &analyzeFlags)
+	analyzeCmd.SilenceUsage = true
+
 	// Multileg commands
+	var multilegHelpExamples bool
 	multilegCmd := &cobra.Command{
 		Use:   "multileg",
 		Short: "Multi-leg options orders",
-		Long:  `Commands for multi-leg options strategies (spreads, straddles, etc.).`,
+		Long: `Commands for multi-leg options strategies (spreads, straddles, etc.).
+
+Use --help-examples to print ready-to-edit templates for common strategies.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if multilegHelpExamples {
+				printMultilegTemplates(cmd.OutOrStdout())
+				return nil
+			}
+			return cmd.Help()
+		},
 	}
+	multilegCmd.Flags().BoolVar(&multilegHelpExamples, "help-examples", false, "Print leg templates for common strategies")
 
 	var multilegPreflightAccountID string
 	var multilegPreflightLegs []string
@@ -1200,6 +3689,8 @@ Each leg is specified with --leg in format: "SIDE SYMBOL OPEN|CLOSE [RATIO]"
   - OPEN|CLOSE: Whether opening or closing the position
   - RATIO: Optional ratio quantity (default 1)
 
+Run 'pub options multileg --help-examples' for strategy templates.
+
 Examples:
   # Vertical call spread (buy lower strike, sell higher strike)
   pub options multileg preflight \
@@ -1227,8 +3718,9 @@ Examples:
 				return err
 			}
 
-			if multilegPreflightAccountID == "" {
-				multilegPreflightAccountID = cfg.AccountUUID
+			multilegPreflightAccountID, err = resolveAccountID(multilegPreflightAccountID, cfg.AccountUUID, cmd.InOrStdin())
+			if err != nil {
+				return err
 			}
 
 			opts.baseURL = cfg.APIBaseURL
@@ -1254,7 +3746,7 @@ Examples:
 		},
 	}
 
-	multilegPreflightCmd.Flags().StringVarP(&multilegPreflightAccountID, "account", "a", "", "Account ID (uses default if not specified)")
+	multilegPreflightCmd.Flags().StringVarP(&multilegPreflightAccountID, "account", "a", "", "Account ID (uses default if not specified; - reads it from stdin)")
 	multilegPreflightCmd.Flags().StringArrayVarP(&multilegPreflightLegs, "leg", "L", nil, "Leg in format 'SIDE SYMBOL OPEN|CLOSE [RATIO]' (repeat for each leg)")
 	multilegPreflightCmd.Flags().StringVarP(&multilegPreflightLimit, "limit", "l", "", "Limit price (required)")
 	multilegPreflightCmd.Flags().StringVarP(&multilegPreflightQty, "quantity", "q", "1", "Number of spreads/strategies")
@@ -1263,11 +3755,16 @@ Examples:
 
 	// Multileg order command
 	var multilegOrderAccountID string
+	var multilegOrderOrderID string
 	var multilegOrderLegs []string
 	var multilegOrderLimit string
+	var multilegOrderNetDebit string
+	var multilegOrderNetCredit string
 	var multilegOrderQty string
 	var multilegOrderExp string
 	var multilegOrderConfirm bool
+	var multilegOrderRisk riskGuard
+	var multilegOrderReason string
 
 	multilegOrderCmd := &cobra.Command{
 		Use:   "order",
@@ -1280,12 +3777,14 @@ Each leg is specified with --leg in format: "SIDE SYMBOL OPEN|CLOSE [RATIO]"
   - OPEN|CLOSE: Whether opening or closing the position
   - RATIO: Optional ratio quantity (default 1)
 
+Run 'pub options multileg --help-examples' for strategy templates.
+
 Examples:
   # Vertical call spread (buy lower strike, sell higher strike)
   pub options multileg order \
     --leg "BUY AAPL250117C00175000 OPEN" \
     --leg "SELL AAPL250117C00180000 OPEN" \
-    --limit 2.50 --quantity 1 --yes
+    --net-debit 2.50 --quantity 1 --yes
 
   # Iron condor (4 legs)
   pub options multileg order \
@@ -1293,7 +3792,23 @@ Examples:
     --leg "BUY AAPL250117P00160000 OPEN" \
     --leg "SELL AAPL250117C00185000 OPEN" \
     --leg "BUY AAPL250117C00190000 OPEN" \
-    --limit 1.20 --quantity 1 --yes`,
+    --net-credit 1.20 --quantity 1 --yes
+
+--limit is unsigned: whether it is paid or received is inferred from the legs.
+--net-debit and --net-credit state the direction and reject the order when
+the legs imply the opposite, e.g. a vertical whose BUY and SELL strikes are
+swapped. Strategies whose direction depends on prices, such as risk reversals
+and ratio spreads, are accepted either way. The preview labels the net DEBIT
+or CREDIT.
+
+The preview shows the maximum loss at expiration computed from the legs.
+Use --max-risk AMOUNT to reject orders that could lose more, or --force to
+override the cap.
+
+Orders where any leg (--quantity times its ratio) or the total across legs
+exceeds max_contracts in the config are rejected before anything is sent;
+--max-contracts N sets the cap for one order and --force skips it.
+Orders on a halted contract or underlying are rejected unless --force is set.`,
 		Args: cobra.NoArgs,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			cfg, err := config.Load(config.ConfigPath())
@@ -1307,14 +3822,27 @@ Examples:
 				return err
 			}
 
-			if multilegOrderAccountID == "" {
-				multilegOrderAccountID = cfg.AccountUUID
+			multilegOrderAccountID, err = resolveAccountID(multilegOrderAccountID, cfg.AccountUUID, cmd.InOrStdin())
+			if err != nil {
+				return err
 			}
 
 			opts.baseURL = cfg.APIBaseURL
 			opts.authToken = token
+			if err := cfg.CheckTrading(); err != nil {
+				return err
+			}
 			opts.accountID = multilegOrderAccountID
 			opts.jsonMode = GetJSONMode()
+			opts.reason = multilegOrderReason
+			opts.requireReason = cfg.RequireReason
+			opts.promptConfirm = cfg.Trading == config.TradingConfirm
+			opts.confirmPrompt = cfg.ConfirmPrompt
+			opts.journalPath = journalPath()
+			opts.maxContracts = cfg.MaxContracts
+			if err := useOrderID(&opts.newOrderID, multilegOrderOrderID); err != nil {
+				return err
+			}
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -1324,22 +3852,30 @@ Examples:
 			if len(multilegOrderLegs) < 2 {
 				return fmt.Errorf("at least 2 legs required (use --leg flag)")
 			}
-			if multilegOrderLimit == "" {
-				return fmt.Errorf("limit price is required (use --limit flag)")
+			limit, net, err := multilegLimit(multilegOrderLimit, multilegOrderNetDebit, multilegOrderNetCredit)
+			if err != nil {
+				return err
 			}
 			if multilegOrderQty == "" {
 				multilegOrderQty = "1"
 			}
-			return runMultilegOrder(cmd, opts, multilegOrderLegs, multilegOrderLimit, multilegOrderQty, multilegOrderExp, multilegOrderConfirm)
+			return runMultilegOrder(cmd, opts, multilegOrderLegs, limit, net, multilegOrderQty, multilegOrderExp, multilegOrderConfirm, multilegOrderRisk)
 		},
 	}
 
-	multilegOrderCmd.Flags().StringVarP(&multilegOrderAccountID, "account", "a", "", "Account ID (uses default if not specified)")
+	multilegOrderCmd.Flags().StringVarP(&multilegOrderAccountID, "account", "a", "", "Account ID (uses default if not specified; - reads it from stdin)")
+	multilegOrderCmd.Flags().StringVar(&multilegOrderOrderID, "order-id", "", "Client order ID (UUID) to use instead of a random one")
 	multilegOrderCmd.Flags().StringArrayVarP(&multilegOrderLegs, "leg", "L", nil, "Leg in format 'SIDE SYMBOL OPEN|CLOSE [RATIO]' (repeat for each leg)")
-	multilegOrderCmd.Flags().StringVarP(&multilegOrderLimit, "limit", "l", "", "Limit price (required)")
+	multilegOrderCmd.Flags().StringVarP(&multilegOrderLimit, "limit", "l", "", "Limit price, unsigned (or use --net-debit or --net-credit)")
+	multilegOrderCmd.Flags().StringVar(&multilegOrderNetDebit, "net-debit", "", "Limit price as a net debit; rejected if the legs open for a credit")
+	multilegOrderCmd.Flags().StringVar(&multilegOrderNetCredit, "net-credit", "", "Limit price as a net credit; rejected if the legs open for a debit")
 	multilegOrderCmd.Flags().StringVarP(&multilegOrderQty, "quantity", "q", "1", "Number of spreads/strategies")
 	multilegOrderCmd.Flags().StringVarP(&multilegOrderExp, "expiration", "e", "DAY", "Order expiration: DAY (default) or GTC")
+	multilegOrderCmd.Flags().StringVar(&multilegOrderReason, "reason", "", "Why you are placing the order; shown in the preview and recorded in the trade journal")
 	multilegOrderCmd.Flags().BoolVarP(&multilegOrderConfirm, "yes", "y", false, "Confirm order placement (required)")
+	multilegOrderCmd.Flags().Float64Var(&multilegOrderRisk.maxRisk, "max-risk", 0, "Reject the order if its maximum loss exceeds this dollar amount")
+	multilegOrderCmd.Flags().IntVar(&multilegOrderRisk.maxContracts, "max-contracts", 0, "Reject the order if it is for more contracts than this (overrides max_contracts)")
+	multilegOrderCmd.Flags().BoolVar(&multilegOrderRisk.force, "force", false, "Place the order even if it exceeds --max-risk, --max-contracts, or max_contracts, or trading is halted")
 	multilegOrderCmd.SilenceUsage = true
 
 	multilegCmd.AddCommand(multilegPreflightCmd)
@@ -1347,7 +3883,9 @@ Examples:
 
 	// Single-leg options buy command
 	var buyAccountID string
+	var buyOrderID string
 	var buyParams singleLegParams
+	var buyReason string
 	var buySkipConfirm bool
 	var buyOpen bool
 	var buyClose bool
@@ -1359,11 +3897,25 @@ Examples:
 
 The symbol should be in OCC format (e.g., AAPL250117C00175000).
 You must specify whether you are opening or closing a position with --open or --close.
+Use --no-preflight to skip the cost estimate and the market quote; the preview
+then shows only the order parameters, and buying-power problems surface only
+when the order is rejected. Use --max-risk to reject orders whose maximum
+loss (the debit for buys, the short payoff for opening sells) exceeds a cap.
+
+Orders for more contracts than max_contracts in the config are rejected;
+--max-contracts N sets the cap for one order and --force skips it.
+Orders on a halted contract or underlying are rejected unless --force is set.
+
+Use --peg bid|ask|mid|last instead of --limit to price the order from the
+contract's current quote, plus --offset dollars; --offset alone pegs to the
+ask. The price is rounded to the standard tick ($0.05 under $3, $0.10 above),
+down for buys.
 
 Examples:
   pub options buy AAPL250117C00175000 --quantity 1 --limit 2.50 --open --yes    # Buy to open
   pub options buy AAPL250117P00170000 --quantity 1 --limit 1.25 --close --yes   # Buy to close (cover short)
-  pub options buy SBUX260220C00100000 -q 8 -l 1.50 --open --yes                 # Buy 8 contracts`,
+  pub options buy SBUX260220C00100000 -q 8 -l 1.50 --open --yes                 # Buy 8 contracts
+  pub options buy AAPL250117C00175000 -q 1 --peg mid --open                     # Limit at the midpoint`,
 		Args: cobra.ExactArgs(1),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			cfg, err := config.Load(config.ConfigPath())
@@ -1377,14 +3929,24 @@ Examples:
 				return err
 			}
 
-			if buyAccountID == "" {
-				buyAccountID = cfg.AccountUUID
+			buyAccountID, err = resolveAccountID(buyAccountID, cfg.AccountUUID, cmd.InOrStdin())
+			if err != nil {
+				return err
 			}
 
 			opts.baseURL = cfg.APIBaseURL
 			opts.authToken = token
 			opts.accountID = buyAccountID
 			opts.jsonMode = GetJSONMode()
+			opts.reason = buyReason
+			opts.requireReason = cfg.RequireReason
+			opts.promptConfirm = cfg.Trading == config.TradingConfirm
+			opts.confirmPrompt = cfg.ConfirmPrompt
+			opts.journalPath = journalPath()
+			opts.maxContracts = cfg.MaxContracts
+			if err := useOrderID(&opts.newOrderID, buyOrderID); err != nil {
+				return err
+			}
 
 			// Set openClose from flags
 			if buyOpen && buyClose {
@@ -1400,14 +3962,22 @@ Examples:
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfg, _ := config.Load(config.ConfigPath())
-			return runSingleLegOrder(cmd, opts, args[0], "BUY", buyParams, buySkipConfirm, cfg.TradingEnabled)
+			return runSingleLegOrder(cmd, opts, args[0], "BUY", buyParams, buySkipConfirm, cfg.TradingEnabled())
 		},
 	}
 
-	buyCmd.Flags().StringVarP(&buyAccountID, "account", "a", "", "Account ID (uses default if not specified)")
+	buyCmd.Flags().StringVarP(&buyAccountID, "account", "a", "", "Account ID (uses default if not specified; - reads it from stdin)")
+	buyCmd.Flags().StringVar(&buyOrderID, "order-id", "", "Client order ID (UUID) to use instead of a random one")
 	buyCmd.Flags().StringVarP(&buyParams.quantity, "quantity", "q", "", "Number of contracts (required)")
-	buyCmd.Flags().StringVarP(&buyParams.limitPrice, "limit", "l", "", "Limit price (required)")
+	buyCmd.Flags().StringVarP(&buyParams.limitPrice, "limit", "l", "", "Limit price (required unless --peg is set)")
+	buyCmd.Flags().StringVar(&buyParams.peg.reference, "peg", "", "Set the limit from the quote: bid, ask, mid, or last")
+	buyCmd.Flags().StringVar(&buyParams.peg.offset, "offset", "", "Dollars added to the --peg reference (e.g. -0.05)")
 	buyCmd.Flags().StringVarP(&buyParams.expiration, "expiration", "e", "DAY", "Order expiration: DAY (default) or GTC")
+	buyCmd.Flags().BoolVar(&buyParams.noPreflight, "no-preflight", false, "Skip the preflight cost estimate")
+	buyCmd.Flags().StringVar(&buyReason, "reason", "", "Why you are placing the order; shown in the preview and recorded in the trade journal")
+	buyCmd.Flags().Float64Var(&buyParams.risk.maxRisk, "max-risk", 0, "Reject the order if its maximum loss exceeds this dollar amount")
+	buyCmd.Flags().IntVar(&buyParams.risk.maxContracts, "max-contracts", 0, "Reject the order if it is for more contracts than this (overrides max_contracts)")
+	buyCmd.Flags().BoolVar(&buyParams.risk.force, "force", false, "Place the order even if it exceeds --max-risk, --max-contracts, or max_contracts, or trading is halted")
 	buyCmd.Flags().BoolVar(&buyOpen, "open", false, "Buy to open a new position")
 	buyCmd.Flags().BoolVar(&buyClose, "close", false, "Buy to close an existing short position")
 	buyCmd.Flags().BoolVarP(&buySkipConfirm, "yes", "y", false, "Skip confirmation prompt")
@@ -1415,7 +3985,9 @@ Examples:
 
 	// Single-leg options sell command
 	var sellAccountID string
+	var sellOrderID string
 	var sellParams singleLegParams
+	var sellReason string
 	var sellSkipConfirm bool
 	var sellOpen bool
 	var sellClose bool
@@ -1427,11 +3999,25 @@ Examples:
 
 The symbol should be in OCC format (e.g., AAPL250117C00175000).
 You must specify whether you are opening or closing a position with --open or --close.
+Use --no-preflight to skip the cost estimate and the market quote; the preview
+then shows only the order parameters, and buying-power problems surface only
+when the order is rejected. Use --max-risk to reject orders whose maximum
+loss (the debit for buys, the short payoff for opening sells) exceeds a cap.
+
+Orders for more contracts than max_contracts in the config are rejected;
+--max-contracts N sets the cap for one order and --force skips it.
+Orders on a halted contract or underlying are rejected unless --force is set.
+
+Use --peg bid|ask|mid|last instead of --limit to price the order from the
+contract's current quote, plus --offset dollars; --offset alone pegs to the
+bid. The price is rounded to the standard tick ($0.05 under $3, $0.10 above),
+up for sells.
 
 Examples:
   pub options sell AAPL250117C00175000 --quantity 1 --limit 2.50 --close --yes  # Sell to close (exit long)
   pub options sell AAPL250117P00170000 --quantity 1 --limit 1.25 --open --yes   # Sell to open (write option)
-  pub options sell SBUX260220C00100000 -q 8 -l 1.50 --close --yes               # Sell 8 contracts`,
+  pub options sell SBUX260220C00100000 -q 8 -l 1.50 --close --yes               # Sell 8 contracts
+  pub options sell AAPL250117C00175000 -q 1 --peg ask --offset -0.05 --close    # A nickel under the ask`,
 		Args: cobra.ExactArgs(1),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			cfg, err := config.Load(config.ConfigPath())
@@ -1445,14 +4031,24 @@ Examples:
 				return err
 			}
 
-			if sellAccountID == "" {
-				sellAccountID = cfg.AccountUUID
+			sellAccountID, err = resolveAccountID(sellAccountID, cfg.AccountUUID, cmd.InOrStdin())
+			if err != nil {
+				return err
 			}
 
 			opts.baseURL = cfg.APIBaseURL
 			opts.authToken = token
 			opts.accountID = sellAccountID
 			opts.jsonMode = GetJSONMode()
+			opts.reason = sellReason
+			opts.requireReason = cfg.RequireReason
+			opts.promptConfirm = cfg.Trading == config.TradingConfirm
+			opts.confirmPrompt = cfg.ConfirmPrompt
+			opts.journalPath = journalPath()
+			opts.maxContracts = cfg.MaxContracts
+			if err := useOrderID(&opts.newOrderID, sellOrderID); err != nil {
+				return err
+			}
 
 			// Set openClose from flags
 			if sellOpen && sellClose {
@@ -1468,14 +4064,22 @@ Examples:
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfg, _ := config.Load(config.ConfigPath())
-			return runSingleLegOrder(cmd, opts, args[0], "SELL", sellParams, sellSkipConfirm, cfg.TradingEnabled)
+			return runSingleLegOrder(cmd, opts, args[0], "SELL", sellParams, sellSkipConfirm, cfg.TradingEnabled())
 		},
 	}
 
-	sellCmd.Flags().StringVarP(&sellAccountID, "account", "a", "", "Account ID (uses default if not specified)")
+	sellCmd.Flags().StringVarP(&sellAccountID, "account", "a", "", "Account ID (uses default if not specified; - reads it from stdin)")
+	sellCmd.Flags().StringVar(&sellOrderID, "order-id", "", "Client order ID (UUID) to use instead of a random one")
 	sellCmd.Flags().StringVarP(&sellParams.quantity, "quantity", "q", "", "Number of contracts (required)")
-	sellCmd.Flags().StringVarP(&sellParams.limitPrice, "limit", "l", "", "Limit price (required)")
+	sellCmd.Flags().StringVarP(&sellParams.limitPrice, "limit", "l", "", "Limit price (required unless --peg is set)")
+	sellCmd.Flags().StringVar(&sellParams.peg.reference, "peg", "", "Set the limit from the quote: bid, ask, mid, or last")
+	sellCmd.Flags().StringVar(&sellParams.peg.offset, "offset", "", "Dollars added to the --peg reference (e.g. -0.05)")
 	sellCmd.Flags().StringVarP(&sellParams.expiration, "expiration", "e", "DAY", "Order expiration: DAY (default) or GTC")
+	sellCmd.Flags().BoolVar(&sellParams.noPreflight, "no-preflight", false, "Skip the preflight cost estimate")
+	sellCmd.Flags().StringVar(&sellReason, "reason", "", "Why you are placing the order; shown in the preview and recorded in the trade journal")
+	sellCmd.Flags().Float64Var(&sellParams.risk.maxRisk, "max-risk", 0, "Reject the order if its maximum loss exceeds this dollar amount")
+	sellCmd.Flags().IntVar(&sellParams.risk.maxContracts, "max-contracts", 0, "Reject the order if it is for more contracts than this (overrides max_contracts)")
+	sellCmd.Flags().BoolVar(&sellParams.risk.force, "force", false, "Place the order even if it exceeds --max-risk, --max-contracts, or max_contracts, or trading is halted")
 	sellCmd.Flags().BoolVar(&sellOpen, "open", false, "Sell to open a new short position")
 	sellCmd.Flags().BoolVar(&sellClose, "close", false, "Sell to close an existing long position")
 	sellCmd.Flags().BoolVarP(&sellSkipConfirm, "yes", "y", false, "Skip confirmation prompt")
@@ -1484,6 +4088,8 @@ Examples:
 	optionsCmd.AddCommand(expirationsCmd)
 	optionsCmd.AddCommand(chainCmd)
 	optionsCmd.AddCommand(greeksCmd)
+	optionsCmd.AddCommand(assignmentCmd)
+	optionsCmd.AddCommand(analyzeCmd)
 	optionsCmd.AddCommand(multilegCmd)
 	optionsCmd.AddCommand(buyCmd)
 	optionsCmd.AddCommand(sellCmd)
@@ -0,0 +1,311 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jonandersen/public-cli/internal/analytics"
+	"github.com/jonandersen/public-cli/internal/api"
+)
+
+// analyzeParams holds the flags of the options analyze command.
+type analyzeParams struct {
+	legs        []string
+	limit       string
+	netDebit    string
+	netCredit   string
+	quantity    string
+	probability bool
+}
+
+// analyzeLegResult is one leg of 'pub options analyze --json'. Delta and
+// ProbabilityITM are set with --probability.
+type analyzeLegResult struct {
+	Side           string   `json:"side"`
+	Symbol         string   `json:"symbol"`
+	Ratio          int      `json:"ratio"`
+	Delta          string   `json:"delta,omitempty"`
+	ProbabilityITM *float64 `json:"probabilityItm,omitempty"`
+}
+
+// analyzeResult is printed by 'pub options analyze --json'. Dollar amounts
+// cover the whole position; an unlimited profit or loss is null with the
+// matching flag set.
+type analyzeResult struct {
+	Quantity            string             `json:"quantity"`
+	Net                 string             `json:"net,omitempty"` // DEBIT or CREDIT
+	Price               string             `json:"price"`
+	Legs                []analyzeLegResult `json:"legs"`
+	MaxProfit           *float64           `json:"maxProfit"`
+	MaxProfitUnlimited  bool               `json:"maxProfitUnlimited,omitempty"`
+	MaxLoss             *float64           `json:"maxLoss"`
+	MaxLossUnlimited    bool               `json:"maxLossUnlimited,omitempty"`
+	Breakevens          []float64          `json:"breakevens"`
+	ProbabilityOfProfit *float64           `json:"probabilityOfProfit,omitempty"`
+	// Method describes how the probabilities were estimated.
+	Method string `json:"method,omitempty"`
+}
+
+// probabilityMethod labels delta-based estimates in JSON output.
+const probabilityMethod = "delta-approximation"
+
+// newOptionsAnalyzeCmd creates the options analyze command with the given options.
+func newOptionsAnalyzeCmd(opts optionsOptions) *cobra.Command {
+	var params analyzeParams
+
+	cmd := &cobra.Command{
+		Use:   "analyze",
+		Short: "Analyze the payoff of an options strategy",
+		Long: `Analyze an options strategy at expiration: max profit, max loss, and
+breakevens. Legs use the multileg order format, so a strategy can be checked
+here and then placed with 'pub options multileg order'. Nothing is submitted.
+
+Price the strategy with one of --limit, --net-debit, or --net-credit, as for
+a multileg order.
+
+--probability fetches each leg's delta and uses it as the chance that leg
+finishes in the money, then estimates the probability of profit for the
+whole strategy. This is an approximation: delta is only a proxy for the
+risk-neutral probability, and prices beyond the outermost strikes are
+extrapolated from implied volatility. It is not a forecast.
+
+Examples:
+  pub options analyze \
+    --leg "BUY AAPL250117C00175000 OPEN" \
+    --leg "SELL AAPL250117C00180000 OPEN" \
+    --net-debit 2.00
+  pub options analyze \
+    --leg "BUY AAPL250117P00160000 OPEN" --leg "SELL AAPL250117P00165000 OPEN" \
+    --leg "SELL AAPL250117C00185000 OPEN" --leg "BUY AAPL250117C00190000 OPEN" \
+    --net-credit 1.20 --probability`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if params.probability && opts.accountID == "" {
+				return fmt.Errorf("account ID is required for --probability (use --account flag or configure default account)")
+			}
+			return runOptionsAnalyze(cmd, opts, params)
+		},
+	}
+
+	addOptionsAnalyzeFlags(cmd, &params)
+	cmd.SilenceUsage = true
+
+	return cmd
+}
+
+// addOptionsAnalyzeFlags registers the flags of the analyze command.
+func addOptionsAnalyzeFlags(cmd *cobra.Command, params *analyzeParams) {
+	cmd.Flags().StringArrayVarP(&params.legs, "leg", "l", nil, "Leg: 'SIDE SYMBOL OPEN|CLOSE [RATIO]' (repeatable, 1-6 legs)")
+	cmd.Flags().StringVar(&params.limit, "limit", "", "Net price per share; debit or credit is inferred from the legs")
+	cmd.Flags().StringVar(&params.netDebit, "net-debit", "", "Net price per share paid to open the strategy")
+	cmd.Flags().StringVar(&params.netCredit, "net-credit", "", "Net price per share received to open the strategy")
+	cmd.Flags().StringVarP(&params.quantity, "quantity", "q", "1", "Number of strategy units")
+	cmd.Flags().BoolVar(&params.probability, "probability", false, "Estimate per-leg and net probability of profit from delta (approximation)")
+}
+
+func runOptionsAnalyze(cmd *cobra.Command, opts optionsOptions, params analyzeParams) error {
+	if len(params.legs) == 0 {
+		return fmt.Errorf("at least one --leg is required")
+	}
+	if len(params.legs) > 6 {
+		return fmt.Errorf("strategies support at most 6 legs")
+	}
+	legs := make([]api.MultilegLeg, 0, len(params.legs))
+	for _, legStr := range params.legs {
+		leg, err := parseLeg(legStr)
+		if err != nil {
+			return err
+		}
+		if leg.Instrument.Type != "OPTION" {
+			return fmt.Errorf("invalid --leg %q: analyze supports option legs only", legStr)
+		}
+		legs = append(legs, leg)
+	}
+
+	qty, err := strconv.Atoi(params.quantity)
+	if err != nil || qty < 1 {
+		return fmt.Errorf("invalid --quantity %q: must be a positive whole number", params.quantity)
+	}
+	price, net, err := multilegLimit(params.limit, params.netDebit, params.netCredit)
+	if err != nil {
+		return err
+	}
+	limit, err := strconv.ParseFloat(price, 64)
+	if err != nil {
+		return fmt.Errorf("invalid --limit %q: must be a price", price)
+	}
+	stated := net
+	if net, err = resolveMultilegNet(legs, price, net); err != nil {
+		return err
+	}
+
+	payoffLegs, _ := multilegPayoffLegs(legs)
+	var premium float64
+	switch stated {
+	case analytics.Debit:
+		premium = -limit
+	case analytics.Credit:
+		premium = limit
+	default:
+		premium = analytics.NetPremium(payoffLegs, limit)
+	}
+
+	units := float64(qty * analytics.ContractMultiplier)
+	result := analyzeResult{
+		Quantity:   params.quantity,
+		Net:        net,
+		Price:      price,
+		Legs:       make([]analyzeLegResult, len(legs)),
+		Breakevens: analytics.Breakevens(payoffLegs, premium),
+	}
+	if result.Breakevens == nil {
+		result.Breakevens = []float64{}
+	}
+	for i := range result.Breakevens {
+		result.Breakevens[i] = math.Round(result.Breakevens[i]*100) / 100
+	}
+	if profit, unlimited := analytics.MaxProfit(payoffLegs, premium); unlimited {
+		result.MaxProfitUnlimited = true
+	} else {
+		profit *= units
+		result.MaxProfit = &profit
+	}
+	if loss, unlimited := analytics.MaxLoss(payoffLegs, premium); unlimited {
+		result.MaxLossUnlimited = true
+	} else {
+		loss *= units
+		result.MaxLoss = &loss
+	}
+	for i, leg := range legs {
+		result.Legs[i] = analyzeLegResult{Side: leg.Side, Symbol: leg.Instrument.Symbol, Ratio: leg.RatioQuantity}
+	}
+
+	if params.probability {
+		if err := addAnalyzeProbability(opts, &result, legs, payoffLegs, premium, time.Now()); err != nil {
+			return err
+		}
+	}
+
+	if opts.jsonMode {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	}
+	printAnalyzeResult(cmd, result)
+	return nil
+}
+
+// addAnalyzeProbability fetches the legs' greeks and fills in the per-leg
+// chance of finishing in the money and the strategy's probability of profit.
+func addAnalyzeProbability(opts optionsOptions, result *analyzeResult, legs []api.MultilegLeg, payoffLegs []analytics.Leg, premium float64, now time.Time) error {
+	symbols := make([]string, 0, len(legs))
+	for _, leg := range legs {
+		symbols = append(symbols, leg.Instrument.Symbol)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	client := api.NewClient(opts.baseURL, opts.authToken)
+	greeksResp, err := client.GetOptionGreeks(ctx, opts.accountID, symbols)
+	if err != nil {
+		return fmt.Errorf("failed to fetch greeks for --probability: %w", err)
+	}
+	greeks := make(map[string]api.GreeksData, len(greeksResp.Greeks))
+	for _, og := range greeksResp.Greeks {
+		greeks[strings.ToUpper(og.Symbol)] = og.Greeks
+	}
+
+	above := make([]analytics.StrikeProbability, 0, len(legs))
+	for i, leg := range legs {
+		g, ok := greeks[leg.Instrument.Symbol]
+		delta, err := strconv.ParseFloat(g.Delta, 64)
+		if !ok || err != nil {
+			return fmt.Errorf("no delta available for %s; --probability needs greeks for every leg", leg.Instrument.Symbol)
+		}
+		osi, _ := analytics.ParseOSI(leg.Instrument.Symbol)
+		itm := analytics.ITMFromDelta(delta)
+		result.Legs[i].Delta = g.Delta
+		result.Legs[i].ProbabilityITM = &itm
+		above = append(above, analytics.StrikeProbability{
+			Strike: osi.Strike,
+			Above:  analytics.AboveFromDelta(osi.Type, delta),
+			StdDev: expirationStdDev(g.ImpliedVolatility, osi.Expiration, now),
+		})
+	}
+
+	pop := analytics.ProbabilityOfProfit(payoffLegs, premium, above)
+	result.ProbabilityOfProfit = &pop
+	result.Method = probabilityMethod
+	return nil
+}
+
+// expirationStdDev returns implied volatility scaled to the time left until
+// expiration, or 0 when the volatility is missing. At least a day is assumed
+// so options expiring today still spread a little.
+func expirationStdDev(impliedVolatility string, expiration, now time.Time) float64 {
+	iv, err := strconv.ParseFloat(impliedVolatility, 64)
+	if err != nil || iv <= 0 {
+		return 0
+	}
+	years := math.Max(1, expiration.Sub(now).Hours()/24) / 365
+	return iv * math.Sqrt(years)
+}
+
+func printAnalyzeResult(cmd *cobra.Command, r analyzeResult) {
+	out := cmd.OutOrStdout()
+	_, _ = fmt.Fprintf(out, "Strategy Analysis (at expiration)\n")
+	_, _ = fmt.Fprintf(out, "%s\n\n", strings.Repeat("-", 40))
+	_, _ = fmt.Fprintf(out, "Quantity:    %s\n", r.Quantity)
+	switch r.Net {
+	case analytics.Debit:
+		_, _ = fmt.Fprintf(out, "Net:         DEBIT $%s (you pay)\n", r.Price)
+	case analytics.Credit:
+		_, _ = fmt.Fprintf(out, "Net:         CREDIT $%s (you receive)\n", r.Price)
+	default:
+		_, _ = fmt.Fprintf(out, "Limit:       $%s\n", r.Price)
+	}
+
+	_, _ = fmt.Fprintf(out, "\nLegs:\n")
+	for _, leg := range r.Legs {
+		_, _ = fmt.Fprintf(out, "  %s %dx %s\n", leg.Side, leg.Ratio, leg.Symbol)
+	}
+
+	_, _ = fmt.Fprintln(out)
+	_, _ = fmt.Fprintf(out, "Max Profit:  %s\n", analyzeAmount(r.MaxProfit))
+	_, _ = fmt.Fprintf(out, "Max Loss:    %s\n", analyzeAmount(r.MaxLoss))
+	breakevens := "none"
+	if len(r.Breakevens) > 0 {
+		prices := make([]string, len(r.Breakevens))
+		for i, b := range r.Breakevens {
+			prices[i] = fmt.Sprintf("%.2f", b)
+		}
+		breakevens = strings.Join(prices, ", ")
+	}
+	_, _ = fmt.Fprintf(out, "Breakevens:  %s\n", breakevens)
+
+	if r.ProbabilityOfProfit == nil {
+		return
+	}
+	_, _ = fmt.Fprintf(out, "\nProbabilities (approximation: delta as the chance of finishing ITM)\n")
+	_, _ = fmt.Fprintf(out, "  %-4s  %-22s  %8s  %7s\n", "Side", "Symbol", "Delta", "P(ITM)")
+	for _, leg := range r.Legs {
+		_, _ = fmt.Fprintf(out, "  %-4s  %-22s  %8s  %6.1f%%\n", leg.Side, leg.Symbol, leg.Delta, *leg.ProbabilityITM*100)
+	}
+	_, _ = fmt.Fprintf(out, "\nProbability of Profit: ~%.1f%% (approximation, not a forecast)\n", *r.ProbabilityOfProfit*100)
+}
+
+// analyzeAmount formats a max profit or loss, nil meaning unlimited.
+func analyzeAmount(amount *float64) string {
+	if amount == nil {
+		return orderRisk{unlimited: true, known: true}.String()
+	}
+	return orderRisk{amount: *amount, known: true}.String()
+}
@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var analyzeBullCall = []string{"BUY AAPL250117C00175000 OPEN", "SELL AAPL250117C00180000 OPEN"}
+
+func TestRunOptionsAnalyze_Payoff(t *testing.T) {
+	cmd := newTestCmd()
+	err := runOptionsAnalyze(cmd, optionsOptions{}, analyzeParams{legs: analyzeBullCall, netDebit: "2.00", quantity: "2"})
+	require.NoError(t, err)
+
+	output := cmd.OutOrStdout().(*bytes.Buffer).String()
+	assert.Contains(t, output, "Net:         DEBIT $2.00 (you pay)")
+	assert.Contains(t, output, "BUY 1x AAPL250117C00175000")
+	assert.Contains(t, output, "Max Profit:  $600.00")
+	assert.Contains(t, output, "Max Loss:    $400.00")
+	assert.Contains(t, output, "Breakevens:  177.00")
+	assert.NotContains(t, output, "Probability")
+}
+
+func TestRunOptionsAnalyze_UnlimitedAndLimit(t *testing.T) {
+	// A naked short call opens for a credit, inferred from a plain --limit
+	cmd := newTestCmd()
+	err := runOptionsAnalyze(cmd, optionsOptions{}, analyzeParams{legs: []string{"SELL AAPL250117C00180000 OPEN"}, limit: "1.50", quantity: "1"})
+	require.NoError(t, err)
+
+	output := cmd.OutOrStdout().(*bytes.Buffer).String()
+	assert.Contains(t, output, "Net:         CREDIT $1.50 (you receive)")
+	assert.Contains(t, output, "Max Profit:  $150.00")
+	assert.Contains(t, output, "Max Loss:    unlimited")
+	assert.Contains(t, output, "Breakevens:  181.50")
+}
+
+func TestRunOptionsAnalyze_Probability(t *testing.T) {
+	var gotSymbols []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/userapigateway/option-details/test-account/greeks", r.URL.Path)
+		gotSymbols = r.URL.Query()["osiSymbols"]
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"greeks": []map[string]any{
+				{"symbol": "AAPL250117C00175000", "greeks": map[string]any{"delta": "0.6000"}},
+				{"symbol": "AAPL250117C00180000", "greeks": map[string]any{"delta": "0.4000"}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	opts := optionsOptions{baseURL: server.URL, authToken: "test-token", accountID: "test-account"}
+	params := analyzeParams{legs: analyzeBullCall, netDebit: "2.00", quantity: "1", probability: true}
+
+	cmd := newTestCmd()
+	require.NoError(t, runOptionsAnalyze(cmd, opts, params))
+	assert.Equal(t, []string{"AAPL250117C00175000", "AAPL250117C00180000"}, gotSymbols)
+
+	output := cmd.OutOrStdout().(*bytes.Buffer).String()
+	assert.Contains(t, output, "approximation: delta as the chance of finishing ITM")
+	assert.Contains(t, output, "BUY   AAPL250117C00175000       0.6000    60.0%")
+	assert.Contains(t, output, "SELL  AAPL250117C00180000       0.4000    40.0%")
+	assert.Contains(t, output, "Probability of Profit: ~52.0% (approximation, not a forecast)")
+
+	opts.jsonMode = true
+	cmd = newTestCmd()
+	require.NoError(t, runOptionsAnalyze(cmd, opts, params))
+
+	var result analyzeResult
+	require.NoError(t, json.Unmarshal(cmd.OutOrStdout().(*bytes.Buffer).Bytes(), &result))
+	assert.Equal(t, "DEBIT", result.Net)
+	require.NotNil(t, result.MaxProfit)
+	assert.InDelta(t, 300, *result.MaxProfit, 1e-9)
+	require.NotNil(t, result.MaxLoss)
+	assert.InDelta(t, 200, *result.MaxLoss, 1e-9)
+	assert.Equal(t, []float64{177}, result.Breakevens)
+	require.Len(t, result.Legs, 2)
+	require.NotNil(t, result.Legs[1].ProbabilityITM)
+	assert.InDelta(t, 0.4, *result.Legs[1].ProbabilityITM, 1e-9)
+	require.NotNil(t, result.ProbabilityOfProfit)
+	assert.InDelta(t, 0.5195, *result.ProbabilityOfProfit, 1e-4)
+	assert.Equal(t, probabilityMethod, result.Method)
+}
+
+func TestRunOptionsAnalyze_ProbabilityMissingDelta(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"greeks": []map[string]any{
+				{"symbol": "AAPL250117C00175000", "greeks": map[string]any{"delta": "0.6000"}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	opts := optionsOptions{baseURL: server.URL, authToken: "test-token", accountID: "test-account"}
+	err := runOptionsAnalyze(newTestCmd(), opts, analyzeParams{legs: analyzeBullCall, netDebit: "2.00", quantity: "1", probability: true})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no delta available for AAPL250117C00180000")
+}
+
+func TestRunOptionsAnalyze_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  analyzeParams
+		wantErr string
+	}{
+		{
+			name:    "no legs",
+			params:  analyzeParams{netDebit: "2.00", quantity: "1"},
+			wantErr: "at least one --leg is required",
+		},
+		{
+			name:    "equity leg",
+			params:  analyzeParams{legs: []string{"BUY AAPL OPEN"}, limit: "2.00", quantity: "1"},
+			wantErr: "analyze supports option legs only",
+		},
+		{
+			name:    "no price",
+			params:  analyzeParams{legs: analyzeBullCall, quantity: "1"},
+			wantErr: "limit price is required",
+		},
+		{
+			name:    "conflicting direction",
+			params:  analyzeParams{legs: analyzeBullCall, netCredit: "2.00", quantity: "1"},
+			wantErr: "--net-credit 2.00 conflicts with the legs, which open for a debit",
+		},
+		{
+			name:    "bad quantity",
+			params:  analyzeParams{legs: analyzeBullCall, netDebit: "2.00", quantity: "0"},
+			wantErr: "invalid --quantity",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runOptionsAnalyze(newTestCmd(), optionsOptions{}, tt.params)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestNewOptionsAnalyzeCmd_ProbabilityNeedsAccount(t *testing.T) {
+	cmd := newOptionsAnalyzeCmd(optionsOptions{})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--leg", analyzeBullCall[0], "--net-debit", "2.00", "--probability"})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "account ID is required for --probability")
+}
+
+func TestExpirationStdDev(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.InDelta(t, 0.25, expirationStdDev("0.25", now.AddDate(0, 0, 365), now), 1e-9)
+	// Expiring today still counts as a day
+	assert.InDelta(t, 0.25/19.105, expirationStdDev("0.25", now, now), 1e-4)
+	assert.Zero(t, expirationStdDev("", now.AddDate(0, 1, 0), now))
+}
@@ -6,13 +6,18 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/jonandersen/public-cli/internal/api"
+	"github.com/jonandersen/public-cli/internal/config"
 )
 
 func TestOptionsExpirationsCmd_Success(t *testing.T) {
@@ -220,6 +225,133 @@ func TestOptionsExpirationsCmd_NoExpirations(t *testing.T) {
 	assert.Contains(t, output, "No expirations")
 }
 
+func newATMIVServer(t *testing.T, failExpiration string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/userapigateway/marketdata/test-account/option-expirations":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"baseSymbol":  "AAPL",
+				"expirations": []string{"2025-01-17", "2025-02-21"},
+			})
+		case "/userapigateway/marketdata/test-account/quotes":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"quotes": []map[string]any{
+					{"instrument": map[string]any{"symbol": "AAPL", "type": "EQUITY"}, "last": "176.20"},
+				},
+			})
+		case "/userapigateway/marketdata/test-account/option-chain":
+			var req map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			exp := req["expirationDate"].(string)
+			if exp == failExpiration {
+				w.WriteHeader(http.StatusTooManyRequests)
+				_, _ = w.Write([]byte("rate limited"))
+				return
+			}
+			code := strings.ReplaceAll(exp[2:], "-", "")
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"baseSymbol": "AAPL",
+				"calls": []map[string]any{
+					{"instrument": map[string]any{"symbol": "AAPL" + code + "C00170000", "type": "OPTION"}},
+					{"instrument": map[string]any{"symbol": "AAPL" + code + "C00175000", "type": "OPTION"}},
+					{"instrument": map[string]any{"symbol": "AAPL" + code + "C00180000", "type": "OPTION"}},
+				},
+			})
+		case "/userapigateway/option-details/test-account/greeks":
+			syms := r.URL.Query()["osiSymbols"]
+			require.Len(t, syms, 1)
+			iv := "0.25"
+			if strings.Contains(syms[0], "250221") {
+				iv = "0.28"
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"greeks": []map[string]any{
+					{"symbol": syms[0], "greeks": map[string]any{"impliedVolatility": iv}},
+				},
+			})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+}
+
+func TestOptionsExpirationsCmd_WithATMIV(t *testing.T) {
+	server := newATMIVServer(t, "")
+	defer server.Close()
+
+	cmd := newOptionsExpirationsCmd(optionsOptions{
+		baseURL:   server.URL,
+		authToken: "test-token",
+		accountID: "test-account",
+	})
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"AAPL", "--with-atm-iv"})
+	require.NoError(t, cmd.Execute())
+
+	output := out.String()
+	assert.Contains(t, output, "ATM IV")
+	assert.Regexp(t, `2025-01-17\s+-?\d+\s+175\.00\s+25\.0%`, output)
+	assert.Regexp(t, `2025-02-21\s+-?\d+\s+175\.00\s+28\.0%`, output)
+	assert.NotContains(t, output, "unavailable")
+}
+
+func TestOptionsExpirationsCmd_WithATMIV_JSON(t *testing.T) {
+	server := newATMIVServer(t, "")
+	defer server.Close()
+
+	cmd := newOptionsExpirationsCmd(optionsOptions{
+		baseURL:   server.URL,
+		authToken: "test-token",
+		accountID: "test-account",
+		jsonMode:  true,
+	})
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"AAPL", "--with-atm-iv"})
+	require.NoError(t, cmd.Execute())
+
+	var result struct {
+		BaseSymbol  string            `json:"baseSymbol"`
+		Last        float64           `json:"last"`
+		Expirations []expirationATMIV `json:"expirations"`
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &result))
+	assert.Equal(t, "AAPL", result.BaseSymbol)
+	assert.Equal(t, 176.20, result.Last)
+	require.Len(t, result.Expirations, 2)
+	assert.Equal(t, "2025-01-17", result.Expirations[0].Expiration)
+	assert.Equal(t, "AAPL250117C00175000", result.Expirations[0].Symbol)
+	assert.Equal(t, 175.0, result.Expirations[0].Strike)
+	assert.Equal(t, "0.25", result.Expirations[0].IV)
+	assert.Equal(t, "0.28", result.Expirations[1].IV)
+}
+
+func TestOptionsExpirationsCmd_WithATMIV_PartialFailure(t *testing.T) {
+	server := newATMIVServer(t, "2025-02-21")
+	defer server.Close()
+
+	cmd := newOptionsExpirationsCmd(optionsOptions{
+		baseURL:   server.URL,
+		authToken: "test-token",
+		accountID: "test-account",
+	})
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"AAPL", "--with-atm-iv"})
+	require.NoError(t, cmd.Execute())
+
+	output := out.String()
+	assert.Regexp(t, `2025-01-17\s+-?\d+\s+175\.00\s+25\.0%`, output)
+	assert.Regexp(t, `2025-02-21\s+-?\d+\s+-\s+-`, output)
+	assert.Contains(t, output, "IV unavailable for 1 expiration(s)")
+}
+
 func TestOptionsChainCmd_Success(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "/userapigateway/marketdata/test-account/option-chain", r.URL.Path)
@@ -314,6 +446,95 @@ func TestOptionsChainCmd_Success(t *testing.T) {
 	assert.Contains(t, output, "5.55")
 }
 
+func TestOptionsChainCmd_Size(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/userapigateway/marketdata/test-account/option-chain", r.URL.Path)
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+
+		// Verify request body
+		var req map[string]any
+		err := json.NewDecoder(r.Body).Decode(&req)
+		require.NoError(t, err)
+
+		inst := req["instrument"].(map[string]any)
+		assert.Equal(t, "AAPL", inst["symbol"])
+		assert.Equal(t, "EQUITY", inst["type"])
+		assert.Equal(t, "2025-01-17", req["expirationDate"])
+
+		resp := map[string]any{
+			"baseSymbol": "AAPL",
+			"calls": []map[string]any{
+				{
+					"instrument": map[string]string{
+						"symbol": "AAPL250117C00175000",
+						"type":   "OPTION",
+					},
+					"outcome":      "SUCCESS",
+					"last":         "5.50",
+					"bid":          "5.45",
+					"bidSize":      50,
+					"ask":          "5.55",
+					"askSize":      100,
+					"volume":       1000,
+					"openInterest": 5000,
+				},
+				{
+					"instrument": map[string]string{
+						"symbol": "AAPL250117C00180000",
+						"type":   "OPTION",
+					},
+					"outcome":      "SUCCESS",
+					"last":         "3.25",
+					"bid":          "3.20",
+					"bidSize":      25,
+					"ask":          "3.30",
+					"askSize":      50,
+					"volume":       500,
+					"openInterest": 2500,
+				},
+			},
+			"puts": []map[string]any{
+				{
+					"instrument": map[string]string{
+						"symbol": "AAPL250117P00175000",
+						"type":   "OPTION",
+					},
+					"outcome":      "SUCCESS",
+					"last":         "4.50",
+					"bid":          "4.45",
+					"bidSize":      30,
+					"ask":          "4.55",
+					"askSize":      75,
+					"volume":       800,
+					"openInterest": 3000,
+				},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	cmd := newOptionsChainCmd(optionsOptions{
+		baseURL:   server.URL,
+		authToken: "test-token",
+		accountID: "test-account",
+	})
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"AAPL", "--expiration", "2025-01-17", "--size"})
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	output := out.String()
+	assert.Contains(t, output, "Bid Size")
+	assert.Contains(t, output, "Ask Size")
+	assert.Regexp(t, `5\.45\s+50\s+5\.55\s+100`, output)
+}
+
 func TestOptionsChainCmd_LowercaseSymbol(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		var req map[string]any
@@ -514,7 +735,7 @@ func TestOptionsChainCmd_EmptyChain(t *testing.T) {
 }
 
 func TestRunMultilegOrder_Success(t *testing.T) {
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	server := httptest.NewServer(stubQuotes(func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, http.MethodPost, r.Method)
 		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
 
@@ -587,7 +808,7 @@ func TestRunMultilegOrder_Success(t *testing.T) {
 	}
 
 	cmd := newTestCmd()
-	err := runMultilegOrder(cmd, opts, legs, "2.50", "1", "DAY", true)
+	err := runMultilegOrder(cmd, opts, legs, "2.50", "", "1", "DAY", true, riskGuard{})
 	require.NoError(t, err)
 
 	output := cmd.OutOrStdout().(*bytes.Buffer).String()
@@ -596,6 +817,55 @@ func TestRunMultilegOrder_Success(t *testing.T) {
 	assert.Contains(t, output, "AAPL")
 }
 
+func TestRunMultilegOrder_Reason(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/userapigateway/trading/test-account/order/multi-leg" {
+			var req api.MultilegOrderRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			_ = json.NewEncoder(w).Encode(api.MultilegOrderResponse{OrderID: req.OrderID})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(api.MultilegPreflightResponse{BaseSymbol: "AAPL", StrategyName: "VERTICAL CALL SPREAD"})
+	}))
+	defer server.Close()
+
+	journal := filepath.Join(t.TempDir(), "journal.jsonl")
+	opts := optionsOptions{
+		baseURL:       server.URL,
+		authToken:     "test-token",
+		accountID:     "test-account",
+		requireReason: true,
+		journalPath:   journal,
+	}
+	legs := []string{"BUY AAPL250117C00175000 OPEN", "SELL AAPL250117C00180000 OPEN"}
+
+	err := runMultilegOrder(newTestCmd(), opts, legs, "2.50", "", "1", "DAY", true, riskGuard{force: true})
+	require.ErrorIs(t, err, config.ErrReasonRequired)
+
+	opts.reason = "earnings play"
+	cmd := newTestCmd()
+	require.NoError(t, runMultilegOrder(cmd, opts, legs, "2.50", "", "1", "DAY", true, riskGuard{force: true}))
+	output := cmd.OutOrStdout().(*bytes.Buffer).String()
+	assert.Contains(t, output, "Reason:      earnings play")
+	assert.Contains(t, output, "  Reason: earnings play")
+
+	data, err := os.ReadFile(journal)
+	require.NoError(t, err)
+	var entry journalEntry
+	require.NoError(t, json.Unmarshal(data, &entry))
+	assert.Equal(t, []string{"BUY 1x AAPL250117C00175000 OPEN", "SELL 1x AAPL250117C00180000 OPEN"}, entry.Legs)
+	assert.Equal(t, "2.50", entry.LimitPrice)
+	assert.Equal(t, "earnings play", entry.Reason)
+
+	opts.jsonMode = true
+	cmd = newTestCmd()
+	require.NoError(t, runMultilegOrder(cmd, opts, legs, "2.50", "", "1", "DAY", true, riskGuard{force: true}))
+	var result MultilegPlacedResult
+	require.NoError(t, json.Unmarshal(cmd.OutOrStdout().(*bytes.Buffer).Bytes(), &result))
+	assert.Equal(t, "earnings play", result.Reason)
+}
+
 func TestRunMultilegOrder_RequiresConfirmation(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Return preflight response
@@ -623,11 +893,119 @@ func TestRunMultilegOrder_RequiresConfirmation(t *testing.T) {
 	}
 
 	cmd := newTestCmd()
-	err := runMultilegOrder(cmd, opts, legs, "2.50", "1", "DAY", false)
+	err := runMultilegOrder(cmd, opts, legs, "2.50", "", "1", "DAY", false, riskGuard{})
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "requires confirmation")
 }
 
+func TestRunMultilegOrder_NetDirection(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(api.MultilegPreflightResponse{BaseSymbol: "AAPL", StrategyName: "VERTICAL CALL SPREAD"})
+	}))
+	defer server.Close()
+
+	opts := optionsOptions{baseURL: server.URL, authToken: "test-token", accountID: "test-account"}
+	bullCall := []string{"BUY AAPL250117C00175000 OPEN", "SELL AAPL250117C00180000 OPEN"}
+	// The same vertical with the sides swapped opens for a credit
+	bearCall := []string{"SELL AAPL250117C00175000 OPEN", "BUY AAPL250117C00180000 OPEN"}
+
+	cmd := newTestCmd()
+	err := runMultilegOrder(cmd, opts, bullCall, "2.50", "DEBIT", "1", "DAY", false, riskGuard{})
+	require.ErrorContains(t, err, "requires confirmation")
+	output := cmd.OutOrStdout().(*bytes.Buffer).String()
+	assert.Contains(t, output, "Net:         DEBIT $2.50 (you pay)")
+	assert.Contains(t, output, "Max Risk:    $250.00")
+
+	cmd = newTestCmd()
+	err = runMultilegOrder(cmd, opts, bearCall, "1.20", "CREDIT", "1", "DAY", false, riskGuard{})
+	require.ErrorContains(t, err, "requires confirmation")
+	output = cmd.OutOrStdout().(*bytes.Buffer).String()
+	assert.Contains(t, output, "Net:         CREDIT $1.20 (you receive)")
+	assert.Contains(t, output, "Max Risk:    $380.00")
+
+	// --limit is unsigned; the label comes from the legs
+	cmd = newTestCmd()
+	err = runMultilegOrder(cmd, opts, bearCall, "1.20", "", "1", "DAY", false, riskGuard{})
+	require.ErrorContains(t, err, "requires confirmation")
+	assert.Contains(t, cmd.OutOrStdout().(*bytes.Buffer).String(), "Net:         CREDIT $1.20")
+
+	before := requests
+	err = runMultilegOrder(newTestCmd(), opts, bearCall, "2.50", "DEBIT", "1", "DAY", true, riskGuard{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--net-debit 2.50 conflicts with the legs, which open for a credit")
+	assert.Contains(t, err.Error(), "use --net-credit")
+
+	err = runMultilegOrder(newTestCmd(), opts, bullCall, "2.50", "CREDIT", "1", "DAY", true, riskGuard{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--net-credit 2.50 conflicts with the legs, which open for a debit")
+	assert.Equal(t, before, requests, "a conflicting order must not reach the API")
+}
+
+func TestRunMultilegOrder_CreditLimitIsNegative(t *testing.T) {
+	var preflightLimit, orderLimit string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(r.URL.Path, "/preflight/") {
+			var req api.MultilegPreflightRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			preflightLimit = req.LimitPrice
+			_ = json.NewEncoder(w).Encode(api.MultilegPreflightResponse{BaseSymbol: "AAPL"})
+			return
+		}
+		var req api.MultilegOrderRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		orderLimit = req.LimitPrice
+		_ = json.NewEncoder(w).Encode(api.MultilegOrderResponse{OrderID: req.OrderID})
+	}))
+	defer server.Close()
+
+	opts := optionsOptions{baseURL: server.URL, authToken: "test-token", accountID: "test-account", jsonMode: true}
+	bearCall := []string{"SELL AAPL250117C00175000 OPEN", "BUY AAPL250117C00180000 OPEN"}
+	bullCall := []string{"BUY AAPL250117C00175000 OPEN", "SELL AAPL250117C00180000 OPEN"}
+
+	require.NoError(t, runMultilegOrder(newTestCmd(), opts, bearCall, "1.20", "CREDIT", "1", "DAY", true, riskGuard{force: true}))
+	assert.Equal(t, "-1.20", preflightLimit)
+	assert.Equal(t, "-1.20", orderLimit)
+
+	// An unsigned --limit on legs that open for a credit is sent the same way
+	require.NoError(t, runMultilegOrder(newTestCmd(), opts, bearCall, "1.20", "", "1", "DAY", true, riskGuard{force: true}))
+	assert.Equal(t, "-1.20", preflightLimit)
+	assert.Equal(t, "-1.20", orderLimit)
+
+	require.NoError(t, runMultilegOrder(newTestCmd(), opts, bullCall, "2.50", "DEBIT", "1", "DAY", true, riskGuard{force: true}))
+	assert.Equal(t, "2.50", preflightLimit)
+	assert.Equal(t, "2.50", orderLimit)
+}
+
+func TestMultilegLimit(t *testing.T) {
+	limit, net, err := multilegLimit("2.50", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, "2.50", limit)
+	assert.Empty(t, net)
+
+	limit, net, err = multilegLimit("", "2.50", "")
+	require.NoError(t, err)
+	assert.Equal(t, "2.50", limit)
+	assert.Equal(t, "DEBIT", net)
+
+	limit, net, err = multilegLimit("", "", "1.20")
+	require.NoError(t, err)
+	assert.Equal(t, "1.20", limit)
+	assert.Equal(t, "CREDIT", net)
+
+	_, _, err = multilegLimit("", "", "")
+	assert.ErrorContains(t, err, "limit price is required")
+	_, _, err = multilegLimit("2.50", "", "1.20")
+	assert.ErrorContains(t, err, "use only one of --limit, --net-debit, or --net-credit")
+	_, _, err = multilegLimit("", "-1.20", "")
+	assert.ErrorContains(t, err, `invalid --net-debit "-1.20": must be a positive price`)
+	_, _, err = multilegLimit("", "", "abc")
+	assert.ErrorContains(t, err, "invalid --net-credit")
+}
+
 func TestRunMultilegOrder_MinimumTwoLegs(t *testing.T) {
 	opts := optionsOptions{
 		baseURL:   "http://localhost",
@@ -640,7 +1018,7 @@ func TestRunMultilegOrder_MinimumTwoLegs(t *testing.T) {
 	}
 
 	cmd := newTestCmd()
-	err := runMultilegOrder(cmd, opts, legs, "2.50", "1", "DAY", true)
+	err := runMultilegOrder(cmd, opts, legs, "2.50", "", "1", "DAY", true, riskGuard{})
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "at least 2 legs")
 }
@@ -663,7 +1041,7 @@ func TestRunMultilegOrder_MaximumSixLegs(t *testing.T) {
 	}
 
 	cmd := newTestCmd()
-	err := runMultilegOrder(cmd, opts, legs, "2.50", "1", "DAY", true)
+	err := runMultilegOrder(cmd, opts, legs, "2.50", "", "1", "DAY", true, riskGuard{})
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "at most 6 legs")
 }
@@ -681,7 +1059,7 @@ func TestRunMultilegOrder_InvalidExpiration(t *testing.T) {
 	}
 
 	cmd := newTestCmd()
-	err := runMultilegOrder(cmd, opts, legs, "2.50", "1", "INVALID", true)
+	err := runMultilegOrder(cmd, opts, legs, "2.50", "", "1", "INVALID", true, riskGuard{})
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid expiration")
 }
@@ -722,7 +1100,7 @@ func TestRunMultilegOrder_JSON(t *testing.T) {
 	}
 
 	cmd := newTestCmd()
-	err := runMultilegOrder(cmd, opts, legs, "2.50", "1", "DAY", true)
+	err := runMultilegOrder(cmd, opts, legs, "2.50", "", "1", "DAY", true, riskGuard{})
 	require.NoError(t, err)
 
 	output := cmd.OutOrStdout().(*bytes.Buffer).String()
@@ -765,7 +1143,7 @@ func TestRunMultilegOrder_APIError(t *testing.T) {
 	}
 
 	cmd := newTestCmd()
-	err := runMultilegOrder(cmd, opts, legs, "2.50", "1", "DAY", true)
+	err := runMultilegOrder(cmd, opts, legs, "2.50", "", "1", "DAY", true, riskGuard{})
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "400")
 	assert.Contains(t, err.Error(), "insufficient buying power")
@@ -863,6 +1241,45 @@ func TestFilterOptions_MinVolume(t *testing.T) {
 	assert.Len(t, result, 2)
 }
 
+func TestFilterOptions_MinBid(t *testing.T) {
+	options := []api.OptionQuote{
+		{Instrument: api.OptionInstrument{Symbol: "AAPL250117P00120000"}, Bid: "0", OpenInterest: 900},
+		{Instrument: api.OptionInstrument{Symbol: "AAPL250117P00125000"}, Bid: "", OpenInterest: 400},
+		{Instrument: api.OptionInstrument{Symbol: "AAPL250117P00130000"}, Bid: "0.03"},
+		{Instrument: api.OptionInstrument{Symbol: "AAPL250117P00135000"}, Bid: "0.05"},
+		{Instrument: api.OptionInstrument{Symbol: "AAPL250117P00140000"}, Bid: "0.45"},
+	}
+
+	// Zero and empty bids are dropped even though they have open interest
+	result := filterOptions(options, chainFilter{minBid: 0.01})
+	require.Len(t, result, 3)
+	assert.Equal(t, "AAPL250117P00130000", result[0].Instrument.Symbol)
+
+	result = filterOptions(options, chainFilter{minBid: 0.05})
+	require.Len(t, result, 2)
+	assert.Equal(t, "AAPL250117P00135000", result[0].Instrument.Symbol)
+
+	// No threshold keeps everything
+	assert.Len(t, filterOptions(options, chainFilter{}), 5)
+}
+
+func TestApplyChainFilter_MinBidAlone(t *testing.T) {
+	calls := []api.OptionQuote{
+		{Instrument: api.OptionInstrument{Symbol: "AAPL250117C00200000"}, Bid: "0.00"},
+		{Instrument: api.OptionInstrument{Symbol: "AAPL250117C00180000"}, Bid: "1.20"},
+	}
+	puts := []api.OptionQuote{
+		{Instrument: api.OptionInstrument{Symbol: "AAPL250117P00140000"}, Bid: ""},
+		{Instrument: api.OptionInstrument{Symbol: "AAPL250117P00170000"}, Bid: "0.85"},
+	}
+
+	calls, puts = applyChainFilter(calls, puts, chainFilter{minBid: 0.01}, 0)
+	require.Len(t, calls, 1)
+	require.Len(t, puts, 1)
+	assert.Equal(t, "AAPL250117C00180000", calls[0].Instrument.Symbol)
+	assert.Equal(t, "AAPL250117P00170000", puts[0].Instrument.Symbol)
+}
+
 func TestFilterOptions_Combined(t *testing.T) {
 	options := []api.OptionQuote{
 		{Instrument: api.OptionInstrument{Symbol: "AAPL250117C00170000"}, Volume: 10, OpenInterest: 50},
@@ -884,58 +1301,382 @@ func TestFilterOptions_Combined(t *testing.T) {
 	assert.Len(t, result, 3)
 }
 
-func TestFilterStrikesAroundATM(t *testing.T) {
-	options := []api.OptionQuote{
-		{Instrument: api.OptionInstrument{Symbol: "AAPL250117C00165000"}}, // idx 0
-		{Instrument: api.OptionInstrument{Symbol: "AAPL250117C00170000"}}, // idx 1
-		{Instrument: api.OptionInstrument{Symbol: "AAPL250117C00175000"}}, // idx 2
-		{Instrument: api.OptionInstrument{Symbol: "AAPL250117C00180000"}}, // idx 3 (ATM)
-		{Instrument: api.OptionInstrument{Symbol: "AAPL250117C00185000"}}, // idx 4
-		{Instrument: api.OptionInstrument{Symbol: "AAPL250117C00190000"}}, // idx 5
-		{Instrument: api.OptionInstrument{Symbol: "AAPL250117C00195000"}}, // idx 6
-	}
+func TestRunOptionsGreeks_WithUnderlying(t *testing.T) {
+	quoteRequests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/userapigateway/option-details/test-account/greeks":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"greeks": []map[string]any{
+					{"symbol": "AAPL250117C00175000", "greeks": map[string]any{"delta": "0.55"}},
+					{"symbol": "AAPL250117P00175000", "greeks": map[string]any{"delta": "-0.45"}},
+					{"symbol": "F250117C00012000", "greeks": map[string]any{"delta": "0.40"}},
+				},
+			})
+		case "/userapigateway/marketdata/test-account/quotes":
+			quoteRequests++
+			var req api.QuoteRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			assert.Equal(t, []api.QuoteInstrument{{Symbol: "AAPL", Type: "EQUITY"}, {Symbol: "F", Type: "EQUITY"}}, req.Instruments)
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"quotes": []map[string]any{
+					{"instrument": map[string]any{"symbol": "AAPL", "type": "EQUITY"}, "outcome": "SUCCESS", "last": "176.20"},
+					{"instrument": map[string]any{"symbol": "F", "type": "EQUITY"}, "outcome": "SUCCESS", "last": "11.85"},
+				},
+			})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
 
-	// Underlying at 180, get 4 strikes (2 below, 2 above ATM at idx 3)
-	// With n=4, half=2: indices 1,2,3,4 -> 170,175,180,185
-	result := filterStrikesAroundATM(options, 4, 180.0)
-	assert.Len(t, result, 4)
-	assert.Equal(t, "AAPL250117C00170000", result[0].Instrument.Symbol)
-	assert.Equal(t, "AAPL250117C00185000", result[3].Instrument.Symbol)
+	symbols := []string{"AAPL250117C00175000", "AAPL250117P00175000", "F250117C00012000"}
+	opts := optionsOptions{baseURL: server.URL, authToken: "test-token", accountID: "test-account", jsonMode: true}
 
-	// Underlying at 177 (closest to 175, idx 2), get 6 strikes
-	// With n=6, half=3: indices -1 to 5 -> adjusted to 0 to 6 -> 165,170,175,180,185,190
-	result = filterStrikesAroundATM(options, 6, 177.0)
-	assert.Len(t, result, 6)
+	cmd := newTestCmd()
+	require.NoError(t, runOptionsGreeks(cmd, opts, symbols, 0, true))
+	assert.Equal(t, 1, quoteRequests)
 
-	// Edge case: underlying at edge (165, idx 0)
-	// With n=4, half=2: indices -2 to 2 -> adjusted to 0 to 4 -> 165,170,175,180
-	result = filterStrikesAroundATM(options, 4, 165.0)
-	assert.Len(t, result, 4)
-	assert.Equal(t, "AAPL250117C00165000", result[0].Instrument.Symbol)
+	var resp struct {
+		Greeks []map[string]any `json:"greeks"`
+	}
+	require.NoError(t, json.Unmarshal(cmd.OutOrStdout().(*bytes.Buffer).Bytes(), &resp))
+	require.Len(t, resp.Greeks, 3)
+	assert.Equal(t, "AAPL250117C00175000", resp.Greeks[0]["symbol"])
+	assert.Equal(t, "AAPL", resp.Greeks[0]["underlying"])
+	assert.Equal(t, "176.20", resp.Greeks[0]["underlyingPrice"])
+	assert.Equal(t, "11.85", resp.Greeks[2]["underlyingPrice"])
+
+	opts.jsonMode = false
+	cmd = newTestCmd()
+	require.NoError(t, runOptionsGreeks(cmd, opts, symbols, 0, true))
+	output := cmd.OutOrStdout().(*bytes.Buffer).String()
+	assert.Contains(t, output, "UNDERLYING")
+	assert.Contains(t, output, "176.20")
+
+	// Without the flag no quotes are fetched
+	cmd = newTestCmd()
+	require.NoError(t, runOptionsGreeks(cmd, opts, symbols, 0, false))
+	assert.Equal(t, 2, quoteRequests)
+	assert.NotContains(t, cmd.OutOrStdout().(*bytes.Buffer).String(), "UNDERLYING")
 }
 
-func TestFilterStrikesAroundATM_SmallList(t *testing.T) {
-	options := []api.OptionQuote{
-		{Instrument: api.OptionInstrument{Symbol: "AAPL250117C00175000"}},
-		{Instrument: api.OptionInstrument{Symbol: "AAPL250117C00180000"}},
+func TestAggregateGreeks(t *testing.T) {
+	positions := []api.Position{
+		{Instrument: api.Instrument{Symbol: "AAPL250117C00175000", Type: "OPTION"}, Quantity: "2"},
+		{Instrument: api.Instrument{Symbol: "AAPL250117P00170000", Type: "OPTION"}, Quantity: "-1"},
+		{Instrument: api.Instrument{Symbol: "F250117C00012000", Type: "OPTION"}, Quantity: "10"},
+		{Instrument: api.Instrument{Symbol: "MSFT250117C00400000", Type: "OPTION"}, Quantity: "1"}, // no greeks
+	}
+	greeks := []api.OptionGreeks{
+		{Symbol: "AAPL250117C00175000", Greeks: api.GreeksData{Delta: "0.50", Gamma: "0.02", Theta: "-0.10", Vega: "0.20"}},
+		{Symbol: "AAPL250117P00170000", Greeks: api.GreeksData{Delta: "-0.30", Gamma: "0.01", Theta: "-0.05", Vega: "0.15"}},
+		{Symbol: "F250117C00012000", Greeks: api.GreeksData{Delta: "0.40", Gamma: "0.10", Theta: "-0.01", Vega: "0.01"}},
 	}
 
-	// Request more strikes than available
-	result := filterStrikesAroundATM(options, 10, 177.5)
-	assert.Len(t, result, 2) // Returns all available
-}
+	underlyings, total, missing := aggregateGreeks(positions, greeks)
+	require.Len(t, underlyings, 2)
+	assert.Equal(t, []string{"MSFT250117C00400000"}, missing)
 
-func TestFilterStrikesAroundATM_Empty(t *testing.T) {
-	result := filterStrikesAroundATM(nil, 10, 180.0)
-	assert.Empty(t, result)
+	assert.Equal(t, "AAPL", underlyings[0].Underlying)
+	// 2 x 100 x 0.50 + -1 x 100 x -0.30
+	assert.InDelta(t, 130.0, underlyings[0].Delta, 0.001)
+	assert.InDelta(t, 3.0, underlyings[0].Gamma, 0.001)
+	assert.InDelta(t, -15.0, underlyings[0].Theta, 0.001)
+	assert.InDelta(t, 25.0, underlyings[0].Vega, 0.001)
 
-	result = filterStrikesAroundATM([]api.OptionQuote{}, 10, 180.0)
-	assert.Empty(t, result)
-}
+	assert.Equal(t, "F", underlyings[1].Underlying)
+	assert.InDelta(t, 400.0, underlyings[1].Delta, 0.001)
 
-// Tests for single-leg options orders
+	assert.InDelta(t, 530.0, total.Delta, 0.001)
+	assert.InDelta(t, -25.0, total.Theta, 0.001)
+}
 
-func TestRunSingleLegOrder_Success(t *testing.T) {
+func TestRunPortfolioGreeks_MissingGreeks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/userapigateway/trading/test-account/portfolio/v2":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"positions": []map[string]any{
+					{"instrument": map[string]any{"symbol": "aapl250117c00175000", "type": "OPTION"}, "quantity": "2"},
+					{"instrument": map[string]any{"symbol": "MSFT250117C00400000", "type": "OPTION"}, "quantity": "1"},
+				},
+			})
+		case "/userapigateway/option-details/test-account/greeks":
+			// MSFT is left out of the response
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"greeks": []map[string]any{
+					{"symbol": "AAPL250117C00175000", "greeks": map[string]any{"delta": "0.50"}},
+				},
+			})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	opts := optionsOptions{baseURL: server.URL, authToken: "test-token", accountID: "test-account"}
+
+	cmd := newTestCmd()
+	var errOut bytes.Buffer
+	cmd.SetErr(&errOut)
+	require.NoError(t, runPortfolioGreeks(cmd, opts, 0))
+	// Symbols match regardless of case
+	assert.Contains(t, cmd.OutOrStdout().(*bytes.Buffer).String(), "100.00")
+	assert.Contains(t, errOut.String(), "Warning: TOTAL leaves out 1 position(s) without greeks: MSFT250117C00400000")
+
+	opts.jsonMode = true
+	cmd = newTestCmd()
+	require.NoError(t, runPortfolioGreeks(cmd, opts, 0))
+	var result struct {
+		Missing []string `json:"missing"`
+	}
+	require.NoError(t, json.Unmarshal(cmd.OutOrStdout().(*bytes.Buffer).Bytes(), &result))
+	assert.Equal(t, []string{"MSFT250117C00400000"}, result.Missing)
+}
+
+func TestFindVerticalSpreads(t *testing.T) {
+	chain := &api.OptionChainResponse{
+		BaseSymbol: "AAPL",
+		Calls: []api.OptionQuote{
+			{Instrument: api.OptionInstrument{Symbol: "AAPL250117C00170000"}, Bid: "6.00", Ask: "6.20"},
+			{Instrument: api.OptionInstrument{Symbol: "AAPL250117C00175000"}, Bid: "3.00", Ask: "3.10"},
+			{Instrument: api.OptionInstrument{Symbol: "AAPL250117C00180000"}, Bid: "1.20", Ask: "1.30"},
+		},
+		Puts: []api.OptionQuote{
+			{Instrument: api.OptionInstrument{Symbol: "AAPL250117P00170000"}, Bid: "1.00", Ask: "1.10"},
+			{Instrument: api.OptionInstrument{Symbol: "AAPL250117P00175000"}, Bid: "2.40", Ask: "2.50"},
+		},
+	}
+
+	spreads := findVerticalSpreads(chain, 5, 1.00)
+	require.Len(t, spreads, 3)
+
+	// Ranked by credit-to-width: 170/175 call (2.90), 175/180 call (1.70), 175/170 put (1.30)
+	assert.Equal(t, "CALL", spreads[0].Type)
+	assert.Equal(t, 170.0, spreads[0].ShortStrike)
+	assert.Equal(t, 175.0, spreads[0].LongStrike)
+	assert.InDelta(t, 2.90, spreads[0].Net, 0.001)
+	assert.InDelta(t, 210.0, spreads[0].MaxRisk, 0.001)
+
+	assert.Equal(t, 175.0, spreads[1].ShortStrike)
+	assert.InDelta(t, 1.70, spreads[1].Net, 0.001)
+
+	assert.Equal(t, "PUT", spreads[2].Type)
+	assert.Equal(t, 175.0, spreads[2].ShortStrike)
+	assert.Equal(t, 170.0, spreads[2].LongStrike)
+	assert.InDelta(t, 1.30, spreads[2].Net, 0.001)
+
+	// A higher minimum drops the smaller credits
+	assert.Len(t, findVerticalSpreads(chain, 5, 2.00), 1)
+	// No strikes are 10 apart with a positive credit requirement met
+	assert.Len(t, findVerticalSpreads(chain, 10, 5.00), 0)
+}
+
+func TestValidateScanFlags(t *testing.T) {
+	newCmd := func() *cobra.Command {
+		cmd := &cobra.Command{Use: "chain"}
+		cmd.Flags().Bool("calls-only", false, "")
+		cmd.Flags().Int("strikes", 0, "")
+		cmd.Flags().Float64("width", 5, "")
+		return cmd
+	}
+
+	cmd := newCmd()
+	require.NoError(t, cmd.Flags().Set("width", "10"))
+	assert.NoError(t, validateScanFlags(cmd))
+
+	cmd = newCmd()
+	require.NoError(t, cmd.Flags().Set("calls-only", "true"))
+	assert.EqualError(t, validateScanFlags(cmd), "--calls-only is not supported with --scan")
+
+	cmd = newCmd()
+	require.NoError(t, cmd.Flags().Set("strikes", "10"))
+	assert.EqualError(t, validateScanFlags(cmd), "--strikes is not supported with --scan")
+}
+
+func TestBuildSpreadGrid(t *testing.T) {
+	chain := []api.OptionQuote{
+		{Instrument: api.OptionInstrument{Symbol: "AAPL250117C00170000"}, Bid: "6.00", Ask: "6.20"},
+		{Instrument: api.OptionInstrument{Symbol: "AAPL250117C00175000"}, Bid: "3.00", Ask: "3.10"},
+		{Instrument: api.OptionInstrument{Symbol: "AAPL250117C00180000"}, Ask: "1.30"},
+	}
+
+	grid := buildSpreadGrid(chain, chain, 5)
+	require.Len(t, grid, 2)
+
+	assert.Equal(t, 170.0, grid[0].LowerStrike)
+	assert.Equal(t, 175.0, grid[0].UpperStrike)
+	require.NotNil(t, grid[0].Credit)
+	assert.InDelta(t, 2.90, *grid[0].Credit, 0.001)
+	assert.InDelta(t, 210.0, *grid[0].CreditMaxRisk, 0.001)
+	require.NotNil(t, grid[0].Debit)
+	assert.InDelta(t, 3.20, *grid[0].Debit, 0.001)
+	assert.InDelta(t, 320.0, *grid[0].DebitMaxRisk, 0.001)
+
+	// The 180 call has no bid, so the 175/180 spread can only be priced as a credit
+	assert.Equal(t, "AAPL250117C00180000", grid[1].UpperSymbol)
+	require.NotNil(t, grid[1].Credit)
+	assert.InDelta(t, 1.70, *grid[1].Credit, 0.001)
+	assert.Nil(t, grid[1].Debit)
+	assert.Nil(t, grid[1].DebitMaxRisk)
+
+	// Rows come from the filtered list, partners from the full chain
+	grid = buildSpreadGrid(chain[1:2], chain, 5)
+	require.Len(t, grid, 1)
+	assert.Equal(t, 175.0, grid[0].LowerStrike)
+
+	assert.Empty(t, buildSpreadGrid(chain, chain, 2.5))
+}
+
+func TestRunOptionsChain_SpreadWidth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"baseSymbol": "AAPL",
+			"calls": []map[string]any{
+				{"instrument": map[string]any{"symbol": "AAPL250117C00170000", "type": "OPTION"}, "bid": "6.00", "ask": "6.20"},
+				{"instrument": map[string]any{"symbol": "AAPL250117C00175000", "type": "OPTION"}, "bid": "3.00", "ask": "3.10"},
+				{"instrument": map[string]any{"symbol": "AAPL250117C00180000", "type": "OPTION"}, "ask": "1.30"},
+			},
+			"puts": []map[string]any{},
+		})
+	}))
+	defer server.Close()
+
+	opts := optionsOptions{baseURL: server.URL, authToken: "test-token", accountID: "test-account"}
+
+	cmd := newTestCmd()
+	require.NoError(t, runOptionsChain(cmd, opts, "AAPL", "2025-01-17", chainFilter{}, chainView{spreadWidth: 5}))
+	output := cmd.OutOrStdout().(*bytes.Buffer).String()
+	assert.Contains(t, output, "Call Verticals for AAPL - Expiration: 2025-01-17 - Width: 5")
+	assert.Regexp(t, `(?m)^170\s+175\s+2\.90\s+210\.00\s+3\.20\s+320\.00$`, output)
+	assert.Regexp(t, `(?m)^175\s+180\s+1\.70\s+330\.00\s+-\s+-$`, output)
+	assert.NotContains(t, output, "CALLS")
+
+	opts.jsonMode = true
+	cmd = newTestCmd()
+	require.NoError(t, runOptionsChain(cmd, opts, "AAPL", "2025-01-17", chainFilter{minStrike: 175}, chainView{spreadWidth: 5}))
+	var rows []spreadGridRow
+	require.NoError(t, json.Unmarshal(cmd.OutOrStdout().(*bytes.Buffer).Bytes(), &rows))
+	require.Len(t, rows, 1)
+	assert.Equal(t, "AAPL250117C00175000", rows[0].LowerSymbol)
+	assert.Nil(t, rows[0].Debit)
+}
+
+func TestValidateSpreadWidth(t *testing.T) {
+	assert.NoError(t, validateSpreadWidth(chainView{}, false, true))
+	assert.NoError(t, validateSpreadWidth(chainView{spreadWidth: 5}, true, false))
+	assert.ErrorContains(t, validateSpreadWidth(chainView{spreadWidth: 0}, true, false), "invalid --spread-width")
+	assert.ErrorContains(t, validateSpreadWidth(chainView{spreadWidth: 5}, true, true), "--puts-only")
+	assert.ErrorContains(t, validateSpreadWidth(chainView{spreadWidth: 5, breakeven: true}, true, false), "cannot be combined")
+}
+
+func TestFilterStrikesAroundATM(t *testing.T) {
+	options := []api.OptionQuote{
+		{Instrument: api.OptionInstrument{Symbol: "AAPL250117C00165000"}}, // idx 0
+		{Instrument: api.OptionInstrument{Symbol: "AAPL250117C00170000"}}, // idx 1
+		{Instrument: api.OptionInstrument{Symbol: "AAPL250117C00175000"}}, // idx 2
+		{Instrument: api.OptionInstrument{Symbol: "AAPL250117C00180000"}}, // idx 3 (ATM)
+		{Instrument: api.OptionInstrument{Symbol: "AAPL250117C00185000"}}, // idx 4
+		{Instrument: api.OptionInstrument{Symbol: "AAPL250117C00190000"}}, // idx 5
+		{Instrument: api.OptionInstrument{Symbol: "AAPL250117C00195000"}}, // idx 6
+	}
+
+	// Underlying at 180, get 4 strikes (2 below, 2 above ATM at idx 3)
+	// With n=4, half=2: indices 1,2,3,4 -> 170,175,180,185
+	result := filterStrikesAroundATM(options, 4, 180.0)
+	assert.Len(t, result, 4)
+	assert.Equal(t, "AAPL250117C00170000", result[0].Instrument.Symbol)
+	assert.Equal(t, "AAPL250117C00185000", result[3].Instrument.Symbol)
+
+	// Underlying at 177 (closest to 175, idx 2), get 6 strikes
+	// With n=6, half=3: indices -1 to 5 -> adjusted to 0 to 6 -> 165,170,175,180,185,190
+	result = filterStrikesAroundATM(options, 6, 177.0)
+	assert.Len(t, result, 6)
+
+	// Edge case: underlying at edge (165, idx 0)
+	// With n=4, half=2: indices -2 to 2 -> adjusted to 0 to 4 -> 165,170,175,180
+	result = filterStrikesAroundATM(options, 4, 165.0)
+	assert.Len(t, result, 4)
+	assert.Equal(t, "AAPL250117C00165000", result[0].Instrument.Symbol)
+}
+
+func TestFilterStrikesAroundATM_SmallList(t *testing.T) {
+	options := []api.OptionQuote{
+		{Instrument: api.OptionInstrument{Symbol: "AAPL250117C00175000"}},
+		{Instrument: api.OptionInstrument{Symbol: "AAPL250117C00180000"}},
+	}
+
+	// Request more strikes than available
+	result := filterStrikesAroundATM(options, 10, 177.5)
+	assert.Len(t, result, 2) // Returns all available
+}
+
+func TestFilterStrikesAroundATM_Empty(t *testing.T) {
+	result := filterStrikesAroundATM(nil, 10, 180.0)
+	assert.Empty(t, result)
+
+	result = filterStrikesAroundATM([]api.OptionQuote{}, 10, 180.0)
+	assert.Empty(t, result)
+}
+
+// Tests for single-leg options orders
+
+func TestRunSingleLegOrder_NoPreflightRequests(t *testing.T) {
+	var quoteRequests, otherRequests int
+	var order map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/quotes") {
+			quoteRequests++
+			_ = json.NewEncoder(w).Encode(map[string]any{"quotes": []map[string]any{{
+				"instrument": map[string]any{"symbol": "AAPL250117C00175000", "type": "OPTION"},
+				"outcome":    "SUCCESS",
+				"bid":        "2.40",
+				"ask":        "2.58",
+			}}})
+			return
+		}
+		otherRequests++
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&order))
+		_ = json.NewEncoder(w).Encode(map[string]any{"orderId": order["orderId"]})
+	}))
+	defer server.Close()
+
+	opts := optionsOptions{baseURL: server.URL, authToken: "test-token", accountID: "test-account"}
+	params := singleLegParams{quantity: "1", expiration: "DAY", openClose: "OPEN", noPreflight: true, limitPrice: "2.50"}
+
+	// The halt check is the only quote request; the preview has no market block
+	cmd := newTestCmd()
+	require.NoError(t, runSingleLegOrder(cmd, opts, "AAPL250117C00175000", "BUY", params, true, true))
+	assert.Equal(t, 1, quoteRequests)
+	assert.Equal(t, 1, otherRequests, "only the order itself")
+	assert.NotContains(t, cmd.OutOrStdout().(*bytes.Buffer).String(), "Market:")
+
+	// --peg reuses the halt check's quote
+	quoteRequests, otherRequests = 0, 0
+	params.limitPrice, params.peg = "", pegSpec{reference: "mid"}
+	require.NoError(t, runSingleLegOrder(newTestCmd(), opts, "AAPL250117C00175000", "BUY", params, true, true))
+	assert.Equal(t, 1, quoteRequests)
+	assert.Equal(t, 1, otherRequests)
+	assert.Equal(t, "2.45", order["limitPrice"])
+
+	// With --force and no --peg nothing is quoted at all
+	quoteRequests, otherRequests = 0, 0
+	params.limitPrice, params.peg = "2.50", pegSpec{}
+	params.risk.force = true
+	require.NoError(t, runSingleLegOrder(newTestCmd(), opts, "AAPL250117C00175000", "BUY", params, true, true))
+	assert.Zero(t, quoteRequests)
+	assert.Equal(t, 1, otherRequests)
+}
+
+func TestRunSingleLegOrder_Success(t *testing.T) {
+	quoteRequests := 0
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, http.MethodPost, r.Method)
 		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
@@ -990,6 +1731,29 @@ func TestRunSingleLegOrder_Success(t *testing.T) {
 			return
 		}
 
+		if r.URL.Path == "/userapigateway/marketdata/test-account/quotes" {
+			quoteRequests++
+			var req api.QuoteRequest
+			err := json.NewDecoder(r.Body).Decode(&req)
+			require.NoError(t, err)
+			// One request for the halt check and the preview: the contract
+			// and its underlying
+			require.Len(t, req.Instruments, 2)
+			assert.Equal(t, "OPTION", req.Instruments[0].Type)
+			assert.Equal(t, "SBUX", req.Instruments[1].Symbol)
+
+			resp := api.QuotesResponse{Quotes: []api.Quote{{
+				Instrument: req.Instruments[0],
+				Outcome:    "SUCCESS",
+				Bid:        "1.40",
+				Ask:        "1.55",
+				Last:       "1.48",
+			}}}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(resp)
+			return
+		}
+
 		t.Errorf("unexpected path: %s", r.URL.Path)
 	}))
 	defer server.Close()
@@ -1014,6 +1778,10 @@ func TestRunSingleLegOrder_Success(t *testing.T) {
 
 	output := cmd.OutOrStdout().(*bytes.Buffer).String()
 	assert.Contains(t, output, "Order placed successfully")
+	assert.Contains(t, output, "Ask:          $1.55")
+	assert.Contains(t, output, "Spread:       $0.15")
+	assert.NotContains(t, output, "Warning")
+	assert.Equal(t, 1, quoteRequests)
 	assert.Contains(t, output, "SBUX260220C00100000")
 	assert.Contains(t, output, "BUY")
 	assert.Contains(t, output, "OPEN")
@@ -1092,6 +1860,40 @@ func TestRunSingleLegOrder_RequiresConfirmation(t *testing.T) {
 	assert.Contains(t, err.Error(), "requires confirmation")
 }
 
+func TestRunSingleLegOrder_NoPreflight(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NotContains(t, r.URL.Path, "preflight")
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(api.OrderResponse{OrderID: "order-1"})
+	}))
+	defer server.Close()
+
+	opts := optionsOptions{
+		baseURL:   server.URL,
+		authToken: "test-token",
+		accountID: "test-account",
+	}
+
+	params := singleLegParams{
+		quantity:    "1",
+		limitPrice:  "2.50",
+		expiration:  "DAY",
+		openClose:   "OPEN",
+		noPreflight: true,
+	}
+
+	cmd := newTestCmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	err := runSingleLegOrder(cmd, opts, "AAPL250117C00175000", "BUY", params, true, true)
+	require.NoError(t, err)
+
+	output := out.String()
+	assert.Contains(t, output, "Options Order Preview")
+	assert.NotContains(t, output, "Estimated Cost")
+	assert.Contains(t, output, "Order placed")
+}
+
 func TestRunSingleLegOrder_RequiresQuantity(t *testing.T) {
 	opts := optionsOptions{
 		baseURL:   "http://localhost",
@@ -1272,6 +2074,132 @@ func TestRunSingleLegOrder_APIError(t *testing.T) {
 	assert.Contains(t, err.Error(), "not valid")
 }
 
+func TestLimitThroughMarket(t *testing.T) {
+	quote := api.Quote{Bid: "1.40", Ask: "1.55"}
+
+	tests := []struct {
+		name    string
+		side    string
+		limit   string
+		wantMsg string
+	}{
+		{"buy at ask", "BUY", "1.55", ""},
+		{"buy above ask", "BUY", "1.60", "above the ask"},
+		{"sell at bid", "SELL", "1.40", ""},
+		{"sell below bid", "SELL", "1.30", "below the bid"},
+		{"invalid limit", "BUY", "abc", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := limitThroughMarket(tt.side, tt.limit, quote)
+			if tt.wantMsg == "" {
+				assert.Empty(t, got)
+			} else {
+				assert.Contains(t, got, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestSingleLegRisk(t *testing.T) {
+	tests := []struct {
+		name      string
+		symbol    string
+		side      string
+		openClose string
+		want      string
+	}{
+		{"buy risks debit", "AAPL250117C00175000", "BUY", "OPEN", "$500.00"},
+		{"sell to close adds no risk", "AAPL250117C00175000", "SELL", "CLOSE", "$0.00"},
+		{"short put risks strike minus credit", "AAPL250117P00050000", "SELL", "OPEN", "$9500.00"},
+		{"short call is unlimited", "AAPL250117C00175000", "SELL", "OPEN", "unlimited"},
+		{"bad symbol is unknown", "AAPL", "SELL", "OPEN", "unavailable"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			risk := singleLegRisk(tt.symbol, tt.side, tt.openClose, "2", "2.50")
+			assert.Equal(t, tt.want, risk.String())
+		})
+	}
+}
+
+func TestMultilegRisk(t *testing.T) {
+	parse := func(legStrs ...string) []api.MultilegLeg {
+		var legs []api.MultilegLeg
+		for _, l := range legStrs {
+			leg, err := parseLeg(l)
+			require.NoError(t, err)
+			legs = append(legs, leg)
+		}
+		return legs
+	}
+
+	// Debit vertical: risk is the debit
+	debit := parse("BUY AAPL250117C00175000 OPEN", "SELL AAPL250117C00180000 OPEN")
+	assert.Equal(t, "$250.00", multilegRisk(debit, "2.50", "1").String())
+
+	// Iron condor: risk is width minus credit
+	condor := parse(
+		"SELL AAPL250117P00165000 OPEN",
+		"BUY AAPL250117P00160000 OPEN",
+		"SELL AAPL250117C00185000 OPEN",
+		"BUY AAPL250117C00190000 OPEN",
+	)
+	assert.Equal(t, "$760.00", multilegRisk(condor, "1.20", "2").String())
+
+	// Equity legs are not supported
+	covered := parse("BUY AAPL OPEN 100", "SELL AAPL250117C00180000 OPEN")
+	assert.Equal(t, "unavailable", multilegRisk(covered, "170.00", "1").String())
+}
+
+func TestRiskGuard_Check(t *testing.T) {
+	known := orderRisk{amount: 500, known: true}
+
+	assert.NoError(t, riskGuard{}.check(known))
+	assert.NoError(t, riskGuard{maxRisk: 500}.check(known))
+	assert.NoError(t, riskGuard{maxRisk: 100, force: true}.check(known))
+
+	err := riskGuard{maxRisk: 100}.check(known)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "max risk $500.00 exceeds --max-risk $100.00")
+
+	assert.Error(t, riskGuard{maxRisk: 100}.check(orderRisk{unlimited: true, known: true}))
+	assert.Error(t, riskGuard{maxRisk: 100}.check(orderRisk{}))
+}
+
+func TestRunSingleLegOrder_MaxRiskExceeded(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NotEqual(t, "/userapigateway/trading/test-account/order", r.URL.Path, "order must not be placed")
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(api.OptionsPreflightResponse{EstimatedCost: "500.00"})
+	}))
+	defer server.Close()
+
+	opts := optionsOptions{
+		baseURL:   server.URL,
+		authToken: "test-token",
+		accountID: "test-account",
+	}
+
+	params := singleLegParams{
+		quantity:   "2",
+		limitPrice: "2.50",
+		expiration: "DAY",
+		openClose:  "OPEN",
+		risk:       riskGuard{maxRisk: 300},
+	}
+
+	cmd := newTestCmd()
+	err := runSingleLegOrder(cmd, opts, "AAPL250117C00175000", "BUY", params, true, true)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exceeds --max-risk")
+
+	output := cmd.OutOrStdout().(*bytes.Buffer).String()
+	assert.Contains(t, output, "Max Risk:   $500.00")
+}
+
 func TestSumOptionsFees(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -1348,3 +2276,688 @@ func TestExtractOptionsErrorMessage(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatGreekValue(t *testing.T) {
+	assert.Equal(t, "0.0012345", formatGreekValue("0.0012345", 0))
+	assert.Equal(t, "0.0012", formatGreekValue("0.0012345", 4))
+	assert.Equal(t, "-0.05", formatGreekValue("-0.0512", 2))
+	assert.Equal(t, "", formatGreekValue("", 2))
+
+	assert.NoError(t, validateGreeksPrecision(0))
+	assert.NoError(t, validateGreeksPrecision(6))
+	assert.EqualError(t, validateGreeksPrecision(7), "invalid --precision 7: must be 1-6, or 0 to keep the values as reported")
+	assert.Error(t, validateGreeksPrecision(-1))
+}
+
+func TestParseExpirationRange(t *testing.T) {
+	now := time.Date(2025, 1, 15, 15, 0, 0, 0, time.UTC)
+
+	start, end, err := parseExpirationRange("2025-01-01:2025-03-31", now)
+	require.NoError(t, err)
+	assert.Equal(t, "2025-01-01", start)
+	assert.Equal(t, "2025-03-31", end)
+
+	start, end, err = parseExpirationRange(":2025-03-31", now)
+	require.NoError(t, err)
+	assert.Empty(t, start)
+	assert.Equal(t, "2025-03-31", end)
+
+	_, _, err = parseExpirationRange("2025-01-01", now)
+	assert.Error(t, err)
+	_, _, err = parseExpirationRange("2025-03-31:2025-01-01", now)
+	assert.Error(t, err)
+	_, _, err = parseExpirationRange("01/17/2025:", now)
+	assert.Error(t, err)
+
+	start, end, err = parseExpirationRange("today:+30d", now)
+	require.NoError(t, err)
+	assert.Equal(t, "2025-01-15", start)
+	assert.Equal(t, "2025-02-14", end)
+}
+
+func TestRunOptionsChainRange(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/userapigateway/marketdata/test-account/option-expirations":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"baseSymbol":  "AAPL",
+				"expirations": []string{"2025-03-21", "2025-01-17", "2025-02-21", "2025-06-20"},
+			})
+		case "/userapigateway/marketdata/test-account/option-chain":
+			exp := req["expirationDate"].(string)
+			date := strings.ReplaceAll(exp[2:], "-", "")
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"baseSymbol": "AAPL",
+				"calls": []map[string]any{
+					{"instrument": map[string]any{"symbol": "AAPL" + date + "C00175000", "type": "OPTION"}, "bid": "1.00", "ask": "1.10", "openInterest": 500},
+					{"instrument": map[string]any{"symbol": "AAPL" + date + "C00250000", "type": "OPTION"}, "bid": "0.05", "ask": "0.10", "openInterest": 5},
+				},
+				"puts": []map[string]any{},
+			})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	opts := optionsOptions{baseURL: server.URL, authToken: "test-token", accountID: "test-account", jsonMode: true}
+	cmd := newTestCmd()
+	out := cmd.OutOrStdout().(*bytes.Buffer)
+
+	err := runOptionsChainRange(cmd, opts, "AAPL", "2025-01-01", "2025-03-31", chainFilter{minOI: 100}, chainView{})
+	require.NoError(t, err)
+
+	var chains []expirationChain
+	require.NoError(t, json.Unmarshal(out.Bytes(), &chains))
+	require.Len(t, chains, 3)
+	assert.Equal(t, "2025-01-17", chains[0].Expiration)
+	assert.Equal(t, "2025-02-21", chains[1].Expiration)
+	assert.Equal(t, "2025-03-21", chains[2].Expiration)
+	for _, c := range chains {
+		require.Len(t, c.Calls, 1)
+		assert.Contains(t, c.Calls[0].Instrument.Symbol, "C00175000")
+	}
+
+	// Table output lines the same strike up across expirations
+	opts.jsonMode = false
+	cmd = newTestCmd()
+	out = cmd.OutOrStdout().(*bytes.Buffer)
+	err = runOptionsChainRange(cmd, opts, "AAPL", "2025-01-01", "2025-03-31", chainFilter{minOI: 100}, chainView{})
+	require.NoError(t, err)
+	assert.Regexp(t, `(?s)175\s+2025-01-17.*175\s+2025-02-21.*175\s+2025-03-21`, out.String())
+	assert.NotContains(t, out.String(), "PUTS")
+}
+
+func TestRunOptionsChain_UnderlyingPriceOverride(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/userapigateway/marketdata/test-account/option-chain" {
+			t.Errorf("unexpected path %s (quote should not be fetched)", r.URL.Path)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		calls := []map[string]any{}
+		for _, strike := range []string{"00150000", "00160000", "00170000", "00180000", "00190000"} {
+			calls = append(calls, map[string]any{
+				"instrument": map[string]any{"symbol": "AAPL250117C" + strike, "type": "OPTION"},
+				"bid":        "1.00",
+				"ask":        "1.10",
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"baseSymbol": "AAPL", "calls": calls, "puts": []any{}})
+	}))
+	defer server.Close()
+
+	opts := optionsOptions{baseURL: server.URL, authToken: "test-token", accountID: "test-account", jsonMode: true}
+	cmd := newTestCmd()
+
+	err := runOptionsChain(cmd, opts, "AAPL", "2025-01-17", chainFilter{strikes: 2, underlyingPrice: 151}, chainView{})
+	require.NoError(t, err)
+
+	var resp api.OptionChainResponse
+	require.NoError(t, json.Unmarshal(cmd.OutOrStdout().(*bytes.Buffer).Bytes(), &resp))
+	require.Len(t, resp.Calls, 2)
+	assert.Equal(t, "AAPL250117C00150000", resp.Calls[0].Instrument.Symbol)
+	assert.Equal(t, "AAPL250117C00160000", resp.Calls[1].Instrument.Symbol)
+}
+
+func TestRunOptionsChain_NearCenter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/userapigateway/marketdata/test-account/option-chain" {
+			t.Errorf("unexpected path %s (quote should not be fetched)", r.URL.Path)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		var calls, puts []map[string]any
+		for _, strike := range []string{"00150000", "00160000", "00170000", "00180000", "00190000", "00200000"} {
+			calls = append(calls, map[string]any{"instrument": map[string]any{"symbol": "AAPL250117C" + strike, "type": "OPTION"}})
+			puts = append(puts, map[string]any{"instrument": map[string]any{"symbol": "AAPL250117P" + strike, "type": "OPTION"}})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"baseSymbol": "AAPL", "calls": calls, "puts": puts})
+	}))
+	defer server.Close()
+
+	opts := optionsOptions{baseURL: server.URL, authToken: "test-token", accountID: "test-account", jsonMode: true}
+	symbols := func(quotes []api.OptionQuote) []string {
+		var out []string
+		for _, q := range quotes {
+			out = append(out, q.Instrument.Symbol)
+		}
+		return out
+	}
+
+	// Centered on a level well below an underlying trading near 200
+	cmd := newTestCmd()
+	err := runOptionsChain(cmd, opts, "AAPL", "2025-01-17", chainFilter{near: 2, center: 158, underlyingPrice: 200}, chainView{})
+	require.NoError(t, err)
+
+	var resp api.OptionChainResponse
+	require.NoError(t, json.Unmarshal(cmd.OutOrStdout().(*bytes.Buffer).Bytes(), &resp))
+	assert.Equal(t, []string{"AAPL250117C00150000", "AAPL250117C00160000"}, symbols(resp.Calls))
+	assert.Equal(t, []string{"AAPL250117P00150000", "AAPL250117P00160000"}, symbols(resp.Puts))
+
+	// Combined with a side filter
+	cmd = newTestCmd()
+	err = runOptionsChain(cmd, opts, "AAPL", "2025-01-17", chainFilter{near: 3, center: 181, putsOnly: true}, chainView{})
+	require.NoError(t, err)
+
+	resp = api.OptionChainResponse{}
+	require.NoError(t, json.Unmarshal(cmd.OutOrStdout().(*bytes.Buffer).Bytes(), &resp))
+	assert.Empty(t, resp.Calls)
+	assert.Equal(t, []string{"AAPL250117P00170000", "AAPL250117P00180000", "AAPL250117P00190000"}, symbols(resp.Puts))
+}
+
+func TestValidateNearFilter(t *testing.T) {
+	assert.NoError(t, validateNearFilter(chainFilter{}, false, false))
+	assert.NoError(t, validateNearFilter(chainFilter{near: 4, center: 150}, true, true))
+
+	tests := []struct {
+		name      string
+		filter    chainFilter
+		nearSet   bool
+		centerSet bool
+		wantErr   string
+	}{
+		{"near without center", chainFilter{near: 4}, true, false, "must be used together"},
+		{"center without near", chainFilter{center: 150}, false, true, "must be used together"},
+		{"zero near", chainFilter{center: 150}, true, true, "invalid --near"},
+		{"negative center", chainFilter{near: 4, center: -1}, true, true, "invalid --center"},
+		{"with strikes", chainFilter{near: 4, center: 150, strikes: 10}, true, true, "--strikes and --near"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateNearFilter(tt.filter, tt.nearSet, tt.centerSet)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestOptionBreakeven(t *testing.T) {
+	option := func(symbol, ask string) api.OptionQuote {
+		opt := api.OptionQuote{Ask: ask}
+		opt.Instrument.Symbol = symbol
+		return opt
+	}
+
+	tests := []struct {
+		name string
+		opt  api.OptionQuote
+		want string
+	}{
+		{"call adds ask", option("AAPL250117C00175000", "2.50"), "177.50"},
+		{"put subtracts ask", option("AAPL250117P00172500", "1.25"), "171.25"},
+		{"missing ask", option("AAPL250117C00175000", ""), "-"},
+		{"zero ask", option("AAPL250117P00175000", "0.00"), "-"},
+		{"not an option symbol", option("AAPL", "2.50"), "-"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, optionBreakeven(tt.opt))
+		})
+	}
+}
+
+func TestRunOptionsChain_Breakeven(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"baseSymbol": "AAPL",
+			"calls": []map[string]any{
+				{"instrument": map[string]any{"symbol": "AAPL250117C00175000", "type": "OPTION"}, "bid": "2.40", "ask": "2.50"},
+			},
+			"puts": []map[string]any{
+				{"instrument": map[string]any{"symbol": "AAPL250117P00175000", "type": "OPTION"}, "bid": "1.10", "ask": "1.20"},
+				{"instrument": map[string]any{"symbol": "AAPL250117P00150000", "type": "OPTION"}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	opts := optionsOptions{baseURL: server.URL, authToken: "test-token", accountID: "test-account"}
+
+	cmd := newTestCmd()
+	require.NoError(t, runOptionsChain(cmd, opts, "AAPL", "2025-01-17", chainFilter{}, chainView{}))
+	assert.NotContains(t, cmd.OutOrStdout().(*bytes.Buffer).String(), "Breakeven")
+
+	cmd = newTestCmd()
+	require.NoError(t, runOptionsChain(cmd, opts, "AAPL", "2025-01-17", chainFilter{}, chainView{breakeven: true}))
+	output := cmd.OutOrStdout().(*bytes.Buffer).String()
+	assert.Contains(t, output, "Breakeven")
+	assert.Contains(t, output, "177.50")
+	assert.Contains(t, output, "173.80")
+	assert.Regexp(t, `(?m)^150 .*\s-$`, output)
+}
+
+func TestRunOptionsChain_DeltaNeutralHint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/userapigateway/option-details/test-account/greeks":
+			assert.ElementsMatch(t, []string{"AAPL250117C00175000", "AAPL250117P00175000", "AAPL250117P00150000"}, r.URL.Query()["osiSymbols"])
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"greeks": []map[string]any{
+					{"symbol": "AAPL250117C00175000", "greeks": map[string]any{"delta": "0.52"}},
+					{"symbol": "AAPL250117P00175000", "greeks": map[string]any{"delta": "-0.48"}},
+				},
+			})
+		default:
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"baseSymbol": "AAPL",
+				"calls": []map[string]any{
+					{"instrument": map[string]any{"symbol": "AAPL250117C00175000", "type": "OPTION"}, "bid": "2.40", "ask": "2.50"},
+				},
+				"puts": []map[string]any{
+					{"instrument": map[string]any{"symbol": "AAPL250117P00175000", "type": "OPTION"}, "bid": "1.10", "ask": "1.20"},
+					{"instrument": map[string]any{"symbol": "AAPL250117P00150000", "type": "OPTION"}},
+				},
+			})
+		}
+	}))
+	defer server.Close()
+
+	opts := optionsOptions{baseURL: server.URL, authToken: "test-token", accountID: "test-account"}
+
+	cmd := newTestCmd()
+	require.NoError(t, runOptionsChain(cmd, opts, "AAPL", "2025-01-17", chainFilter{}, chainView{deltaHint: true, contracts: 3}))
+	output := cmd.OutOrStdout().(*bytes.Buffer).String()
+	assert.Contains(t, output, "Hedge Shares")
+	assert.Regexp(t, `(?m)^175 .*\s-156$`, output)
+	assert.Regexp(t, `(?m)^175 .*\s\+144$`, output)
+	assert.Regexp(t, `(?m)^150 .*\s-$`, output)
+	assert.Contains(t, output, "delta-neutralize 3 long contract(s)")
+}
+
+func TestValidateDeltaHint(t *testing.T) {
+	assert.NoError(t, validateDeltaHint(false, 1, false, true))
+	assert.NoError(t, validateDeltaHint(true, 2, true, false))
+	assert.ErrorContains(t, validateDeltaHint(false, 2, true, false), "--contracts requires --delta-neutral-hint")
+	assert.ErrorContains(t, validateDeltaHint(true, 0, true, false), "invalid --contracts")
+	assert.ErrorContains(t, validateDeltaHint(true, 1, false, true), "not supported with --json")
+}
+
+func TestSortChainOptions(t *testing.T) {
+	chain := func() []api.OptionQuote {
+		return []api.OptionQuote{
+			{Instrument: api.OptionInstrument{Symbol: "AAPL250117C00180000"}, Bid: "3.20", Ask: "3.30", Volume: 500, OpenInterest: 9000},
+			{Instrument: api.OptionInstrument{Symbol: "AAPL250117C00170000"}, Bid: "8.00", Ask: "8.50", Volume: 200, OpenInterest: 1000},
+			{Instrument: api.OptionInstrument{Symbol: "AAPL250117C00175000"}, Bid: "5.45", Ask: "5.55", Volume: 1000, OpenInterest: 5000},
+			{Instrument: api.OptionInstrument{Symbol: "AAPL250117C00185000"}, Volume: 10, OpenInterest: 20},
+		}
+	}
+	strikes := func(opts []api.OptionQuote) []float64 {
+		var out []float64
+		for _, o := range opts {
+			out = append(out, parseStrikeFloat(o.Instrument.Symbol))
+		}
+		return out
+	}
+
+	tests := []struct {
+		key  string
+		desc bool
+		want []float64
+	}{
+		{"strike", false, []float64{170, 175, 180, 185}},
+		{"strike", true, []float64{185, 180, 175, 170}},
+		{"", false, []float64{170, 175, 180, 185}},
+		{"volume", false, []float64{185, 170, 180, 175}},
+		{"volume", true, []float64{175, 180, 170, 185}},
+		{"oi", true, []float64{180, 175, 170, 185}},
+		{"spread", false, []float64{175, 180, 170, 185}},
+		{"spread", true, []float64{170, 175, 180, 185}},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s desc=%v", tt.key, tt.desc), func(t *testing.T) {
+			opts := chain()
+			sortChainOptions(opts, tt.key, tt.desc)
+			assert.Equal(t, tt.want, strikes(opts))
+		})
+	}
+}
+
+func TestParseChainSort(t *testing.T) {
+	key, err := parseChainSort("OI")
+	require.NoError(t, err)
+	assert.Equal(t, "oi", key)
+
+	_, err = parseChainSort("delta")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid --sort value")
+}
+
+func TestBuildAssignmentRisks(t *testing.T) {
+	now := time.Date(2025, 1, 10, 15, 0, 0, 0, time.UTC)
+	positions := []api.Position{
+		{Instrument: api.Instrument{Symbol: "AAPL250117C00170000", Type: "OPTION"}, Quantity: "-1"},
+		{Instrument: api.Instrument{Symbol: "AAPL250221C00175000", Type: "OPTION"}, Quantity: "-2"},
+		{Instrument: api.Instrument{Symbol: "AAPL250117P00160000", Type: "OPTION"}, Quantity: "-1"},
+		{Instrument: api.Instrument{Symbol: "AAPL250117C00180000", Type: "OPTION"}, Quantity: "1"},
+		{Instrument: api.Instrument{Symbol: "AAPL", Type: "EQUITY"}, Quantity: "-100"},
+	}
+	quotes := map[string]api.Quote{
+		"AAPL":                {Bid: "179.90", Ask: "180.10"},
+		"AAPL250117C00170000": {Bid: "10.00", Ask: "10.10"},
+		"AAPL250221C00175000": {Bid: "8.00", Ask: "8.40"},
+		"AAPL250117P00160000": {Last: "0.05"},
+	}
+	exDiv := map[string]string{"AAPL": "2025-02-07"}
+
+	risks := buildAssignmentRisks(positions, quotes, exDiv, now)
+	require.Len(t, risks, 3)
+
+	// Deep ITM near expiry with ~$0.05 time value
+	assert.Equal(t, "AAPL250117C00170000", risks[0].Symbol)
+	assert.Equal(t, assignmentRiskHigh, risks[0].Risk)
+	assert.True(t, risks[0].InTheMoney)
+	assert.InDelta(t, 10.0, risks[0].Intrinsic, 0.001)
+	assert.InDelta(t, 0.05, risks[0].Extrinsic, 0.001)
+	assert.Equal(t, 7, risks[0].DTE)
+	assert.Empty(t, risks[0].ExDividend, "ex-div after expiration is ignored")
+
+	// ITM call with ex-dividend before expiration
+	assert.Equal(t, "AAPL250221C00175000", risks[1].Symbol)
+	assert.Equal(t, assignmentRiskHigh, risks[1].Risk)
+	assert.Equal(t, "2025-02-07", risks[1].ExDividend)
+	assert.InDelta(t, 3.2, risks[1].Extrinsic, 0.001)
+
+	// OTM put
+	assert.Equal(t, "AAPL250117P00160000", risks[2].Symbol)
+	assert.Equal(t, assignmentRiskLow, risks[2].Risk)
+	assert.False(t, risks[2].InTheMoney)
+	assert.InDelta(t, 0.05, risks[2].Extrinsic, 0.001)
+}
+
+func TestParseExDividendDates(t *testing.T) {
+	dates, err := parseExDividendDates([]string{"aapl=2025-02-07", "MSFT=2025-02-20"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"AAPL": "2025-02-07", "MSFT": "2025-02-20"}, dates)
+
+	_, err = parseExDividendDates([]string{"AAPL"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "expected SYMBOL=YYYY-MM-DD")
+
+	_, err = parseExDividendDates([]string{"AAPL=02/07/2025"})
+	require.Error(t, err)
+}
+
+func TestRunOptionsAssignmentRisk(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/userapigateway/trading/test-account/portfolio/v2":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"positions": []map[string]any{
+					{"instrument": map[string]any{"symbol": "AAPL250117C00170000", "type": "OPTION"}, "quantity": "-1"},
+				},
+			})
+		case "/userapigateway/marketdata/test-account/quotes":
+			var req map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Len(t, req["instruments"], 2)
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"quotes": []map[string]any{
+					{"instrument": map[string]any{"symbol": "AAPL", "type": "EQUITY"}, "last": "180.00"},
+					{"instrument": map[string]any{"symbol": "AAPL250117C00170000", "type": "OPTION"}, "bid": "10.00", "ask": "10.10"},
+				},
+			})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	opts := optionsOptions{baseURL: server.URL, authToken: "test-token", accountID: "test-account"}
+	cmd := newTestCmd()
+	require.NoError(t, runOptionsAssignmentRisk(cmd, opts, nil))
+
+	output := cmd.OutOrStdout().(*bytes.Buffer).String()
+	assert.Contains(t, output, "AAPL250117C00170000")
+	assert.Contains(t, output, "HIGH")
+	assert.Contains(t, output, "yes")
+}
+
+func TestParseStrikeSpec(t *testing.T) {
+	strike, optType, err := parseStrikeSpec("175c")
+	require.NoError(t, err)
+	assert.Equal(t, 175.0, strike)
+	assert.Equal(t, "CALL", optType)
+
+	strike, optType, err = parseStrikeSpec("172.5P")
+	require.NoError(t, err)
+	assert.Equal(t, 172.5, strike)
+	assert.Equal(t, "PUT", optType)
+
+	for _, bad := range []string{"", "c", "175", "175x", "abcP", "-5c"} {
+		_, _, err := parseStrikeSpec(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func exportStrategyServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/userapigateway/marketdata/test-account/option-chain", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"baseSymbol": "AAPL",
+			"calls": []map[string]any{
+				{"instrument": map[string]any{"symbol": "AAPL250117C00175000", "type": "OPTION"}, "bid": "5.00", "ask": "5.20"},
+				{"instrument": map[string]any{"symbol": "AAPL250117C00180000", "type": "OPTION"}, "bid": "2.50", "ask": "2.70"},
+			},
+			"puts": []map[string]any{},
+		})
+	}))
+}
+
+func TestRunOptionsExportStrategy(t *testing.T) {
+	server := exportStrategyServer(t)
+	defer server.Close()
+
+	opts := optionsOptions{baseURL: server.URL, authToken: "test-token", accountID: "test-account"}
+	spec := strategySpec{buys: []string{"175c"}, sells: []string{"180c"}, quantity: "2"}
+
+	cmd := newTestCmd()
+	require.NoError(t, runOptionsExportStrategy(cmd, opts, "aapl", "2025-01-17", spec))
+
+	output := cmd.OutOrStdout().(*bytes.Buffer).String()
+	assert.Contains(t, output, "Net mid: $2.50")
+	assert.Contains(t, output, `--leg "BUY AAPL250117C00175000 OPEN"`)
+	assert.Contains(t, output, `--leg "SELL AAPL250117C00180000 OPEN"`)
+	assert.Contains(t, output, "--limit 2.50 --quantity 2")
+
+	// JSON emits the structured legs
+	opts.jsonMode = true
+	spec.limit = "2.40"
+	cmd = newTestCmd()
+	require.NoError(t, runOptionsExportStrategy(cmd, opts, "AAPL", "2025-01-17", spec))
+
+	var result exportedStrategy
+	require.NoError(t, json.Unmarshal(cmd.OutOrStdout().(*bytes.Buffer).Bytes(), &result))
+	assert.Equal(t, "2.40", result.LimitPrice)
+	require.Len(t, result.Legs, 2)
+	assert.Equal(t, "BUY", result.Legs[0].Side)
+	assert.Equal(t, "AAPL250117C00175000", result.Legs[0].Symbol)
+	assert.Equal(t, 180.0, result.Legs[1].Strike)
+	assert.Contains(t, result.Command, "--limit 2.40")
+}
+
+func TestRunOptionsExportStrategy_Errors(t *testing.T) {
+	server := exportStrategyServer(t)
+	defer server.Close()
+
+	opts := optionsOptions{baseURL: server.URL, authToken: "test-token", accountID: "test-account"}
+
+	// Strike not listed in the chain
+	err := runOptionsExportStrategy(newTestCmd(), opts, "AAPL", "2025-01-17", strategySpec{buys: []string{"175c"}, sells: []string{"185c"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "AAPL250117C00185000 is not in the AAPL chain")
+
+	// A single leg is not a multileg order
+	err = runOptionsExportStrategy(newTestCmd(), opts, "AAPL", "2025-01-17", strategySpec{buys: []string{"175c"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "at least 2 legs")
+
+	// --execute honours the trading gate
+	err = runOptionsExportStrategy(newTestCmd(), opts, "AAPL", "2025-01-17", strategySpec{buys: []string{"175c"}, sells: []string{"180c"}, execute: true})
+	assert.ErrorIs(t, err, config.ErrTradingDisabled)
+}
+
+func TestParseLeg_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		leg     string
+		problem string
+		try     string
+	}{
+		{"missing open/close", "BUY AAPL250117C00175000", "expected at least 3 fields, got 2", `"BUY AAPL250117C00175000 OPEN"`},
+		{"symbol first", "aapl250117c00175000 sell open", `side must be BUY or SELL, got "aapl250117c00175000"`, `"SELL AAPL250117C00175000 OPEN"`},
+		{"broker shorthand", "STO AAPL250117P00170000 OPEN", `side must be BUY or SELL, got "STO"`, `"SELL AAPL250117P00170000 OPEN"`},
+		{"bad open/close", "BUY AAPL250117C00175000 OPENING", `third field must be OPEN or CLOSE, got "OPENING"`, `"BUY AAPL250117C00175000 OPEN"`},
+		{"bad ratio", "SELL AAPL250117C00180000 OPEN x2", `ratio must be a positive whole number, got "x2"`, `"SELL AAPL250117C00180000 OPEN"`},
+		{"too many fields", "BUY AAPL250117C00175000 OPEN 2 DAY", "expected at most 4 fields, got 5", `"BUY AAPL250117C00175000 OPEN 2"`},
+		{"bad OSI", "BUY AAPL175C OPEN", "AAPL175C is not an OSI option symbol", `"BUY AAPL175C OPEN"`},
+		{"empty", "", "expected at least 3 fields, got 0", `"BUY AAPL250117C00175000 OPEN"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseLeg(tt.leg)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.problem)
+			assert.Contains(t, err.Error(), `expected: "SIDE SYMBOL OPEN|CLOSE [RATIO]"`)
+			assert.Contains(t, err.Error(), "try:      "+tt.try)
+		})
+	}
+}
+
+func TestParseLeg_Valid(t *testing.T) {
+	leg, err := parseLeg("sell aapl250117c00180000 close 2")
+	require.NoError(t, err)
+	assert.Equal(t, "SELL", leg.Side)
+	assert.Equal(t, "AAPL250117C00180000", leg.Instrument.Symbol)
+	assert.Equal(t, "OPTION", leg.Instrument.Type)
+	assert.Equal(t, "CLOSE", leg.OpenCloseIndicator)
+	assert.Equal(t, 2, leg.RatioQuantity)
+
+	leg, err = parseLeg("BUY AAPL OPEN 100")
+	require.NoError(t, err)
+	assert.Equal(t, "EQUITY", leg.Instrument.Type)
+}
+
+func TestPrintMultilegTemplates(t *testing.T) {
+	var out bytes.Buffer
+	printMultilegTemplates(&out)
+
+	output := out.String()
+	assert.Contains(t, output, "Vertical call spread")
+	assert.Contains(t, output, "Iron condor")
+	assert.Contains(t, output, "Long straddle")
+
+	// Every template leg must parse
+	for _, tmpl := range multilegTemplates {
+		for _, l := range tmpl.legs {
+			_, err := parseLeg(l)
+			assert.NoError(t, err, l)
+			assert.Contains(t, output, fmt.Sprintf("--leg %q", l))
+		}
+	}
+}
+
+func TestRunSingleLegOrder_MaxContracts(t *testing.T) {
+	opts := optionsOptions{
+		baseURL:      "http://localhost",
+		authToken:    "test-token",
+		accountID:    "test-account",
+		maxContracts: 10,
+	}
+	params := singleLegParams{
+		quantity:   "25",
+		limitPrice: "2.50",
+		expiration: "DAY",
+		openClose:  "OPEN",
+	}
+
+	err := runSingleLegOrder(newTestCmd(), opts, "AAPL250117C00175000", "BUY", params, true, true)
+	require.Error(t, err)
+	assert.Equal(t, "order of 25 contracts exceeds max_contracts 10 (use --force to override)", err.Error())
+
+	params.risk.maxContracts = 20
+	err = runSingleLegOrder(newTestCmd(), opts, "AAPL250117C00175000", "BUY", params, true, true)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exceeds --max-contracts 20")
+}
+
+func TestRiskGuard_CheckLegContracts(t *testing.T) {
+	legs := []api.MultilegLeg{
+		{Instrument: api.MultilegInstrument{Symbol: "AAPL250117P00165000"}, RatioQuantity: 1},
+		{Instrument: api.MultilegInstrument{Symbol: "AAPL250117P00160000"}, RatioQuantity: 2},
+	}
+	opts := optionsOptions{maxContracts: 10}
+
+	assert.NoError(t, riskGuard{}.checkLegContracts(opts, legs, "3"))
+
+	err := riskGuard{}.checkLegContracts(opts, legs, "6")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "leg AAPL250117P00160000 of 12 contracts exceeds max_contracts 10")
+
+	err = riskGuard{}.checkLegContracts(opts, legs, "4")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "order total across legs of 12 contracts exceeds max_contracts 10")
+
+	assert.NoError(t, riskGuard{force: true}.checkLegContracts(opts, legs, "6"))
+	assert.NoError(t, riskGuard{maxContracts: 20}.checkLegContracts(opts, legs, "6"))
+}
+
+func TestCheckChainAsOf(t *testing.T) {
+	now := time.Date(2025, 1, 15, 15, 0, 0, 0, time.UTC)
+
+	require.NoError(t, checkChainAsOf("", now))
+	require.NoError(t, checkChainAsOf("today", now))
+	require.NoError(t, checkChainAsOf("2025-01-15", now))
+
+	err := checkChainAsOf("2025-01-14", now)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "historical option chains are not supported")
+	assert.Contains(t, err.Error(), "2025-01-14")
+
+	err = checkChainAsOf("-1d", now)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--as-of 2025-01-14")
+
+	err = checkChainAsOf("tomorrow", now)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid --as-of: 2025-01-16 is in the future")
+
+	err = checkChainAsOf("yesterdayish", now)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid --as-of")
+}
+
+func TestOptionsChainCmd_AsOfPastNeverFetchesLiveChain(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL.Path)
+	}))
+	defer server.Close()
+
+	cmd := newOptionsChainCmd(optionsOptions{
+		baseURL:   server.URL,
+		authToken: "test-token",
+		accountID: "test-account",
+	})
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs([]string{"AAPL", "--expiration", "2025-01-17", "--as-of", "2020-06-01"})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "historical option chains are not supported")
+}
@@ -4,8 +4,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -15,6 +21,8 @@ import (
 	"github.com/jonandersen/public-cli/internal/api"
 	"github.com/jonandersen/public-cli/internal/config"
 	"github.com/jonandersen/public-cli/internal/keyring"
+	"github.com/jonandersen/public-cli/internal/money"
+	"github.com/jonandersen/public-cli/internal/output"
 )
 
 // orderOptions holds dependencies for the order command.
@@ -23,7 +31,49 @@ type orderOptions struct {
 	authToken      string
 	accountID      string
 	tradingEnabled bool
+	promptConfirm  bool   // trading_enabled: confirm; orders and cancels are confirmed at a prompt, even with --yes
+	confirmPrompt  string // confirm_prompt from config: the question asked before placing an order; empty uses the default
 	jsonMode       bool
+	wide           bool             // add price and timestamp columns to order tables
+	newOrderID     orderIDGenerator // nil uses random UUIDs
+	retryDelay     time.Duration    // pause between --retry-on-reject attempts; zero uses defaultRetryDelay
+	maxShares      float64          // max_shares from config; 0 disables the cap
+	maxContracts   int              // max_contracts from config, for order submit; 0 disables the cap
+	confirmKey     []byte           // signs --preview-json-then-confirm tokens; the keyring secret
+	color          bool             // highlight changed rows in order list --watch; see GetColorMode
+	requireReason  bool             // require_reason from config: orders need a --reason
+	journalPath    string           // trade journal for orders with a --reason; empty disables it
+}
+
+// defaultRetryDelay is the pause before resubmitting a transiently rejected order.
+const defaultRetryDelay = 2 * time.Second
+
+// maxRetryOnReject caps --retry-on-reject so a persistent halt can't loop for long.
+const maxRetryOnReject = 5
+
+// orderIDGenerator returns client-side order IDs. Production uses random
+// UUIDs; tests and --order-id substitute a fixed ID.
+type orderIDGenerator func() string
+
+// generateOrderID returns the next order ID from gen, or a random UUID if gen is nil.
+func generateOrderID(gen orderIDGenerator) string {
+	if gen == nil {
+		return uuid.New().String()
+	}
+	return gen()
+}
+
+// useOrderID replaces gen with one that returns the user-supplied ID from
+// --order-id. An empty id leaves gen unchanged.
+func useOrderID(gen *orderIDGenerator, id string) error {
+	if id == "" {
+		return nil
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return fmt.Errorf("invalid --order-id %q: must be a UUID", id)
+	}
+	*gen = func() string { return id }
+	return nil
 }
 
 // newOrderCmd creates the parent order command.
@@ -36,6 +86,9 @@ func newOrderCmd() *cobra.Command {
 Examples:
   pub order buy AAPL --quantity 10                              # Buy 10 shares of Apple
   pub order sell AAPL --quantity 5                              # Sell 5 shares of Apple
+  pub order close AAPL --percent 50                             # Sell half the AAPL position
+  pub order estimate AAPL --side BUY --quantity 10              # Estimate cost without ordering
+  pub order submit --input order.json --yes                     # Submit a raw JSON order body
   pub order list                                                # List open orders
   pub order status 912710f1-1a45-4ef0-88a7-cd513781933d         # Check order status
   pub order cancel 912710f1-1a45-4ef0-88a7-cd513781933d --yes   # Cancel an order`,
@@ -46,16 +99,215 @@ Examples:
 
 // orderParams holds the parameters for an order.
 type orderParams struct {
-	quantity   string
-	limitPrice string
-	stopPrice  string
-	expiration string
+	quantity    string
+	limitPrice  string
+	stopPrice   string
+	expiration  string
+	noPreflight bool
+	reduceOnly  bool
+	// retryOnReject resubmits up to this many times after a transient rejection.
+	retryOnReject int
+	// risk sizes the order so a move from entry to stopPrice loses at most this
+	// many dollars; stopPrice is then a protective stop, not an order trigger.
+	risk string
+	// maxShares caps this order's quantity in place of max_shares; force
+	// skips the cap.
+	maxShares float64
+	force     bool
+	// closing marks an order from order close; it only reduces the position,
+	// so the share cap and halt check do not apply.
+	closing bool
+	// peg prices a LIMIT order from a fresh quote instead of --limit.
+	peg pegSpec
+	// instrumentType is sent as the order's instrument type; empty means
+	// EQUITY and auto is resolved against the instrument endpoint.
+	instrumentType string
+	// previewJSON prints the preview and a confirm token instead of placing
+	// the order; confirmToken places a previewed order as is.
+	previewJSON  bool
+	confirmToken string
+	// reason says why the order is placed; it is shown in the preview and
+	// recorded in the trade journal.
+	reason string
+}
+
+// riskSizing is the quantity derived from --risk and --stop.
+type riskSizing struct {
+	entry        float64 // limit price, or the ask when buying at market
+	stop         string
+	quantity     int64
+	riskPerShare float64
+	totalRisk    float64
+}
+
+// sizeByRisk computes the whole-share quantity of a BUY whose loss from entry
+// down to stop stays within risk dollars.
+func sizeByRisk(risk string, entry float64, stop string) (riskSizing, error) {
+	riskAmount, err := strconv.ParseFloat(risk, 64)
+	if err != nil || riskAmount <= 0 {
+		return riskSizing{}, fmt.Errorf("invalid --risk %q: must be a positive dollar amount", risk)
+	}
+	stopPrice, err := strconv.ParseFloat(stop, 64)
+	if err != nil || stopPrice <= 0 {
+		return riskSizing{}, fmt.Errorf("invalid --stop %q: must be a positive price", stop)
+	}
+	if stopPrice >= entry {
+		return riskSizing{}, fmt.Errorf("--stop %s must be below the entry price %.2f for a BUY", stop, entry)
+	}
+
+	perShare := entry - stopPrice
+	quantity := int64(math.Floor(riskAmount / perShare))
+	if quantity < 1 {
+		return riskSizing{}, fmt.Errorf("--risk %s is less than the $%.2f risk of a single share (entry %.2f, stop %s)", risk, perShare, entry, stop)
+	}
+	return riskSizing{
+		entry:        entry,
+		stop:         stop,
+		quantity:     quantity,
+		riskPerShare: perShare,
+		totalRisk:    perShare * float64(quantity),
+	}, nil
+}
+
+// riskEntryPrice returns the expected entry for --risk sizing: the limit
+// price when set, otherwise the ask (or last when there is no ask) from
+// quotes; quoteErr is the error fetching them, if any.
+func riskEntryPrice(quotes []api.Quote, quoteErr error, symbol, limitPrice string) (float64, error) {
+	if limitPrice != "" {
+		entry, err := strconv.ParseFloat(limitPrice, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid --limit %q", limitPrice)
+		}
+		return entry, nil
+	}
+
+	if quoteErr != nil {
+		return 0, fmt.Errorf("--risk: failed to fetch quote: %w", quoteErr)
+	}
+	if len(quotes) > 0 && quotes[0].Outcome == "SUCCESS" {
+		for _, price := range []string{quotes[0].Ask, quotes[0].Last} {
+			if entry, err := strconv.ParseFloat(price, 64); err == nil && entry > 0 {
+				return entry, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("--risk: no price available for %s; pass --limit to set the entry", symbol)
+}
+
+// checkReduceOnly verifies that a side/quantity order against a position of
+// held shares (negative when short) only reduces it: it must trade against
+// the position's direction and for no more than is held.
+func checkReduceOnly(symbol, side, quantity string, held float64) error {
+	qty, err := strconv.ParseFloat(quantity, 64)
+	if err != nil {
+		return fmt.Errorf("invalid quantity %q", quantity)
+	}
+	heldStr := strconv.FormatFloat(math.Abs(held), 'f', -1, 64)
+
+	switch {
+	case held == 0:
+		return fmt.Errorf("--reduce-only: no %s position to reduce; %s %s would open one", symbol, side, quantity)
+	case side == "BUY" && held > 0:
+		return fmt.Errorf("--reduce-only: BUY would increase your long %s position of %s shares", symbol, heldStr)
+	case side == "SELL" && held < 0:
+		return fmt.Errorf("--reduce-only: SELL would increase your short %s position of %s shares", symbol, heldStr)
+	case qty > math.Abs(held):
+		return fmt.Errorf("--reduce-only: %s %s exceeds the %s shares of %s held and would flip the position (use --quantity %s or less)",
+			side, quantity, heldStr, symbol, heldStr)
+	}
+	return nil
+}
+
+// findPosition returns the position in symbol held as instType, or nil. An
+// empty instType matches any type but OPTION, so order close finds ETF, ADR,
+// and crypto positions as well as stock.
+func findPosition(positions []api.Position, symbol, instType string) *api.Position {
+	for i, pos := range positions {
+		if pos.Instrument.Symbol != symbol {
+			continue
+		}
+		if (instType == "" && pos.Instrument.Type != "OPTION") || pos.Instrument.Type == instType {
+			return &positions[i]
+		}
+	}
+	return nil
+}
+
+// validateOrderParams checks an equity order's flags before anything is
+// fetched or sent: the side, how the quantity is given, prices, expiration,
+// retries, and the order qualifiers the API cannot honor. Every message names
+// the flags involved.
+func validateOrderParams(params orderParams, side string) error {
+	switch side {
+	case "BUY", "SELL":
+	case "":
+		return fmt.Errorf("side is required (use --side BUY or --side SELL)")
+	default:
+		return fmt.Errorf("invalid --side %q: must be BUY or SELL", side)
+	}
+
+	switch {
+	case params.risk != "":
+		if params.quantity != "" {
+			return fmt.Errorf("--risk computes the quantity; do not combine it with --quantity")
+		}
+		if params.stopPrice == "" {
+			return fmt.Errorf("--risk requires --stop, the price where you would exit")
+		}
+		if side != "BUY" {
+			return fmt.Errorf("--risk is only supported for buy orders")
+		}
+		if params.reduceOnly {
+			return fmt.Errorf("--risk opens a new position and cannot be combined with --reduce-only")
+		}
+	case params.quantity == "":
+		return fmt.Errorf("quantity is required (use --quantity flag)")
+	default:
+		if qty, err := strconv.ParseFloat(params.quantity, 64); err != nil || qty <= 0 {
+			return fmt.Errorf("invalid --quantity %q: must be a positive number", params.quantity)
+		}
+	}
+
+	for _, price := range []struct{ flag, value string }{
+		{"--limit", params.limitPrice},
+		{"--stop", params.stopPrice},
+	} {
+		if price.value == "" {
+			continue
+		}
+		if p, err := strconv.ParseFloat(price.value, 64); err != nil || p <= 0 {
+			return fmt.Errorf("invalid %s %q: must be a positive price", price.flag, price.value)
+		}
+	}
+
+	if err := params.peg.validate(params.limitPrice); err != nil {
+		return err
+	}
+	if _, err := parseInstrumentType(params.instrumentType); err != nil {
+		return err
+	}
+	if params.previewJSON && params.retryOnReject > 0 {
+		return fmt.Errorf("--preview-json-then-confirm previews a single order; it cannot be combined with --retry-on-reject")
+	}
+
+	if expiration := strings.ToUpper(params.expiration); expiration != "DAY" && expiration != "GTC" {
+		return fmt.Errorf("invalid --expiration %q: use DAY or GTC", params.expiration)
+	}
+	if params.retryOnReject < 0 || params.retryOnReject > maxRetryOnReject {
+		return fmt.Errorf("invalid --retry-on-reject %d: must be between 0 and %d", params.retryOnReject, maxRetryOnReject)
+	}
+	if params.maxShares < 0 {
+		return fmt.Errorf("invalid --max-shares %v: must not be negative", params.maxShares)
+	}
+
+	return nil
 }
 
 // newOrderBuyCmd creates the buy subcommand with the given options.
 func newOrderBuyCmd(opts orderOptions) *cobra.Command {
 	var params orderParams
 	var skipConfirm bool
+	var orderID string
 
 	cmd := &cobra.Command{
 		Use:   "buy SYMBOL",
@@ -68,22 +320,89 @@ Order types are determined by the flags used:
   - --stop: STOP order (triggers when stop price is reached)
   - --limit and --stop: STOP_LIMIT order (triggers at stop, executes at limit)
 
+By default a preflight call estimates the order cost before submitting. Use
+--no-preflight to skip it for lower latency; the preview then shows only the
+order parameters, and buying-power problems surface only when the order is
+rejected.
+
+Use --retry-on-reject N to resubmit with a new order ID when the order is
+rejected for a transient reason such as a momentary trading halt. Rejections
+like insufficient buying power are never retried, and neither are HTTP errors
+such as 429 or 503, since the order may already have been accepted.
+
+Use --peg bid|ask|mid|last to set the limit from a fresh quote instead of
+--limit, plus --offset dollars (negative to improve on it). --offset alone
+pegs to the ask for buys and the bid for sells. The price is rounded to the
+tick (a penny, or $0.0001 under $1) away from paying up: down for buys, up
+for sells. The preview shows the reference and the computed limit.
+
+Use --reduce-only to guarantee the order only shrinks a position you hold: it
+is checked against the portfolio before sending and rejected if it would
+open, increase, or flip the position. The API cannot enforce it itself.
+
+Orders for more shares than max_shares in the config are rejected before
+anything is sent, whatever the price; --max-shares N sets the cap for one
+order and --force skips it.
+
+Orders for a symbol whose quote reports a trading halt are rejected too;
+--force places them anyway. --no-preflight skips the halt check unless --peg
+or --risk fetches a quote regardless.
+
+Orders are sent as EQUITY instruments. Use --instrument-type ETF, ADR, or
+CRYPTO for a symbol the API classifies differently, or --instrument-type auto
+to look the symbol up and use the type the API reports.
+
+For tools that show an order before placing it, --preview-json-then-confirm
+prints the preview (the exact order request, order ID included, and the
+preflight estimate) as JSON with a confirmToken, and places nothing. Running
+the same command with --confirm-token TOKEN and no other order flags places
+that exact order. Tokens are signed with your secret key and expire after 5
+minutes.
+
+Use --risk to size by risk instead of share count. With --risk, --stop is the
+price where you would exit rather than an order trigger: the quantity is
+--risk divided by the per-share risk (entry minus stop), rounded down to whole
+shares. The entry is --limit when set, otherwise the current ask. The stop is
+not placed with the entry, since it would sell shares not yet held if it
+triggered first; once the entry fills, place it with the printed command.
+
 Examples:
   pub order buy AAPL --quantity 10                           # Market order
   pub order buy AAPL --quantity 10 --limit 175.00            # Limit order
   pub order buy AAPL --quantity 10 --stop 180.00             # Stop order
   pub order buy AAPL --quantity 10 --limit 175.00 --stop 174.00  # Stop-limit order
-  pub order buy AAPL --quantity 10 --limit 175.00 --expiration GTC  # Good till cancelled`,
+  pub order buy AAPL --quantity 10 --limit 175.00 --expiration GTC  # Good till cancelled
+  pub order buy AAPL --quantity 10 --peg mid                  # Limit at the midpoint
+  pub order buy AAPL --quantity 10 --peg bid --offset 0.01    # Limit a penny above the bid
+  pub order buy AAPL --quantity 10 --reduce-only                # Cover part of a short
+  pub order buy AAPL --risk 100 --stop 170                      # Lose at most $100 at 170
+  pub order buy AAPL --risk 100 --stop 170 --limit 175          # Same, entering at 175`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := useOrderID(&opts.newOrderID, orderID); err != nil {
+				return err
+			}
 			return runOrder(cmd, opts, args[0], "BUY", params, skipConfirm)
 		},
 	}
 
-	cmd.Flags().StringVarP(&params.quantity, "quantity", "q", "", "Number of shares to buy (required)")
+	cmd.Flags().StringVarP(&params.quantity, "quantity", "q", "", "Number of shares to buy (required unless --risk is set)")
+	cmd.Flags().StringVar(&params.risk, "risk", "", "Dollars to risk; sizes the order from the entry down to --stop")
 	cmd.Flags().StringVarP(&params.limitPrice, "limit", "l", "", "Limit price for LIMIT or STOP_LIMIT orders")
+	cmd.Flags().StringVar(&params.peg.reference, "peg", "", "Set the limit from the quote: bid, ask, mid, or last")
+	cmd.Flags().StringVar(&params.peg.offset, "offset", "", "Dollars added to the --peg reference (e.g. -0.02)")
 	cmd.Flags().StringVarP(&params.stopPrice, "stop", "s", "", "Stop price for STOP or STOP_LIMIT orders")
 	cmd.Flags().StringVarP(&params.expiration, "expiration", "e", "DAY", "Order expiration: DAY (default) or GTC")
+	cmd.Flags().StringVar(&params.instrumentType, "instrument-type", "EQUITY", "Instrument type: EQUITY, ETF, ADR, CRYPTO, or auto to look it up")
+	cmd.Flags().BoolVar(&params.noPreflight, "no-preflight", false, "Skip the preflight cost estimate")
+	cmd.Flags().BoolVar(&params.previewJSON, "preview-json-then-confirm", false, "Print the preview and a confirm token as JSON instead of placing the order")
+	cmd.Flags().StringVar(&params.confirmToken, "confirm-token", "", "Place the order previewed with --preview-json-then-confirm")
+	cmd.Flags().StringVar(&params.reason, "reason", "", "Why you are placing the order; shown in the preview and recorded in the trade journal")
+	cmd.Flags().BoolVar(&params.reduceOnly, "reduce-only", false, "Only reduce an existing position; reject orders that would increase or flip it")
+	cmd.Flags().Float64Var(&params.maxShares, "max-shares", 0, "Reject the order if it is for more shares than this (overrides max_shares)")
+	cmd.Flags().BoolVar(&params.force, "force", false, "Place the order even if it exceeds --max-shares or max_shares, or the symbol is halted")
+	cmd.Flags().IntVar(&params.retryOnReject, "retry-on-reject", 0, "Resubmit up to N times if the order is rejected for a transient reason")
+	cmd.Flags().StringVar(&orderID, "order-id", "", "Client order ID (UUID) to use instead of a random one")
 	cmd.Flags().BoolVarP(&skipConfirm, "yes", "y", false, "Skip confirmation prompt")
 	cmd.SilenceUsage = true
 
@@ -94,6 +413,7 @@ Examples:
 func newOrderSellCmd(opts orderOptions) *cobra.Command {
 	var params orderParams
 	var skipConfirm bool
+	var orderID string
 
 	cmd := &cobra.Command{
 		Use:   "sell SYMBOL",
@@ -106,47 +426,248 @@ Order types are determined by the flags used:
   - --stop: STOP order (triggers when stop price is reached)
   - --limit and --stop: STOP_LIMIT order (triggers at stop, executes at limit)
 
+By default a preflight call estimates the order cost before submitting. Use
+--no-preflight to skip it for lower latency; the preview then shows only the
+order parameters, and buying-power problems surface only when the order is
+rejected.
+
+Use --retry-on-reject N to resubmit with a new order ID when the order is
+rejected for a transient reason such as a momentary trading halt. Rejections
+like insufficient buying power are never retried, and neither are HTTP errors
+such as 429 or 503, since the order may already have been accepted.
+
+Use --peg bid|ask|mid|last to set the limit from a fresh quote instead of
+--limit, plus --offset dollars (negative to improve on it). --offset alone
+pegs to the ask for buys and the bid for sells. The price is rounded to the
+tick (a penny, or $0.0001 under $1) away from paying up: down for buys, up
+for sells. The preview shows the reference and the computed limit.
+
+Use --reduce-only to guarantee the order only shrinks a position you hold: it
+is checked against the portfolio before sending and rejected if it would
+open, increase, or flip the position. The API cannot enforce it itself.
+
+Orders for more shares than max_shares in the config are rejected before
+anything is sent, whatever the price; --max-shares N sets the cap for one
+order and --force skips it.
+
+Orders for a symbol whose quote reports a trading halt are rejected too;
+--force places them anyway. --no-preflight skips the halt check unless --peg
+or --risk fetches a quote regardless.
+
+Orders are sent as EQUITY instruments. Use --instrument-type ETF, ADR, or
+CRYPTO for a symbol the API classifies differently, or --instrument-type auto
+to look the symbol up and use the type the API reports.
+
+For tools that show an order before placing it, --preview-json-then-confirm
+prints the preview (the exact order request, order ID included, and the
+preflight estimate) as JSON with a confirmToken, and places nothing. Running
+the same command with --confirm-token TOKEN and no other order flags places
+that exact order. Tokens are signed with your secret key and expire after 5
+minutes.
+
 Examples:
   pub order sell AAPL --quantity 5                           # Market order
   pub order sell AAPL --quantity 5 --limit 180.00            # Limit order
   pub order sell AAPL --quantity 5 --stop 145.00             # Stop loss order
   pub order sell AAPL --quantity 5 --limit 144.00 --stop 145.00  # Stop-limit order
-  pub order sell AAPL --quantity 5 --limit 180.00 --expiration GTC  # Good till cancelled`,
+  pub order sell AAPL --quantity 5 --limit 180.00 --expiration GTC  # Good till cancelled
+  pub order sell AAPL --quantity 10 --peg mid                  # Limit at the midpoint
+  pub order sell AAPL --quantity 10 --offset 0.02              # Limit two cents above the bid
+  pub order sell AAPL --quantity 10 --reduce-only                # Trim a long, never go short`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := useOrderID(&opts.newOrderID, orderID); err != nil {
+				return err
+			}
 			return runOrder(cmd, opts, args[0], "SELL", params, skipConfirm)
 		},
 	}
 
 	cmd.Flags().StringVarP(&params.quantity, "quantity", "q", "", "Number of shares to sell (required)")
 	cmd.Flags().StringVarP(&params.limitPrice, "limit", "l", "", "Limit price for LIMIT or STOP_LIMIT orders")
+	cmd.Flags().StringVar(&params.peg.reference, "peg", "", "Set the limit from the quote: bid, ask, mid, or last")
+	cmd.Flags().StringVar(&params.peg.offset, "offset", "", "Dollars added to the --peg reference (e.g. -0.02)")
 	cmd.Flags().StringVarP(&params.stopPrice, "stop", "s", "", "Stop price for STOP or STOP_LIMIT orders")
 	cmd.Flags().StringVarP(&params.expiration, "expiration", "e", "DAY", "Order expiration: DAY (default) or GTC")
+	cmd.Flags().StringVar(&params.instrumentType, "instrument-type", "EQUITY", "Instrument type: EQUITY, ETF, ADR, CRYPTO, or auto to look it up")
+	cmd.Flags().BoolVar(&params.noPreflight, "no-preflight", false, "Skip the preflight cost estimate")
+	cmd.Flags().BoolVar(&params.previewJSON, "preview-json-then-confirm", false, "Print the preview and a confirm token as JSON instead of placing the order")
+	cmd.Flags().StringVar(&params.confirmToken, "confirm-token", "", "Place the order previewed with --preview-json-then-confirm")
+	cmd.Flags().StringVar(&params.reason, "reason", "", "Why you are placing the order; shown in the preview and recorded in the trade journal")
+	cmd.Flags().BoolVar(&params.reduceOnly, "reduce-only", false, "Only reduce an existing position; reject orders that would increase or flip it")
+	cmd.Flags().Float64Var(&params.maxShares, "max-shares", 0, "Reject the order if it is for more shares than this (overrides max_shares)")
+	cmd.Flags().BoolVar(&params.force, "force", false, "Place the order even if it exceeds --max-shares or max_shares, or the symbol is halted")
+	cmd.Flags().IntVar(&params.retryOnReject, "retry-on-reject", 0, "Resubmit up to N times if the order is rejected for a transient reason")
+	cmd.Flags().StringVar(&orderID, "order-id", "", "Client order ID (UUID) to use instead of a random one")
+	cmd.Flags().BoolVarP(&skipConfirm, "yes", "y", false, "Skip confirmation prompt")
+	cmd.SilenceUsage = true
+
+	return cmd
+}
+
+// closeParams holds the parameters for closing a position.
+type closeParams struct {
+	limitPrice  string
+	percent     float64
+	noPreflight bool
+	reason      string
+}
+
+// newOrderCloseCmd creates the close subcommand with the given options.
+func newOrderCloseCmd(opts orderOptions) *cobra.Command {
+	var params closeParams
+	var skipConfirm bool
+
+	cmd := &cobra.Command{
+		Use:   "close SYMBOL",
+		Short: "Close a stock, ETF, ADR, or crypto position",
+		Long: `Close a stock, ETF, ADR, or crypto position in full, or partially with --percent.
+
+The held quantity and instrument type are read from the portfolio. Long positions are closed with
+a SELL and short positions with a BUY to cover. Partial closes round down to
+whole shares unless the position itself is fractional.
+
+max_shares and the trading halt check do not apply: a close only reduces
+the position, and a halted one can still be queued with --limit.
+
+Examples:
+  pub order close AAPL                         # Market order for the full position
+  pub order close AAPL --limit 180.00          # Limit order for the full position
+  pub order close AAPL --percent 50 --yes      # Close half the position`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runOrderClose(cmd, opts, args[0], params, skipConfirm)
+		},
+	}
+
+	cmd.Flags().StringVarP(&params.limitPrice, "limit", "l", "", "Limit price (default: market order)")
+	cmd.Flags().Float64Var(&params.percent, "percent", 100, "Percentage of the position to close")
+	cmd.Flags().BoolVar(&params.noPreflight, "no-preflight", false, "Skip the preflight cost estimate")
+	cmd.Flags().StringVar(&params.reason, "reason", "", "Why you are placing the order; shown in the preview and recorded in the trade journal")
+	cmd.Flags().BoolVarP(&skipConfirm, "yes", "y", false, "Skip confirmation prompt")
+	cmd.SilenceUsage = true
+
+	return cmd
+}
+
+// newOrderSubmitCmd creates the submit subcommand with the given options.
+func newOrderSubmitCmd(opts orderOptions) *cobra.Command {
+	var input string
+	var reason string
+	var force bool
+	var skipConfirm bool
+
+	cmd := &cobra.Command{
+		Use:   "submit",
+		Short: "Submit a complete order body from JSON",
+		Long: `Submit a complete single-leg order body read as JSON from a file or stdin.
+
+This is an escape hatch for fields the order flags don't cover yet. The body
+uses the API's order shape (instrument, orderSide, orderType, expiration,
+quantity or amount, limitPrice, ...). Option orders also need
+openCloseIndicator. Unknown fields are sent through unchanged. A random
+orderId is added if the body has none.
+
+The order is previewed and requires --yes. The server response is printed as
+JSON. Orders for more shares than max_shares, or more contracts than
+max_contracts, are rejected unless --force is set.
+
+Examples:
+  pub order submit --input order.json --yes
+  cat order.json | pub order submit --input - --yes`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runOrderSubmit(cmd, opts, input, reason, force, skipConfirm)
+		},
+	}
+
+	cmd.Flags().StringVarP(&input, "input", "i", "", "JSON order file, or - for stdin (required)")
+	cmd.Flags().StringVar(&reason, "reason", "", "Why you are placing the order; shown in the preview and recorded in the trade journal")
+	cmd.Flags().BoolVar(&force, "force", false, "Submit the order even if it exceeds max_shares or max_contracts")
 	cmd.Flags().BoolVarP(&skipConfirm, "yes", "y", false, "Skip confirmation prompt")
 	cmd.SilenceUsage = true
 
 	return cmd
 }
 
+// newOrderEstimateCmd creates the estimate subcommand with the given options.
+func newOrderEstimateCmd(opts orderOptions) *cobra.Command {
+	var params orderParams
+	var side string
+
+	cmd := &cobra.Command{
+		Use:   "estimate SYMBOL",
+		Short: "Estimate the cost of an order without placing it",
+		Long: `Estimate the cost of an equity order without placing it.
+
+Calls the preflight endpoint and prints the order value, commission,
+regulatory fees, total cost, and buying power requirement. Nothing is
+submitted, so no confirmation is needed and trading does not need to be
+enabled.
+
+Examples:
+  pub order estimate AAPL --side BUY -q 10                 # Market buy estimate
+  pub order estimate AAPL --side SELL -q 5 --limit 180.00  # Limit sell estimate
+  pub order estimate AAPL --side BUY -q 10 --json          # Output as JSON`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runOrderEstimate(cmd, opts, args[0], side, params)
+		},
+	}
+
+	cmd.Flags().StringVar(&side, "side", "", "Order side: BUY or SELL (required)")
+	cmd.Flags().StringVarP(&params.quantity, "quantity", "q", "", "Number of shares (required)")
+	cmd.Flags().StringVarP(&params.limitPrice, "limit", "l", "", "Limit price for LIMIT or STOP_LIMIT orders")
+	cmd.Flags().StringVarP(&params.stopPrice, "stop", "s", "", "Stop price for STOP or STOP_LIMIT orders")
+	cmd.Flags().StringVarP(&params.expiration, "expiration", "e", "DAY", "Order expiration: DAY (default) or GTC")
+	cmd.Flags().StringVar(&params.instrumentType, "instrument-type", "EQUITY", "Instrument type: EQUITY, ETF, ADR, CRYPTO, or auto to look it up")
+	cmd.SilenceUsage = true
+
+	return cmd
+}
+
 // newOrderCancelCmd creates the cancel subcommand with the given options.
 func newOrderCancelCmd(opts orderOptions) *cobra.Command {
 	var skipConfirm bool
+	var params cancelParams
 
 	cmd := &cobra.Command{
-		Use:   "cancel ORDER_ID",
-		Short: "Cancel an open order",
-		Long: `Cancel an open order by its order ID.
+		Use:   "cancel [ORDER_ID...]",
+		Short: "Cancel open orders",
+		Long: `Cancel open orders by order ID, or every open order with --all.
+
+--older-than cancels only open orders placed at least that long ago, such as
+forgotten GTC orders. It takes days (7d), weeks (2w), or hours (36h). Orders
+whose creation time cannot be read are skipped with a warning. --symbol and
+--side narrow --all or --older-than to one symbol or side. The matching orders
+are previewed with their age; nothing is cancelled without --yes.
+
+When more than one order is cancelled, a summary follows with the number of
+cancellations that succeeded and failed, the estimated value of the cancelled
+orders, and each order's result. With --json the summary is a single object
+with a results array. The command exits non-zero if any cancellation failed.
 
 Examples:
   pub order cancel 912710f1-1a45-4ef0-88a7-cd513781933d        # Cancel order (requires confirmation)
-  pub order cancel 912710f1-1a45-4ef0-88a7-cd513781933d --yes  # Skip confirmation`,
-		Args: cobra.ExactArgs(1),
+  pub order cancel 912710f1-1a45-4ef0-88a7-cd513781933d --yes  # Skip confirmation
+  pub order cancel ORDER_ID_1 ORDER_ID_2 --yes                 # Cancel several orders
+  pub order cancel --all --yes --json                          # Cancel everything, summary as JSON
+  pub order cancel --older-than 7d                             # Preview orders over a week old
+  pub order cancel --older-than 30d --symbol AAPL --side BUY --yes  # Clean up stale AAPL bids`,
+		Args: cancelArgs(&params),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runCancelOrder(cmd, opts, args[0], skipConfirm)
+			if !params.selects() && len(args) == 1 {
+				return runCancelOrder(cmd, opts, args[0], skipConfirm)
+			}
+			return runCancelOrders(cmd, opts, args, params, skipConfirm)
 		},
 	}
 
 	cmd.Flags().BoolVarP(&skipConfirm, "yes", "y", false, "Skip confirmation prompt")
+	cmd.Flags().BoolVar(&params.all, "all", false, "Cancel every open order")
+	cmd.Flags().StringVar(&params.olderThan, "older-than", "", "Cancel open orders placed at least this long ago (e.g. 7d, 2w, 36h)")
+	cmd.Flags().StringVar(&params.symbol, "symbol", "", "With --all or --older-than, only cancel orders for this symbol")
+	cmd.Flags().StringVar(&params.side, "side", "", "With --all or --older-than, only cancel BUY or SELL orders")
 	cmd.SilenceUsage = true
 
 	return cmd
@@ -197,8 +718,8 @@ func runOrderStatus(cmd *cobra.Command, opts orderOptions, orderID string) error
 	}
 
 	var orderStatus api.OrderStatusResponse
-	if err := json.NewDecoder(resp.Body).Decode(&orderStatus); err != nil {
-		return fmt.Errorf("failed to decode response: %w", err)
+	if err := api.DecodeJSON(resp, &orderStatus); err != nil {
+		return err
 	}
 
 	// Output result
@@ -252,32 +773,21 @@ func runCancelOrder(cmd *cobra.Command, opts orderOptions, orderID string, skipC
 	}
 
 	// Require confirmation unless --yes flag is set
-	if !skipConfirm {
-		return fmt.Errorf("cancel requires confirmation (use --yes to confirm)")
+	if err := confirmTrade(cmd, opts.promptConfirm, skipConfirm, opts.jsonMode, "cancel", "Cancel this order"); err != nil {
+		return err
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
 	client := api.NewClient(opts.baseURL, opts.authToken)
-	path := fmt.Sprintf("/userapigateway/trading/%s/order/%s", opts.accountID, orderID)
-	resp, err := client.Delete(ctx, path)
-	if err != nil {
-		return fmt.Errorf("failed to cancel order: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != 200 {
-		respBody, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API error: %d - %s", resp.StatusCode, string(respBody))
+	if err := cancelOrder(ctx, client, opts.accountID, orderID); err != nil {
+		return err
 	}
 
 	// Output result
 	if opts.jsonMode {
-		result := map[string]any{
-			"orderId": orderID,
-			"status":  "cancel_requested",
-		}
+		result := CancelResult{OrderID: orderID, Status: statusCancelRequested}
 		enc := json.NewEncoder(cmd.OutOrStdout())
 		enc.SetIndent("", "  ")
 		return enc.Encode(result)
@@ -290,43 +800,116 @@ func runCancelOrder(cmd *cobra.Command, opts orderOptions, orderID string, skipC
 	return nil
 }
 
-// newOrderListCmd creates the list subcommand with the given options.
-func newOrderListCmd(opts orderOptions) *cobra.Command {
-	cmd := &cobra.Command{
-		Use:   "list",
-		Short: "List open orders",
-		Long: `List all open orders for your account.
+// cancelParams picks the orders to cancel from the open order list instead
+// of by ID.
+type cancelParams struct {
+	all       bool
+	olderThan string // minimum order age, e.g. 7d; empty for any age
+	symbol    string
+	side      string
+}
 
-Shows orders that are pending, new, or partially filled.
+// selects reports whether orders are picked from the open order list.
+func (p cancelParams) selects() bool {
+	return p.all || p.olderThan != ""
+}
 
-Examples:
-  pub order list                # List open orders
-  pub order list --json         # Output as JSON`,
-		Args: cobra.NoArgs,
-		RunE: func(cmd *cobra.Command, args []string) error {
-			return runOrderList(cmd, opts)
-		},
+// cancelArgs requires order IDs, or none when *p selects open orders.
+func cancelArgs(p *cancelParams) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if len(args) > 0 {
+			switch {
+			case p.all:
+				return fmt.Errorf("cannot use --all with order IDs")
+			case p.olderThan != "":
+				return fmt.Errorf("cannot use --older-than with order IDs")
+			case p.symbol != "" || p.side != "":
+				return fmt.Errorf("--symbol and --side filter --all or --older-than and cannot be used with order IDs")
+			}
+		}
+		if !p.selects() && len(args) == 0 {
+			return fmt.Errorf("requires at least 1 arg(s) (ORDER_ID), --all, or --older-than")
+		}
+		return nil
 	}
+}
 
-	cmd.SilenceUsage = true
+// parseOrderAge parses an --older-than value: days (7d), weeks (2w), or a
+// Go duration such as 36h.
+func parseOrderAge(value string) (time.Duration, error) {
+	v := strings.ToLower(strings.TrimSpace(value))
+	var age time.Duration
+	var err error
+	switch {
+	case strings.HasSuffix(v, "d"), strings.HasSuffix(v, "w"):
+		unit := 24 * time.Hour
+		if strings.HasSuffix(v, "w") {
+			unit *= 7
+		}
+		var n int
+		n, err = strconv.Atoi(v[:len(v)-1])
+		age = time.Duration(n) * unit
+	default:
+		age, err = time.ParseDuration(v)
+	}
+	if err != nil || age <= 0 {
+		return 0, fmt.Errorf("invalid --older-than %q: use a duration such as 7d, 2w, or 36h", value)
+	}
+	return age, nil
+}
 
-	return cmd
+// orderTimeLayouts are the createdAt formats parseOrderTime understands. Times
+// without a zone are taken as UTC.
+var orderTimeLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02"}
+
+// parseOrderTime parses an order's createdAt timestamp.
+func parseOrderTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range orderTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized time %q", value)
 }
 
-func runOrderList(cmd *cobra.Command, opts orderOptions) error {
-	// Validate inputs
-	if opts.accountID == "" {
-		return fmt.Errorf("account ID is required (use --account flag or configure default account)")
+// ordersOlderThan returns the orders created at least minAge before now.
+// Orders with an unreadable createdAt have no known age, so they are skipped
+// with a warning to errW rather than cancelled.
+func ordersOlderThan(orders []api.Order, minAge time.Duration, now time.Time, errW io.Writer) []api.Order {
+	old := make([]api.Order, 0, len(orders))
+	for _, o := range orders {
+		created, err := parseOrderTime(o.CreatedAt)
+		if err != nil {
+			_, _ = fmt.Fprintf(errW, "Warning: skipping order %s: cannot read createdAt %q\n", o.OrderID, o.CreatedAt)
+			continue
+		}
+		if now.Sub(created) >= minAge {
+			old = append(old, o)
+		}
 	}
+	return old
+}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
+// formatOrderAge formats an order's age in whole days, or hours or minutes
+// under a day.
+func formatOrderAge(age time.Duration) string {
+	switch {
+	case age >= 24*time.Hour:
+		return fmt.Sprintf("%dd", int(age/(24*time.Hour)))
+	case age >= time.Hour:
+		return fmt.Sprintf("%dh", int(age/time.Hour))
+	default:
+		return fmt.Sprintf("%dm", int(age/time.Minute))
+	}
+}
 
-	client := api.NewClient(opts.baseURL, opts.authToken)
-	path := fmt.Sprintf("/userapigateway/trading/%s/portfolio/v2", opts.accountID)
-	resp, err := client.Get(ctx, path)
+// cancelOrder sends the cancel request for one order.
+func cancelOrder(ctx context.Context, client *api.Client, accountID, orderID string) error {
+	path := fmt.Sprintf("/userapigateway/trading/%s/order/%s", accountID, orderID)
+	resp, err := client.Delete(ctx, path)
 	if err != nil {
-		return fmt.Errorf("failed to fetch orders: %w", err)
+		return fmt.Errorf("failed to cancel order: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
@@ -334,80 +917,652 @@ func runOrderList(cmd *cobra.Command, opts orderOptions) error {
 		respBody, _ := io.ReadAll(resp.Body)
 		return fmt.Errorf("API error: %d - %s", resp.StatusCode, string(respBody))
 	}
+	return nil
+}
 
-	var orderList api.OrderListResponse
-	if err := json.NewDecoder(resp.Body).Decode(&orderList); err != nil {
-		return fmt.Errorf("failed to decode response: %w", err)
+// runCancelOrders cancels several orders, or the open orders params selects,
+// and ends with an opSummary of each cancellation. Orders are looked up in the
+// open order list to report their symbol and value; with explicit IDs that
+// lookup is best effort.
+func runCancelOrders(cmd *cobra.Command, opts orderOptions, orderIDs []string, params cancelParams, skipConfirm bool) error {
+	if !opts.tradingEnabled {
+		return config.ErrTradingDisabled
+	}
+	if opts.accountID == "" {
+		return fmt.Errorf("account ID is required (use --account flag or configure default account)")
+	}
+	filter, err := parseOrderListFilter(orderListParams{symbol: params.symbol, side: params.side})
+	if err != nil {
+		return err
+	}
+	var minAge time.Duration
+	if params.olderThan != "" {
+		if minAge, err = parseOrderAge(params.olderThan); err != nil {
+			return err
+		}
 	}
 
-	// Output result
-	if opts.jsonMode {
-		enc := json.NewEncoder(cmd.OutOrStdout())
-		enc.SetIndent("", "  ")
-		return enc.Encode(orderList.Orders)
+	client := api.NewClient(opts.baseURL, opts.authToken)
+	fetchCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	open, err := fetchOpenOrders(fetchCtx, client, opts.accountID)
+	cancel()
+	if err != nil && params.selects() {
+		return err
 	}
 
-	if len(orderList.Orders) == 0 {
-		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No open orders")
-		return nil
+	byID := make(map[string]api.Order, len(open))
+	for _, o := range open {
+		byID[o.OrderID] = o
+	}
+	now := time.Now()
+	if params.selects() {
+		selected := filterOrders(open, filter)
+		if params.olderThan != "" {
+			selected = ordersOlderThan(selected, minAge, now, cmd.ErrOrStderr())
+		}
+		orderIDs = make([]string, 0, len(selected))
+		for _, o := range selected {
+			orderIDs = append(orderIDs, o.OrderID)
+		}
 	}
 
-	// Human-readable table output
-	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "\n%-38s %-6s %-5s %-8s %-10s %-6s %s\n",
-		"ORDER ID", "SYMBOL", "SIDE", "TYPE", "STATUS", "QTY", "FILLED")
-	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s\n", strings.Repeat("-", 90))
+	out := cmd.OutOrStdout()
+	summary := newOpSummary("Cancel orders")
+	if len(orderIDs) == 0 {
+		if opts.jsonMode {
+			return summary.write(out, true)
+		}
+		if params.olderThan != "" {
+			_, _ = fmt.Fprintf(out, "No open orders older than %s to cancel\n", params.olderThan)
+			return nil
+		}
+		_, _ = fmt.Fprintln(out, "No open orders to cancel")
+		return nil
+	}
 
-	for _, order := range orderList.Orders {
-		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%-38s %-6s %-5s %-8s %-10s %-6s %s\n",
-			order.OrderID,
-			order.Instrument.Symbol,
-			order.Side,
-			order.Type,
-			order.Status,
-			order.Quantity,
-			order.FilledQuantity)
+	if !opts.jsonMode {
+		_, _ = fmt.Fprintf(out, "\nCancel %d Orders:\n", len(orderIDs))
+		for _, id := range orderIDs {
+			o := byID[id]
+			if params.olderThan == "" {
+				_, _ = fmt.Fprintf(out, "  %s  %s\n", id, o.Instrument.Symbol)
+				continue
+			}
+			created, _ := parseOrderTime(o.CreatedAt)
+			_, _ = fmt.Fprintf(out, "  %s  %s  %s  placed %s ago\n", id, o.Instrument.Symbol, o.Side, formatOrderAge(now.Sub(created)))
+		}
+		_, _ = fmt.Fprintln(out)
 	}
 
-	return nil
-}
+	if err := confirmTrade(cmd, opts.promptConfirm, skipConfirm, opts.jsonMode, "cancel", fmt.Sprintf("Cancel %d orders", len(orderIDs))); err != nil {
+		return err
+	}
 
-// sumFees calculates the total regulatory fees.
-func sumFees(fees api.RegulatoryFees) string {
-	var total float64
-	if v, err := parseFloat(fees.SECFee); err == nil {
-		total += v
+	// Each cancel gets its own timeout, started after the prompt, so a slow
+	// answer or a long list cannot run the later cancels out of time
+	for _, id := range orderIDs {
+		o := byID[id]
+		if err := cancelWithTimeout(client, opts.accountID, id); err != nil {
+			summary.fail(id, o.Instrument.Symbol, orderValue(o), err)
+			continue
+		}
+		summary.succeed(id, o.Instrument.Symbol, orderValue(o))
 	}
-	if v, err := parseFloat(fees.TAFFee); err == nil {
-		total += v
+
+	if err := summary.write(out, opts.jsonMode); err != nil {
+		return err
 	}
-	if v, err := parseFloat(fees.ORFFee); err == nil {
-		total += v
+	if !opts.jsonMode && summary.Succeeded > 0 {
+		_, _ = fmt.Fprintln(out, "\nNote: Cancellation is asynchronous. Use 'pub order list' to verify.")
 	}
-	return fmt.Sprintf("%.2f", total)
+	return summary.err()
 }
 
-// parseFloat parses a string as a float64, returning 0 on error.
-func parseFloat(s string) (float64, error) {
-	if s == "" {
-		return 0, nil
-	}
-	var v float64
-	_, err := fmt.Sscanf(s, "%f", &v)
-	return v, err
+// cancelWithTimeout cancels one order of a bulk cancellation.
+func cancelWithTimeout(client *api.Client, accountID, orderID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	return cancelOrder(ctx, client, accountID, orderID)
 }
 
-// extractErrorMessage extracts a human-readable message from an API error.
-func extractErrorMessage(err error) string {
-	if err == nil {
+// orderValue estimates the value of an order's unfilled quantity at its limit
+// (or stop) price, with the contract multiplier for options. It returns ""
+// for market orders and orders that are not known.
+func orderValue(o api.Order) string {
+	price := o.LimitPrice
+	if price == "" {
+		price = o.StopPrice
+	}
+	p, err := money.Parse(price)
+	if err != nil || p.IsZero() {
 		return ""
 	}
-	errStr := err.Error()
+	qty, err := money.Parse(o.Quantity)
+	if err != nil {
+		return ""
+	}
+	if filled, err := money.Parse(o.FilledQuantity); err == nil {
+		qty = qty.Sub(filled)
+	}
+	value := p.Mul(qty)
+	if o.Instrument.Type == "OPTION" {
+		value = value.MulInt(100)
+	}
+	return value.String()
+}
 
-	// Try to extract JSON error message from API response
-	// Format: "preflight API error: 400 - {"code":3003,"header":"...","message":"..."}"
-	if idx := strings.Index(errStr, "{"); idx != -1 {
-		jsonPart := errStr[idx:]
-		var apiErr struct {
+// orderListParams holds the parameters for listing orders.
+type orderListParams struct {
+	includeClosed bool
+	from          string
+	status        string
+	symbol        string
+	side          string
+	summary       bool // add an orderSummary footer
+	watch         bool // reprint the table every interval until interrupted
+	interval      time.Duration
+}
+
+// knownOrderStatuses lists the order statuses accepted by --status.
+var knownOrderStatuses = []string{
+	"PENDING", "NEW", "PARTIALLY_FILLED", "FILLED", "CANCELLED", "REJECTED", "EXPIRED", "REPLACED",
+}
+
+// orderListFilter narrows a fetched order list client-side.
+type orderListFilter struct {
+	statuses map[string]bool
+	symbol   string
+	side     string
+}
+
+// active reports whether any filter is set.
+func (f orderListFilter) active() bool {
+	return len(f.statuses) > 0 || f.symbol != "" || f.side != ""
+}
+
+// parseOrderListFilter validates and normalizes the --status, --symbol, and
+// --side flags.
+func parseOrderListFilter(params orderListParams) (orderListFilter, error) {
+	var f orderListFilter
+
+	if params.status != "" {
+		f.statuses = make(map[string]bool)
+		for _, s := range strings.Split(params.status, ",") {
+			s = strings.ToUpper(strings.TrimSpace(s))
+			if s == "" {
+				continue
+			}
+			if !slices.Contains(knownOrderStatuses, s) {
+				return f, fmt.Errorf("invalid --status %q: must be one of %s", s, strings.Join(knownOrderStatuses, ", "))
+			}
+			f.statuses[s] = true
+		}
+	}
+
+	f.symbol = strings.ToUpper(strings.TrimSpace(params.symbol))
+
+	if params.side != "" {
+		f.side = strings.ToUpper(strings.TrimSpace(params.side))
+		if f.side != "BUY" && f.side != "SELL" {
+			return f, fmt.Errorf("invalid --side %q: must be BUY or SELL", params.side)
+		}
+	}
+
+	return f, nil
+}
+
+// filterOrders returns the orders matching every set filter.
+func filterOrders(orders []api.Order, f orderListFilter) []api.Order {
+	filtered := make([]api.Order, 0, len(orders))
+	for _, order := range orders {
+		if len(f.statuses) > 0 && !f.statuses[strings.ToUpper(order.Status)] {
+			continue
+		}
+		if f.symbol != "" && !strings.EqualFold(order.Instrument.Symbol, f.symbol) {
+			continue
+		}
+		if f.side != "" && !strings.EqualFold(order.Side, f.side) {
+			continue
+		}
+		filtered = append(filtered, order)
+	}
+	return filtered
+}
+
+// newOrderListCmd creates the list subcommand with the given options.
+func newOrderListCmd(opts orderOptions) *cobra.Command {
+	var params orderListParams
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List open orders",
+		Long: `List all open orders for your account.
+
+Shows orders that are pending, new, or partially filled.
+
+With --include-closed, filled trades from the account history are merged in
+and each row is marked OPEN or CLOSED, newest first. Use --from to limit how
+far back history is read. The API only reports executed trades in history, so
+cancelled, rejected, and expired orders are not included once they close.
+History rows show the trade's transaction ID, since the history has no order
+IDs; the fills of a partly filled open order appear as their own CLOSED rows.
+
+Use --status (comma-separated), --symbol, and --side to narrow the list.
+Filters apply to both table and JSON output.
+
+--summary adds a footer with the number of orders by status and the working
+notional: the unfilled quantity of each open order times its limit (or stop)
+price, with the 100x multiplier for options. Open market orders have no price
+and are counted separately. With --json the output becomes an object with
+orders and summary fields.
+
+--watch reprints the open orders every --interval (default 5s) until Ctrl-C,
+as a live blotter. Orders whose status or filled quantity changed since the
+last refresh are marked with the change (e.g. NEW -> PARTIALLY_FILLED) and,
+on a terminal, highlighted; --no-color or NO_COLOR turns highlighting off.
+Orders that leave the list are reported below the table.
+
+Examples:
+  pub order list                                  # List open orders
+  pub order list --json                           # Output as JSON
+  pub order list --include-closed --from 2025-01-01  # Include recent fills
+  pub order list --status PARTIALLY_FILLED --side sell  # Partially filled sells
+  pub order list --summary                        # Totals by status and notional
+  pub order list --watch --interval 5s            # Live blotter until Ctrl-C`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runOrderList(cmd, opts, params)
+		},
+	}
+
+	cmd.Flags().BoolVar(&params.includeClosed, "include-closed", false, "Include filled orders from account history")
+	cmd.Flags().StringVar(&params.from, "from", "", "Earliest date for closed orders with --include-closed (YYYY-MM-DD, -Nd, or ISO 8601)")
+	cmd.Flags().StringVar(&params.status, "status", "", "Only show these statuses (comma-separated, e.g. NEW,PARTIALLY_FILLED)")
+	cmd.Flags().StringVar(&params.symbol, "symbol", "", "Only show orders for this symbol")
+	cmd.Flags().StringVar(&params.side, "side", "", "Only show BUY or SELL orders")
+	cmd.Flags().BoolVar(&params.summary, "summary", false, "Show order counts by status and the working notional")
+	cmd.Flags().BoolVar(&params.watch, "watch", false, "Reprint open orders every --interval until interrupted, marking changes")
+	cmd.Flags().DurationVar(&params.interval, "interval", 5*time.Second, "Refresh interval for --watch")
+	cmd.SilenceUsage = true
+
+	return cmd
+}
+
+// listedOrder is an order annotated with whether it is still working.
+type listedOrder struct {
+	api.Order
+	State string `json:"state"`
+}
+
+// orderState classifies an order status as OPEN or CLOSED.
+func orderState(status string) string {
+	switch strings.ToUpper(status) {
+	case "FILLED", "CANCELLED", "REJECTED", "EXPIRED", "REPLACED":
+		return "CLOSED"
+	default:
+		return "OPEN"
+	}
+}
+
+// fetchOpenOrders returns the account's open orders from the portfolio.
+func fetchOpenOrders(ctx context.Context, client *api.Client, accountID string) ([]api.Order, error) {
+	path := fmt.Sprintf("/userapigateway/trading/%s/portfolio/v2", accountID)
+	resp, err := client.Get(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch orders: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != 200 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("API error: %d - %s", resp.StatusCode, string(respBody))
+	}
+
+	var orderList api.OrderListResponse
+	if err := api.DecodeJSON(resp, &orderList); err != nil {
+		return nil, err
+	}
+	return orderList.Orders, nil
+}
+
+// fetchClosedOrders reads executed trades from the account history and
+// returns them as filled orders. The history has no order IDs, so each one
+// carries its transaction ID instead.
+func fetchClosedOrders(ctx context.Context, client *api.Client, accountID, from string) ([]api.Order, error) {
+	queryParams := make(map[string]string)
+	if from != "" {
+		queryParams["start"] = from
+	}
+
+	path := fmt.Sprintf("/userapigateway/trading/%s/history", accountID)
+	resp, err := client.GetWithParams(ctx, path, queryParams)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch history: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != 200 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("API error: %d - %s", resp.StatusCode, string(respBody))
+	}
+
+	var historyResp api.HistoryResponse
+	if err := api.DecodeJSON(resp, &historyResp); err != nil {
+		return nil, err
+	}
+
+	var orders []api.Order
+	for _, txn := range historyResp.Transactions {
+		if txn.Type != "TRADE" {
+			continue
+		}
+		orders = append(orders, api.Order{
+			OrderID:        txn.ID,
+			Instrument:     api.Instrument{Symbol: txn.Symbol, Type: txn.SecurityType},
+			Side:           txn.Side,
+			Status:         "FILLED",
+			Quantity:       txn.Quantity,
+			FilledQuantity: txn.Quantity,
+			CreatedAt:      txn.Timestamp,
+		})
+	}
+
+	return orders, nil
+}
+
+// mergeOrders combines order lists and sorts the result newest first. Open
+// orders and history fills share no ID, so nothing is de-duplicated: a
+// partly filled order is listed open and its fills closed. The lists come
+// from different endpoints whose timestamps may differ in format or offset,
+// so times are compared parsed, and as strings only when one cannot be read.
+func mergeOrders(lists ...[]api.Order) []listedOrder {
+	var merged []listedOrder
+	for _, list := range lists {
+		for _, order := range list {
+			merged = append(merged, listedOrder{Order: order, State: orderState(order.Status)})
+		}
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		ti, errI := parseOrderTime(merged[i].CreatedAt)
+		tj, errJ := parseOrderTime(merged[j].CreatedAt)
+		if errI == nil && errJ == nil {
+			return ti.After(tj)
+		}
+		return merged[i].CreatedAt > merged[j].CreatedAt
+	})
+
+	return merged
+}
+
+func runOrderList(cmd *cobra.Command, opts orderOptions, params orderListParams) error {
+	// Validate inputs
+	if opts.accountID == "" {
+		return fmt.Errorf("account ID is required (use --account flag or configure default account)")
+	}
+
+	filter, err := parseOrderListFilter(params)
+	if err != nil {
+		return err
+	}
+	if err := validateOrderListWatch(params, opts.jsonMode); err != nil {
+		return err
+	}
+	if params.from != "" && !params.includeClosed {
+		return fmt.Errorf("--from requires --include-closed")
+	}
+	if params.watch {
+		streamOutput(cmd)
+		return runOrderListWatch(cmd, opts, params, filter)
+	}
+	if params.from != "" {
+		from, err := parseHistoryBound(params.from, false, time.Now())
+		if err != nil {
+			return fmt.Errorf("invalid --from: %w", err)
+		}
+		params.from = from
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	client := api.NewClient(opts.baseURL, opts.authToken)
+	orders, err := fetchOpenOrders(ctx, client, opts.accountID)
+	if err != nil {
+		return err
+	}
+	if filter.active() {
+		orders = filterOrders(orders, filter)
+	}
+
+	if params.includeClosed {
+		closed, err := fetchClosedOrders(ctx, client, opts.accountID, params.from)
+		if err != nil {
+			return err
+		}
+		if filter.active() {
+			closed = filterOrders(closed, filter)
+		}
+		merged := mergeOrders(orders, closed)
+		var summary *orderSummary
+		if params.summary {
+			all := make([]api.Order, 0, len(merged))
+			for _, o := range merged {
+				all = append(all, o.Order)
+			}
+			summary = summarizeOrders(all)
+		}
+		return printListedOrders(cmd, opts, merged, summary)
+	}
+
+	var summary *orderSummary
+	if params.summary {
+		summary = summarizeOrders(orders)
+	}
+
+	// Output result
+	if opts.jsonMode {
+		return writeOrderListJSON(cmd.OutOrStdout(), orders, summary)
+	}
+
+	if len(orders) == 0 {
+		if filter.active() {
+			_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No open orders match the filters")
+			return nil
+		}
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No open orders")
+		return nil
+	}
+
+	printOrderTable(cmd.OutOrStdout(), orders, opts.wide, nil, false)
+	printOrderSummary(cmd.OutOrStdout(), summary)
+	return nil
+}
+
+// printOrderTable prints open orders as a table, with price and timestamp
+// columns when wide. Rows whose order ID is in changes are followed by the
+// change and, with color, highlighted.
+func printOrderTable(w io.Writer, orders []api.Order, wide bool, changes map[string]string, color bool) {
+	headers := []string{"ORDER ID", "SYMBOL", "SIDE", "TYPE", "STATUS", "QTY", "FILLED", "LIMIT", "STOP", "CREATED"}
+	rows := make([][]string, 0, len(orders))
+	for _, order := range orders {
+		rows = append(rows, []string{
+			order.OrderID,
+			order.Instrument.Symbol,
+			order.Side,
+			order.Type,
+			order.Status,
+			order.Quantity,
+			order.FilledQuantity,
+			valueOrDash(order.LimitPrice),
+			valueOrDash(order.StopPrice),
+			order.CreatedAt,
+		})
+	}
+
+	// Render to a buffer so changed rows can be marked once aligned; the
+	// table's first two lines are the header and its underline
+	var buf bytes.Buffer
+	_ = output.New(&buf, false).WithWide(wide).WideTable(headers, rows, "LIMIT", "STOP", "CREATED")
+	_, _ = fmt.Fprintln(w)
+	for i, line := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+		if i >= 2 {
+			if change, ok := changes[orders[i-2].OrderID]; ok {
+				line += "  <- " + change
+				if color {
+					line = ansiHighlight + line + ansiReset
+				}
+			}
+		}
+		_, _ = fmt.Fprintln(w, line)
+	}
+}
+
+// orderSummary tallies a listed set of orders for --summary.
+type orderSummary struct {
+	Count    int            `json:"count"`
+	ByStatus map[string]int `json:"byStatus"`
+	// WorkingNotional is the orderValue of every open order.
+	WorkingNotional string `json:"workingNotional"`
+	// Unpriced counts open orders with no limit or stop price (market orders),
+	// which WorkingNotional leaves out.
+	Unpriced int `json:"unpriced"`
+}
+
+// summarizeOrders counts orders by status and totals the value of the open ones.
+func summarizeOrders(orders []api.Order) *orderSummary {
+	s := &orderSummary{Count: len(orders), ByStatus: make(map[string]int)}
+	notional := money.Zero
+	for _, o := range orders {
+		s.ByStatus[strings.ToUpper(o.Status)]++
+		if orderState(o.Status) != "OPEN" {
+			continue
+		}
+		value, err := money.Parse(orderValue(o))
+		if err != nil || value.IsZero() {
+			s.Unpriced++
+			continue
+		}
+		notional = notional.Add(value)
+	}
+	s.WorkingNotional = notional.String()
+	return s
+}
+
+// statuses lists the summary's statuses in knownOrderStatuses order, with any
+// others after them alphabetically.
+func (s *orderSummary) statuses() []string {
+	statuses := make([]string, 0, len(s.ByStatus))
+	for status := range s.ByStatus {
+		statuses = append(statuses, status)
+	}
+	rank := func(status string) int {
+		if i := slices.Index(knownOrderStatuses, status); i >= 0 {
+			return i
+		}
+		return len(knownOrderStatuses)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		if ri, rj := rank(statuses[i]), rank(statuses[j]); ri != rj {
+			return ri < rj
+		}
+		return statuses[i] < statuses[j]
+	})
+	return statuses
+}
+
+// printOrderSummary prints the --summary footer; a nil summary prints nothing.
+func printOrderSummary(w io.Writer, s *orderSummary) {
+	if s == nil {
+		return
+	}
+	parts := make([]string, 0, len(s.ByStatus))
+	for _, status := range s.statuses() {
+		parts = append(parts, fmt.Sprintf("%s %d", status, s.ByStatus[status]))
+	}
+	_, _ = fmt.Fprintf(w, "\nSummary: %d orders (%s); working notional $%s", s.Count, strings.Join(parts, ", "), s.WorkingNotional)
+	if s.Unpriced > 0 {
+		_, _ = fmt.Fprintf(w, " plus %d market order(s) without a price", s.Unpriced)
+	}
+	_, _ = fmt.Fprintln(w)
+}
+
+// writeOrderListJSON encodes the listed orders, wrapped in an object with
+// their summary when --summary is set.
+func writeOrderListJSON(w io.Writer, orders any, summary *orderSummary) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if summary == nil {
+		return enc.Encode(orders)
+	}
+	return enc.Encode(map[string]any{"orders": orders, "summary": summary})
+}
+
+// valueOrDash returns v, or "-" when it is empty.
+func valueOrDash(v string) string {
+	if v == "" {
+		return "-"
+	}
+	return v
+}
+
+// printListedOrders prints open and closed orders with their state.
+func printListedOrders(cmd *cobra.Command, opts orderOptions, orders []listedOrder, summary *orderSummary) error {
+	if opts.jsonMode {
+		if orders == nil {
+			orders = []listedOrder{}
+		}
+		return writeOrderListJSON(cmd.OutOrStdout(), orders, summary)
+	}
+
+	if len(orders) == 0 {
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No orders")
+		return nil
+	}
+
+	headers := []string{"ORDER ID", "STATE", "SYMBOL", "SIDE", "TYPE", "STATUS", "QTY", "FILLED", "LIMIT", "STOP", "CREATED"}
+	rows := make([][]string, 0, len(orders))
+	for _, order := range orders {
+		rows = append(rows, []string{
+			order.OrderID,
+			order.State,
+			order.Instrument.Symbol,
+			order.Side,
+			order.Type,
+			order.Status,
+			order.Quantity,
+			order.FilledQuantity,
+			valueOrDash(order.LimitPrice),
+			valueOrDash(order.StopPrice),
+			order.CreatedAt,
+		})
+	}
+	_, _ = fmt.Fprintln(cmd.OutOrStdout())
+	if err := output.New(cmd.OutOrStdout(), false).WithWide(opts.wide).WideTable(headers, rows, "LIMIT", "STOP"); err != nil {
+		return err
+	}
+
+	printOrderSummary(cmd.OutOrStdout(), summary)
+	return nil
+}
+
+// sumFees calculates the total regulatory fees.
+func sumFees(fees api.RegulatoryFees) string {
+	return money.Sum(fees.SECFee, fees.TAFFee, fees.ORFFee).String()
+}
+
+// extractErrorMessage extracts a human-readable message from an API error.
+func extractErrorMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+	errStr := err.Error()
+
+	// Try to extract JSON error message from API response
+	// Format: "preflight API error: 400 - {"code":3003,"header":"...","message":"..."}"
+	if idx := strings.Index(errStr, "{"); idx != -1 {
+		jsonPart := errStr[idx:]
+		var apiErr struct {
 			Code    any    `json:"code"`
 			Header  string `json:"header"`
 			Message string `json:"message"`
@@ -456,10 +1611,15 @@ func runPreflight(opts orderOptions, symbol, side string, params orderParams) (*
 		expiration = "DAY"
 	}
 
+	instType, err := parseInstrumentType(params.instrumentType)
+	if err != nil {
+		return nil, err
+	}
+
 	preflightReq := api.PreflightRequest{
 		Instrument: api.OrderInstrument{
 			Symbol: strings.ToUpper(symbol),
-			Type:   "EQUITY",
+			Type:   instType,
 		},
 		OrderSide: side,
 		OrderType: orderType,
@@ -476,171 +1636,708 @@ func runPreflight(opts orderOptions, symbol, side string, params orderParams) (*
 		return nil, fmt.Errorf("failed to encode preflight request: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	client := api.NewClient(opts.baseURL, opts.authToken)
+	path := fmt.Sprintf("/userapigateway/trading/%s/preflight/single-leg", opts.accountID)
+	resp, err := client.Post(ctx, path, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to call preflight: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != 200 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("preflight API error: %d - %s", resp.StatusCode, string(respBody))
+	}
+
+	var preflightResp api.PreflightResponse
+	if err := api.DecodeJSON(resp, &preflightResp); err != nil {
+		return nil, err
+	}
+
+	return &preflightResp, nil
+}
+
+func runOrder(cmd *cobra.Command, opts orderOptions, symbol, side string, params orderParams, skipConfirm bool) error {
+	// Check trading is enabled
+	if !opts.tradingEnabled {
+		return config.ErrTradingDisabled
+	}
+
+	// Validate inputs
+	if opts.accountID == "" {
+		return fmt.Errorf("account ID is required (use --account flag or configure default account)")
+	}
+	reason, err := parseReason(params.reason, opts.requireReason)
+	if err != nil {
+		return err
+	}
+	params.reason = reason
+
+	if params.confirmToken != "" {
+		return runConfirmedOrder(cmd, opts, symbol, side, params)
+	}
+	if err := validateOrderParams(params, side); err != nil {
+		return err
+	}
+
+	symbol = strings.ToUpper(symbol)
+	client := api.NewClient(opts.baseURL, opts.authToken)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	instType, err := resolveInstrumentType(ctx, client, symbol, params.instrumentType)
+	cancel()
+	if err != nil {
+		return err
+	}
+	params.instrumentType = instType
+
+	// One quote serves the halt check, --peg, and --risk at market. Closes
+	// skip the halt check, and --no-preflight skips it unless a quote is
+	// fetched anyway, to keep the order to one round trip.
+	checkHalt := !params.force && !params.closing
+	needQuote := params.peg.set() || (params.risk != "" && params.limitPrice == "")
+	var quotes []api.Quote
+	var quoteErr error
+	if needQuote || (checkHalt && !params.noPreflight) {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		quotes, quoteErr = client.GetQuotes(ctx, opts.accountID, []api.QuoteInstrument{{Symbol: symbol, Type: instType}})
+		cancel()
+		// The halt check is best-effort: without quotes the venue decides
+		if checkHalt && quoteErr == nil {
+			if err := haltedIn(quotes); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Price a pegged order from the quote; it then proceeds as --limit
+	var pegged *peggedLimit
+	if params.peg.set() {
+		if quoteErr != nil {
+			return fmt.Errorf("--peg: failed to fetch quote: %w", quoteErr)
+		}
+		if len(quotes) == 0 {
+			return fmt.Errorf("--peg: no quote returned for %s", symbol)
+		}
+		resolved, err := params.peg.resolve(quotes[0], side, equityTick)
+		if err != nil {
+			return err
+		}
+		pegged = &resolved
+		params.limitPrice = resolved.price()
+	}
+
+	// Size the order by risk: --stop becomes the protective stop, and the entry
+	// order itself is a MARKET or LIMIT order
+	var sizing *riskSizing
+	if params.risk != "" {
+		entry, err := riskEntryPrice(quotes, quoteErr, symbol, params.limitPrice)
+		if err != nil {
+			return err
+		}
+		sized, err := sizeByRisk(params.risk, entry, params.stopPrice)
+		if err != nil {
+			return err
+		}
+		sizing = &sized
+		params.quantity = strconv.FormatInt(sized.quantity, 10)
+		params.stopPrice = ""
+	}
+
+	orderID := generateOrderID(opts.newOrderID)
+	orderType := determineOrderType(params.limitPrice, params.stopPrice)
+	expiration := strings.ToUpper(params.expiration)
+
+	if !params.force && !params.closing {
+		qty, _ := strconv.ParseFloat(params.quantity, 64)
+		shareCap := newQuantityCap("shares", "--max-shares", params.maxShares, "max_shares", opts.maxShares)
+		if err := shareCap.check("order", qty); err != nil {
+			return err
+		}
+	}
+
+	// Check the held position before anything is shown or sent; the order
+	// API has no reduce-only field, so this check is the only enforcement.
+	var held float64
+	if params.reduceOnly {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		portfolio, err := client.GetPortfolio(ctx, opts.accountID)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("--reduce-only: failed to check position: %w", err)
+		}
+		if pos := findPosition(portfolio.Positions, symbol, instType); pos != nil {
+			held, err = strconv.ParseFloat(pos.Quantity, 64)
+			if err != nil {
+				return fmt.Errorf("invalid position quantity %q for %s", pos.Quantity, symbol)
+			}
+		}
+		if err := checkReduceOnly(symbol, side, params.quantity, held); err != nil {
+			return err
+		}
+	}
+
+	// Call preflight to get estimated costs unless explicitly skipped
+	var preflight *api.PreflightResponse
+	var preflightErr error
+	if !params.noPreflight {
+		preflight, preflightErr = runPreflight(opts, symbol, side, params)
+	}
+
+	// Show order preview (not in JSON mode)
+	if !opts.jsonMode && !params.previewJSON {
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "\nOrder Preview:\n")
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  Action:   %s\n", side)
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  Symbol:   %s\n", symbol)
+		if instType != "EQUITY" {
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  Asset:    %s\n", instType)
+		}
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  Quantity: %s shares\n", params.quantity)
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  Type:     %s\n", orderType)
+		if params.limitPrice != "" {
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  Limit:    $%s\n", params.limitPrice)
+		}
+		if pegged != nil {
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  Peg:      %s\n", pegged.describe())
+		}
+		if params.stopPrice != "" {
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  Stop:     $%s\n", params.stopPrice)
+		}
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  Expires:  %s\n", expiration)
+		if params.reason != "" {
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  Reason:   %s\n", params.reason)
+		}
+		if params.reduceOnly {
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  Reduce:   Only (holding %s shares; checked here, not by the API)\n", strconv.FormatFloat(held, 'f', -1, 64))
+		}
+		if sizing != nil {
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  Risk:     $%.2f/share x %d = $%.2f (entry ~$%.2f, stop $%s)\n",
+				sizing.riskPerShare, sizing.quantity, sizing.totalRisk, sizing.entry, sizing.stop)
+		}
+
+		// Show preflight cost estimates if available
+		if preflightErr == nil && preflight != nil {
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "\n  Estimated Cost:\n")
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "    Order Value:  $%s\n", preflight.OrderValue)
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "    Commission:   $%s\n", preflight.EstimatedCommission)
+			totalFees := sumFees(preflight.RegulatoryFees)
+			if totalFees != "0.00" {
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "    Reg Fees:     $%s\n", totalFees)
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "    Total:        $%s\n", preflight.EstimatedCost)
+		} else if preflightErr != nil {
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "\n  Cost Estimate: unavailable (%s)\n", extractErrorMessage(preflightErr))
+		}
+
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "\n  Order ID: %s\n\n", orderID)
+	}
+
+	// Build order request
+	orderReq := api.OrderRequest{
+		OrderID: orderID,
+		Instrument: api.OrderInstrument{
+			Symbol: symbol,
+			Type:   instType,
+		},
+		OrderSide: side,
+		OrderType: orderType,
+		Expiration: api.OrderExpiration{
+			TimeInForce: expiration,
+		},
+		Quantity:   params.quantity,
+		LimitPrice: params.limitPrice,
+		StopPrice:  params.stopPrice,
+	}
+
+	if params.previewJSON {
+		return printOrderPreviewJSON(cmd, opts, orderReq, params.reason, preflight, preflightErr)
+	}
+
+	// Require confirmation unless --yes flag is set
+	if err := confirmTrade(cmd, opts.promptConfirm, skipConfirm, opts.jsonMode, "order", placeOrderQuestion(opts.confirmPrompt, defaultPlaceQuestion)); err != nil {
+		return err
+	}
+
+	orderResp, err := placeEquityOrder(client, opts.accountID, orderReq)
+	for attempt := 1; err != nil && attempt <= params.retryOnReject; attempt++ {
+		var apiErr *api.APIError
+		if !errors.As(err, &apiErr) || !apiErr.IsTransientRejection() {
+			break
+		}
+		delay := opts.retryDelay
+		if delay == 0 {
+			delay = defaultRetryDelay
+		}
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Order rejected: %s; retrying in %s (attempt %d of %d)\n",
+			apiErr, delay, attempt, params.retryOnReject)
+		time.Sleep(delay)
+		// A fresh ID every attempt, even with --order-id: reusing the
+		// rejected order's ID would be deduped by the server.
+		orderReq.OrderID = uuid.New().String()
+		orderResp, err = placeEquityOrder(client, opts.accountID, orderReq)
+	}
+	if err != nil {
+		return withSymbolSuggestions(client, symbol, err)
+	}
+
+	orderReq.OrderID = orderResp.OrderID
+	recordJournal(cmd.ErrOrStderr(), opts.journalPath, equityJournalEntry(opts.accountID, orderReq, params.reason), time.Now())
+
+	// Output result
+	if opts.jsonMode {
+		result := OrderPlacedResult{
+			OrderID:    orderResp.OrderID,
+			Status:     statusPlaced,
+			Symbol:     symbol,
+			Side:       side,
+			Quantity:   params.quantity,
+			OrderType:  orderType,
+			LimitPrice: params.limitPrice,
+			StopPrice:  params.stopPrice,
+			ReduceOnly: params.reduceOnly,
+			Reason:     params.reason,
+		}
+		if instType != "EQUITY" {
+			result.InstrumentType = instType
+		}
+		if sizing != nil {
+			result.RiskPerShare = fmt.Sprintf("%.2f", sizing.riskPerShare)
+			result.TotalRisk = fmt.Sprintf("%.2f", sizing.totalRisk)
+			result.ProtectiveStop = sizing.stop
+		}
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	}
+
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Order placed successfully!\n")
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  Order ID: %s\n", orderResp.OrderID)
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  %s %s shares of %s (%s)\n", side, params.quantity, symbol, orderType)
+	if params.limitPrice != "" {
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  Limit: $%s\n", params.limitPrice)
+	}
+	if params.stopPrice != "" {
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  Stop: $%s\n", params.stopPrice)
+	}
+	if sizing != nil {
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  Risk: $%.2f to a stop at $%s\n", sizing.totalRisk, sizing.stop)
+	}
+	if sizing != nil {
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  Once the entry fills, place the protective stop:\n    pub order sell %s --quantity %s --stop %s --expiration GTC\n",
+			symbol, params.quantity, sizing.stop)
+	}
+	if params.reason != "" {
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  Reason: %s\n", params.reason)
+	}
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "\nNote: Order placement is asynchronous. Use 'pub order status %s' to check execution status.\n", orderResp.OrderID)
+
+	return nil
+}
+
+// orderRejectedError is returned when the API rejects an order. It keeps the
+// raw response for display and unwraps to the parsed APIError so callers can
+// classify the rejection.
+type orderRejectedError struct {
+	statusCode int
+	body       string
+	apiErr     *api.APIError
+}
+
+func (e *orderRejectedError) Error() string {
+	return fmt.Sprintf("API error: %d - %s", e.statusCode, e.body)
+}
+
+func (e *orderRejectedError) Unwrap() error {
+	return e.apiErr
+}
+
+// maxSymbolSuggestions caps the "Did you mean" list on invalid-symbol errors.
+const maxSymbolSuggestions = 3
+
+// symbolSuggestionError adds close symbol matches to an invalid-symbol error.
+type symbolSuggestionError struct {
+	err         error
+	suggestions []string
+}
+
+func (e *symbolSuggestionError) Error() string {
+	return fmt.Sprintf("%s\nDid you mean: %s?", e.err, strings.Join(e.suggestions, ", "))
+}
+
+func (e *symbolSuggestionError) Unwrap() error {
+	return e.err
+}
+
+// withSymbolSuggestions looks up symbols similar to the rejected one when the
+// API reports it as invalid. It is best-effort: if the lookup fails or finds
+// nothing close, the original error is returned unchanged.
+func withSymbolSuggestions(client *api.Client, symbol string, err error) error {
+	var apiErr *api.APIError
+	if !errors.As(err, &apiErr) || !apiErr.IsInvalidSymbol() {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	instResp, lookupErr := client.GetInstruments(ctx, "EQUITY", "")
+	if lookupErr != nil {
+		return err
+	}
+
+	symbols := make([]string, 0, len(instResp.Instruments))
+	for _, inst := range instResp.Instruments {
+		symbols = append(symbols, inst.Instrument.Symbol)
+	}
+	suggestions := api.SuggestSymbols(symbol, symbols, maxSymbolSuggestions)
+	if len(suggestions) == 0 {
+		return err
+	}
+	return &symbolSuggestionError{err: err, suggestions: suggestions}
+}
+
+// placeEquityOrder submits a single equity order.
+func placeEquityOrder(client *api.Client, accountID string, orderReq api.OrderRequest) (*api.OrderResponse, error) {
+	body, err := json.Marshal(orderReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	var orderResp api.OrderResponse
+	if err := submitOrderBody(client, accountID, body, &orderResp); err != nil {
+		return nil, err
+	}
+	return &orderResp, nil
+}
+
+// submitOrderBody posts an already-encoded single-leg order body and decodes
+// the response into target.
+func submitOrderBody(client *api.Client, accountID string, body []byte, target any) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	path := fmt.Sprintf("/userapigateway/trading/%s/order", accountID)
+	resp, err := client.Post(ctx, path, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to place order: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != 200 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return &orderRejectedError{
+			statusCode: resp.StatusCode,
+			body:       string(respBody),
+			apiErr:     api.ParseAPIError(resp.StatusCode, respBody),
+		}
+	}
+
+	return api.DecodeJSON(resp, target)
+}
+
+// submittedOrder is the subset of a raw single-leg order body that order
+// submit validates and previews. Equity and option orders share the shape;
+// options add openCloseIndicator.
+type submittedOrder struct {
+	api.OrderRequest
+	OpenCloseIndicator string `json:"openCloseIndicator,omitempty"`
+}
+
+// validateSubmitStdin rejects --account - together with --input -: both
+// would read stdin, and the account line would be taken from the order body.
+func validateSubmitStdin(accountFlag, input string) error {
+	if accountFlag == stdinAccount && input == "-" {
+		return fmt.Errorf("--account - cannot be combined with --input -: both read stdin")
+	}
+	return nil
+}
+
+// readOrderInput reads a JSON order body from path, or from stdin when path is "-".
+func readOrderInput(cmd *cobra.Command, path string) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(cmd.InOrStdin())
+	}
+	return os.ReadFile(path)
+}
+
+// validateSubmittedOrder performs the minimal checks order submit applies
+// before sending a raw body. Anything the API might accept beyond this is
+// passed through untouched.
+func validateSubmittedOrder(order submittedOrder) error {
+	if order.Instrument.Symbol == "" {
+		return fmt.Errorf("order is missing instrument.symbol")
+	}
+	switch order.Instrument.Type {
+	case "EQUITY":
+	case "OPTION":
+		if order.OpenCloseIndicator != "OPEN" && order.OpenCloseIndicator != "CLOSE" {
+			return fmt.Errorf("option orders require openCloseIndicator OPEN or CLOSE")
+		}
+	default:
+		return fmt.Errorf("invalid instrument.type %q: must be EQUITY or OPTION", order.Instrument.Type)
+	}
+	if order.OrderSide != "BUY" && order.OrderSide != "SELL" {
+		return fmt.Errorf("invalid orderSide %q: must be BUY or SELL", order.OrderSide)
+	}
+	if order.OrderType == "" {
+		return fmt.Errorf("order is missing orderType")
+	}
+	if order.Expiration.TimeInForce == "" {
+		return fmt.Errorf("order is missing expiration.timeInForce")
+	}
+	if order.Quantity == "" && order.Amount == "" {
+		return fmt.Errorf("order requires quantity or amount")
+	}
+	if order.OrderID != "" {
+		if _, err := uuid.Parse(order.OrderID); err != nil {
+			return fmt.Errorf("invalid orderId %q: must be a UUID", order.OrderID)
+		}
+	}
+	return nil
+}
+
+// submittedOrderCap is the cap for a submitted order's instrument type:
+// max_contracts for options, max_shares otherwise.
+func submittedOrderCap(order submittedOrder, opts orderOptions) quantityCap {
+	if order.Instrument.Type == "OPTION" {
+		return newQuantityCap("contracts", "", 0, "max_contracts", float64(opts.maxContracts))
+	}
+	return newQuantityCap("shares", "", 0, "max_shares", opts.maxShares)
+}
+
+// submittedQuantity is the order's quantity, or 0 for a dollar amount or a
+// quantity that doesn't parse; neither is capped.
+func submittedQuantity(order submittedOrder) float64 {
+	qty, err := strconv.ParseFloat(order.Quantity, 64)
+	if err != nil {
+		return 0
+	}
+	return qty
+}
+
+// runOrderSubmit sends a complete order body read from a file or stdin.
+func runOrderSubmit(cmd *cobra.Command, opts orderOptions, input, reason string, force, skipConfirm bool) error {
+	if !opts.tradingEnabled {
+		return config.ErrTradingDisabled
+	}
+	if opts.accountID == "" {
+		return fmt.Errorf("account ID is required (use --account flag or configure default account)")
+	}
+	reason, err := parseReason(reason, opts.requireReason)
+	if err != nil {
+		return err
+	}
+	if input == "" {
+		return fmt.Errorf("order body is required (use --input FILE or --input - for stdin)")
+	}
+	// The confirm prompt reads its answer from stdin, which the body would
+	// already have consumed
+	if input == "-" && opts.promptConfirm {
+		return fmt.Errorf("--input - cannot be used while trading_enabled is confirm: the prompt reads its answer from stdin")
+	}
+
+	data, err := readOrderInput(cmd, input)
+	if err != nil {
+		return fmt.Errorf("failed to read order: %w", err)
+	}
+
+	var order submittedOrder
+	if err := json.Unmarshal(data, &order); err != nil {
+		return fmt.Errorf("invalid order JSON: %w", err)
+	}
+	// Keep every field, including ones the CLI doesn't know about yet
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("invalid order JSON: %w", err)
+	}
+	if err := validateSubmittedOrder(order); err != nil {
+		return err
+	}
+	if !force {
+		if err := submittedOrderCap(order, opts).check("order", submittedQuantity(order)); err != nil {
+			return err
+		}
+	}
+	if order.OrderID == "" {
+		order.OrderID = generateOrderID(opts.newOrderID)
+		raw["orderId"] = order.OrderID
+	}
+
+	out := cmd.OutOrStdout()
+	if !opts.jsonMode {
+		_, _ = fmt.Fprintf(out, "\nOrder Preview:\n")
+		_, _ = fmt.Fprintf(out, "  Action:   %s\n", order.OrderSide)
+		_, _ = fmt.Fprintf(out, "  Symbol:   %s (%s)\n", order.Instrument.Symbol, order.Instrument.Type)
+		if order.Quantity != "" {
+			_, _ = fmt.Fprintf(out, "  Quantity: %s\n", order.Quantity)
+		} else {
+			_, _ = fmt.Fprintf(out, "  Amount:   $%s\n", order.Amount)
+		}
+		_, _ = fmt.Fprintf(out, "  Type:     %s\n", order.OrderType)
+		if order.LimitPrice != "" {
+			_, _ = fmt.Fprintf(out, "  Limit:    $%s\n", order.LimitPrice)
+		}
+		if order.StopPrice != "" {
+			_, _ = fmt.Fprintf(out, "  Stop:     $%s\n", order.StopPrice)
+		}
+		if order.OpenCloseIndicator != "" {
+			_, _ = fmt.Fprintf(out, "  Position: %s\n", order.OpenCloseIndicator)
+		}
+		_, _ = fmt.Fprintf(out, "  Expires:  %s\n", order.Expiration.TimeInForce)
+		if reason != "" {
+			_, _ = fmt.Fprintf(out, "  Reason:   %s\n", reason)
+		}
+		_, _ = fmt.Fprintf(out, "\n  Order ID: %s\n\n", order.OrderID)
+	}
 
-	client := api.NewClient(opts.baseURL, opts.authToken)
-	path := fmt.Sprintf("/userapigateway/trading/%s/preflight/single-leg", opts.accountID)
-	resp, err := client.Post(ctx, path, bytes.NewReader(body))
-	if err != nil {
-		return nil, fmt.Errorf("failed to call preflight: %w", err)
+	if err := confirmTrade(cmd, opts.promptConfirm, skipConfirm, opts.jsonMode, "order", placeOrderQuestion(opts.confirmPrompt, defaultPlaceQuestion)); err != nil {
+		return err
 	}
-	defer func() { _ = resp.Body.Close() }()
 
-	if resp.StatusCode != 200 {
-		respBody, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("preflight API error: %d - %s", resp.StatusCode, string(respBody))
+	body, err := json.Marshal(raw)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
 	}
 
-	var preflightResp api.PreflightResponse
-	if err := json.NewDecoder(resp.Body).Decode(&preflightResp); err != nil {
-		return nil, fmt.Errorf("failed to decode preflight response: %w", err)
+	client := api.NewClient(opts.baseURL, opts.authToken)
+	var result map[string]any
+	if err := submitOrderBody(client, opts.accountID, body, &result); err != nil {
+		return err
 	}
+	recordJournal(cmd.ErrOrStderr(), opts.journalPath, equityJournalEntry(opts.accountID, order.OrderRequest, reason), time.Now())
 
-	return &preflightResp, nil
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(result)
 }
 
-func runOrder(cmd *cobra.Command, opts orderOptions, symbol, side string, params orderParams, skipConfirm bool) error {
-	// Check trading is enabled
-	if !opts.tradingEnabled {
-		return config.ErrTradingDisabled
-	}
-
-	// Validate inputs
+func runOrderEstimate(cmd *cobra.Command, opts orderOptions, symbol, side string, params orderParams) error {
 	if opts.accountID == "" {
 		return fmt.Errorf("account ID is required (use --account flag or configure default account)")
 	}
 
-	if params.quantity == "" {
-		return fmt.Errorf("quantity is required (use --quantity flag)")
+	side = strings.ToUpper(side)
+	if err := validateOrderParams(params, side); err != nil {
+		return err
 	}
 
 	symbol = strings.ToUpper(symbol)
-	orderID := uuid.New().String()
-	orderType := determineOrderType(params.limitPrice, params.stopPrice)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	instType, err := resolveInstrumentType(ctx, api.NewClient(opts.baseURL, opts.authToken), symbol, params.instrumentType)
+	cancel()
+	if err != nil {
+		return err
+	}
+	params.instrumentType = instType
 
-	// Validate expiration
-	expiration := strings.ToUpper(params.expiration)
-	if expiration != "DAY" && expiration != "GTC" {
-		return fmt.Errorf("invalid expiration: %s (use DAY or GTC)", params.expiration)
+	preflight, err := runPreflight(opts, symbol, side, params)
+	if err != nil {
+		return err
 	}
 
-	// Call preflight to get estimated costs
-	preflight, preflightErr := runPreflight(opts, symbol, side, params)
+	if opts.jsonMode {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(preflight)
+	}
 
-	// Show order preview (not in JSON mode)
-	if !opts.jsonMode {
-		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "\nOrder Preview:\n")
-		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  Action:   %s\n", side)
-		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  Symbol:   %s\n", symbol)
-		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  Quantity: %s shares\n", params.quantity)
-		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  Type:     %s\n", orderType)
-		if params.limitPrice != "" {
-			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  Limit:    $%s\n", params.limitPrice)
-		}
-		if params.stopPrice != "" {
-			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  Stop:     $%s\n", params.stopPrice)
-		}
-		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  Expires:  %s\n", expiration)
+	out := cmd.OutOrStdout()
+	_, _ = fmt.Fprintf(out, "\nEstimate: %s %s shares of %s (%s)\n",
+		side, params.quantity, symbol, determineOrderType(params.limitPrice, params.stopPrice))
+	_, _ = fmt.Fprintf(out, "  Order Value:  $%s\n", preflight.OrderValue)
+	_, _ = fmt.Fprintf(out, "  Commission:   $%s\n", preflight.EstimatedCommission)
+	_, _ = fmt.Fprintf(out, "  Reg Fees:     $%s\n", sumFees(preflight.RegulatoryFees))
+	_, _ = fmt.Fprintf(out, "  Total:        $%s\n", preflight.EstimatedCost)
+	if preflight.BuyingPowerRequirement != "" {
+		_, _ = fmt.Fprintf(out, "  Buying Power: $%s\n", preflight.BuyingPowerRequirement)
+	}
 
-		// Show preflight cost estimates if available
-		if preflightErr == nil && preflight != nil {
-			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "\n  Estimated Cost:\n")
-			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "    Order Value:  $%s\n", preflight.OrderValue)
-			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "    Commission:   $%s\n", preflight.EstimatedCommission)
-			totalFees := sumFees(preflight.RegulatoryFees)
-			if totalFees != "0.00" {
-				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "    Reg Fees:     $%s\n", totalFees)
-			}
-			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "    Total:        $%s\n", preflight.EstimatedCost)
-		} else if preflightErr != nil {
-			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "\n  Cost Estimate: unavailable (%s)\n", extractErrorMessage(preflightErr))
-		}
+	return nil
+}
 
-		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "\n  Order ID: %s\n\n", orderID)
+// closeQuantity returns the side and quantity needed to close the given
+// share of a position. Whole-share positions are rounded down to whole shares.
+func closeQuantity(held float64, percent float64) (string, string, error) {
+	if held == 0 {
+		return "", "", fmt.Errorf("position quantity is zero")
+	}
+	side := "SELL"
+	if held < 0 {
+		side = "BUY"
+		held = -held
 	}
 
-	// Require confirmation unless --yes flag is set
-	if !skipConfirm {
-		return fmt.Errorf("order requires confirmation (use --yes to confirm)")
+	qty := held * percent / 100
+	if held == math.Trunc(held) {
+		qty = math.Floor(qty)
+	} else {
+		qty = math.Floor(qty*1e5) / 1e5
+	}
+	if qty <= 0 {
+		return "", "", fmt.Errorf("%.4g%% of %s shares rounds to zero", percent, strconv.FormatFloat(held, 'f', -1, 64))
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
+	return side, strconv.FormatFloat(qty, 'f', -1, 64), nil
+}
 
-	// Build order request
-	orderReq := api.OrderRequest{
-		OrderID: orderID,
-		Instrument: api.OrderInstrument{
-			Symbol: symbol,
-			Type:   "EQUITY",
-		},
-		OrderSide: side,
-		OrderType: orderType,
-		Expiration: api.OrderExpiration{
-			TimeInForce: expiration,
-		},
-		Quantity:   params.quantity,
-		LimitPrice: params.limitPrice,
-		StopPrice:  params.stopPrice,
+func runOrderClose(cmd *cobra.Command, opts orderOptions, symbol string, params closeParams, skipConfirm bool) error {
+	if !opts.tradingEnabled {
+		return config.ErrTradingDisabled
 	}
-
-	body, err := json.Marshal(orderReq)
-	if err != nil {
-		return fmt.Errorf("failed to encode request: %w", err)
+	if opts.accountID == "" {
+		return fmt.Errorf("account ID is required (use --account flag or configure default account)")
 	}
+	if params.percent <= 0 || params.percent > 100 {
+		return fmt.Errorf("invalid --percent %g: must be greater than 0 and at most 100", params.percent)
+	}
+
+	symbol = strings.ToUpper(symbol)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
 
 	client := api.NewClient(opts.baseURL, opts.authToken)
-	path := fmt.Sprintf("/userapigateway/trading/%s/order", opts.accountID)
-	resp, err := client.Post(ctx, path, bytes.NewReader(body))
+	portfolio, err := client.GetPortfolio(ctx, opts.accountID)
 	if err != nil {
-		return fmt.Errorf("failed to place order: %w", err)
+		return err
 	}
-	defer func() { _ = resp.Body.Close() }()
 
-	if resp.StatusCode != 200 {
-		respBody, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API error: %d - %s", resp.StatusCode, string(respBody))
+	position := findPosition(portfolio.Positions, symbol, "")
+	if position == nil {
+		return fmt.Errorf("no position in %s", symbol)
 	}
 
-	var orderResp api.OrderResponse
-	if err := json.NewDecoder(resp.Body).Decode(&orderResp); err != nil {
-		return fmt.Errorf("failed to decode response: %w", err)
+	held, err := strconv.ParseFloat(position.Quantity, 64)
+	if err != nil {
+		return fmt.Errorf("invalid position quantity %q for %s", position.Quantity, symbol)
 	}
 
-	// Output result
-	if opts.jsonMode {
-		result := map[string]any{
-			"orderId":   orderResp.OrderID,
-			"status":    "placed",
-			"symbol":    symbol,
-			"side":      side,
-			"quantity":  params.quantity,
-			"orderType": orderType,
-		}
-		if params.limitPrice != "" {
-			result["limitPrice"] = params.limitPrice
-		}
-		if params.stopPrice != "" {
-			result["stopPrice"] = params.stopPrice
-		}
-		enc := json.NewEncoder(cmd.OutOrStdout())
-		enc.SetIndent("", "  ")
-		return enc.Encode(result)
+	side, quantity, err := closeQuantity(held, params.percent)
+	if err != nil {
+		return fmt.Errorf("cannot close %s: %w", symbol, err)
 	}
 
-	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Order placed successfully!\n")
-	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  Order ID: %s\n", orderResp.OrderID)
-	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  %s %s shares of %s (%s)\n", side, params.quantity, symbol, orderType)
-	if params.limitPrice != "" {
-		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  Limit: $%s\n", params.limitPrice)
-	}
-	if params.stopPrice != "" {
-		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  Stop: $%s\n", params.stopPrice)
+	if !opts.jsonMode {
+		direction := "long"
+		if held < 0 {
+			direction = "short"
+		}
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "\nClosing %s position: %s of %s shares of %s\n",
+			direction, quantity, position.Quantity, symbol)
 	}
-	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "\nNote: Order placement is asynchronous. Use 'pub order status %s' to check execution status.\n", orderResp.OrderID)
 
-	return nil
+	return runOrder(cmd, opts, symbol, side, orderParams{
+		quantity:       quantity,
+		limitPrice:     params.limitPrice,
+		expiration:     "DAY",
+		noPreflight:    params.noPreflight,
+		reason:         params.reason,
+		instrumentType: position.Instrument.Type,
+		closing:        true,
+	}, skipConfirm)
 }
 
 func init() {
@@ -651,6 +2348,7 @@ func init() {
 	// Buy subcommand
 	var buyParams orderParams
 	var buySkipConfirm bool
+	var buyOrderID string
 	buyCmd := &cobra.Command{
 		Use:   "buy SYMBOL",
 		Short: "Buy shares of a stock",
@@ -662,12 +2360,63 @@ Order types are determined by the flags used:
   - --stop: STOP order (triggers when stop price is reached)
   - --limit and --stop: STOP_LIMIT order (triggers at stop, executes at limit)
 
+By default a preflight call estimates the order cost before submitting. Use
+--no-preflight to skip it for lower latency; the preview then shows only the
+order parameters, and buying-power problems surface only when the order is
+rejected.
+
+Use --retry-on-reject N to resubmit with a new order ID when the order is
+rejected for a transient reason such as a momentary trading halt. Rejections
+like insufficient buying power are never retried, and neither are HTTP errors
+such as 429 or 503, since the order may already have been accepted.
+
+Use --peg bid|ask|mid|last to set the limit from a fresh quote instead of
+--limit, plus --offset dollars (negative to improve on it). --offset alone
+pegs to the ask for buys and the bid for sells. The price is rounded to the
+tick (a penny, or $0.0001 under $1) away from paying up: down for buys, up
+for sells. The preview shows the reference and the computed limit.
+
+Use --reduce-only to guarantee the order only shrinks a position you hold: it
+is checked against the portfolio before sending and rejected if it would
+open, increase, or flip the position. The API cannot enforce it itself.
+
+Orders for more shares than max_shares in the config are rejected before
+anything is sent, whatever the price; --max-shares N sets the cap for one
+order and --force skips it.
+
+Orders for a symbol whose quote reports a trading halt are rejected too;
+--force places them anyway. --no-preflight skips the halt check unless --peg
+or --risk fetches a quote regardless.
+
+Orders are sent as EQUITY instruments. Use --instrument-type ETF, ADR, or
+CRYPTO for a symbol the API classifies differently, or --instrument-type auto
+to look the symbol up and use the type the API reports.
+
+For tools that show an order before placing it, --preview-json-then-confirm
+prints the preview (the exact order request, order ID included, and the
+preflight estimate) as JSON with a confirmToken, and places nothing. Running
+the same command with --confirm-token TOKEN and no other order flags places
+that exact order. Tokens are signed with your secret key and expire after 5
+minutes.
+
+Use --risk to size by risk instead of share count. With --risk, --stop is the
+price where you would exit rather than an order trigger: the quantity is
+--risk divided by the per-share risk (entry minus stop), rounded down to whole
+shares. The entry is --limit when set, otherwise the current ask. The stop is
+not placed with the entry, since it would sell shares not yet held if it
+triggered first; once the entry fills, place it with the printed command.
+
 Examples:
   pub order buy AAPL --quantity 10                           # Market order
   pub order buy AAPL --quantity 10 --limit 175.00            # Limit order
   pub order buy AAPL --quantity 10 --stop 180.00             # Stop order
   pub order buy AAPL --quantity 10 --limit 175.00 --stop 174.00  # Stop-limit order
-  pub order buy AAPL --quantity 10 --limit 175.00 --expiration GTC  # Good till cancelled`,
+  pub order buy AAPL --quantity 10 --limit 175.00 --expiration GTC  # Good till cancelled
+  pub order buy AAPL --quantity 10 --peg mid                  # Limit at the midpoint
+  pub order buy AAPL --quantity 10 --peg bid --offset 0.01    # Limit a penny above the bid
+  pub order buy AAPL --quantity 10 --reduce-only                # Cover part of a short
+  pub order buy AAPL --risk 100 --stop 170                      # Lose at most $100 at 170
+  pub order buy AAPL --risk 100 --stop 170 --limit 175          # Same, entering at 175`,
 		Args: cobra.ExactArgs(1),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			return nil // Validation happens in RunE
@@ -684,32 +2433,63 @@ Examples:
 				return err
 			}
 
-			if accountID == "" {
-				accountID = cfg.AccountUUID
+			accountID, err = resolveAccountID(accountID, cfg.AccountUUID, cmd.InOrStdin())
+			if err != nil {
+				return err
 			}
 
 			opts := orderOptions{
 				baseURL:        cfg.APIBaseURL,
 				authToken:      token,
 				accountID:      accountID,
-				tradingEnabled: cfg.TradingEnabled,
+				tradingEnabled: cfg.TradingEnabled(),
+				promptConfirm:  cfg.Trading == config.TradingConfirm,
+				confirmPrompt:  cfg.ConfirmPrompt,
 				jsonMode:       GetJSONMode(),
+				maxShares:      cfg.MaxShares,
+				requireReason:  cfg.RequireReason,
+				journalPath:    journalPath(),
+			}
+
+			if err := useOrderID(&opts.newOrderID, buyOrderID); err != nil {
+				return err
+			}
+			if buyParams.previewJSON || buyParams.confirmToken != "" {
+				secret, err := store.Get(keyring.ServiceName, keyring.KeySecretKey)
+				if err != nil {
+					return fmt.Errorf("failed to read secret key for the confirm token: %w", err)
+				}
+				opts.confirmKey = []byte(secret)
 			}
 
 			return runOrder(cmd, opts, args[0], "BUY", buyParams, buySkipConfirm)
 		},
 	}
-	buyCmd.Flags().StringVarP(&buyParams.quantity, "quantity", "q", "", "Number of shares to buy (required)")
+	buyCmd.Flags().StringVarP(&buyParams.quantity, "quantity", "q", "", "Number of shares to buy (required unless --risk is set)")
+	buyCmd.Flags().StringVar(&buyParams.risk, "risk", "", "Dollars to risk; sizes the order from the entry down to --stop")
 	buyCmd.Flags().StringVarP(&buyParams.limitPrice, "limit", "l", "", "Limit price for LIMIT or STOP_LIMIT orders")
+	buyCmd.Flags().StringVar(&buyParams.peg.reference, "peg", "", "Set the limit from the quote: bid, ask, mid, or last")
+	buyCmd.Flags().StringVar(&buyParams.peg.offset, "offset", "", "Dollars added to the --peg reference (e.g. -0.02)")
 	buyCmd.Flags().StringVarP(&buyParams.stopPrice, "stop", "s", "", "Stop price for STOP or STOP_LIMIT orders")
 	buyCmd.Flags().StringVarP(&buyParams.expiration, "expiration", "e", "DAY", "Order expiration: DAY (default) or GTC")
+	buyCmd.Flags().StringVar(&buyParams.instrumentType, "instrument-type", "EQUITY", "Instrument type: EQUITY, ETF, ADR, CRYPTO, or auto to look it up")
+	buyCmd.Flags().BoolVar(&buyParams.noPreflight, "no-preflight", false, "Skip the preflight cost estimate")
+	buyCmd.Flags().BoolVar(&buyParams.previewJSON, "preview-json-then-confirm", false, "Print the preview and a confirm token as JSON instead of placing the order")
+	buyCmd.Flags().StringVar(&buyParams.confirmToken, "confirm-token", "", "Place the order previewed with --preview-json-then-confirm")
+	buyCmd.Flags().StringVar(&buyParams.reason, "reason", "", "Why you are placing the order; shown in the preview and recorded in the trade journal")
+	buyCmd.Flags().BoolVar(&buyParams.reduceOnly, "reduce-only", false, "Only reduce an existing position; reject orders that would increase or flip it")
+	buyCmd.Flags().Float64Var(&buyParams.maxShares, "max-shares", 0, "Reject the order if it is for more shares than this (overrides max_shares)")
+	buyCmd.Flags().BoolVar(&buyParams.force, "force", false, "Place the order even if it exceeds --max-shares or max_shares, or the symbol is halted")
+	buyCmd.Flags().IntVar(&buyParams.retryOnReject, "retry-on-reject", 0, "Resubmit up to N times if the order is rejected for a transient reason")
+	buyCmd.Flags().StringVar(&buyOrderID, "order-id", "", "Client order ID (UUID) to use instead of a random one")
 	buyCmd.Flags().BoolVarP(&buySkipConfirm, "yes", "y", false, "Skip confirmation prompt")
-	buyCmd.Flags().StringVarP(&accountID, "account", "a", "", "Account ID (uses default if not specified)")
+	buyCmd.Flags().StringVarP(&accountID, "account", "a", "", "Account ID (uses default if not specified; - reads it from stdin)")
 	buyCmd.SilenceUsage = true
 
 	// Sell subcommand
 	var sellParams orderParams
 	var sellSkipConfirm bool
+	var sellOrderID string
 	sellCmd := &cobra.Command{
 		Use:   "sell SYMBOL",
 		Short: "Sell shares of a stock",
@@ -721,12 +2501,54 @@ Order types are determined by the flags used:
   - --stop: STOP order (triggers when stop price is reached)
   - --limit and --stop: STOP_LIMIT order (triggers at stop, executes at limit)
 
+By default a preflight call estimates the order cost before submitting. Use
+--no-preflight to skip it for lower latency; the preview then shows only the
+order parameters, and buying-power problems surface only when the order is
+rejected.
+
+Use --retry-on-reject N to resubmit with a new order ID when the order is
+rejected for a transient reason such as a momentary trading halt. Rejections
+like insufficient buying power are never retried, and neither are HTTP errors
+such as 429 or 503, since the order may already have been accepted.
+
+Use --peg bid|ask|mid|last to set the limit from a fresh quote instead of
+--limit, plus --offset dollars (negative to improve on it). --offset alone
+pegs to the ask for buys and the bid for sells. The price is rounded to the
+tick (a penny, or $0.0001 under $1) away from paying up: down for buys, up
+for sells. The preview shows the reference and the computed limit.
+
+Use --reduce-only to guarantee the order only shrinks a position you hold: it
+is checked against the portfolio before sending and rejected if it would
+open, increase, or flip the position. The API cannot enforce it itself.
+
+Orders for more shares than max_shares in the config are rejected before
+anything is sent, whatever the price; --max-shares N sets the cap for one
+order and --force skips it.
+
+Orders for a symbol whose quote reports a trading halt are rejected too;
+--force places them anyway. --no-preflight skips the halt check unless --peg
+or --risk fetches a quote regardless.
+
+Orders are sent as EQUITY instruments. Use --instrument-type ETF, ADR, or
+CRYPTO for a symbol the API classifies differently, or --instrument-type auto
+to look the symbol up and use the type the API reports.
+
+For tools that show an order before placing it, --preview-json-then-confirm
+prints the preview (the exact order request, order ID included, and the
+preflight estimate) as JSON with a confirmToken, and places nothing. Running
+the same command with --confirm-token TOKEN and no other order flags places
+that exact order. Tokens are signed with your secret key and expire after 5
+minutes.
+
 Examples:
   pub order sell AAPL --quantity 5                           # Market order
   pub order sell AAPL --quantity 5 --limit 180.00            # Limit order
   pub order sell AAPL --quantity 5 --stop 145.00             # Stop loss order
   pub order sell AAPL --quantity 5 --limit 144.00 --stop 145.00  # Stop-limit order
-  pub order sell AAPL --quantity 5 --limit 180.00 --expiration GTC  # Good till cancelled`,
+  pub order sell AAPL --quantity 5 --limit 180.00 --expiration GTC  # Good till cancelled
+  pub order sell AAPL --quantity 10 --peg mid                  # Limit at the midpoint
+  pub order sell AAPL --quantity 10 --offset 0.02              # Limit two cents above the bid
+  pub order sell AAPL --quantity 10 --reduce-only                # Trim a long, never go short`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfg, err := config.Load(config.ConfigPath())
@@ -740,16 +2562,33 @@ Examples:
 				return err
 			}
 
-			if accountID == "" {
-				accountID = cfg.AccountUUID
+			accountID, err = resolveAccountID(accountID, cfg.AccountUUID, cmd.InOrStdin())
+			if err != nil {
+				return err
 			}
 
 			opts := orderOptions{
 				baseURL:        cfg.APIBaseURL,
 				authToken:      token,
 				accountID:      accountID,
-				tradingEnabled: cfg.TradingEnabled,
+				tradingEnabled: cfg.TradingEnabled(),
+				promptConfirm:  cfg.Trading == config.TradingConfirm,
+				confirmPrompt:  cfg.ConfirmPrompt,
 				jsonMode:       GetJSONMode(),
+				maxShares:      cfg.MaxShares,
+				requireReason:  cfg.RequireReason,
+				journalPath:    journalPath(),
+			}
+
+			if err := useOrderID(&opts.newOrderID, sellOrderID); err != nil {
+				return err
+			}
+			if sellParams.previewJSON || sellParams.confirmToken != "" {
+				secret, err := store.Get(keyring.ServiceName, keyring.KeySecretKey)
+				if err != nil {
+					return fmt.Errorf("failed to read secret key for the confirm token: %w", err)
+				}
+				opts.confirmKey = []byte(secret)
 			}
 
 			return runOrder(cmd, opts, args[0], "SELL", sellParams, sellSkipConfirm)
@@ -757,23 +2596,234 @@ Examples:
 	}
 	sellCmd.Flags().StringVarP(&sellParams.quantity, "quantity", "q", "", "Number of shares to sell (required)")
 	sellCmd.Flags().StringVarP(&sellParams.limitPrice, "limit", "l", "", "Limit price for LIMIT or STOP_LIMIT orders")
+	sellCmd.Flags().StringVar(&sellParams.peg.reference, "peg", "", "Set the limit from the quote: bid, ask, mid, or last")
+	sellCmd.Flags().StringVar(&sellParams.peg.offset, "offset", "", "Dollars added to the --peg reference (e.g. -0.02)")
 	sellCmd.Flags().StringVarP(&sellParams.stopPrice, "stop", "s", "", "Stop price for STOP or STOP_LIMIT orders")
 	sellCmd.Flags().StringVarP(&sellParams.expiration, "expiration", "e", "DAY", "Order expiration: DAY (default) or GTC")
+	sellCmd.Flags().StringVar(&sellParams.instrumentType, "instrument-type", "EQUITY", "Instrument type: EQUITY, ETF, ADR, CRYPTO, or auto to look it up")
+	sellCmd.Flags().BoolVar(&sellParams.noPreflight, "no-preflight", false, "Skip the preflight cost estimate")
+	sellCmd.Flags().BoolVar(&sellParams.previewJSON, "preview-json-then-confirm", false, "Print the preview and a confirm token as JSON instead of placing the order")
+	sellCmd.Flags().StringVar(&sellParams.confirmToken, "confirm-token", "", "Place the order previewed with --preview-json-then-confirm")
+	sellCmd.Flags().StringVar(&sellParams.reason, "reason", "", "Why you are placing the order; shown in the preview and recorded in the trade journal")
+	sellCmd.Flags().BoolVar(&sellParams.reduceOnly, "reduce-only", false, "Only reduce an existing position; reject orders that would increase or flip it")
+	sellCmd.Flags().Float64Var(&sellParams.maxShares, "max-shares", 0, "Reject the order if it is for more shares than this (overrides max_shares)")
+	sellCmd.Flags().BoolVar(&sellParams.force, "force", false, "Place the order even if it exceeds --max-shares or max_shares, or the symbol is halted")
+	sellCmd.Flags().IntVar(&sellParams.retryOnReject, "retry-on-reject", 0, "Resubmit up to N times if the order is rejected for a transient reason")
+	sellCmd.Flags().StringVar(&sellOrderID, "order-id", "", "Client order ID (UUID) to use instead of a random one")
 	sellCmd.Flags().BoolVarP(&sellSkipConfirm, "yes", "y", false, "Skip confirmation prompt")
-	sellCmd.Flags().StringVarP(&accountID, "account", "a", "", "Account ID (uses default if not specified)")
+	sellCmd.Flags().StringVarP(&accountID, "account", "a", "", "Account ID (uses default if not specified; - reads it from stdin)")
 	sellCmd.SilenceUsage = true
 
+	// Close subcommand
+	var closeParamsFlags closeParams
+	var closeSkipConfirm bool
+	closeCmd := &cobra.Command{
+		Use:   "close SYMBOL",
+		Short: "Close a stock, ETF, ADR, or crypto position",
+		Long: `Close a stock, ETF, ADR, or crypto position in full, or partially with --percent.
+
+The held quantity and instrument type are read from the portfolio. Long positions are closed with
+a SELL and short positions with a BUY to cover. Partial closes round down to
+whole shares unless the position itself is fractional.
+
+max_shares and the trading halt check do not apply: a close only reduces
+the position, and a halted one can still be queued with --limit.
+
+Examples:
+  pub order close AAPL                         # Market order for the full position
+  pub order close AAPL --limit 180.00          # Limit order for the full position
+  pub order close AAPL --percent 50 --yes      # Close half the position`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load(config.ConfigPath())
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			store := keyring.NewEnvStore(keyring.NewSystemStore())
+			token, err := api.GetAuthToken(store, cfg.APIBaseURL, false)
+			if err != nil {
+				return err
+			}
+
+			accountID, err = resolveAccountID(accountID, cfg.AccountUUID, cmd.InOrStdin())
+			if err != nil {
+				return err
+			}
+
+			opts := orderOptions{
+				baseURL:        cfg.APIBaseURL,
+				authToken:      token,
+				accountID:      accountID,
+				tradingEnabled: cfg.TradingEnabled(),
+				promptConfirm:  cfg.Trading == config.TradingConfirm,
+				confirmPrompt:  cfg.ConfirmPrompt,
+				jsonMode:       GetJSONMode(),
+				maxShares:      cfg.MaxShares,
+				requireReason:  cfg.RequireReason,
+				journalPath:    journalPath(),
+			}
+
+			return runOrderClose(cmd, opts, args[0], closeParamsFlags, closeSkipConfirm)
+		},
+	}
+	closeCmd.Flags().StringVarP(&closeParamsFlags.limitPrice, "limit", "l", "", "Limit price (default: market order)")
+	closeCmd.Flags().Float64Var(&closeParamsFlags.percent, "percent", 100, "Percentage of the position to close")
+	closeCmd.Flags().BoolVar(&closeParamsFlags.noPreflight, "no-preflight", false, "Skip the preflight cost estimate")
+	closeCmd.Flags().StringVar(&closeParamsFlags.reason, "reason", "", "Why you are placing the order; shown in the preview and recorded in the trade journal")
+	closeCmd.Flags().BoolVarP(&closeSkipConfirm, "yes", "y", false, "Skip confirmation prompt")
+	closeCmd.Flags().StringVarP(&accountID, "account", "a", "", "Account ID (uses default if not specified; - reads it from stdin)")
+	closeCmd.SilenceUsage = true
+
+	// Submit subcommand
+	var submitInput string
+	var submitSkipConfirm bool
+	var submitReason string
+	var submitForce bool
+	submitCmd := &cobra.Command{
+		Use:   "submit",
+		Short: "Submit a complete order body from JSON",
+		Long: `Submit a complete single-leg order body read as JSON from a file or stdin.
+
+This is an escape hatch for fields the order flags don't cover yet. The body
+uses the API's order shape (instrument, orderSide, orderType, expiration,
+quantity or amount, limitPrice, ...). Option orders also need
+openCloseIndicator. Unknown fields are sent through unchanged. A random
+orderId is added if the body has none.
+
+The order is previewed and requires --yes. The server response is printed as
+JSON. Orders for more shares than max_shares, or more contracts than
+max_contracts, are rejected unless --force is set.
+
+Examples:
+  pub order submit --input order.json --yes
+  cat order.json | pub order submit --input - --yes`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load(config.ConfigPath())
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			store := keyring.NewEnvStore(keyring.NewSystemStore())
+			token, err := api.GetAuthToken(store, cfg.APIBaseURL, false)
+			if err != nil {
+				return err
+			}
+
+			if err := validateSubmitStdin(accountID, submitInput); err != nil {
+				return err
+			}
+			accountID, err = resolveAccountID(accountID, cfg.AccountUUID, cmd.InOrStdin())
+			if err != nil {
+				return err
+			}
+
+			opts := orderOptions{
+				baseURL:        cfg.APIBaseURL,
+				authToken:      token,
+				accountID:      accountID,
+				tradingEnabled: cfg.TradingEnabled(),
+				promptConfirm:  cfg.Trading == config.TradingConfirm,
+				confirmPrompt:  cfg.ConfirmPrompt,
+				jsonMode:       GetJSONMode(),
+				maxShares:      cfg.MaxShares,
+				maxContracts:   cfg.MaxContracts,
+				requireReason:  cfg.RequireReason,
+				journalPath:    journalPath(),
+			}
+
+			return runOrderSubmit(cmd, opts, submitInput, submitReason, submitForce, submitSkipConfirm)
+		},
+	}
+	submitCmd.Flags().StringVarP(&submitInput, "input", "i", "", "JSON order file, or - for stdin (required)")
+	submitCmd.Flags().BoolVarP(&submitSkipConfirm, "yes", "y", false, "Skip confirmation prompt")
+	submitCmd.Flags().StringVar(&submitReason, "reason", "", "Why you are placing the order; shown in the preview and recorded in the trade journal")
+	submitCmd.Flags().BoolVar(&submitForce, "force", false, "Submit the order even if it exceeds max_shares or max_contracts")
+	submitCmd.Flags().StringVarP(&accountID, "account", "a", "", "Account ID (uses default if not specified; - reads it from stdin)")
+	submitCmd.SilenceUsage = true
+
+	// Estimate subcommand
+	var estimateParams orderParams
+	var estimateSide string
+	estimateCmd := &cobra.Command{
+		Use:   "estimate SYMBOL",
+		Short: "Estimate the cost of an order without placing it",
+		Long: `Estimate the cost of an equity order without placing it.
+
+Calls the preflight endpoint and prints the order value, commission,
+regulatory fees, total cost, and buying power requirement. Nothing is
+submitted, so no confirmation is needed and trading does not need to be
+enabled.
+
+Examples:
+  pub order estimate AAPL --side BUY -q 10                 # Market buy estimate
+  pub order estimate AAPL --side SELL -q 5 --limit 180.00  # Limit sell estimate
+  pub order estimate AAPL --side BUY -q 10 --json          # Output as JSON`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load(config.ConfigPath())
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			store := keyring.NewEnvStore(keyring.NewSystemStore())
+			token, err := api.GetAuthToken(store, cfg.APIBaseURL, false)
+			if err != nil {
+				return err
+			}
+
+			accountID, err = resolveAccountID(accountID, cfg.AccountUUID, cmd.InOrStdin())
+			if err != nil {
+				return err
+			}
+
+			opts := orderOptions{
+				baseURL:   cfg.APIBaseURL,
+				authToken: token,
+				accountID: accountID,
+				jsonMode:  GetJSONMode(),
+			}
+
+			return runOrderEstimate(cmd, opts, args[0], estimateSide, estimateParams)
+		},
+	}
+	estimateCmd.Flags().StringVar(&estimateSide, "side", "", "Order side: BUY or SELL (required)")
+	estimateCmd.Flags().StringVarP(&estimateParams.quantity, "quantity", "q", "", "Number of shares (required)")
+	estimateCmd.Flags().StringVarP(&estimateParams.limitPrice, "limit", "l", "", "Limit price for LIMIT or STOP_LIMIT orders")
+	estimateCmd.Flags().StringVarP(&estimateParams.stopPrice, "stop", "s", "", "Stop price for STOP or STOP_LIMIT orders")
+	estimateCmd.Flags().StringVarP(&estimateParams.expiration, "expiration", "e", "DAY", "Order expiration: DAY (default) or GTC")
+	estimateCmd.Flags().StringVar(&estimateParams.instrumentType, "instrument-type", "EQUITY", "Instrument type: EQUITY, ETF, ADR, CRYPTO, or auto to look it up")
+	estimateCmd.Flags().StringVarP(&accountID, "account", "a", "", "Account ID (uses default if not specified; - reads it from stdin)")
+	estimateCmd.SilenceUsage = true
+
 	// Cancel subcommand
 	var cancelSkipConfirm bool
+	var cancelSelect cancelParams
 	cancelCmd := &cobra.Command{
-		Use:   "cancel ORDER_ID",
-		Short: "Cancel an open order",
-		Long: `Cancel an open order by its order ID.
+		Use:   "cancel [ORDER_ID...]",
+		Short: "Cancel open orders",
+		Long: `Cancel open orders by order ID, or every open order with --all.
+
+--older-than cancels only open orders placed at least that long ago, such as
+forgotten GTC orders. It takes days (7d), weeks (2w), or hours (36h). Orders
+whose creation time cannot be read are skipped with a warning. --symbol and
+--side narrow --all or --older-than to one symbol or side. The matching orders
+are previewed with their age; nothing is cancelled without --yes.
+
+When more than one order is cancelled, a summary follows with the number of
+cancellations that succeeded and failed, the estimated value of the cancelled
+orders, and each order's result. With --json the summary is a single object
+with a results array. The command exits non-zero if any cancellation failed.
 
 Examples:
   pub order cancel 912710f1-1a45-4ef0-88a7-cd513781933d        # Cancel order (requires confirmation)
-  pub order cancel 912710f1-1a45-4ef0-88a7-cd513781933d --yes  # Skip confirmation`,
-		Args: cobra.ExactArgs(1),
+  pub order cancel 912710f1-1a45-4ef0-88a7-cd513781933d --yes  # Skip confirmation
+  pub order cancel ORDER_ID_1 ORDER_ID_2 --yes                 # Cancel several orders
+  pub order cancel --all --yes --json                          # Cancel everything, summary as JSON
+  pub order cancel --older-than 7d                             # Preview orders over a week old
+  pub order cancel --older-than 30d --symbol AAPL --side BUY --yes  # Clean up stale AAPL bids`,
+		Args: cancelArgs(&cancelSelect),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfg, err := config.Load(config.ConfigPath())
 			if err != nil {
@@ -786,23 +2836,34 @@ Examples:
 				return err
 			}
 
-			if accountID == "" {
-				accountID = cfg.AccountUUID
+			accountID, err = resolveAccountID(accountID, cfg.AccountUUID, cmd.InOrStdin())
+			if err != nil {
+				return err
 			}
 
 			opts := orderOptions{
 				baseURL:        cfg.APIBaseURL,
 				authToken:      token,
 				accountID:      accountID,
-				tradingEnabled: cfg.TradingEnabled,
+				tradingEnabled: cfg.TradingEnabled(),
+				promptConfirm:  cfg.Trading == config.TradingConfirm,
+				confirmPrompt:  cfg.ConfirmPrompt,
 				jsonMode:       GetJSONMode(),
+				maxShares:      cfg.MaxShares,
 			}
 
-			return runCancelOrder(cmd, opts, args[0], cancelSkipConfirm)
+			if !cancelSelect.selects() && len(args) == 1 {
+				return runCancelOrder(cmd, opts, args[0], cancelSkipConfirm)
+			}
+			return runCancelOrders(cmd, opts, args, cancelSelect, cancelSkipConfirm)
 		},
 	}
 	cancelCmd.Flags().BoolVarP(&cancelSkipConfirm, "yes", "y", false, "Skip confirmation prompt")
-	cancelCmd.Flags().StringVarP(&accountID, "account", "a", "", "Account ID (uses default if not specified)")
+	cancelCmd.Flags().BoolVar(&cancelSelect.all, "all", false, "Cancel every open order")
+	cancelCmd.Flags().StringVar(&cancelSelect.olderThan, "older-than", "", "Cancel open orders placed at least this long ago (e.g. 7d, 2w, 36h)")
+	cancelCmd.Flags().StringVar(&cancelSelect.symbol, "symbol", "", "With --all or --older-than, only cancel orders for this symbol")
+	cancelCmd.Flags().StringVar(&cancelSelect.side, "side", "", "With --all or --older-than, only cancel BUY or SELL orders")
+	cancelCmd.Flags().StringVarP(&accountID, "account", "a", "", "Account ID (uses default if not specified; - reads it from stdin)")
 	cancelCmd.SilenceUsage = true
 
 	// Status subcommand
@@ -828,8 +2889,9 @@ Examples:
 				return err
 			}
 
-			if accountID == "" {
-				accountID = cfg.AccountUUID
+			accountID, err = resolveAccountID(accountID, cfg.AccountUUID, cmd.InOrStdin())
+			if err != nil {
+				return err
 			}
 
 			opts := orderOptions{
@@ -842,10 +2904,11 @@ Examples:
 			return runOrderStatus(cmd, opts, args[0])
 		},
 	}
-	statusCmd.Flags().StringVarP(&accountID, "account", "a", "", "Account ID (uses default if not specified)")
+	statusCmd.Flags().StringVarP(&accountID, "account", "a", "", "Account ID (uses default if not specified; - reads it from stdin)")
 	statusCmd.SilenceUsage = true
 
 	// List subcommand
+	var listParams orderListParams
 	listCmd := &cobra.Command{
 		Use:   "list",
 		Short: "List open orders",
@@ -853,9 +2916,35 @@ Examples:
 
 Shows orders that are pending, new, or partially filled.
 
+With --include-closed, filled trades from the account history are merged in
+and each row is marked OPEN or CLOSED, newest first. Use --from to limit how
+far back history is read. The API only reports executed trades in history, so
+cancelled, rejected, and expired orders are not included once they close.
+History rows show the trade's transaction ID, since the history has no order
+IDs; the fills of a partly filled open order appear as their own CLOSED rows.
+
+Use --status (comma-separated), --symbol, and --side to narrow the list.
+Filters apply to both table and JSON output.
+
+--summary adds a footer with the number of orders by status and the working
+notional: the unfilled quantity of each open order times its limit (or stop)
+price, with the 100x multiplier for options. Open market orders have no price
+and are counted separately. With --json the output becomes an object with
+orders and summary fields.
+
+--watch reprints the open orders every --interval (default 5s) until Ctrl-C,
+as a live blotter. Orders whose status or filled quantity changed since the
+last refresh are marked with the change (e.g. NEW -> PARTIALLY_FILLED) and,
+on a terminal, highlighted; --no-color or NO_COLOR turns highlighting off.
+Orders that leave the list are reported below the table.
+
 Examples:
-  pub order list                # List open orders
-  pub order list --json         # Output as JSON`,
+  pub order list                                  # List open orders
+  pub order list --json                           # Output as JSON
+  pub order list --include-closed --from 2025-01-01  # Include recent fills
+  pub order list --status PARTIALLY_FILLED --side sell  # Partially filled sells
+  pub order list --summary                        # Totals by status and notional
+  pub order list --watch --interval 5s            # Live blotter until Ctrl-C`,
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfg, err := config.Load(config.ConfigPath())
@@ -869,8 +2958,9 @@ Examples:
 				return err
 			}
 
-			if accountID == "" {
-				accountID = cfg.AccountUUID
+			accountID, err = resolveAccountID(accountID, cfg.AccountUUID, cmd.InOrStdin())
+			if err != nil {
+				return err
 			}
 
 			opts := orderOptions{
@@ -878,16 +2968,29 @@ Examples:
 				authToken: token,
 				accountID: accountID,
 				jsonMode:  GetJSONMode(),
+				wide:      GetWideMode(),
+				color:     GetColorMode(cmd.OutOrStdout()),
 			}
 
-			return runOrderList(cmd, opts)
+			return runOrderList(cmd, opts, listParams)
 		},
 	}
-	listCmd.Flags().StringVarP(&accountID, "account", "a", "", "Account ID (uses default if not specified)")
+	listCmd.Flags().StringVarP(&accountID, "account", "a", "", "Account ID (uses default if not specified; - reads it from stdin)")
+	listCmd.Flags().BoolVar(&listParams.includeClosed, "include-closed", false, "Include filled orders from account history")
+	listCmd.Flags().StringVar(&listParams.from, "from", "", "Earliest date for closed orders with --include-closed (YYYY-MM-DD, -Nd, or ISO 8601)")
+	listCmd.Flags().StringVar(&listParams.status, "status", "", "Only show these statuses (comma-separated, e.g. NEW,PARTIALLY_FILLED)")
+	listCmd.Flags().StringVar(&listParams.symbol, "symbol", "", "Only show orders for this symbol")
+	listCmd.Flags().StringVar(&listParams.side, "side", "", "Only show BUY or SELL orders")
+	listCmd.Flags().BoolVar(&listParams.summary, "summary", false, "Show order counts by status and the working notional")
+	listCmd.Flags().BoolVar(&listParams.watch, "watch", false, "Reprint open orders every --interval until interrupted, marking changes")
+	listCmd.Flags().DurationVar(&listParams.interval, "interval", 5*time.Second, "Refresh interval for --watch")
 	listCmd.SilenceUsage = true
 
 	orderCmd.AddCommand(buyCmd)
 	orderCmd.AddCommand(sellCmd)
+	orderCmd.AddCommand(closeCmd)
+	orderCmd.AddCommand(estimateCmd)
+	orderCmd.AddCommand(submitCmd)
 	orderCmd.AddCommand(cancelCmd)
 	orderCmd.AddCommand(statusCmd)
 	orderCmd.AddCommand(listCmd)
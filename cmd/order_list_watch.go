@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jonandersen/public-cli/internal/api"
+)
+
+// minOrderListInterval is the shortest --interval for 'order list --watch',
+// so a blotter left running does not hammer the API.
+const minOrderListInterval = time.Second
+
+// validateOrderListWatch checks --watch against the other list flags. The
+// blotter is a refreshing table of open orders, so JSON output and closed
+// orders from history are rejected.
+func validateOrderListWatch(params orderListParams, jsonMode bool) error {
+	if !params.watch {
+		return nil
+	}
+	switch {
+	case jsonMode:
+		return fmt.Errorf("--watch prints a refreshing table and cannot be combined with --json")
+	case params.includeClosed:
+		return fmt.Errorf("--watch follows open orders and cannot be combined with --include-closed")
+	case params.interval < minOrderListInterval:
+		return fmt.Errorf("invalid --interval %s: must be at least %s", params.interval, minOrderListInterval)
+	}
+	return nil
+}
+
+// diffOrders compares the open orders with those seen on the last poll, by
+// order ID. It returns what changed for each order whose status or filled
+// quantity moved, or that was not listed before, and the orders from the last
+// poll that are no longer listed (filled, cancelled, or filtered out). A nil
+// previous, the first poll, reports nothing.
+func diffOrders(previous map[string]api.Order, orders []api.Order) (map[string]string, []api.Order) {
+	if previous == nil {
+		return nil, nil
+	}
+	changes := make(map[string]string)
+	listed := make(map[string]bool, len(orders))
+	for _, order := range orders {
+		listed[order.OrderID] = true
+		before, ok := previous[order.OrderID]
+		switch {
+		case !ok:
+			changes[order.OrderID] = "new order"
+		case before.Status != order.Status:
+			changes[order.OrderID] = before.Status + " -> " + order.Status
+		case before.FilledQuantity != order.FilledQuantity:
+			changes[order.OrderID] = fmt.Sprintf("filled %s -> %s", valueOrDash(before.FilledQuantity), valueOrDash(order.FilledQuantity))
+		}
+	}
+
+	var gone []api.Order
+	for id, order := range previous {
+		if !listed[id] {
+			gone = append(gone, order)
+		}
+	}
+	sort.Slice(gone, func(i, j int) bool { return gone[i].OrderID < gone[j].OrderID })
+	return changes, gone
+}
+
+// runOrderListWatch reprints the open-orders table every interval until
+// interrupted, marking orders that changed since the last poll. Fetch errors
+// are reported and retried on the next tick, as with portfolio --watch.
+func runOrderListWatch(cmd *cobra.Command, opts orderOptions, params orderListParams, filter orderListFilter) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	ticker := time.NewTicker(params.interval)
+	defer ticker.Stop()
+
+	client := api.NewClient(opts.baseURL, opts.authToken)
+	var previous map[string]api.Order
+	for {
+		previous = pollOrderList(ctx, cmd.OutOrStdout(), cmd.ErrOrStderr(), client, opts, params, filter, previous, time.Now())
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// pollOrderList fetches and prints one refresh of the blotter and returns the
+// orders to compare the next poll against. On a failed fetch it warns and
+// keeps previous, so changes are still caught once the API answers again.
+func pollOrderList(ctx context.Context, w, errW io.Writer, client *api.Client, opts orderOptions, params orderListParams, filter orderListFilter, previous map[string]api.Order, now time.Time) map[string]api.Order {
+	fetchCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	orders, err := fetchOpenOrders(fetchCtx, client, opts.accountID)
+	if err != nil {
+		if ctx.Err() == nil {
+			_, _ = fmt.Fprintf(errW, "Warning: %s\n", err)
+		}
+		return previous
+	}
+	if filter.active() {
+		orders = filterOrders(orders, filter)
+	}
+	changes, gone := diffOrders(previous, orders)
+
+	_, _ = fmt.Fprintf(w, "--- %s ---\n", now.Format("2006-01-02 15:04:05"))
+	if len(orders) == 0 {
+		_, _ = fmt.Fprintln(w, "No open orders")
+	} else {
+		printOrderTable(w, orders, opts.wide, changes, opts.color)
+	}
+	for _, order := range gone {
+		line := fmt.Sprintf("No longer listed: %s %s %s (was %s)", order.OrderID, order.Instrument.Symbol, order.Side, order.Status)
+		if opts.color {
+			line = ansiHighlight + line + ansiReset
+		}
+		_, _ = fmt.Fprintln(w, line)
+	}
+	if params.summary {
+		printOrderSummary(w, summarizeOrders(orders))
+	}
+	_, _ = fmt.Fprintln(w)
+
+	current := make(map[string]api.Order, len(orders))
+	for _, order := range orders {
+		current[order.OrderID] = order
+	}
+	return current
+}
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jonandersen/public-cli/internal/api"
+)
+
+func TestValidateOrderListWatch(t *testing.T) {
+	watch := orderListParams{watch: true, interval: 5 * time.Second}
+	assert.NoError(t, validateOrderListWatch(watch, false))
+	assert.NoError(t, validateOrderListWatch(orderListParams{interval: 0}, true), "flags are ignored without --watch")
+
+	err := validateOrderListWatch(watch, true)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be combined with --json")
+
+	closed := watch
+	closed.includeClosed = true
+	err = validateOrderListWatch(closed, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be combined with --include-closed")
+
+	fast := watch
+	fast.interval = 100 * time.Millisecond
+	err = validateOrderListWatch(fast, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid --interval 100ms: must be at least 1s")
+}
+
+func TestDiffOrders(t *testing.T) {
+	orders := []api.Order{
+		{OrderID: "a", Status: "PARTIALLY_FILLED", FilledQuantity: "3"},
+		{OrderID: "b", Status: "NEW", FilledQuantity: "0"},
+		{OrderID: "c", Status: "PARTIALLY_FILLED", FilledQuantity: "4"},
+		{OrderID: "d", Status: "NEW", FilledQuantity: "0"},
+	}
+
+	changes, gone := diffOrders(nil, orders)
+	assert.Empty(t, changes, "the first poll has nothing to compare against")
+	assert.Empty(t, gone)
+
+	previous := map[string]api.Order{
+		"a": {OrderID: "a", Status: "NEW", FilledQuantity: "0"},
+		"b": {OrderID: "b", Status: "NEW", FilledQuantity: "0"},
+		"c": {OrderID: "c", Status: "PARTIALLY_FILLED", FilledQuantity: "2"},
+		"z": {OrderID: "z", Status: "NEW", Instrument: api.Instrument{Symbol: "MSFT"}},
+	}
+	changes, gone = diffOrders(previous, orders)
+	assert.Equal(t, map[string]string{
+		"a": "NEW -> PARTIALLY_FILLED",
+		"c": "filled 2 -> 4",
+		"d": "new order",
+	}, changes)
+	require.Len(t, gone, 1)
+	assert.Equal(t, "z", gone[0].OrderID)
+}
+
+func TestPollOrderList(t *testing.T) {
+	status := "NEW"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/userapigateway/trading/test-account/portfolio/v2", r.URL.Path)
+		orders := []map[string]any{{
+			"orderId":        "order-1",
+			"instrument":     map[string]any{"symbol": "AAPL", "type": "EQUITY"},
+			"side":           "BUY",
+			"type":           "LIMIT",
+			"status":         status,
+			"quantity":       "10",
+			"filledQuantity": map[string]string{"NEW": "0", "PARTIALLY_FILLED": "4"}[status],
+		}}
+		if status == "NEW" {
+			orders = append(orders, map[string]any{
+				"orderId":    "order-2",
+				"instrument": map[string]any{"symbol": "TSLA", "type": "EQUITY"},
+				"side":       "SELL",
+				"type":       "MARKET",
+				"status":     "NEW",
+				"quantity":   "5",
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"accountId": "test-account", "orders": orders})
+	}))
+	defer server.Close()
+
+	opts := orderOptions{baseURL: server.URL, authToken: "test-token", accountID: "test-account"}
+	client := api.NewClient(opts.baseURL, opts.authToken)
+	now := time.Date(2025, 1, 10, 15, 4, 5, 0, time.Local)
+
+	var out, errOut bytes.Buffer
+	previous := pollOrderList(context.Background(), &out, &errOut, client, opts, orderListParams{}, orderListFilter{}, nil, now)
+	assert.Len(t, previous, 2)
+	assert.Contains(t, out.String(), "--- 2025-01-10 15:04:05 ---")
+	assert.NotContains(t, out.String(), "<-", "nothing is marked on the first poll")
+
+	status = "PARTIALLY_FILLED"
+	out.Reset()
+	previous = pollOrderList(context.Background(), &out, &errOut, client, opts, orderListParams{}, orderListFilter{}, previous, now)
+	assert.Len(t, previous, 1)
+	output := out.String()
+	assert.Contains(t, output, "<- NEW -> PARTIALLY_FILLED")
+	assert.Contains(t, output, "No longer listed: order-2 TSLA SELL (was NEW)")
+	assert.NotContains(t, output, ansiHighlight, "no color unless enabled")
+	assert.Empty(t, errOut.String())
+
+	// With color the changed row is highlighted
+	status = "NEW"
+	opts.color = true
+	out.Reset()
+	pollOrderList(context.Background(), &out, &errOut, client, opts, orderListParams{}, orderListFilter{}, previous, now)
+	for _, line := range strings.Split(out.String(), "\n") {
+		if strings.Contains(line, "order-1") {
+			assert.True(t, strings.HasPrefix(line, ansiHighlight), "changed row should be highlighted: %q", line)
+			assert.True(t, strings.HasSuffix(line, ansiReset))
+		}
+		if strings.Contains(line, "order-2") {
+			assert.Contains(t, line, "<- new order")
+		}
+	}
+}
+
+func TestPollOrderList_ErrorKeepsPrevious(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte("unavailable"))
+	}))
+	defer server.Close()
+
+	opts := orderOptions{baseURL: server.URL, authToken: "test-token", accountID: "test-account"}
+	client := api.NewClient(opts.baseURL, opts.authToken)
+	previous := map[string]api.Order{"order-1": {OrderID: "order-1", Status: "NEW"}}
+
+	var out, errOut bytes.Buffer
+	got := pollOrderList(context.Background(), &out, &errOut, client, opts, orderListParams{}, orderListFilter{}, previous, time.Now())
+	assert.Equal(t, previous, got)
+	assert.Empty(t, out.String())
+	assert.Contains(t, errOut.String(), "Warning: API error: 503")
+}
+
+func TestOrderListCmd_WatchRejectsJSON(t *testing.T) {
+	cmd := newOrderListCmd(orderOptions{accountID: "test-account", jsonMode: true})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--watch"})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--watch prints a refreshing table")
+}
+
+func TestGetColorMode(t *testing.T) {
+	// A buffer is not a terminal
+	assert.False(t, GetColorMode(&bytes.Buffer{}))
+
+	t.Setenv("NO_COLOR", "1")
+	assert.False(t, GetColorMode(&bytes.Buffer{}))
+}
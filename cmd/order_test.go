@@ -5,17 +5,22 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/jonandersen/public-cli/internal/api"
+	"github.com/jonandersen/public-cli/internal/config"
 )
 
 func TestOrderBuyCmd_Success(t *testing.T) {
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	server := httptest.NewServer(stubQuotes(func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, http.MethodPost, r.Method)
 		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
 
@@ -81,7 +86,7 @@ func TestOrderBuyCmd_Success(t *testing.T) {
 }
 
 func TestOrderSellCmd_Success(t *testing.T) {
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	server := httptest.NewServer(stubQuotes(func(w http.ResponseWriter, r *http.Request) {
 		var req map[string]any
 		err := json.NewDecoder(r.Body).Decode(&req)
 		require.NoError(t, err)
@@ -273,11 +278,73 @@ func TestOrderCmd_JSON(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotEmpty(t, result["orderId"])
 	assert.Equal(t, "placed", result["status"])
+
+	var placed OrderPlacedResult
+	require.NoError(t, json.Unmarshal(out.Bytes(), &placed))
+	assert.Equal(t, OrderPlacedResult{
+		OrderID: placed.OrderID, Status: statusPlaced, Symbol: "AAPL", Side: "BUY", Quantity: "10", OrderType: "MARKET",
+	}, placed)
+}
+
+func TestOrderCmd_Reason(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		assert.NotContains(t, req, "reason", "the reason stays local")
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"orderId": req["orderId"]})
+	}))
+	defer server.Close()
+
+	journal := filepath.Join(t.TempDir(), "journal.jsonl")
+	opts := orderOptions{
+		baseURL:        server.URL,
+		authToken:      "test-token",
+		accountID:      "test-account",
+		tradingEnabled: true,
+		requireReason:  true,
+		journalPath:    journal,
+	}
+
+	// require_reason rejects an order without one before anything is sent
+	cmd := newOrderBuyCmd(opts)
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"AAPL", "--quantity", "10", "--no-preflight", "--yes"})
+	require.ErrorIs(t, cmd.Execute(), config.ErrReasonRequired)
+
+	cmd = newOrderBuyCmd(opts)
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"AAPL", "--quantity", "10", "--no-preflight", "--yes", "--reason", " earnings play "})
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), "  Reason:   earnings play\n")
+	assert.Contains(t, out.String(), "  Reason: earnings play\n")
+
+	data, err := os.ReadFile(journal)
+	require.NoError(t, err)
+	var entry journalEntry
+	require.NoError(t, json.Unmarshal(data, &entry))
+	assert.Equal(t, "AAPL", entry.Symbol)
+	assert.Equal(t, "BUY", entry.Side)
+	assert.Equal(t, "10", entry.Quantity)
+	assert.Equal(t, "earnings play", entry.Reason)
+	assert.NotEmpty(t, entry.OrderID)
+
+	opts.jsonMode = true
+	cmd = newOrderBuyCmd(opts)
+	out.Reset()
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"AAPL", "--quantity", "10", "--no-preflight", "--yes", "--reason", "earnings play"})
+	require.NoError(t, cmd.Execute())
+	var placed OrderPlacedResult
+	require.NoError(t, json.Unmarshal(out.Bytes(), &placed))
+	assert.Equal(t, "earnings play", placed.Reason)
 }
 
 func TestOrderCmd_SymbolUppercased(t *testing.T) {
 	var receivedSymbol string
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	server := httptest.NewServer(stubQuotes(func(w http.ResponseWriter, r *http.Request) {
 		var req map[string]any
 		_ = json.NewDecoder(r.Body).Decode(&req)
 		instrument := req["instrument"].(map[string]any)
@@ -460,6 +527,160 @@ func TestOrderCancelCmd_JSON(t *testing.T) {
 	assert.Equal(t, "cancel_requested", result["status"])
 }
 
+func TestOrderListCmd_Wide(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"orders": []map[string]any{
+				{"orderId": "order-1", "instrument": map[string]any{"symbol": "AAPL", "type": "EQUITY"}, "side": "BUY", "type": "STOP_LIMIT",
+					"status": "NEW", "quantity": "10", "limitPrice": "175.00", "stopPrice": "176.00", "createdAt": "2025-01-10T10:30:00Z"},
+			},
+		})
+	}))
+	defer server.Close()
+
+	run := func(wide bool) string {
+		cmd := newOrderListCmd(orderOptions{baseURL: server.URL, authToken: "test-token", accountID: "test-account", wide: wide})
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetArgs([]string{})
+		require.NoError(t, cmd.Execute())
+		return out.String()
+	}
+
+	narrow := run(false)
+	assert.NotContains(t, narrow, "CREATED")
+	assert.NotContains(t, narrow, "176.00")
+
+	wide := run(true)
+	assert.Contains(t, wide, "CREATED")
+	assert.Contains(t, wide, "175.00")
+	assert.Contains(t, wide, "176.00")
+	assert.Contains(t, wide, "2025-01-10T10:30:00Z")
+}
+
+func TestOrderCancelCmd_AllJSONSummary(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/userapigateway/trading/test-account/portfolio/v2":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"orders": []map[string]any{
+					{"orderId": "order-1", "instrument": map[string]any{"symbol": "AAPL", "type": "EQUITY"}, "type": "LIMIT", "quantity": "10", "filledQuantity": "0", "limitPrice": "175.00"},
+					{"orderId": "order-2", "instrument": map[string]any{"symbol": "AAPL250117C00180000", "type": "OPTION"}, "type": "LIMIT", "quantity": "2", "limitPrice": "1.25"},
+					{"orderId": "order-3", "instrument": map[string]any{"symbol": "TSLA", "type": "EQUITY"}, "type": "MARKET", "quantity": "1"},
+				},
+			})
+		case r.Method == http.MethodDelete && r.URL.Path == "/userapigateway/trading/test-account/order/order-3":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"order already filled"}`))
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	cmd := newOrderCancelCmd(orderOptions{
+		baseURL:        server.URL,
+		authToken:      "test-token",
+		accountID:      "test-account",
+		tradingEnabled: true,
+		jsonMode:       true,
+	})
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--all", "--yes"})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 of 3 failed")
+
+	var summary opSummary
+	require.NoError(t, json.Unmarshal(out.Bytes(), &summary))
+	assert.Equal(t, 2, summary.Succeeded)
+	assert.Equal(t, 1, summary.Failed)
+	assert.Equal(t, "2000.00", summary.TotalValue) // 10 x 175 + 2 x 1.25 x 100
+	require.Len(t, summary.Results, 3)
+	assert.Equal(t, "order-1", summary.Results[0].ID)
+	assert.Equal(t, "1750.00", summary.Results[0].Value)
+	assert.Equal(t, opFailed, summary.Results[2].Status)
+	assert.Empty(t, summary.Results[2].Value)
+	assert.Contains(t, summary.Results[2].Error, "order already filled")
+}
+
+func TestOrderCancelCmd_MultipleIDs(t *testing.T) {
+	var cancelled []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"orders": []map[string]any{
+					{"orderId": "order-1", "instrument": map[string]any{"symbol": "AAPL", "type": "EQUITY"}, "quantity": "10", "filledQuantity": "4", "limitPrice": "100"},
+				},
+			})
+			return
+		}
+		cancelled = append(cancelled, strings.TrimPrefix(r.URL.Path, "/userapigateway/trading/test-account/order/"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cmd := newOrderCancelCmd(orderOptions{
+		baseURL:        server.URL,
+		authToken:      "test-token",
+		accountID:      "test-account",
+		tradingEnabled: true,
+	})
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"order-1", "order-9", "--yes"})
+
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, []string{"order-1", "order-9"}, cancelled)
+
+	output := out.String()
+	assert.Contains(t, output, "Cancel 2 Orders:")
+	assert.Contains(t, output, "Cancel orders: 2 succeeded, 0 failed, est. value $600.00")
+	assert.Contains(t, output, "order-9")
+}
+
+func TestOrderCancelCmd_AllNoOpenOrders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		_ = json.NewEncoder(w).Encode(map[string]any{"orders": []map[string]any{}})
+	}))
+	defer server.Close()
+
+	cmd := newOrderCancelCmd(orderOptions{
+		baseURL:        server.URL,
+		authToken:      "test-token",
+		accountID:      "test-account",
+		tradingEnabled: true,
+	})
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--all"})
+
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), "No open orders to cancel")
+}
+
+func TestOrderCancelCmd_AllWithIDs(t *testing.T) {
+	cmd := newOrderCancelCmd(orderOptions{accountID: "test-account", tradingEnabled: true})
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs([]string{"order-1", "--all"})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot use --all with order IDs")
+}
+
 func TestOrderStatusCmd_Success(t *testing.T) {
 	orderID := "912710f1-1a45-4ef0-88a7-cd513781933d"
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -742,6 +963,121 @@ func TestOrderListCmd_Success(t *testing.T) {
 	assert.Contains(t, output, "SELL")
 }
 
+func TestOrderListCmd_IncludeClosed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/userapigateway/trading/test-account/portfolio/v2":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"orders": []map[string]any{
+					{
+						"orderId":    "order-open",
+						"instrument": map[string]any{"symbol": "AAPL", "type": "EQUITY"},
+						"side":       "BUY",
+						"type":       "LIMIT",
+						"status":     "NEW",
+						"quantity":   "10",
+						"createdAt":  "2025-01-10T10:30:00Z",
+					},
+				},
+			})
+		case "/userapigateway/trading/test-account/history":
+			// Dates are US/Eastern market days
+			assert.Equal(t, "2025-01-01T05:00:00Z", r.URL.Query().Get("start"))
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"transactions": []map[string]any{
+					{"id": "txn-1", "type": "TRADE", "symbol": "TSLA", "securityType": "EQUITY", "side": "SELL", "quantity": "5", "timestamp": "2025-01-11T09:00:00Z"},
+					{"id": "txn-2", "type": "MONEY_MOVEMENT", "subType": "DEPOSIT", "timestamp": "2025-01-12T09:00:00Z"},
+					{"id": "txn-3", "type": "TRADE", "symbol": "MSFT", "securityType": "EQUITY", "side": "BUY", "quantity": "2", "timestamp": "2025-01-05T09:00:00Z"},
+				},
+			})
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	cmd := newOrderListCmd(orderOptions{
+		baseURL:   server.URL,
+		authToken: "test-token",
+		accountID: "test-account",
+		jsonMode:  true,
+	})
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--include-closed", "--from", "2025-01-01"})
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	var orders []map[string]any
+	require.NoError(t, json.Unmarshal(out.Bytes(), &orders))
+	require.Len(t, orders, 3)
+
+	// Newest first, money movements skipped
+	assert.Equal(t, "txn-1", orders[0]["orderId"])
+	assert.Equal(t, "CLOSED", orders[0]["state"])
+	assert.Equal(t, "FILLED", orders[0]["status"])
+	assert.Equal(t, "order-open", orders[1]["orderId"])
+	assert.Equal(t, "OPEN", orders[1]["state"])
+	assert.Equal(t, "txn-3", orders[2]["orderId"])
+}
+
+func TestOrderListCmd_FromRequiresIncludeClosed(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	cmd := newOrderListCmd(orderOptions{
+		baseURL:   server.URL,
+		authToken: "test-token",
+		accountID: "test-account",
+	})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--from", "2025-01-01"})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Equal(t, "--from requires --include-closed", err.Error())
+	assert.Zero(t, requests)
+}
+
+func TestMergeOrders(t *testing.T) {
+	open := []api.Order{{OrderID: "a", Status: "PARTIALLY_FILLED", CreatedAt: "2025-01-10T10:00:00Z"}}
+	closed := []api.Order{
+		{OrderID: "txn-1", Status: "FILLED", CreatedAt: "2025-01-10T10:05:00Z"},
+		{OrderID: "txn-2", Status: "FILLED", CreatedAt: "2025-01-11T10:00:00Z"},
+	}
+
+	// The partial fill keeps both its open order and its history row
+	merged := mergeOrders(open, closed)
+	require.Len(t, merged, 3)
+	assert.Equal(t, "txn-2", merged[0].OrderID)
+	assert.Equal(t, "CLOSED", merged[0].State)
+	assert.Equal(t, "txn-1", merged[1].OrderID)
+	assert.Equal(t, "a", merged[2].OrderID)
+	assert.Equal(t, "OPEN", merged[2].State)
+}
+
+func TestMergeOrders_ParsesOffsets(t *testing.T) {
+	// 09:00 at -05:00 is 14:00 UTC, after the 13:00Z fill, though it sorts
+	// before it as a string
+	open := []api.Order{{OrderID: "a", Status: "NEW", CreatedAt: "2025-01-10T09:00:00-05:00"}}
+	closed := []api.Order{
+		{OrderID: "txn-1", Status: "FILLED", CreatedAt: "2025-01-10T13:00:00Z"},
+		{OrderID: "txn-2", Status: "FILLED", CreatedAt: "2025-01-10T13:30:00.123456Z"},
+	}
+
+	merged := mergeOrders(open, closed)
+	require.Len(t, merged, 3)
+	assert.Equal(t, "a", merged[0].OrderID)
+	assert.Equal(t, "txn-2", merged[1].OrderID)
+	assert.Equal(t, "txn-1", merged[2].OrderID)
+}
+
 func TestOrderListCmd_NoOrders(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		resp := map[string]any{
@@ -818,6 +1154,114 @@ func TestOrderListCmd_JSON(t *testing.T) {
 	assert.Equal(t, "BUY", result[0]["side"])
 }
 
+func TestFilterOrders(t *testing.T) {
+	orders := []api.Order{
+		{OrderID: "1", Instrument: api.Instrument{Symbol: "AAPL"}, Side: "BUY", Status: "NEW"},
+		{OrderID: "2", Instrument: api.Instrument{Symbol: "AAPL"}, Side: "SELL", Status: "PARTIALLY_FILLED"},
+		{OrderID: "3", Instrument: api.Instrument{Symbol: "TSLA"}, Side: "SELL", Status: "PARTIALLY_FILLED"},
+		{OrderID: "4", Instrument: api.Instrument{Symbol: "TSLA"}, Side: "BUY", Status: "PENDING"},
+	}
+
+	tests := []struct {
+		name   string
+		params orderListParams
+		want   []string
+	}{
+		{"no filters", orderListParams{}, []string{"1", "2", "3", "4"}},
+		{"single status", orderListParams{status: "new"}, []string{"1"}},
+		{"status list", orderListParams{status: "NEW, pending"}, []string{"1", "4"}},
+		{"symbol", orderListParams{symbol: "tsla"}, []string{"3", "4"}},
+		{"side", orderListParams{side: "buy"}, []string{"1", "4"}},
+		{"status and side", orderListParams{status: "PARTIALLY_FILLED", side: "SELL"}, []string{"2", "3"}},
+		{"all three", orderListParams{status: "PARTIALLY_FILLED", side: "SELL", symbol: "AAPL"}, []string{"2"}},
+		{"no match", orderListParams{symbol: "MSFT"}, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := parseOrderListFilter(tt.params)
+			require.NoError(t, err)
+
+			ids := []string{}
+			for _, o := range filterOrders(orders, f) {
+				ids = append(ids, o.OrderID)
+			}
+			assert.Equal(t, tt.want, ids)
+		})
+	}
+}
+
+func TestParseOrderListFilter_Invalid(t *testing.T) {
+	_, err := parseOrderListFilter(orderListParams{status: "NEW,OPEN"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid --status "OPEN"`)
+
+	_, err = parseOrderListFilter(orderListParams{side: "short"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must be BUY or SELL")
+}
+
+func TestOrderListCmd_Filters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"orders": []map[string]any{
+				{"orderId": "order-1", "instrument": map[string]any{"symbol": "AAPL"}, "side": "BUY", "type": "LIMIT", "status": "NEW"},
+				{"orderId": "order-2", "instrument": map[string]any{"symbol": "TSLA"}, "side": "SELL", "type": "LIMIT", "status": "PARTIALLY_FILLED"},
+			},
+		})
+	}))
+	defer server.Close()
+
+	cmd := newOrderListCmd(orderOptions{
+		baseURL:   server.URL,
+		authToken: "test-token",
+		accountID: "test-account",
+		jsonMode:  true,
+	})
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--status", "partially_filled", "--side", "sell"})
+
+	require.NoError(t, cmd.Execute())
+
+	var result []map[string]any
+	require.NoError(t, json.Unmarshal(out.Bytes(), &result))
+	require.Len(t, result, 1)
+	assert.Equal(t, "order-2", result[0]["orderId"])
+
+	// Table output reports when nothing matches
+	cmd = newOrderListCmd(orderOptions{
+		baseURL:   server.URL,
+		authToken: "test-token",
+		accountID: "test-account",
+	})
+	out.Reset()
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--symbol", "MSFT"})
+
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), "No open orders match the filters")
+}
+
+func TestOrderListCmd_InvalidStatus(t *testing.T) {
+	cmd := newOrderListCmd(orderOptions{
+		baseURL:   "http://localhost",
+		authToken: "test-token",
+		accountID: "test-account",
+	})
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs([]string{"--status", "bogus"})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid --status")
+}
+
 func TestOrderListCmd_RequiresAccount(t *testing.T) {
 	cmd := newOrderListCmd(orderOptions{
 		baseURL:   "http://localhost",
@@ -859,7 +1303,7 @@ func TestOrderListCmd_APIError(t *testing.T) {
 }
 
 func TestOrderBuyCmd_LimitOrder(t *testing.T) {
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	server := httptest.NewServer(stubQuotes(func(w http.ResponseWriter, r *http.Request) {
 		var req map[string]any
 		err := json.NewDecoder(r.Body).Decode(&req)
 		require.NoError(t, err)
@@ -896,7 +1340,7 @@ func TestOrderBuyCmd_LimitOrder(t *testing.T) {
 }
 
 func TestOrderSellCmd_StopOrder(t *testing.T) {
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	server := httptest.NewServer(stubQuotes(func(w http.ResponseWriter, r *http.Request) {
 		var req map[string]any
 		err := json.NewDecoder(r.Body).Decode(&req)
 		require.NoError(t, err)
@@ -932,7 +1376,7 @@ func TestOrderSellCmd_StopOrder(t *testing.T) {
 }
 
 func TestOrderBuyCmd_StopLimitOrder(t *testing.T) {
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	server := httptest.NewServer(stubQuotes(func(w http.ResponseWriter, r *http.Request) {
 		var req map[string]any
 		err := json.NewDecoder(r.Body).Decode(&req)
 		require.NoError(t, err)
@@ -968,7 +1412,7 @@ func TestOrderBuyCmd_StopLimitOrder(t *testing.T) {
 }
 
 func TestOrderBuyCmd_GTC_Expiration(t *testing.T) {
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	server := httptest.NewServer(stubQuotes(func(w http.ResponseWriter, r *http.Request) {
 		var req map[string]any
 		err := json.NewDecoder(r.Body).Decode(&req)
 		require.NoError(t, err)
@@ -1200,7 +1644,7 @@ func TestRunPreflight_APIError(t *testing.T) {
 
 func TestOrderBuyCmd_ShowsPreflightCost(t *testing.T) {
 	requestCount := 0
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	server := httptest.NewServer(stubQuotes(func(w http.ResponseWriter, r *http.Request) {
 		requestCount++
 		if strings.Contains(r.URL.Path, "preflight") {
 			// Preflight request
@@ -1255,7 +1699,7 @@ func TestOrderBuyCmd_ShowsPreflightCost(t *testing.T) {
 }
 
 func TestOrderCmd_PreviewShowsPreflightCost(t *testing.T) {
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	server := httptest.NewServer(stubQuotes(func(w http.ResponseWriter, r *http.Request) {
 		// Only preflight should be called (not order) since we don't confirm
 		assert.Contains(t, r.URL.Path, "preflight")
 
@@ -1332,3 +1776,1150 @@ func TestOrderCmd_PreflightErrorShowsMessage(t *testing.T) {
 	assert.Contains(t, output, "Cost Estimate: unavailable")
 	assert.Contains(t, output, "A deposit of $2,548.67 is required to place this order.")
 }
+
+func TestOrderBuyCmd_NoPreflightSkipsEstimate(t *testing.T) {
+	requestCount := 0
+	server := httptest.NewServer(stubQuotes(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+		assert.NotContains(t, r.URL.Path, "preflight")
+
+		var req map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		resp := map[string]any{"orderId": req["orderId"]}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	cmd := newOrderBuyCmd(orderOptions{
+		baseURL:        server.URL,
+		authToken:      "test-token",
+		accountID:      "test-account",
+		tradingEnabled: true,
+	})
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"AAPL", "--quantity", "10", "--no-preflight", "--yes"})
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	output := out.String()
+	assert.Contains(t, output, "Order Preview")
+	assert.NotContains(t, output, "Estimated Cost")
+	assert.NotContains(t, output, "Cost Estimate")
+	assert.Contains(t, output, "Order placed")
+	// Only the order request should have been made
+	assert.Equal(t, 1, requestCount)
+}
+
+func TestCloseQuantity(t *testing.T) {
+	tests := []struct {
+		name     string
+		held     float64
+		percent  float64
+		wantSide string
+		wantQty  string
+		wantErr  bool
+	}{
+		{"full long", 100, 100, "SELL", "100", false},
+		{"full short", -20, 100, "BUY", "20", false},
+		{"half rounds down", 15, 50, "SELL", "7", false},
+		{"fractional position", 2.5, 50, "SELL", "1.25", false},
+		{"rounds to zero", 1, 50, "", "", true},
+		{"no position", 0, 100, "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			side, qty, err := closeQuantity(tt.held, tt.percent)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSide, side)
+			assert.Equal(t, tt.wantQty, qty)
+		})
+	}
+}
+
+func TestOrderCloseCmd_ShortPosition(t *testing.T) {
+	server := httptest.NewServer(stubQuotes(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/userapigateway/trading/test-account/portfolio/v2":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"accountId": "test-account",
+				"positions": []map[string]any{
+					{
+						"instrument": map[string]any{"symbol": "TSLA", "type": "EQUITY"},
+						"quantity":   "-10",
+					},
+				},
+			})
+		case "/userapigateway/trading/test-account/order":
+			var req map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "BUY", req["orderSide"])
+			assert.Equal(t, "5", req["quantity"])
+			assert.Equal(t, "LIMIT", req["orderType"])
+			assert.Equal(t, "250.00", req["limitPrice"])
+			_ = json.NewEncoder(w).Encode(map[string]any{"orderId": req["orderId"]})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	cmd := newOrderCloseCmd(orderOptions{
+		baseURL:        server.URL,
+		authToken:      "test-token",
+		accountID:      "test-account",
+		tradingEnabled: true,
+	})
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"tsla", "--percent", "50", "--limit", "250.00", "--no-preflight", "--yes"})
+
+	require.NoError(t, cmd.Execute())
+
+	output := out.String()
+	assert.Contains(t, output, "Closing short position: 5 of -10 shares of TSLA")
+	assert.Contains(t, output, "Order placed")
+}
+
+func TestOrderCloseCmd_IgnoresMaxShares(t *testing.T) {
+	var placed bool
+	server := httptest.NewServer(stubQuotes(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/userapigateway/trading/test-account/portfolio/v2":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"accountId": "test-account",
+				"positions": []map[string]any{
+					{
+						"instrument": map[string]any{"symbol": "AAPL", "type": "EQUITY"},
+						"quantity":   "500",
+					},
+				},
+			})
+		case "/userapigateway/trading/test-account/order":
+			var req map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "SELL", req["orderSide"])
+			assert.Equal(t, "500", req["quantity"])
+			placed = true
+			_ = json.NewEncoder(w).Encode(map[string]any{"orderId": req["orderId"]})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	cmd := newOrderCloseCmd(orderOptions{
+		baseURL:        server.URL,
+		authToken:      "test-token",
+		accountID:      "test-account",
+		tradingEnabled: true,
+		maxShares:      100,
+	})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetArgs([]string{"AAPL", "--no-preflight", "--yes"})
+
+	require.NoError(t, cmd.Execute())
+	assert.True(t, placed, "a close larger than max_shares is still placed")
+}
+
+func TestCheckReduceOnly(t *testing.T) {
+	assert.NoError(t, checkReduceOnly("AAPL", "SELL", "10", 10))
+	assert.NoError(t, checkReduceOnly("AAPL", "SELL", "0.5", 2.25))
+	assert.NoError(t, checkReduceOnly("TSLA", "BUY", "4", -10))
+
+	tests := []struct {
+		name     string
+		side     string
+		quantity string
+		held     float64
+		wantErr  string
+	}{
+		{"no position", "SELL", "1", 0, "no AAPL position to reduce"},
+		{"adds to long", "BUY", "1", 10, "increase your long AAPL position of 10 shares"},
+		{"adds to short", "SELL", "1", -10, "increase your short AAPL position of 10 shares"},
+		{"flips long", "SELL", "15", 10, "would flip the position (use --quantity 10 or less)"},
+		{"flips short", "BUY", "11", -10, "exceeds the 10 shares"},
+		{"bad quantity", "SELL", "abc", 10, "invalid quantity"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkReduceOnly("AAPL", tt.side, tt.quantity, tt.held)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestSizeByRisk(t *testing.T) {
+	sizing, err := sizeByRisk("100", 175.30, "170")
+	require.NoError(t, err)
+	assert.Equal(t, int64(18), sizing.quantity)
+	assert.InDelta(t, 5.30, sizing.riskPerShare, 1e-9)
+	assert.InDelta(t, 95.40, sizing.totalRisk, 1e-9)
+
+	tests := []struct {
+		name    string
+		risk    string
+		entry   float64
+		stop    string
+		wantErr string
+	}{
+		{"stop above entry", "100", 175, "180", "must be below the entry price"},
+		{"stop at entry", "100", 175, "175", "must be below the entry price"},
+		{"risk below one share", "3", 175, "170", "less than the $5.00 risk of a single share"},
+		{"bad risk", "lots", 175, "170", "invalid --risk"},
+		{"negative risk", "-100", 175, "170", "invalid --risk"},
+		{"bad stop", "100", 175, "low", "invalid --stop"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := sizeByRisk(tt.risk, tt.entry, tt.stop)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestOrderBuyCmd_RiskPrintsStopCommand(t *testing.T) {
+	var orders []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/userapigateway/marketdata/test-account/quotes":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"quotes": []map[string]any{
+					{"instrument": map[string]any{"symbol": "AAPL", "type": "EQUITY"}, "outcome": "SUCCESS", "last": "175.10", "ask": "175.30"},
+				},
+			})
+		case "/userapigateway/trading/test-account/order":
+			var req map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			orders = append(orders, req)
+			_ = json.NewEncoder(w).Encode(map[string]any{"orderId": req["orderId"]})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	cmd := newOrderBuyCmd(orderOptions{
+		baseURL:        server.URL,
+		authToken:      "test-token",
+		accountID:      "test-account",
+		tradingEnabled: true,
+	})
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"AAPL", "--risk", "100", "--stop", "170", "--no-preflight", "--yes"})
+
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), "Quantity: 18 shares")
+	assert.Contains(t, out.String(), "Type:     MARKET")
+	assert.Contains(t, out.String(), "Risk:     $5.30/share x 18 = $95.40 (entry ~$175.30, stop $170)")
+	assert.Contains(t, out.String(), "pub order sell AAPL --quantity 18 --stop 170 --expiration GTC")
+
+	require.Len(t, orders, 1, "the stop is not placed before the entry fills")
+	assert.Equal(t, "BUY", orders[0]["orderSide"])
+	assert.Equal(t, "MARKET", orders[0]["orderType"])
+	assert.Equal(t, "18", orders[0]["quantity"])
+	assert.Nil(t, orders[0]["stopPrice"])
+}
+
+func TestOrderBuyCmd_RiskValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"requires stop", []string{"AAPL", "--risk", "100", "--limit", "175"}, "--risk requires --stop"},
+		{"rejects quantity", []string{"AAPL", "--risk", "100", "--stop", "170", "-q", "5"}, "do not combine it with --quantity"},
+		{"stop above limit", []string{"AAPL", "--risk", "100", "--stop", "180", "--limit", "175"}, "must be below the entry price"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newOrderBuyCmd(orderOptions{baseURL: "http://127.0.0.1:0", authToken: "test-token", accountID: "test-account", tradingEnabled: true})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+			cmd.SetArgs(append(tt.args, "--no-preflight", "--yes"))
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestOrderBuyCmd_ReduceOnly(t *testing.T) {
+	server := httptest.NewServer(stubQuotes(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/userapigateway/trading/test-account/portfolio/v2":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"positions": []map[string]any{
+					{"instrument": map[string]any{"symbol": "TSLA", "type": "EQUITY"}, "quantity": "-10"},
+				},
+			})
+		case "/userapigateway/trading/test-account/order":
+			var req map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.NotContains(t, req, "reduceOnly", "the order API has no reduce-only field")
+			assert.Equal(t, "BUY", req["orderSide"])
+			_ = json.NewEncoder(w).Encode(map[string]any{"orderId": req["orderId"]})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	cmd := newOrderBuyCmd(orderOptions{
+		baseURL:        server.URL,
+		authToken:      "test-token",
+		accountID:      "test-account",
+		tradingEnabled: true,
+	})
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"tsla", "-q", "4", "--reduce-only", "--no-preflight", "--yes"})
+
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), "Reduce:   Only (holding -10 shares; checked here, not by the API)")
+}
+
+func TestOrderSellCmd_ReduceOnlyCrypto(t *testing.T) {
+	var placed bool
+	server := httptest.NewServer(stubQuotes(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/userapigateway/trading/test-account/portfolio/v2":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"positions": []map[string]any{
+					{"instrument": map[string]any{"symbol": "BTC", "type": "EQUITY"}, "quantity": "3"},
+					{"instrument": map[string]any{"symbol": "BTC", "type": "CRYPTO"}, "quantity": "0.5"},
+				},
+			})
+		case "/userapigateway/trading/test-account/order":
+			placed = true
+			var req map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			_ = json.NewEncoder(w).Encode(map[string]any{"orderId": req["orderId"]})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	opts := orderOptions{
+		baseURL:        server.URL,
+		authToken:      "test-token",
+		accountID:      "test-account",
+		tradingEnabled: true,
+	}
+	cmd := newOrderSellCmd(opts)
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"BTC", "-q", "0.25", "--instrument-type", "CRYPTO", "--reduce-only", "--no-preflight", "--yes"})
+
+	require.NoError(t, cmd.Execute())
+	assert.True(t, placed)
+	assert.Contains(t, out.String(), "holding 0.5 shares")
+
+	// The CRYPTO position is checked, not the EQUITY one with the same symbol
+	cmd = newOrderSellCmd(opts)
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"BTC", "-q", "1", "--instrument-type", "CRYPTO", "--reduce-only", "--no-preflight", "--yes"})
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "would flip the position")
+}
+
+func TestOrderSellCmd_ReduceOnlyWouldFlip(t *testing.T) {
+	server := httptest.NewServer(stubQuotes(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/userapigateway/trading/test-account/portfolio/v2" {
+			t.Errorf("order must not be sent: %s %s", r.Method, r.URL.Path)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"positions": []map[string]any{
+				{"instrument": map[string]any{"symbol": "AAPL", "type": "EQUITY"}, "quantity": "10"},
+			},
+		})
+	}))
+	defer server.Close()
+
+	cmd := newOrderSellCmd(orderOptions{
+		baseURL:        server.URL,
+		authToken:      "test-token",
+		accountID:      "test-account",
+		tradingEnabled: true,
+	})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"AAPL", "-q", "25", "--reduce-only", "--yes"})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "would flip the position")
+}
+
+func TestOrderCloseCmd_NoPosition(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"accountId": "test-account"})
+	}))
+	defer server.Close()
+
+	cmd := newOrderCloseCmd(orderOptions{
+		baseURL:        server.URL,
+		authToken:      "test-token",
+		accountID:      "test-account",
+		tradingEnabled: true,
+	})
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs([]string{"AAPL", "--yes"})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no position in AAPL")
+}
+
+func TestOrderCloseCmd_ETFPosition(t *testing.T) {
+	var order map[string]any
+	server := httptest.NewServer(stubQuotes(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/userapigateway/trading/test-account/portfolio/v2":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"positions": []map[string]any{
+					{"instrument": map[string]any{"symbol": "SPY", "type": "ETF"}, "quantity": "4"},
+				},
+			})
+		case "/userapigateway/trading/test-account/order":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&order))
+			_ = json.NewEncoder(w).Encode(map[string]any{"orderId": order["orderId"]})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	cmd := newOrderCloseCmd(orderOptions{
+		baseURL:        server.URL,
+		authToken:      "test-token",
+		accountID:      "test-account",
+		tradingEnabled: true,
+	})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetArgs([]string{"SPY", "--no-preflight", "--yes"})
+
+	require.NoError(t, cmd.Execute())
+	require.NotNil(t, order)
+	assert.Equal(t, "SELL", order["orderSide"])
+	assert.Equal(t, "4", order["quantity"])
+	assert.Equal(t, map[string]any{"symbol": "SPY", "type": "ETF"}, order["instrument"])
+}
+
+func TestOrderBuyCmd_InjectedOrderID(t *testing.T) {
+	const fixedID = "00000000-0000-4000-8000-000000000001"
+
+	server := httptest.NewServer(stubQuotes(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, fixedID, req["orderId"])
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"orderId": req["orderId"]})
+	}))
+	defer server.Close()
+
+	cmd := newOrderBuyCmd(orderOptions{
+		baseURL:        server.URL,
+		authToken:      "test-token",
+		accountID:      "test-account",
+		tradingEnabled: true,
+		newOrderID:     func() string { return fixedID },
+	})
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"AAPL", "--quantity", "1", "--no-preflight", "--yes"})
+
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), fixedID)
+}
+
+func TestOrderBuyCmd_OrderIDFlag(t *testing.T) {
+	const userID = "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+
+	server := httptest.NewServer(stubQuotes(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, userID, req["orderId"])
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"orderId": req["orderId"]})
+	}))
+	defer server.Close()
+
+	opts := orderOptions{
+		baseURL:        server.URL,
+		authToken:      "test-token",
+		accountID:      "test-account",
+		tradingEnabled: true,
+	}
+
+	cmd := newOrderBuyCmd(opts)
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"AAPL", "--quantity", "1", "--no-preflight", "--order-id", userID, "--yes"})
+	require.NoError(t, cmd.Execute())
+
+	cmd = newOrderBuyCmd(opts)
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"AAPL", "--quantity", "1", "--order-id", "not-a-uuid", "--yes"})
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid --order-id")
+}
+
+func TestOrderEstimateCmd(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/userapigateway/trading/test-account/preflight/single-leg", r.URL.Path)
+
+		var req map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "BUY", req["orderSide"])
+		assert.Equal(t, "LIMIT", req["orderType"])
+		assert.Equal(t, "10", req["quantity"])
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"orderValue":             "1750.00",
+			"estimatedCommission":    "0.00",
+			"regulatoryFees":         map[string]any{"secFee": "0.01", "tafFee": "0.02"},
+			"estimatedCost":          "1750.03",
+			"buyingPowerRequirement": "1750.03",
+		})
+	}))
+	defer server.Close()
+
+	cmd := newOrderEstimateCmd(orderOptions{
+		baseURL:   server.URL,
+		authToken: "test-token",
+		accountID: "test-account",
+	})
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"aapl", "--side", "buy", "-q", "10", "--limit", "175.00"})
+
+	require.NoError(t, cmd.Execute())
+
+	output := out.String()
+	assert.Contains(t, output, "BUY 10 shares of AAPL (LIMIT)")
+	assert.Contains(t, output, "Reg Fees:     $0.03")
+	assert.Contains(t, output, "Total:        $1750.03")
+	assert.NotContains(t, output, "Order ID")
+}
+
+func TestOrderEstimateCmd_RequiresSide(t *testing.T) {
+	cmd := newOrderEstimateCmd(orderOptions{
+		baseURL:   "http://localhost",
+		authToken: "test-token",
+		accountID: "test-account",
+	})
+
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"AAPL", "-q", "10"})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "side is required")
+}
+
+func TestValidateOrderParams(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  orderParams
+		side    string
+		wantErr string
+	}{
+		{"market buy", orderParams{quantity: "10", expiration: "DAY"}, "BUY", ""},
+		{"fractional limit sell", orderParams{quantity: "0.5", limitPrice: "175.00", expiration: "gtc"}, "SELL", ""},
+		{"missing side", orderParams{quantity: "10", expiration: "DAY"}, "", "side is required"},
+		{"bad side", orderParams{quantity: "10", expiration: "DAY"}, "HOLD", `invalid --side "HOLD"`},
+		{"missing quantity", orderParams{expiration: "DAY"}, "BUY", "quantity is required"},
+		{"zero quantity", orderParams{quantity: "0", expiration: "DAY"}, "BUY", `invalid --quantity "0"`},
+		{"non-numeric quantity", orderParams{quantity: "ten", expiration: "DAY"}, "BUY", `invalid --quantity "ten"`},
+		{"negative limit", orderParams{quantity: "10", limitPrice: "-1", expiration: "DAY"}, "BUY", `invalid --limit "-1"`},
+		{"non-numeric stop", orderParams{quantity: "10", stopPrice: "abc", expiration: "DAY"}, "SELL", `invalid --stop "abc"`},
+		{"bad expiration", orderParams{quantity: "10", expiration: "IOC"}, "BUY", `invalid --expiration "IOC"`},
+		{"retry out of range", orderParams{quantity: "10", expiration: "DAY", retryOnReject: maxRetryOnReject + 1}, "BUY", "invalid --retry-on-reject"},
+		{"risk with quantity", orderParams{risk: "100", quantity: "10", stopPrice: "170", expiration: "DAY"}, "BUY", "do not combine it with --quantity"},
+		{"risk without stop", orderParams{risk: "100", expiration: "DAY"}, "BUY", "--risk requires --stop"},
+		{"risk on sell", orderParams{risk: "100", stopPrice: "170", expiration: "DAY"}, "SELL", "only supported for buy orders"},
+		{"risk with reduce-only", orderParams{risk: "100", stopPrice: "170", reduceOnly: true, expiration: "DAY"}, "BUY", "--reduce-only"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateOrderParams(tt.params, tt.side)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestOrderBuyCmd_RetryOnReject(t *testing.T) {
+	var orderIDs []string
+	server := httptest.NewServer(stubQuotes(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		orderIDs = append(orderIDs, req["orderId"].(string))
+
+		if len(orderIDs) < 3 {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"Trading in this symbol is temporarily halted"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"orderId": req["orderId"]})
+	}))
+	defer server.Close()
+
+	cmd := newOrderBuyCmd(orderOptions{
+		baseURL:        server.URL,
+		authToken:      "test-token",
+		accountID:      "test-account",
+		tradingEnabled: true,
+		retryDelay:     time.Millisecond,
+	})
+	var out, errOut bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&errOut)
+	cmd.SetArgs([]string{"AAPL", "-q", "1", "--limit", "175.00", "--no-preflight", "--retry-on-reject", "3", "--yes"})
+
+	require.NoError(t, cmd.Execute())
+	require.Len(t, orderIDs, 3)
+	assert.NotEqual(t, orderIDs[0], orderIDs[1])
+	assert.Contains(t, errOut.String(), "attempt 1 of 3")
+	assert.Contains(t, errOut.String(), "attempt 2 of 3")
+	assert.Contains(t, out.String(), "Order placed successfully!")
+}
+
+func TestOrderBuyCmd_RetryOnRejectOrderID(t *testing.T) {
+	const orderID = "0a4c6f3e-0f7c-4f7e-9d0e-6f1d2b3c4a5e"
+	var orderIDs []string
+	server := httptest.NewServer(stubQuotes(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		orderIDs = append(orderIDs, req["orderId"].(string))
+
+		if len(orderIDs) < 2 {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"Trading in this symbol is temporarily halted"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"orderId": req["orderId"]})
+	}))
+	defer server.Close()
+
+	cmd := newOrderBuyCmd(orderOptions{
+		baseURL:        server.URL,
+		authToken:      "test-token",
+		accountID:      "test-account",
+		tradingEnabled: true,
+		retryDelay:     time.Millisecond,
+	})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"AAPL", "-q", "1", "--order-id", orderID, "--no-preflight", "--retry-on-reject", "3", "--yes"})
+
+	require.NoError(t, cmd.Execute())
+	require.Len(t, orderIDs, 2)
+	assert.Equal(t, orderID, orderIDs[0])
+	assert.NotEqual(t, orderID, orderIDs[1], "a retry must not reuse the rejected order's ID")
+}
+
+func TestOrderBuyCmd_RetryOnRejectSkipsServiceUnavailable(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(stubQuotes(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	cmd := newOrderBuyCmd(orderOptions{
+		baseURL:        server.URL,
+		authToken:      "test-token",
+		accountID:      "test-account",
+		tradingEnabled: true,
+		retryDelay:     time.Millisecond,
+	})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"AAPL", "-q", "1", "--no-preflight", "--retry-on-reject", "3", "--yes"})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "API error: 503")
+	assert.Equal(t, 1, calls, "a 503 may follow an accepted order, so it is never resubmitted")
+}
+
+func TestOrderBuyCmd_RetryOnRejectSkipsPermanent(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(stubQuotes(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"message":"Insufficient buying power"}`))
+	}))
+	defer server.Close()
+
+	cmd := newOrderBuyCmd(orderOptions{
+		baseURL:        server.URL,
+		authToken:      "test-token",
+		accountID:      "test-account",
+		tradingEnabled: true,
+		retryDelay:     time.Millisecond,
+	})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"AAPL", "-q", "1", "--no-preflight", "--retry-on-reject", "3", "--yes"})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "API error: 400")
+	assert.Equal(t, 1, calls)
+}
+
+func TestOrderBuyCmd_InvalidSymbolSuggestions(t *testing.T) {
+	server := httptest.NewServer(stubQuotes(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/userapigateway/trading/test-account/order":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"code":140,"message":"Provided symbol 'AAPLE' is not valid"}`))
+		case "/userapigateway/trading/instruments":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"instruments":[
+				{"instrument":{"symbol":"MSFT","type":"EQUITY"}},
+				{"instrument":{"symbol":"AAP","type":"EQUITY"}},
+				{"instrument":{"symbol":"AAPL","type":"EQUITY"}}]}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	cmd := newOrderBuyCmd(orderOptions{
+		baseURL:        server.URL,
+		authToken:      "test-token",
+		accountID:      "test-account",
+		tradingEnabled: true,
+	})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"AAPLE", "-q", "1", "--no-preflight", "--yes"})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "API error: 400")
+	assert.Contains(t, err.Error(), "Did you mean: AAPL, AAP?")
+
+	var apiErr *api.APIError
+	assert.ErrorAs(t, err, &apiErr)
+}
+
+func TestOrderBuyCmd_InvalidSymbolLookupFails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"message":"Provided symbol 'XYZ' is not valid"}`))
+	}))
+	defer server.Close()
+
+	cmd := newOrderBuyCmd(orderOptions{
+		baseURL:        server.URL,
+		authToken:      "test-token",
+		accountID:      "test-account",
+		tradingEnabled: true,
+	})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"XYZ", "-q", "1", "--no-preflight", "--yes"})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not valid")
+	assert.NotContains(t, err.Error(), "Did you mean")
+}
+
+func TestOrderSubmitCmd_Stdin(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/userapigateway/trading/test-account/order", r.URL.Path)
+
+		var req map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "00000000-0000-4000-8000-000000000001", req["orderId"])
+		assert.Equal(t, "LIMIT", req["orderType"])
+		// Fields unknown to the CLI pass through untouched
+		assert.Equal(t, "EXTENDED", req["session"])
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"orderId": req["orderId"]})
+	}))
+	defer server.Close()
+
+	cmd := newOrderSubmitCmd(orderOptions{
+		baseURL:        server.URL,
+		authToken:      "test-token",
+		accountID:      "test-account",
+		tradingEnabled: true,
+		newOrderID:     func() string { return "00000000-0000-4000-8000-000000000001" },
+	})
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetIn(strings.NewReader(`{
+		"instrument": {"symbol": "AAPL", "type": "EQUITY"},
+		"orderSide": "BUY",
+		"orderType": "LIMIT",
+		"expiration": {"timeInForce": "DAY"},
+		"quantity": "10",
+		"limitPrice": "175.00",
+		"session": "EXTENDED"
+	}`))
+	cmd.SetArgs([]string{"--input", "-", "--yes"})
+
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), "Order Preview:")
+	assert.Contains(t, out.String(), `"orderId": "00000000-0000-4000-8000-000000000001"`)
+}
+
+func TestValidateSubmitStdin(t *testing.T) {
+	assert.NoError(t, validateSubmitStdin("-", "order.json"))
+	assert.NoError(t, validateSubmitStdin("", "-"))
+	assert.EqualError(t, validateSubmitStdin("-", "-"), "--account - cannot be combined with --input -: both read stdin")
+}
+
+func TestOrderSubmitCmd_ForceOverridesCap(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"orderId": "order-1"})
+	}))
+	defer server.Close()
+
+	cmd := newOrderSubmitCmd(orderOptions{
+		baseURL:        server.URL,
+		authToken:      "test-token",
+		accountID:      "test-account",
+		tradingEnabled: true,
+		maxShares:      100,
+	})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetIn(strings.NewReader(`{"instrument":{"symbol":"AAPL","type":"EQUITY"},"orderSide":"BUY","orderType":"MARKET","expiration":{"timeInForce":"DAY"},"quantity":"150"}`))
+	cmd.SetArgs([]string{"--input", "-", "--force", "--yes"})
+
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, 1, calls)
+}
+
+func TestOrderSubmitCmd_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		args    []string
+		opts    orderOptions
+		wantErr string
+	}{
+		{
+			name:    "trading disabled",
+			body:    `{}`,
+			opts:    orderOptions{accountID: "test-account"},
+			wantErr: "trading is disabled",
+		},
+		{
+			name:    "bad json",
+			body:    `{`,
+			wantErr: "invalid order JSON",
+		},
+		{
+			name:    "missing symbol",
+			body:    `{"orderSide":"BUY"}`,
+			wantErr: "missing instrument.symbol",
+		},
+		{
+			name:    "option without open/close",
+			body:    `{"instrument":{"symbol":"AAPL250117C00175000","type":"OPTION"},"orderSide":"BUY","orderType":"LIMIT","expiration":{"timeInForce":"DAY"},"quantity":"1"}`,
+			wantErr: "openCloseIndicator",
+		},
+		{
+			name:    "requires confirmation",
+			body:    `{"instrument":{"symbol":"AAPL","type":"EQUITY"},"orderSide":"BUY","orderType":"MARKET","expiration":{"timeInForce":"DAY"},"quantity":"1"}`,
+			args:    []string{"--input", "-"},
+			wantErr: "requires confirmation",
+		},
+		{
+			name:    "over max_shares",
+			body:    `{"instrument":{"symbol":"AAPL","type":"EQUITY"},"orderSide":"BUY","orderType":"MARKET","expiration":{"timeInForce":"DAY"},"quantity":"150"}`,
+			opts:    orderOptions{accountID: "test-account", tradingEnabled: true, maxShares: 100, maxContracts: 500},
+			wantErr: "order of 150 shares exceeds max_shares 100 (use --force to override)",
+		},
+		{
+			name:    "over max_contracts",
+			body:    `{"instrument":{"symbol":"AAPL250117C00175000","type":"OPTION"},"orderSide":"BUY","orderType":"LIMIT","expiration":{"timeInForce":"DAY"},"quantity":"20","limitPrice":"1.00","openCloseIndicator":"OPEN"}`,
+			opts:    orderOptions{accountID: "test-account", tradingEnabled: true, maxShares: 5, maxContracts: 10},
+			wantErr: "order of 20 contracts exceeds max_contracts 10",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := tt.opts
+			if opts.accountID == "" {
+				opts = orderOptions{accountID: "test-account", tradingEnabled: true}
+			}
+			args := tt.args
+			if args == nil {
+				args = []string{"--input", "-", "--yes"}
+			}
+			cmd := newOrderSubmitCmd(opts)
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+			cmd.SetIn(strings.NewReader(tt.body))
+			cmd.SetArgs(args)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestOrderBuyCmd_MaxShares(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(stubQuotes(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		var req map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"orderId": req["orderId"]})
+	}))
+	defer server.Close()
+
+	run := func(args ...string) error {
+		cmd := newOrderBuyCmd(orderOptions{
+			baseURL:        server.URL,
+			authToken:      "test-token",
+			accountID:      "test-account",
+			tradingEnabled: true,
+			maxShares:      100,
+		})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs(append([]string{"AAPL", "--no-preflight", "--yes"}, args...))
+		return cmd.Execute()
+	}
+
+	err := run("-q", "1000")
+	require.Error(t, err)
+	assert.Equal(t, "order of 1000 shares exceeds max_shares 100 (use --force to override)", err.Error())
+	assert.Zero(t, calls, "nothing is sent when the cap rejects the order")
+
+	err = run("-q", "60", "--max-shares", "50")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exceeds --max-shares 50")
+
+	require.NoError(t, run("-q", "100"))
+	require.NoError(t, run("-q", "1000", "--force"))
+	require.NoError(t, run("-q", "150", "--max-shares", "200"))
+	assert.Equal(t, 3, calls)
+}
+
+func TestOrderCancelCmd_OlderThan(t *testing.T) {
+	old := time.Now().Add(-10 * 24 * time.Hour).UTC().Format(time.RFC3339)
+	recent := time.Now().Add(-2 * time.Hour).UTC().Format(time.RFC3339)
+
+	var cancelled []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"orders": []map[string]any{
+					{"orderId": "order-old", "instrument": map[string]any{"symbol": "AAPL", "type": "EQUITY"}, "side": "BUY", "quantity": "10", "limitPrice": "150", "createdAt": old},
+					{"orderId": "order-new", "instrument": map[string]any{"symbol": "AAPL", "type": "EQUITY"}, "side": "BUY", "quantity": "10", "limitPrice": "150", "createdAt": recent},
+					{"orderId": "order-sell", "instrument": map[string]any{"symbol": "AAPL", "type": "EQUITY"}, "side": "SELL", "quantity": "5", "limitPrice": "200", "createdAt": old},
+					{"orderId": "order-tsla", "instrument": map[string]any{"symbol": "TSLA", "type": "EQUITY"}, "side": "BUY", "quantity": "1", "limitPrice": "100", "createdAt": "2020-01-02"},
+					{"orderId": "order-bad", "instrument": map[string]any{"symbol": "AAPL", "type": "EQUITY"}, "side": "BUY", "quantity": "1", "createdAt": "last tuesday"},
+				},
+			})
+			return
+		}
+		cancelled = append(cancelled, strings.TrimPrefix(r.URL.Path, "/userapigateway/trading/test-account/order/"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	newCmd := func(args ...string) (*cobra.Command, *bytes.Buffer, *bytes.Buffer) {
+		cmd := newOrderCancelCmd(orderOptions{
+			baseURL:        server.URL,
+			authToken:      "test-token",
+			accountID:      "test-account",
+			tradingEnabled: true,
+		})
+		var out, errOut bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetErr(&errOut)
+		cmd.SetArgs(args)
+		return cmd, &out, &errOut
+	}
+
+	// Preview without --yes cancels nothing
+	cmd, out, errOut := newCmd("--older-than", "7d")
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "requires confirmation")
+	assert.Empty(t, cancelled)
+	assert.Contains(t, out.String(), "Cancel 3 Orders:")
+	assert.Contains(t, out.String(), "order-old  AAPL  BUY  placed 10d ago")
+	assert.Contains(t, out.String(), "order-tsla")
+	assert.NotContains(t, out.String(), "order-new")
+	assert.Contains(t, errOut.String(), `Warning: skipping order order-bad: cannot read createdAt "last tuesday"`)
+
+	// Combined with --symbol and --side
+	cmd, out, _ = newCmd("--older-than", "1w", "--symbol", "aapl", "--side", "buy", "--yes")
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, []string{"order-old"}, cancelled)
+	assert.Contains(t, out.String(), "Cancel orders: 1 succeeded, 0 failed, est. value $1500.00")
+
+	// Nothing old enough
+	cancelled = nil
+	cmd, out, _ = newCmd("--older-than", "3650d", "--yes")
+	require.NoError(t, cmd.Execute())
+	assert.Empty(t, cancelled)
+	assert.Contains(t, out.String(), "No open orders older than 3650d to cancel")
+}
+
+func TestOrderCancelCmd_OlderThanInvalid(t *testing.T) {
+	for _, args := range [][]string{
+		{"--older-than", "soon"},
+		{"--older-than", "-3d"},
+		{"--older-than", "7d", "--side", "hold"},
+		{"order-1", "--older-than", "7d"},
+		{"order-1", "--symbol", "AAPL"},
+	} {
+		cmd := newOrderCancelCmd(orderOptions{accountID: "test-account", tradingEnabled: true})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs(args)
+		assert.Error(t, cmd.Execute(), args)
+	}
+}
+
+func TestParseOrderAge(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"7d", 7 * 24 * time.Hour},
+		{"2W", 14 * 24 * time.Hour},
+		{"36h", 36 * time.Hour},
+		{" 90m ", 90 * time.Minute},
+	}
+	for _, tt := range tests {
+		got, err := parseOrderAge(tt.value)
+		require.NoError(t, err, tt.value)
+		assert.Equal(t, tt.want, got, tt.value)
+	}
+	for _, bad := range []string{"", "d", "0d", "1.5d", "week"} {
+		_, err := parseOrderAge(bad)
+		assert.ErrorContains(t, err, "invalid --older-than", bad)
+	}
+}
+
+func TestParseOrderTime(t *testing.T) {
+	want := time.Date(2025, 1, 2, 15, 4, 5, 0, time.UTC)
+	for _, value := range []string{"2025-01-02T15:04:05Z", "2025-01-02T10:04:05-05:00", "2025-01-02T15:04:05.000Z", "2025-01-02T15:04:05", "2025-01-02 15:04:05"} {
+		got, err := parseOrderTime(value)
+		require.NoError(t, err, value)
+		assert.True(t, want.Equal(got), value)
+	}
+	_, err := parseOrderTime("")
+	assert.Error(t, err)
+}
+
+func TestOrderListCmd_Summary(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"orders": []map[string]any{
+				{"orderId": "order-1", "instrument": map[string]any{"symbol": "AAPL", "type": "EQUITY"}, "side": "BUY", "type": "LIMIT", "status": "NEW", "quantity": "10", "filledQuantity": "0", "limitPrice": "150"},
+				{"orderId": "order-2", "instrument": map[string]any{"symbol": "AAPL250117C00180000", "type": "OPTION"}, "side": "BUY", "type": "LIMIT", "status": "PARTIALLY_FILLED", "quantity": "3", "filledQuantity": "1", "limitPrice": "1.25"},
+				{"orderId": "order-3", "instrument": map[string]any{"symbol": "TSLA", "type": "EQUITY"}, "side": "SELL", "type": "MARKET", "status": "NEW", "quantity": "1"},
+			},
+		})
+	}))
+	defer server.Close()
+
+	cmd := newOrderListCmd(orderOptions{baseURL: server.URL, authToken: "test-token", accountID: "test-account"})
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--summary"})
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), "Summary: 3 orders (NEW 2, PARTIALLY_FILLED 1); working notional $1750.00 plus 1 market order(s) without a price")
+
+	// Without --summary there is no footer
+	cmd = newOrderListCmd(orderOptions{baseURL: server.URL, authToken: "test-token", accountID: "test-account"})
+	out.Reset()
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{})
+	require.NoError(t, cmd.Execute())
+	assert.NotContains(t, out.String(), "Summary:")
+
+	cmd = newOrderListCmd(orderOptions{baseURL: server.URL, authToken: "test-token", accountID: "test-account", jsonMode: true})
+	out.Reset()
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--summary", "--side", "buy"})
+	require.NoError(t, cmd.Execute())
+
+	var result struct {
+		Orders  []api.Order  `json:"orders"`
+		Summary orderSummary `json:"summary"`
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &result))
+	assert.Len(t, result.Orders, 2)
+	assert.Equal(t, 2, result.Summary.Count)
+	assert.Equal(t, map[string]int{"NEW": 1, "PARTIALLY_FILLED": 1}, result.Summary.ByStatus)
+	assert.Equal(t, "1750.00", result.Summary.WorkingNotional)
+	assert.Zero(t, result.Summary.Unpriced)
+}
+
+func TestSummarizeOrders_ClosedExcludedFromNotional(t *testing.T) {
+	s := summarizeOrders([]api.Order{
+		{Status: "FILLED", Quantity: "10", LimitPrice: "100"},
+		{Status: "NEW", Quantity: "2", LimitPrice: "50"},
+		{Status: "ODD_STATUS", Quantity: "1", LimitPrice: "5"},
+	})
+	assert.Equal(t, 3, s.Count)
+	assert.Equal(t, "105.00", s.WorkingNotional)
+	assert.Equal(t, []string{"NEW", "FILLED", "ODD_STATUS"}, s.statuses())
+}
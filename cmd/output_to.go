@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// outputStdout is the default --output-to destination.
+const outputStdout = "stdout"
+
+// outputDestination is the --output-to flag: stdout, a file path, or an
+// http(s) URL to POST to. It is checked as the flag is parsed, so a bad
+// destination fails before the command runs.
+type outputDestination struct {
+	value string
+}
+
+func (d *outputDestination) String() string { return d.value }
+
+func (d *outputDestination) Type() string { return "destination" }
+
+func (d *outputDestination) Set(s string) error {
+	invalid := strings.TrimSpace(s) == ""
+	if strings.Contains(s, "://") {
+		u, err := url.Parse(s)
+		invalid = err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https")
+	}
+	if invalid {
+		return fmt.Errorf("use stdout, a file path, or an http(s) URL")
+	}
+	d.value = s
+	return nil
+}
+
+// isWebhook reports whether the destination is a URL to POST to.
+func (d *outputDestination) isWebhook() bool {
+	return strings.HasPrefix(d.value, "http://") || strings.HasPrefix(d.value, "https://")
+}
+
+// outputHeaders is the repeatable --output-header flag, "Name: value" pairs
+// sent with webhook posts.
+type outputHeaders struct {
+	header http.Header
+}
+
+// String lists only the header names so values such as tokens are not echoed.
+func (h *outputHeaders) String() string {
+	names := make([]string, 0, len(h.header))
+	for name := range h.header {
+		names = append(names, name)
+	}
+	return strings.Join(names, ",")
+}
+
+func (h *outputHeaders) Type() string { return "header" }
+
+func (h *outputHeaders) Set(s string) error {
+	name, value, ok := strings.Cut(s, ":")
+	name = strings.TrimSpace(name)
+	if !ok || name == "" || strings.ContainsAny(name, " \t") {
+		return fmt.Errorf(`use "Name: value"`)
+	}
+	if h.header == nil {
+		h.header = make(http.Header)
+	}
+	h.header.Add(name, strings.TrimSpace(value))
+	return nil
+}
+
+// outputTarget delivers a command's primary output once it has finished.
+type outputTarget interface {
+	deliver(data []byte) error
+	String() string
+}
+
+// stdoutTarget writes the output to stdout once the command finishes. It
+// holds --json output so --timings can be merged into the result.
+type stdoutTarget struct {
+	w io.Writer
+}
+
+func (t stdoutTarget) deliver(data []byte) error {
+	_, err := t.w.Write(data)
+	return err
+}
+
+func (t stdoutTarget) String() string { return outputStdout }
+
+// fileTarget writes the output to a file, replacing it. The file is private
+// to the user, as the output can hold account details.
+type fileTarget struct {
+	path string
+}
+
+func (t fileTarget) deliver(data []byte) error {
+	return os.WriteFile(t.path, data, 0600)
+}
+
+func (t fileTarget) String() string { return t.path }
+
+// webhookTimeout bounds a webhook post.
+const webhookTimeout = 30 * time.Second
+
+// webhookTarget POSTs the output to a URL. The URL must answer with a 2xx
+// status.
+type webhookTarget struct {
+	url         string
+	header      http.Header
+	contentType string
+	client      *http.Client
+}
+
+func (t webhookTarget) deliver(data []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), webhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	for name, values := range t.header {
+		for _, v := range values {
+			req.Header.Add(name, v)
+		}
+	}
+	req.Header.Set("Content-Type", t.contentType)
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook returned %d - %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+func (t webhookTarget) String() string { return t.url }
+
+// newOutputTarget returns where --output-to sends output, or nil for stdout.
+// Webhook posts are JSON when jsonMode is set and plain text otherwise.
+func newOutputTarget(dest *outputDestination, headers *outputHeaders, jsonMode bool) outputTarget {
+	switch {
+	case dest.value == "" || dest.value == outputStdout:
+		return nil
+	case dest.isWebhook():
+		contentType := "text/plain; charset=utf-8"
+		if jsonMode {
+			contentType = "application/json"
+		}
+		return webhookTarget{url: dest.value, header: headers.header, contentType: contentType, client: &http.Client{}}
+	}
+	return fileTarget{path: dest.value}
+}
+
+// bufferedOutput collects a command's primary output for an outputTarget.
+// Output is held until the command finishes so a webhook receives a single
+// complete document.
+type bufferedOutput struct {
+	bytes.Buffer
+	target outputTarget
+}
+
+// flag names the option that buffered the output, for errors about it.
+func (b *bufferedOutput) flag() string {
+	if _, ok := b.target.(stdoutTarget); ok {
+		return "--timings with --json"
+	}
+	return "--output-to"
+}
+
+// deliver sends the collected output to the target.
+func (b *bufferedOutput) deliver() error {
+	if err := b.target.deliver(b.Bytes()); err != nil {
+		return fmt.Errorf("failed to send output to %s: %w", b.target, err)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputDestination_Set(t *testing.T) {
+	tests := []struct {
+		value   string
+		webhook bool
+		wantErr bool
+	}{
+		{"stdout", false, false},
+		{"out/portfolio.json", false, false},
+		{"https://hooks.example.com/pub", true, false},
+		{"http://localhost:8080/ingest", true, false},
+		{"ftp://example.com/out", false, true},
+		{"https://", false, true},
+		{" ", false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			var d outputDestination
+			err := d.Set(tt.value)
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "use stdout, a file path, or an http(s) URL")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.webhook, d.isWebhook())
+		})
+	}
+}
+
+func TestOutputHeaders_Set(t *testing.T) {
+	var h outputHeaders
+	require.NoError(t, h.Set("Authorization: Bearer secret"))
+	require.NoError(t, h.Set("X-Source:pub"))
+	assert.Equal(t, "Bearer secret", h.header.Get("Authorization"))
+	assert.Equal(t, "pub", h.header.Get("X-Source"))
+	assert.NotContains(t, h.String(), "secret")
+
+	assert.ErrorContains(t, h.Set("no colon"), `use "Name: value"`)
+	assert.ErrorContains(t, h.Set(": value"), `use "Name: value"`)
+}
+
+func TestNewOutputTarget(t *testing.T) {
+	assert.Nil(t, newOutputTarget(&outputDestination{value: outputStdout}, &outputHeaders{}, false))
+	assert.Equal(t, fileTarget{path: "out.txt"}, newOutputTarget(&outputDestination{value: "out.txt"}, &outputHeaders{}, false))
+
+	target := newOutputTarget(&outputDestination{value: "https://hooks.example.com"}, &outputHeaders{}, true)
+	require.IsType(t, webhookTarget{}, target)
+	assert.Equal(t, "application/json", target.(webhookTarget).contentType)
+}
+
+func TestBufferedOutput_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "portfolio.json")
+	out := &bufferedOutput{target: fileTarget{path: path}}
+	_, _ = out.WriteString(`{"ok": true}` + "\n")
+	require.NoError(t, out.deliver())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `{"ok": true}`+"\n", string(data))
+
+	out = &bufferedOutput{target: fileTarget{path: filepath.Join(t.TempDir(), "missing", "out.json")}}
+	err = out.deliver()
+	assert.ErrorContains(t, err, "failed to send output to")
+}
+
+func TestBufferedOutput_Webhook(t *testing.T) {
+	var gotBody, gotAuth, gotType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		gotAuth = r.Header.Get("Authorization")
+		gotType = r.Header.Get("Content-Type")
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	var headers outputHeaders
+	require.NoError(t, headers.Set("Authorization: Bearer hook-token"))
+	out := &bufferedOutput{target: newOutputTarget(&outputDestination{value: server.URL}, &headers, true)}
+	_, _ = out.WriteString(`{"positions": []}`)
+	require.NoError(t, out.deliver())
+
+	assert.Equal(t, `{"positions": []}`, gotBody)
+	assert.Equal(t, "Bearer hook-token", gotAuth)
+	assert.Equal(t, "application/json", gotType)
+}
+
+func TestBufferedOutput_WebhookRejects(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte("bad token"))
+	}))
+	defer server.Close()
+
+	out := &bufferedOutput{target: newOutputTarget(&outputDestination{value: server.URL}, &outputHeaders{}, false)}
+	_, _ = out.WriteString("AAPL 175.50\n")
+	err := out.deliver()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to send output to "+server.URL)
+	assert.Contains(t, err.Error(), "webhook returned 403 - bad token")
+}
+
+func TestRootCmd_OutputToFlagsExist(t *testing.T) {
+	flag := rootCmd.PersistentFlags().Lookup("output-to")
+	require.NotNil(t, flag)
+	assert.Equal(t, outputStdout, flag.DefValue)
+	assert.NotNil(t, rootCmd.PersistentFlags().Lookup("output-header"))
+}
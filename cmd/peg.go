@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jonandersen/public-cli/internal/api"
+	"github.com/jonandersen/public-cli/internal/money"
+)
+
+// pegReferences are the quote prices --peg can price a limit order from.
+var pegReferences = []string{"bid", "ask", "mid", "last"}
+
+// pegSpec is --peg and --offset as given: the limit price is the reference
+// price from a fresh quote plus the offset, rounded to the tick.
+type pegSpec struct {
+	reference string // bid, ask, mid, or last; empty uses the side's default
+	offset    string // signed dollars added to the reference
+}
+
+// set reports whether the limit price should be pegged. --offset alone pegs
+// to the side's default reference.
+func (p pegSpec) set() bool {
+	return p.reference != "" || p.offset != ""
+}
+
+// validate checks the flags before any quote is fetched. limitPrice is the
+// --limit value, which a peg replaces.
+func (p pegSpec) validate(limitPrice string) error {
+	if !p.set() {
+		return nil
+	}
+	if limitPrice != "" {
+		return fmt.Errorf("--peg computes the limit price; do not combine it with --limit")
+	}
+	if p.reference != "" && !slices.Contains(pegReferences, strings.ToLower(p.reference)) {
+		return fmt.Errorf("invalid --peg %q: use bid, ask, mid, or last", p.reference)
+	}
+	if _, err := money.Parse(p.offset); err != nil {
+		return fmt.Errorf("invalid --offset %q: must be a dollar amount such as 0.05 or -0.02", p.offset)
+	}
+	return nil
+}
+
+// defaultPegReference is the price a marketable order works from: the ask
+// for buys and the bid for sells.
+func defaultPegReference(side string) string {
+	if side == "SELL" {
+		return "bid"
+	}
+	return "ask"
+}
+
+// peggedLimit is a limit price computed from a quote by --peg.
+type peggedLimit struct {
+	reference string
+	refPrice  money.Money
+	offset    money.Money
+	tick      money.Money
+	limit     money.Money
+}
+
+// price formats the limit for the order request, with four decimals only
+// for sub-penny ticks.
+func (p peggedLimit) price() string {
+	if p.tick.Cmp(money.MustParse("0.01")) < 0 {
+		return p.limit.StringFixed(4)
+	}
+	return p.limit.String()
+}
+
+// describe explains the limit for the order preview, e.g.
+// "ask $175.22 -0.02, tick 0.01".
+func (p peggedLimit) describe() string {
+	desc := fmt.Sprintf("%s $%s", p.reference, pegAmount(p.refPrice))
+	if !p.offset.IsZero() {
+		sign := "+"
+		if p.offset.Sign() < 0 {
+			sign = ""
+		}
+		desc += fmt.Sprintf(" %s%s", sign, pegAmount(p.offset))
+	}
+	return desc + fmt.Sprintf(", tick %s", pegAmount(p.tick))
+}
+
+// pegAmount formats m with two decimals, or four when it has sub-cent digits
+// such as a midpoint of 175.175.
+func pegAmount(m money.Money) string {
+	if fine := m.StringFixed(4); fine != m.StringFixed(2)+"00" {
+		return fine
+	}
+	return m.String()
+}
+
+// resolve computes the limit price for an order on side from q. The result
+// is rounded to tickFor's increment away from paying up: down for buys and up
+// for sells.
+func (p pegSpec) resolve(q api.Quote, side string, tickFor func(money.Money) money.Money) (peggedLimit, error) {
+	ref := strings.ToLower(p.reference)
+	if ref == "" {
+		ref = defaultPegReference(side)
+	}
+	refPrice, err := pegReferencePrice(q, ref)
+	if err != nil {
+		return peggedLimit{}, err
+	}
+	offset, err := money.Parse(p.offset)
+	if err != nil {
+		return peggedLimit{}, fmt.Errorf("invalid --offset %q: must be a dollar amount such as 0.05 or -0.02", p.offset)
+	}
+
+	raw := refPrice.Add(offset)
+	tick := tickFor(raw)
+	limit := raw.Floor(tick)
+	if side == "SELL" {
+		limit = raw.Ceil(tick)
+	}
+	pegged := peggedLimit{reference: ref, refPrice: refPrice, offset: offset, tick: tick, limit: limit}
+	if limit.Sign() <= 0 {
+		return peggedLimit{}, fmt.Errorf("--peg %s gives a limit of %s; it must be positive", pegged.describe(), pegged.price())
+	}
+	return pegged, nil
+}
+
+// pegReferencePrice reads ref from q. mid is halfway between bid and ask.
+func pegReferencePrice(q api.Quote, ref string) (money.Money, error) {
+	if q.Outcome != "SUCCESS" {
+		return money.Zero, fmt.Errorf("--peg: no quote for %s (%s)", q.Instrument.Symbol, q.Outcome)
+	}
+	field := func(name, value string) (money.Money, error) {
+		m, err := money.Parse(value)
+		if err != nil || m.Sign() <= 0 {
+			return money.Zero, fmt.Errorf("--peg %s: the quote for %s has no %s", ref, q.Instrument.Symbol, name)
+		}
+		return m, nil
+	}
+
+	switch ref {
+	case "bid":
+		return field("bid", q.Bid)
+	case "ask":
+		return field("ask", q.Ask)
+	case "last":
+		return field("last price", q.Last)
+	}
+	bid, err := field("bid", q.Bid)
+	if err != nil {
+		return money.Zero, err
+	}
+	ask, err := field("ask", q.Ask)
+	if err != nil {
+		return money.Zero, err
+	}
+	return bid.Add(ask).Mul(money.MustParse("0.5")), nil
+}
+
+// equityTick is the minimum price increment for a stock at price: a penny
+// from $1 up, and $0.0001 below.
+func equityTick(price money.Money) money.Money {
+	if price.Cmp(money.FromInt(1)) >= 0 {
+		return money.MustParse("0.01")
+	}
+	return money.MustParse("0.0001")
+}
+
+// optionTick is the standard minimum increment for an option premium: $0.05
+// below $3 and $0.10 from $3 up. Contracts in the penny program accept
+// finer prices, so this may round more coarsely than required.
+func optionTick(price money.Money) money.Money {
+	if price.Cmp(money.FromInt(3)) < 0 {
+		return money.MustParse("0.05")
+	}
+	return money.MustParse("0.10")
+}
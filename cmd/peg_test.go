@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jonandersen/public-cli/internal/api"
+	"github.com/jonandersen/public-cli/internal/money"
+)
+
+func TestPegSpec_Validate(t *testing.T) {
+	assert.NoError(t, pegSpec{}.validate("175.00"))
+	assert.NoError(t, pegSpec{reference: "MID"}.validate(""))
+	assert.NoError(t, pegSpec{offset: "-0.02"}.validate(""))
+
+	err := pegSpec{reference: "mid"}.validate("175.00")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "do not combine it with --limit")
+
+	err = pegSpec{reference: "close"}.validate("")
+	require.Error(t, err)
+	assert.Equal(t, `invalid --peg "close": use bid, ask, mid, or last`, err.Error())
+
+	err = pegSpec{reference: "bid", offset: "a penny"}.validate("")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid --offset "a penny"`)
+}
+
+func TestPegSpec_Resolve(t *testing.T) {
+	quote := api.Quote{
+		Instrument: api.QuoteInstrument{Symbol: "AAPL"},
+		Outcome:    "SUCCESS",
+		Bid:        "175.10",
+		Ask:        "175.25",
+		Last:       "175.18",
+	}
+
+	tests := []struct {
+		name  string
+		peg   pegSpec
+		side  string
+		limit string
+		desc  string
+	}{
+		{"buy defaults to ask", pegSpec{}, "BUY", "175.25", "ask $175.25, tick 0.01"},
+		{"sell defaults to bid", pegSpec{offset: "0.02"}, "SELL", "175.12", "bid $175.10 +0.02, tick 0.01"},
+		{"buy mid rounds down", pegSpec{reference: "mid"}, "BUY", "175.17", "mid $175.1750, tick 0.01"},
+		{"sell mid rounds up", pegSpec{reference: "MID"}, "SELL", "175.18", "mid $175.1750, tick 0.01"},
+		{"last with negative offset", pegSpec{reference: "last", offset: "-0.05"}, "BUY", "175.13", "last $175.18 -0.05, tick 0.01"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.peg.resolve(quote, tt.side, equityTick)
+			require.NoError(t, err)
+			assert.Equal(t, tt.limit, got.price())
+			assert.Equal(t, tt.desc, got.describe())
+		})
+	}
+}
+
+func TestPegSpec_ResolveErrors(t *testing.T) {
+	noAsk := api.Quote{Instrument: api.QuoteInstrument{Symbol: "XYZ"}, Outcome: "SUCCESS", Bid: "1.00"}
+	_, err := pegSpec{reference: "mid"}.resolve(noAsk, "BUY", equityTick)
+	require.Error(t, err)
+	assert.Equal(t, "--peg mid: the quote for XYZ has no ask", err.Error())
+
+	failed := api.Quote{Instrument: api.QuoteInstrument{Symbol: "XYZ"}, Outcome: "FAILURE"}
+	_, err = pegSpec{reference: "bid"}.resolve(failed, "SELL", equityTick)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no quote for XYZ (FAILURE)")
+
+	cheap := api.Quote{Instrument: api.QuoteInstrument{Symbol: "XYZ"}, Outcome: "SUCCESS", Bid: "0.05", Ask: "0.10"}
+	_, err = pegSpec{reference: "bid", offset: "-0.10"}.resolve(cheap, "BUY", optionTick)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must be positive")
+}
+
+func TestTicks(t *testing.T) {
+	assert.Equal(t, "0.0100", equityTick(money.MustParse("1.00")).StringFixed(4))
+	assert.Equal(t, "0.0001", equityTick(money.MustParse("0.9999")).StringFixed(4))
+	assert.Equal(t, "0.05", optionTick(money.MustParse("2.99")).String())
+	assert.Equal(t, "0.10", optionTick(money.MustParse("3.00")).String())
+
+	sub := api.Quote{Outcome: "SUCCESS", Bid: "0.4321", Ask: "0.4388"}
+	got, err := pegSpec{reference: "mid"}.resolve(sub, "BUY", equityTick)
+	require.NoError(t, err)
+	assert.Equal(t, "0.4354", got.price(), "sub-dollar stocks keep four decimals")
+}
+
+// pegServer serves an AAPL quote and records the order it is sent.
+func pegServer(t *testing.T, quote map[string]any) (*httptest.Server, *map[string]any) {
+	t.Helper()
+	var order map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/quotes"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"quotes": []map[string]any{quote}})
+		case strings.HasSuffix(r.URL.Path, "/order"):
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&order))
+			_ = json.NewEncoder(w).Encode(map[string]any{"orderId": order["orderId"]})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &order
+}
+
+func TestOrderBuyCmd_Peg(t *testing.T) {
+	server, order := pegServer(t, map[string]any{
+		"instrument": map[string]any{"symbol": "AAPL", "type": "EQUITY"},
+		"outcome":    "SUCCESS",
+		"bid":        "175.10",
+		"ask":        "175.25",
+	})
+
+	cmd := newOrderBuyCmd(orderOptions{
+		baseURL:        server.URL,
+		authToken:      "test-token",
+		accountID:      "test-account",
+		tradingEnabled: true,
+	})
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"AAPL", "-q", "10", "--peg", "mid", "--no-preflight", "--yes"})
+
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, "LIMIT", (*order)["orderType"])
+	assert.Equal(t, "175.17", (*order)["limitPrice"])
+	assert.Contains(t, out.String(), "Limit:    $175.17")
+	assert.Contains(t, out.String(), "Peg:      mid $175.1750, tick 0.01")
+}
+
+func TestOrderBuyCmd_PegWithLimit(t *testing.T) {
+	cmd := newOrderBuyCmd(orderOptions{
+		baseURL:        "http://unused",
+		authToken:      "test-token",
+		accountID:      "test-account",
+		tradingEnabled: true,
+	})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"AAPL", "-q", "10", "--peg", "bid", "--limit", "175", "--yes"})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "do not combine it with --limit")
+}
+
+func TestRunSingleLegOrder_Peg(t *testing.T) {
+	server, order := pegServer(t, map[string]any{
+		"instrument": map[string]any{"symbol": "AAPL250117C00175000", "type": "OPTION"},
+		"outcome":    "SUCCESS",
+		"bid":        "2.40",
+		"ask":        "2.58",
+	})
+	opts := optionsOptions{baseURL: server.URL, authToken: "test-token", accountID: "test-account"}
+	params := singleLegParams{
+		quantity:    "1",
+		expiration:  "DAY",
+		openClose:   "CLOSE",
+		noPreflight: true,
+		peg:         pegSpec{reference: "mid"},
+	}
+
+	cmd := newTestCmd()
+	require.NoError(t, runSingleLegOrder(cmd, opts, "AAPL250117C00175000", "SELL", params, true, true))
+	assert.Equal(t, "2.50", (*order)["limitPrice"], "the 2.49 midpoint rounds up to the nickel for a sell")
+	output := cmd.OutOrStdout().(*bytes.Buffer).String()
+	assert.Contains(t, output, "Peg:        mid $2.49, tick 0.05")
+}
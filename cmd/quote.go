@@ -1,19 +1,21 @@
 package cmd
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/jonandersen/public-cli/internal/analytics"
 	"github.com/jonandersen/public-cli/internal/api"
 	"github.com/jonandersen/public-cli/internal/config"
 	"github.com/jonandersen/public-cli/internal/keyring"
+	"github.com/jonandersen/public-cli/internal/marketdate"
 	"github.com/jonandersen/public-cli/internal/output"
 	"github.com/jonandersen/public-cli/pkg/publicapi"
 )
@@ -24,6 +26,15 @@ type quoteOptions struct {
 	authToken string
 	accountID string
 	jsonMode  bool
+	showSize  bool
+
+	instrumentType string // --type: auto infers the type per symbol
+
+	optionsFor bool // show the ATM straddle for the nearest expiration
+	minDTE     int  // skip expirations closer than this many days
+
+	session    string // label quotes against this trading session; "" disables
+	afterHours bool   // shorthand for --session extended
 }
 
 // newQuoteCmd creates the quote command with the given options.
@@ -36,7 +47,30 @@ func newQuoteCmd(opts quoteOptions) *cobra.Command {
 Examples:
   pub quote AAPL              # Get quote for Apple
   pub quote AAPL GOOGL MSFT   # Get quotes for multiple symbols
-  pub quote AAPL --json       # Output in JSON format`,
+  pub quote BRK-B             # Class share (BRK.B)
+  pub quote AAPL AAPL250117C00175000   # Stock and option together
+  pub quote BTC-USD --type crypto      # Crypto, quoted as BTC
+  pub quote AAPL --json       # Output in JSON format
+  pub quote AAPL --size       # Include bid/ask sizes
+  pub quote AAPL --options-for          # Quote plus ATM straddle, nearest expiration
+  pub quote AAPL --options-for --dte 30 # ATM straddle at least 30 days out
+  pub quote AAPL --session regular      # Flag quotes not from today's regular session
+  pub quote AAPL --after-hours          # Same as --session extended
+
+The quotes endpoint always returns the latest trade, whatever session it
+printed in; it cannot be asked for a specific session's price. --session
+(regular, pre, post, or extended for all three) instead labels each quote
+with the session and Eastern time of its last trade, shows the session the
+market is in now, and marks a quote stale when its last trade is from an
+earlier day or outside the requested session. Exchange holidays are not
+known and are treated as ordinary weekdays.
+
+--type auto (the default) picks the instrument type per symbol: a valid OSI
+option symbol is an OPTION and anything else is EQUITY. The table shows the
+type each symbol was quoted as. --type equity, option, or crypto applies one
+type to every symbol instead. Crypto is never picked by auto, since the
+quotes endpoint documents only EQUITY, OPTION, and INDEX; pass --type crypto
+to quote BTC or BTC-USD as the coin.`,
 		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) == 0 {
@@ -45,53 +79,151 @@ Examples:
 			if opts.accountID == "" {
 				return fmt.Errorf("account ID is required (use --account flag or configure default account)")
 			}
-			return runQuote(cmd, opts, args)
+			if err := validateQuoteType(opts.instrumentType, opts.optionsFor); err != nil {
+				return err
+			}
+			if opts.optionsFor {
+				if len(args) != 1 {
+					return fmt.Errorf("--options-for takes exactly one symbol")
+				}
+				if opts.minDTE < 0 {
+					return fmt.Errorf("invalid --dte: must not be negative")
+				}
+				return runQuoteOptionsFor(cmd, opts, args[0], time.Now())
+			}
+			if opts.afterHours {
+				if opts.session != "" && opts.session != "extended" {
+					return fmt.Errorf("--after-hours cannot be combined with --session %s", opts.session)
+				}
+				opts.session = "extended"
+			}
+			if err := validateQuoteSession(opts.session); err != nil {
+				return err
+			}
+			return runQuote(cmd, opts, args, time.Now())
 		},
 	}
 
+	cmd.Flags().BoolVar(&opts.showSize, "size", false, "Show bid/ask size columns")
+	cmd.Flags().StringVarP(&opts.instrumentType, "type", "t", quoteTypeAuto, "Instrument type: auto (detect per symbol), equity, option, or crypto")
+	cmd.Flags().BoolVar(&opts.optionsFor, "options-for", false, "Also show the ATM call, put, and straddle for the nearest expiration")
+	cmd.Flags().IntVar(&opts.minDTE, "dte", 0, "With --options-for, use the first expiration at least N days out")
+	cmd.Flags().StringVar(&opts.session, "session", "", "Label quotes with their trading session and flag stale ones (regular, pre, post, extended)")
+	cmd.Flags().BoolVar(&opts.afterHours, "after-hours", false, "Same as --session extended")
 	cmd.SilenceUsage = true
 
 	return cmd
 }
 
-func runQuote(cmd *cobra.Command, opts quoteOptions, symbols []string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
+// quoteTypeAuto is the --type that infers each symbol's instrument type.
+const quoteTypeAuto = "auto"
+
+// quoteTypes are the accepted --type values besides auto.
+var quoteTypes = []string{"EQUITY", "OPTION", "CRYPTO"}
+
+// validateQuoteType checks a --type value. --options-for always quotes an
+// equity underlying, so it only accepts auto.
+func validateQuoteType(instType string, optionsFor bool) error {
+	if strings.EqualFold(instType, quoteTypeAuto) {
+		return nil
+	}
+	if !slices.Contains(quoteTypes, strings.ToUpper(instType)) {
+		return fmt.Errorf("invalid --type %q: must be auto, equity, option, or crypto", instType)
+	}
+	if optionsFor {
+		return fmt.Errorf("--type cannot be combined with --options-for")
+	}
+	return nil
+}
 
-	// Build request
-	instruments := make([]api.QuoteInstrument, 0, len(symbols))
-	for _, sym := range symbols {
-		instruments = append(instruments, api.QuoteInstrument{
-			Symbol: strings.ToUpper(sym),
-			Type:   "EQUITY",
-		})
+// quoteInstruments types symbols for --type: inferred per symbol for auto,
+// otherwise all as the given type. Forced options must be valid OSI symbols.
+func quoteInstruments(symbols []string, instType string) ([]api.QuoteInstrument, error) {
+	if instType == "" || strings.EqualFold(instType, quoteTypeAuto) {
+		return api.ResolveInstruments(symbols), nil
 	}
+	instruments := api.InstrumentsAs(symbols, strings.ToUpper(instType))
+	for _, inst := range instruments {
+		if inst.Type != "OPTION" {
+			continue
+		}
+		if _, err := analytics.ParseOSI(inst.Symbol); err != nil {
+			return nil, err
+		}
+	}
+	return instruments, nil
+}
 
-	reqBody := api.QuoteRequest{Instruments: instruments}
-	body, err := json.Marshal(reqBody)
-	if err != nil {
-		return fmt.Errorf("failed to encode request: %w", err)
+// quoteSessions are the accepted --session values.
+var quoteSessions = []string{"regular", "pre", "post", "extended"}
+
+// validateQuoteSession checks a --session value; empty means no labeling.
+func validateQuoteSession(session string) error {
+	if session == "" || slices.Contains(quoteSessions, session) {
+		return nil
 	}
+	return fmt.Errorf("invalid --session %q: must be one of %s", session, strings.Join(quoteSessions, ", "))
+}
 
-	client := api.NewClient(opts.baseURL, opts.authToken)
-	path := fmt.Sprintf("/userapigateway/marketdata/%s/quotes", opts.accountID)
-	resp, err := client.Post(ctx, path, bytes.NewReader(body))
+// quoteSessionCovers reports whether a trade printed in s belongs to the
+// requested --session.
+func quoteSessionCovers(requested string, s marketdate.Session) bool {
+	if requested == "extended" {
+		return s != marketdate.SessionClosed
+	}
+	return string(s) == requested
+}
+
+// quoteFreshness returns the session and Eastern time of q's last trade,
+// plus a note when that trade is from an earlier day or falls outside the
+// requested session.
+func quoteFreshness(q api.Quote, requested string, now time.Time) (session, at, note string) {
+	ts, err := time.Parse(time.RFC3339, q.LastTimestamp)
 	if err != nil {
-		return fmt.Errorf("failed to fetch quotes: %w", err)
+		return "-", "-", "no trade time"
 	}
-	defer func() { _ = resp.Body.Close() }()
+	s := marketdate.SessionAt(ts)
+	at = ts.In(marketdate.Eastern).Format("15:04 MST")
+	switch {
+	case marketdate.Today(ts).Before(marketdate.Today(now)):
+		note = fmt.Sprintf("stale (%s)", ts.In(marketdate.Eastern).Format(marketdate.Layout))
+	case !quoteSessionCovers(requested, s):
+		note = fmt.Sprintf("outside %s session", requested)
+	}
+	return string(s), at, note
+}
+
+func runQuote(cmd *cobra.Command, opts quoteOptions, symbols []string, now time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
 
-	if resp.StatusCode != 200 {
-		respBody, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API error: %d - %s", resp.StatusCode, string(respBody))
+	instruments, err := quoteInstruments(symbols, opts.instrumentType)
+	if err != nil {
+		return err
+	}
+	client := api.NewClient(opts.baseURL, opts.authToken)
+	bySymbol, err := client.QuoteInstruments(ctx, opts.accountID, symbols, instruments)
+	if err != nil {
+		return err
 	}
 
-	var quotesResp api.QuotesResponse
-	if err := json.NewDecoder(resp.Body).Decode(&quotesResp); err != nil {
-		return fmt.Errorf("failed to decode response: %w", err)
+	// Keep the order symbols were given in, listing each quote once even
+	// when aliases (BRK-B, brk.b) resolve to the same instrument. types holds
+	// the type each quote was requested as, for the Type column.
+	var quotes []api.Quote
+	var types []string
+	listed := make(map[api.QuoteInstrument]bool)
+	for i, symbol := range symbols {
+		q, ok := bySymbol[symbol]
+		if !ok || listed[q.Instrument] {
+			continue
+		}
+		listed[q.Instrument] = true
+		quotes = append(quotes, q)
+		types = append(types, instruments[i].Type)
 	}
 
-	if len(quotesResp.Quotes) == 0 {
+	if len(quotes) == 0 {
 		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No quotes returned")
 		return nil
 	}
@@ -99,28 +231,258 @@ func runQuote(cmd *cobra.Command, opts quoteOptions, symbols []string) error {
 	// Format output
 	formatter := output.New(cmd.OutOrStdout(), opts.jsonMode)
 	headers := []string{"Symbol", "Last", "Bid", "Ask", "Volume"}
-	rows := make([][]string, 0, len(quotesResp.Quotes))
+	if opts.showSize {
+		headers = []string{"Symbol", "Last", "Bid", "Bid Size", "Ask", "Ask Size", "Volume"}
+	}
+	if opts.session != "" {
+		headers = append(headers, "Session", "Time", "Note")
+		if !opts.jsonMode {
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Market session now: %s (%s)\n\n",
+				marketdate.SessionAt(now), now.In(marketdate.Eastern).Format("15:04 MST"))
+		}
+	}
+	var halted []string
+	for _, q := range quotes {
+		if api.IsHalted(q) {
+			halted = append(halted, q.Instrument.Symbol)
+		}
+	}
+	if len(halted) > 0 {
+		headers = append(headers, "Status")
+	}
+	if !opts.jsonMode {
+		headers = slices.Insert(headers, 1, "Type")
+	}
+	rows := make([][]string, 0, len(quotes))
 
-	for _, q := range quotesResp.Quotes {
-		if q.Outcome != "SUCCESS" {
-			rows = append(rows, []string{
-				q.Instrument.Symbol,
-				q.Outcome,
-				"-",
-				"-",
-				"-",
-			})
+	for i, q := range quotes {
+		if q.Outcome != "SUCCESS" && !api.IsHalted(q) {
+			row := []string{q.Instrument.Symbol, q.Outcome}
+			if !opts.jsonMode {
+				row = []string{q.Instrument.Symbol, types[i], q.Outcome}
+			}
+			for len(row) < len(headers) {
+				row = append(row, "-")
+			}
+			rows = append(rows, row)
 			continue
 		}
-		rows = append(rows, []string{
-			q.Instrument.Symbol,
-			q.Last,
-			q.Bid,
-			q.Ask,
-			publicapi.FormatVolume(q.Volume),
-		})
+		var row []string
+		if opts.showSize {
+			row = []string{
+				q.Instrument.Symbol,
+				q.Last,
+				q.Bid,
+				publicapi.FormatVolume(int64(q.BidSize)),
+				q.Ask,
+				publicapi.FormatVolume(int64(q.AskSize)),
+				publicapi.FormatVolume(q.Volume),
+			}
+		} else {
+			row = []string{
+				q.Instrument.Symbol,
+				q.Last,
+				q.Bid,
+				q.Ask,
+				publicapi.FormatVolume(q.Volume),
+			}
+		}
+		if opts.session != "" {
+			session, at, note := quoteFreshness(q, opts.session, now)
+			if note == "" {
+				note = "-"
+			}
+			row = append(row, session, at, note)
+		}
+		if len(halted) > 0 {
+			status := "-"
+			if api.IsHalted(q) {
+				status = "HALTED"
+			}
+			row = append(row, status)
+		}
+		if !opts.jsonMode {
+			row = slices.Insert(row, 1, types[i])
+		}
+		rows = append(rows, row)
 	}
 
+	if err := formatter.Table(headers, rows); err != nil {
+		return err
+	}
+	if len(halted) > 0 && !opts.jsonMode {
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "\nTrading halted: %s. Quotes are frozen and orders will not fill until trading resumes.\n",
+			strings.Join(halted, ", "))
+	}
+	return nil
+}
+
+// atmLeg is one side of the ATM straddle in an options snapshot.
+type atmLeg struct {
+	Symbol string `json:"symbol"`
+	Bid    string `json:"bid"`
+	Ask    string `json:"ask"`
+}
+
+// atmSnapshot is the underlying quote plus the ATM straddle for one expiration.
+type atmSnapshot struct {
+	Symbol      string  `json:"symbol"`
+	Last        string  `json:"last"`
+	Expiration  string  `json:"expiration"`
+	DTE         int     `json:"dte"`
+	Strike      float64 `json:"strike"`
+	Call        *atmLeg `json:"call,omitempty"`
+	Put         *atmLeg `json:"put,omitempty"`
+	StraddleBid string  `json:"straddleBid,omitempty"`
+	StraddleAsk string  `json:"straddleAsk,omitempty"`
+	StraddleMid string  `json:"straddleMid,omitempty"`
+}
+
+// nearestExpiration returns the earliest expiration at least minDTE days out.
+func nearestExpiration(expirations []string, minDTE int, now time.Time) (string, int, bool) {
+	sorted := slices.Clone(expirations)
+	slices.Sort(sorted)
+	for _, exp := range sorted {
+		if dte := marketdate.DaysUntil(exp, now); dte >= minDTE {
+			return exp, dte, true
+		}
+	}
+	return "", 0, false
+}
+
+// atmOption returns the option whose strike is closest to price.
+func atmOption(options []api.OptionQuote, price float64) (api.OptionQuote, bool) {
+	if len(options) == 0 {
+		return api.OptionQuote{}, false
+	}
+	best := options[0]
+	for _, opt := range options[1:] {
+		if abs(parseStrikeFloat(opt.Instrument.Symbol)-price) < abs(parseStrikeFloat(best.Instrument.Symbol)-price) {
+			best = opt
+		}
+	}
+	return best, true
+}
+
+// legAtStrike returns the option at strike, or nil when the chain has none.
+func legAtStrike(options []api.OptionQuote, strike float64) *atmLeg {
+	for _, opt := range options {
+		if parseStrikeFloat(opt.Instrument.Symbol) == strike {
+			return &atmLeg{Symbol: opt.Instrument.Symbol, Bid: opt.Bid, Ask: opt.Ask}
+		}
+	}
+	return nil
+}
+
+// atmPrice is the underlying price the ATM strike is picked against: the last
+// price, or the bid/ask midpoint when the quote has no last trade.
+func atmPrice(quote api.Quote) (float64, error) {
+	if last, err := strconv.ParseFloat(quote.Last, 64); err == nil && last > 0 {
+		return last, nil
+	}
+	if mid := quoteMidOrLast(quote); mid > 0 {
+		return mid, nil
+	}
+	return 0, fmt.Errorf("no last price or bid/ask for %s; cannot pick the ATM strike", quote.Instrument.Symbol)
+}
+
+// buildATMSnapshot picks the ATM strike nearest price from the calls and
+// pairs it with the put at the same strike, pricing the straddle from both
+// legs.
+func buildATMSnapshot(quote api.Quote, price float64, expiration string, dte int, chain *api.OptionChainResponse) atmSnapshot {
+	snap := atmSnapshot{Symbol: quote.Instrument.Symbol, Last: quote.Last, Expiration: expiration, DTE: dte}
+
+	atm, ok := atmOption(chain.Calls, price)
+	if !ok {
+		if atm, ok = atmOption(chain.Puts, price); !ok {
+			return snap
+		}
+	}
+	snap.Strike = parseStrikeFloat(atm.Instrument.Symbol)
+	snap.Call = legAtStrike(chain.Calls, snap.Strike)
+	snap.Put = legAtStrike(chain.Puts, snap.Strike)
+
+	if snap.Call != nil && snap.Put != nil {
+		callBid, err1 := strconv.ParseFloat(snap.Call.Bid, 64)
+		callAsk, err2 := strconv.ParseFloat(snap.Call.Ask, 64)
+		putBid, err3 := strconv.ParseFloat(snap.Put.Bid, 64)
+		putAsk, err4 := strconv.ParseFloat(snap.Put.Ask, 64)
+		if err1 == nil && err2 == nil && err3 == nil && err4 == nil {
+			bid, ask := callBid+putBid, callAsk+putAsk
+			snap.StraddleBid = fmt.Sprintf("%.2f", bid)
+			snap.StraddleAsk = fmt.Sprintf("%.2f", ask)
+			snap.StraddleMid = fmt.Sprintf("%.2f", (bid+ask)/2)
+		}
+	}
+	return snap
+}
+
+// runQuoteOptionsFor prints the underlying quote and the ATM straddle for the
+// nearest expiration at least opts.minDTE days out.
+func runQuoteOptionsFor(cmd *cobra.Command, opts quoteOptions, symbol string, now time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	symbol = strings.ToUpper(symbol)
+	client := api.NewClient(opts.baseURL, opts.authToken)
+	quotes, err := client.GetQuotes(ctx, opts.accountID, []api.QuoteInstrument{{Symbol: symbol, Type: "EQUITY"}})
+	if err != nil {
+		return fmt.Errorf("failed to fetch quotes: %w", err)
+	}
+	if len(quotes) == 0 || quotes[0].Outcome != "SUCCESS" {
+		return fmt.Errorf("no quote available for %s", symbol)
+	}
+	quote := quotes[0]
+	price, err := atmPrice(quote)
+	if err != nil {
+		return err
+	}
+
+	expResp, err := client.GetOptionExpirations(ctx, opts.accountID, symbol)
+	if err != nil {
+		return err
+	}
+	expiration, dte, ok := nearestExpiration(expResp.Expirations, opts.minDTE, now)
+	if !ok {
+		return fmt.Errorf("no option expirations for %s at least %d days out", symbol, opts.minDTE)
+	}
+
+	chain, err := client.GetOptionChain(ctx, opts.accountID, symbol, expiration)
+	if err != nil {
+		return err
+	}
+	snap := buildATMSnapshot(quote, price, expiration, dte, chain)
+
+	w := cmd.OutOrStdout()
+	if opts.jsonMode {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(snap)
+	}
+
+	_, _ = fmt.Fprintf(w, "%s  Last %s  Bid %s  Ask %s\n\n", snap.Symbol, quote.Last, quote.Bid, quote.Ask)
+	if snap.Call == nil && snap.Put == nil {
+		_, _ = fmt.Fprintf(w, "No options available for %s expiring %s\n", symbol, expiration)
+		return nil
+	}
+	_, _ = fmt.Fprintf(w, "ATM straddle %s (%d DTE), strike %s\n", expiration, dte, strconv.FormatFloat(snap.Strike, 'f', -1, 64))
+
+	formatter := output.New(w, false)
+	headers := []string{"Leg", "Symbol", "Bid", "Ask"}
+	var rows [][]string
+	for _, leg := range []struct {
+		name string
+		leg  *atmLeg
+	}{{"Call", snap.Call}, {"Put", snap.Put}} {
+		if leg.leg == nil {
+			rows = append(rows, []string{leg.name, "-", "-", "-"})
+			continue
+		}
+		rows = append(rows, []string{leg.name, leg.leg.Symbol, leg.leg.Bid, leg.leg.Ask})
+	}
+	if snap.StraddleAsk != "" {
+		rows = append(rows, []string{"Straddle", "mid " + snap.StraddleMid, snap.StraddleBid, snap.StraddleAsk})
+	}
 	return formatter.Table(headers, rows)
 }
 
@@ -136,7 +498,30 @@ func init() {
 Examples:
   pub quote AAPL              # Get quote for Apple
   pub quote AAPL GOOGL MSFT   # Get quotes for multiple symbols
-  pub quote AAPL --json       # Output in JSON format`,
+  pub quote BRK-B             # Class share (BRK.B)
+  pub quote AAPL AAPL250117C00175000   # Stock and option together
+  pub quote BTC-USD --type crypto      # Crypto, quoted as BTC
+  pub quote AAPL --json       # Output in JSON format
+  pub quote AAPL --size       # Include bid/ask sizes
+  pub quote AAPL --options-for          # Quote plus ATM straddle, nearest expiration
+  pub quote AAPL --options-for --dte 30 # ATM straddle at least 30 days out
+  pub quote AAPL --session regular      # Flag quotes not from today's regular session
+  pub quote AAPL --after-hours          # Same as --session extended
+
+The quotes endpoint always returns the latest trade, whatever session it
+printed in; it cannot be asked for a specific session's price. --session
+(regular, pre, post, or extended for all three) instead labels each quote
+with the session and Eastern time of its last trade, shows the session the
+market is in now, and marks a quote stale when its last trade is from an
+earlier day or outside the requested session. Exchange holidays are not
+known and are treated as ordinary weekdays.
+
+--type auto (the default) picks the instrument type per symbol: a valid OSI
+option symbol is an OPTION and anything else is EQUITY. The table shows the
+type each symbol was quoted as. --type equity, option, or crypto applies one
+type to every symbol instead. Crypto is never picked by auto, since the
+quotes endpoint documents only EQUITY, OPTION, and INDEX; pass --type crypto
+to quote BTC or BTC-USD as the coin.`,
 		Args: cobra.MinimumNArgs(1),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			// Load config
@@ -153,14 +538,16 @@ Examples:
 			}
 
 			// Use flag value or default from config
-			if accountID == "" {
-				accountID = cfg.AccountUUID
+			accountID, err = resolveAccountID(accountID, cfg.AccountUUID, cmd.InOrStdin())
+			if err != nil {
+				return err
 			}
 
 			opts.baseURL = cfg.APIBaseURL
 			opts.authToken = token
 			opts.accountID = accountID
 			opts.jsonMode = GetJSONMode()
+			opts.showSize = opts.showSize || GetWideMode()
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -170,11 +557,38 @@ Examples:
 			if opts.accountID == "" {
 				return fmt.Errorf("account ID is required (use --account flag or configure default account)")
 			}
-			return runQuote(cmd, opts, args)
+			if err := validateQuoteType(opts.instrumentType, opts.optionsFor); err != nil {
+				return err
+			}
+			if opts.optionsFor {
+				if len(args) != 1 {
+					return fmt.Errorf("--options-for takes exactly one symbol")
+				}
+				if opts.minDTE < 0 {
+					return fmt.Errorf("invalid --dte: must not be negative")
+				}
+				return runQuoteOptionsFor(cmd, opts, args[0], time.Now())
+			}
+			if opts.afterHours {
+				if opts.session != "" && opts.session != "extended" {
+					return fmt.Errorf("--after-hours cannot be combined with --session %s", opts.session)
+				}
+				opts.session = "extended"
+			}
+			if err := validateQuoteSession(opts.session); err != nil {
+				return err
+			}
+			return runQuote(cmd, opts, args, time.Now())
 		},
 	}
 
-	quoteCmd.Flags().StringVarP(&accountID, "account", "a", "", "Account ID (uses default if not specified)")
+	quoteCmd.Flags().StringVarP(&accountID, "account", "a", "", "Account ID (uses default if not specified; - reads it from stdin)")
+	quoteCmd.Flags().BoolVar(&opts.showSize, "size", false, "Show bid/ask size columns")
+	quoteCmd.Flags().StringVarP(&opts.instrumentType, "type", "t", quoteTypeAuto, "Instrument type: auto (detect per symbol), equity, option, or crypto")
+	quoteCmd.Flags().BoolVar(&opts.optionsFor, "options-for", false, "Also show the ATM call, put, and straddle for the nearest expiration")
+	quoteCmd.Flags().IntVar(&opts.minDTE, "dte", 0, "With --options-for, use the first expiration at least N days out")
+	quoteCmd.Flags().StringVar(&opts.session, "session", "", "Label quotes with their trading session and flag stale ones (regular, pre, post, extended)")
+	quoteCmd.Flags().BoolVar(&opts.afterHours, "after-hours", false, "Same as --session extended")
 	quoteCmd.SilenceUsage = true
 
 	rootCmd.AddCommand(quoteCmd)
@@ -6,9 +6,12 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/jonandersen/public-cli/internal/api"
 )
 
 func TestQuoteCmd_SingleSymbol(t *testing.T) {
@@ -131,6 +134,44 @@ func TestQuoteCmd_MultipleSymbols(t *testing.T) {
 	assert.Contains(t, output, "380.00")
 }
 
+func TestQuoteCmd_AliasesListedOnce(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req api.QuoteRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, []api.QuoteInstrument{
+			{Symbol: "MSFT", Type: "EQUITY"},
+			{Symbol: "BRK.B", Type: "EQUITY"},
+			{Symbol: "BTC-USD", Type: "EQUITY"},
+		}, req.Instruments)
+
+		// Returned out of request order
+		var resp api.QuotesResponse
+		for i := len(req.Instruments) - 1; i >= 0; i-- {
+			resp.Quotes = append(resp.Quotes, api.Quote{Instrument: req.Instruments[i], Outcome: "SUCCESS", Last: "1.00"})
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	cmd := newQuoteCmd(quoteOptions{
+		baseURL:   server.URL,
+		authToken: "test-token",
+		accountID: "test-account",
+		jsonMode:  true,
+	})
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"msft", "BRK-B", "brk.b", "btc-usd"})
+	require.NoError(t, cmd.Execute())
+
+	var rows []map[string]string
+	require.NoError(t, json.Unmarshal(out.Bytes(), &rows))
+	require.Len(t, rows, 3)
+	assert.Equal(t, "MSFT", rows[0]["Symbol"])
+	assert.Equal(t, "BRK.B", rows[1]["Symbol"])
+	assert.Equal(t, "BTC-USD", rows[2]["Symbol"])
+}
+
 func TestQuoteCmd_JSON(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		resp := map[string]any{
@@ -262,3 +303,380 @@ func TestQuoteCmd_FailedQuote(t *testing.T) {
 	assert.Contains(t, output, "INVALID")
 	assert.Contains(t, output, "FAILURE")
 }
+
+func TestQuoteCmd_Size(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := map[string]any{
+			"quotes": []map[string]any{
+				{
+					"instrument": map[string]any{"symbol": "AAPL", "type": "EQUITY"},
+					"outcome":    "SUCCESS",
+					"last":       "175.50",
+					"bid":        "175.45",
+					"bidSize":    1200,
+					"ask":        "175.55",
+					"askSize":    300,
+					"volume":     50000000,
+				},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	cmd := newQuoteCmd(quoteOptions{
+		baseURL:   server.URL,
+		authToken: "test-token",
+		accountID: "test-account",
+	})
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"AAPL", "--size"})
+
+	require.NoError(t, cmd.Execute())
+
+	output := out.String()
+	assert.Contains(t, output, "Bid Size")
+	assert.Contains(t, output, "Ask Size")
+	assert.Contains(t, output, "1,200")
+	assert.Contains(t, output, "300")
+}
+
+func TestNearestExpiration(t *testing.T) {
+	now := time.Date(2025, 1, 10, 16, 0, 0, 0, time.UTC)
+	expirations := []string{"2025-02-21", "2025-01-10", "2025-01-17"}
+
+	exp, dte, ok := nearestExpiration(expirations, 0, now)
+	assert.True(t, ok)
+	assert.Equal(t, "2025-01-10", exp)
+	assert.Equal(t, 0, dte)
+
+	exp, dte, ok = nearestExpiration(expirations, 5, now)
+	assert.True(t, ok)
+	assert.Equal(t, "2025-01-17", exp)
+	assert.Equal(t, 7, dte)
+
+	_, _, ok = nearestExpiration(expirations, 60, now)
+	assert.False(t, ok)
+}
+
+func TestATMPrice(t *testing.T) {
+	price, err := atmPrice(api.Quote{Last: "150.25", Bid: "150.00", Ask: "151.00"})
+	require.NoError(t, err)
+	assert.Equal(t, 150.25, price)
+
+	// No last trade falls back to the midpoint
+	price, err = atmPrice(api.Quote{Bid: "150.00", Ask: "151.00"})
+	require.NoError(t, err)
+	assert.Equal(t, 150.5, price)
+
+	_, err = atmPrice(api.Quote{Instrument: api.QuoteInstrument{Symbol: "AAPL"}})
+	assert.EqualError(t, err, "no last price or bid/ask for AAPL; cannot pick the ATM strike")
+}
+
+func quoteOptionsForServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/userapigateway/marketdata/test-account/quotes":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"quotes": []map[string]any{
+					{"instrument": map[string]any{"symbol": "AAPL", "type": "EQUITY"}, "outcome": "SUCCESS", "last": "176.20", "bid": "176.15", "ask": "176.25"},
+				},
+			})
+		case "/userapigateway/marketdata/test-account/option-expirations":
+			_ = json.NewEncoder(w).Encode(map[string]any{"baseSymbol": "AAPL", "expirations": []string{"2025-01-10", "2025-01-17"}})
+		case "/userapigateway/marketdata/test-account/option-chain":
+			var req map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			assert.Equal(t, "2025-01-17", req["expirationDate"])
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"baseSymbol": "AAPL",
+				"calls": []map[string]any{
+					{"instrument": map[string]any{"symbol": "AAPL250117C00175000", "type": "OPTION"}, "bid": "3.10", "ask": "3.30"},
+					{"instrument": map[string]any{"symbol": "AAPL250117C00180000", "type": "OPTION"}, "bid": "1.10", "ask": "1.20"},
+				},
+				"puts": []map[string]any{
+					{"instrument": map[string]any{"symbol": "AAPL250117P00175000", "type": "OPTION"}, "bid": "2.00", "ask": "2.20"},
+					{"instrument": map[string]any{"symbol": "AAPL250117P00180000", "type": "OPTION"}, "bid": "4.80", "ask": "5.00"},
+				},
+			})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestRunQuoteOptionsFor(t *testing.T) {
+	server := quoteOptionsForServer(t)
+	defer server.Close()
+
+	now := time.Date(2025, 1, 10, 16, 0, 0, 0, time.UTC)
+	opts := quoteOptions{baseURL: server.URL, authToken: "test-token", accountID: "test-account", minDTE: 1}
+
+	cmd := newTestCmd()
+	require.NoError(t, runQuoteOptionsFor(cmd, opts, "aapl", now))
+	output := cmd.OutOrStdout().(*bytes.Buffer).String()
+	assert.Contains(t, output, "AAPL  Last 176.20")
+	assert.Contains(t, output, "ATM straddle 2025-01-17 (7 DTE), strike 175")
+	assert.Contains(t, output, "AAPL250117C00175000")
+	assert.Contains(t, output, "AAPL250117P00175000")
+	assert.Contains(t, output, "5.50")
+	assert.NotContains(t, output, "AAPL250117C00180000")
+
+	opts.jsonMode = true
+	cmd = newTestCmd()
+	require.NoError(t, runQuoteOptionsFor(cmd, opts, "AAPL", now))
+	var snap atmSnapshot
+	require.NoError(t, json.Unmarshal(cmd.OutOrStdout().(*bytes.Buffer).Bytes(), &snap))
+	assert.Equal(t, "2025-01-17", snap.Expiration)
+	assert.Equal(t, 175.0, snap.Strike)
+	require.NotNil(t, snap.Call)
+	require.NotNil(t, snap.Put)
+	assert.Equal(t, "5.10", snap.StraddleBid)
+	assert.Equal(t, "5.50", snap.StraddleAsk)
+	assert.Equal(t, "5.30", snap.StraddleMid)
+}
+
+func TestQuoteCmd_OptionsForRequiresOneSymbol(t *testing.T) {
+	cmd := newQuoteCmd(quoteOptions{accountID: "test-account"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"AAPL", "MSFT", "--options-for"})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exactly one symbol")
+}
+
+func TestQuoteFreshness(t *testing.T) {
+	// Wednesday 2025-01-15, 17:30 Eastern (after hours).
+	now := time.Date(2025, 1, 15, 22, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		timestamp string
+		requested string
+		session   string
+		at        string
+		note      string
+	}{
+		{"regular trade, regular session", "2025-01-15T20:59:00Z", "regular", "regular", "15:59 EST", ""},
+		{"after-hours trade, regular session", "2025-01-15T22:15:00Z", "regular", "post", "17:15 EST", "outside regular session"},
+		{"after-hours trade, extended", "2025-01-15T22:15:00Z", "extended", "post", "17:15 EST", ""},
+		{"yesterday's close", "2025-01-14T20:59:00Z", "extended", "regular", "15:59 EST", "stale (2025-01-14)"},
+		{"no timestamp", "", "regular", "-", "-", "no trade time"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session, at, note := quoteFreshness(api.Quote{LastTimestamp: tt.timestamp}, tt.requested, now)
+			assert.Equal(t, tt.session, session)
+			assert.Equal(t, tt.at, at)
+			assert.Equal(t, tt.note, note)
+		})
+	}
+}
+
+func TestRunQuote_Session(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := map[string]any{
+			"quotes": []map[string]any{
+				{
+					"instrument":    map[string]any{"symbol": "AAPL", "type": "EQUITY"},
+					"outcome":       "SUCCESS",
+					"last":          "176.10",
+					"lastTimestamp": "2025-01-15T22:15:00Z",
+					"bid":           "176.00",
+					"ask":           "176.20",
+					"volume":        1000,
+				},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	now := time.Date(2025, 1, 15, 22, 30, 0, 0, time.UTC)
+	opts := quoteOptions{baseURL: server.URL, authToken: "test-token", accountID: "test-account", session: "regular"}
+
+	cmd := newTestCmd()
+	require.NoError(t, runQuote(cmd, opts, []string{"AAPL"}, now))
+	output := cmd.OutOrStdout().(*bytes.Buffer).String()
+	assert.Contains(t, output, "Market session now: post (17:30 EST)")
+	assert.Contains(t, output, "Session")
+	assert.Contains(t, output, "17:15 EST")
+	assert.Contains(t, output, "outside regular session")
+
+	opts.jsonMode = true
+	cmd = newTestCmd()
+	require.NoError(t, runQuote(cmd, opts, []string{"AAPL"}, now))
+	var rows []map[string]string
+	require.NoError(t, json.Unmarshal(cmd.OutOrStdout().(*bytes.Buffer).Bytes(), &rows))
+	require.Len(t, rows, 1)
+	assert.Equal(t, "post", rows[0]["Session"])
+	assert.Equal(t, "outside regular session", rows[0]["Note"])
+}
+
+func TestQuoteCmd_InvalidSession(t *testing.T) {
+	for _, args := range [][]string{
+		{"AAPL", "--session", "overnight"},
+		{"AAPL", "--session", "pre", "--after-hours"},
+	} {
+		cmd := newQuoteCmd(quoteOptions{accountID: "test-account"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs(args)
+
+		err := cmd.Execute()
+		require.Error(t, err, args)
+		assert.Contains(t, err.Error(), "--session")
+	}
+}
+
+func TestQuoteCmd_MixedTypes(t *testing.T) {
+	var requested []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Instruments []map[string]any `json:"instruments"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		requested = req.Instruments
+
+		quotes := make([]map[string]any, 0, len(req.Instruments))
+		for _, inst := range req.Instruments {
+			quotes = append(quotes, map[string]any{"instrument": inst, "outcome": "SUCCESS", "last": "1.00"})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"quotes": quotes})
+	}))
+	defer server.Close()
+
+	opts := quoteOptions{baseURL: server.URL, authToken: "test-token", accountID: "test-account"}
+	cmd := newQuoteCmd(opts)
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"AAPL", "aapl250117c00175000", "ETH-USD"})
+	require.NoError(t, cmd.Execute())
+
+	assert.Equal(t, []map[string]any{
+		{"symbol": "AAPL", "type": "EQUITY"},
+		{"symbol": "AAPL250117C00175000", "type": "OPTION"},
+		{"symbol": "ETH-USD", "type": "EQUITY"},
+	}, requested)
+	output := out.String()
+	assert.Contains(t, output, "Type")
+	assert.Regexp(t, `AAPL\s+EQUITY\s+1\.00`, output)
+	assert.Regexp(t, `AAPL250117C00175000\s+OPTION\s+1\.00`, output)
+	assert.Regexp(t, `ETH-USD\s+EQUITY\s+1\.00`, output)
+
+	// Crypto is only quoted when asked for
+	cmd = newQuoteCmd(opts)
+	out.Reset()
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"BTC-USD", "--type", "crypto"})
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, []map[string]any{{"symbol": "BTC", "type": "CRYPTO"}}, requested)
+	assert.Regexp(t, `BTC\s+CRYPTO`, out.String())
+
+	// JSON rows keep their existing fields
+	opts.jsonMode = true
+	cmd = newQuoteCmd(opts)
+	out.Reset()
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"AAPL"})
+	require.NoError(t, cmd.Execute())
+	assert.NotContains(t, out.String(), "Type")
+}
+
+func TestQuoteCmd_InvalidType(t *testing.T) {
+	tests := []struct {
+		args    []string
+		wantErr string
+	}{
+		{[]string{"AAPL", "--type", "bond"}, `invalid --type "bond"`},
+		{[]string{"AAPL", "--type", "option"}, "invalid OSI symbol"},
+		{[]string{"AAPL", "--type", "equity", "--options-for"}, "--type cannot be combined with --options-for"},
+	}
+
+	for _, tt := range tests {
+		cmd := newQuoteCmd(quoteOptions{accountID: "test-account"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs(tt.args)
+
+		err := cmd.Execute()
+		require.Error(t, err, tt.args)
+		assert.Contains(t, err.Error(), tt.wantErr)
+	}
+}
+
+func TestQuoteCmd_Halted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := map[string]any{
+			"quotes": []map[string]any{
+				{
+					"instrument": map[string]any{"symbol": "AAPL", "type": "EQUITY"},
+					"outcome":    "SUCCESS",
+					"last":       "176.10",
+					"bid":        "176.00",
+					"ask":        "176.20",
+				},
+				{
+					"instrument": map[string]any{"symbol": "XYZ", "type": "EQUITY"},
+					"outcome":    "SUCCESS",
+					"status":     "HALTED",
+					"last":       "12.40",
+				},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	opts := quoteOptions{baseURL: server.URL, authToken: "test-token", accountID: "test-account"}
+
+	cmd := newTestCmd()
+	require.NoError(t, runQuote(cmd, opts, []string{"AAPL", "XYZ"}, time.Now()))
+	output := cmd.OutOrStdout().(*bytes.Buffer).String()
+	assert.Contains(t, output, "Status")
+	assert.Regexp(t, `XYZ\s+EQUITY\s+12\.40.*HALTED`, output)
+	assert.Contains(t, output, "Trading halted: XYZ.")
+
+	opts.jsonMode = true
+	cmd = newTestCmd()
+	require.NoError(t, runQuote(cmd, opts, []string{"AAPL", "XYZ"}, time.Now()))
+	var rows []map[string]string
+	require.NoError(t, json.Unmarshal(cmd.OutOrStdout().(*bytes.Buffer).Bytes(), &rows))
+	require.Len(t, rows, 2)
+	assert.Equal(t, "-", rows[0]["Status"])
+	assert.Equal(t, "HALTED", rows[1]["Status"])
+	assert.Equal(t, "12.40", rows[1]["Last"])
+}
+
+func TestQuoteCmd_HaltedOutcome(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := map[string]any{
+			"quotes": []map[string]any{
+				{
+					"instrument": map[string]any{"symbol": "XYZ", "type": "EQUITY"},
+					"outcome":    "TRADING_HALTED",
+					"last":       "12.40",
+				},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	opts := quoteOptions{baseURL: server.URL, authToken: "test-token", accountID: "test-account"}
+	cmd := newTestCmd()
+	require.NoError(t, runQuote(cmd, opts, []string{"XYZ"}, time.Now()))
+	output := cmd.OutOrStdout().(*bytes.Buffer).String()
+	assert.Regexp(t, `XYZ\s+EQUITY\s+12\.40.*HALTED`, output, "a halt outcome still shows the last price")
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import "github.com/jonandersen/public-cli/internal/api"
+
+// JSON results printed with --json by commands that change state. Their field
+// names are part of the CLI's scripting contract: add fields freely, but do not
+// rename or remove them.
+
+// Result status values.
+const (
+	statusPlaced          = "placed"
+	statusCancelRequested = "cancel_requested"
+)
+
+// OrderPlacedResult is printed by 'pub order buy' and 'pub order sell'.
+type OrderPlacedResult struct {
+	OrderID    string `json:"orderId"`
+	Status     string `json:"status"` // always "placed"; fills are asynchronous
+	Symbol     string `json:"symbol"`
+	Side       string `json:"side"` // BUY or SELL
+	Quantity   string `json:"quantity"`
+	OrderType  string `json:"orderType"` // MARKET, LIMIT, STOP, or STOP_LIMIT
+	LimitPrice string `json:"limitPrice,omitempty"`
+	StopPrice  string `json:"stopPrice,omitempty"`
+	// ReduceOnly is set when the order was checked against the position.
+	ReduceOnly bool `json:"reduceOnly,omitempty"`
+	// InstrumentType is set when the order was not sent as EQUITY.
+	InstrumentType string `json:"instrumentType,omitempty"`
+
+	// Set when the order was sized with --risk.
+	RiskPerShare   string `json:"riskPerShare,omitempty"`
+	TotalRisk      string `json:"totalRisk,omitempty"`
+	ProtectiveStop string `json:"protectiveStop,omitempty"`
+	// Reason is the --reason the order was placed with.
+	Reason string `json:"reason,omitempty"`
+}
+
+// OrderPreviewResult is printed by 'pub order buy/sell
+// --preview-json-then-confirm'. Order is the exact request --confirm-token
+// will submit.
+type OrderPreviewResult struct {
+	Order          api.OrderRequest       `json:"order"`
+	Preflight      *api.PreflightResponse `json:"preflight,omitempty"`
+	PreflightError string                 `json:"preflightError,omitempty"`
+	ConfirmToken   string                 `json:"confirmToken"`
+	ExpiresAt      string                 `json:"expiresAt"` // RFC 3339
+	Reason         string                 `json:"reason,omitempty"`
+}
+
+// CancelResult is printed by 'pub order cancel ORDER_ID'.
+type CancelResult struct {
+	OrderID string `json:"orderId"`
+	Status  string `json:"status"` // always "cancel_requested"; cancellation is asynchronous
+}
+
+// OptionOrderPlacedResult is printed by 'pub options buy' and 'pub options sell'.
+type OptionOrderPlacedResult struct {
+	OrderID    string `json:"orderId"`
+	Status     string `json:"status"` // always "placed"
+	Symbol     string `json:"symbol"` // OSI option symbol
+	Side       string `json:"side"`   // BUY or SELL
+	Quantity   string `json:"quantity"`
+	LimitPrice string `json:"limitPrice"`
+	OpenClose  string `json:"openClose"` // OPEN or CLOSE
+	Reason     string `json:"reason,omitempty"`
+}
+
+// MultilegPlacedResult is printed by 'pub options multileg order'.
+type MultilegPlacedResult struct {
+	OrderID    string `json:"orderId"`
+	Status     string `json:"status"`     // always "placed"
+	Strategy   string `json:"strategy"`   // strategy name reported by preflight, may be empty
+	Underlying string `json:"underlying"` // base symbol reported by preflight
+	Quantity   string `json:"quantity"`
+	LimitPrice string `json:"limitPrice"`
+	Net        string `json:"net,omitempty"` // DEBIT or CREDIT, when stated or inferred from the legs
+	Legs       int    `json:"legs"`
+	Reason     string `json:"reason,omitempty"`
+}
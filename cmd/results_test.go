@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The JSON field names below are a contract with scripts; a failure here means
+// a --json output changed shape.
+func TestResultJSONFields(t *testing.T) {
+	tests := []struct {
+		name   string
+		result any
+		want   string
+	}{
+		{
+			name: "order placed, minimal",
+			result: OrderPlacedResult{
+				OrderID: "id-1", Status: statusPlaced, Symbol: "AAPL", Side: "BUY", Quantity: "10", OrderType: "MARKET",
+			},
+			want: `{"orderId":"id-1","status":"placed","symbol":"AAPL","side":"BUY","quantity":"10","orderType":"MARKET"}`,
+		},
+		{
+			name: "order placed, every field",
+			result: OrderPlacedResult{
+				OrderID: "id-1", Status: statusPlaced, Symbol: "AAPL", Side: "BUY", Quantity: "18", OrderType: "STOP_LIMIT",
+				LimitPrice: "175.00", StopPrice: "174.00", ReduceOnly: true,
+				RiskPerShare: "5.30", TotalRisk: "95.40", ProtectiveStop: "170",
+			},
+			want: `{"orderId":"id-1","status":"placed","symbol":"AAPL","side":"BUY","quantity":"18","orderType":"STOP_LIMIT",` +
+				`"limitPrice":"175.00","stopPrice":"174.00","reduceOnly":true,` +
+				`"riskPerShare":"5.30","totalRisk":"95.40","protectiveStop":"170"}`,
+		},
+		{
+			name:   "cancel",
+			result: CancelResult{OrderID: "id-1", Status: statusCancelRequested},
+			want:   `{"orderId":"id-1","status":"cancel_requested"}`,
+		},
+		{
+			name: "option order placed",
+			result: OptionOrderPlacedResult{
+				OrderID: "id-1", Status: statusPlaced, Symbol: "AAPL250117C00175000", Side: "BUY", Quantity: "1", LimitPrice: "2.50", OpenClose: "OPEN",
+			},
+			want: `{"orderId":"id-1","status":"placed","symbol":"AAPL250117C00175000","side":"BUY","quantity":"1","limitPrice":"2.50","openClose":"OPEN"}`,
+		},
+		{
+			name: "multileg placed",
+			result: MultilegPlacedResult{
+				OrderID: "id-1", Status: statusPlaced, Strategy: "VERTICAL CALL SPREAD", Underlying: "AAPL", Quantity: "1", LimitPrice: "2.50", Legs: 2,
+			},
+			want: `{"orderId":"id-1","status":"placed","strategy":"VERTICAL CALL SPREAD","underlying":"AAPL","quantity":"1","limitPrice":"2.50","legs":2}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.result)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
@@ -1,9 +1,16 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
 	"os"
 
 	"github.com/spf13/cobra"
+
+	"github.com/jonandersen/public-cli/internal/api"
+	"github.com/jonandersen/public-cli/internal/auth"
 )
 
 var Version = "dev"
@@ -11,6 +18,25 @@ var Version = "dev"
 // jsonOutput controls whether output is formatted as JSON
 var jsonOutput bool
 
+// wideOutput shows every available column in tables instead of the default set
+var wideOutput bool
+
+// verboseOutput controls whether diagnostic detail such as full response bodies is shown
+var verboseOutput bool
+
+// timingsOutput reports how long each API request took once the command finishes
+var timingsOutput bool
+
+// noColorOutput turns off ANSI colors even on a terminal
+var noColorOutput bool
+
+// outputTo and outputHeader direct the primary output to a file or webhook
+var outputTo = outputDestination{value: outputStdout}
+var outputHeader outputHeaders
+
+// redirectedOutput collects the primary output when --output-to is not stdout
+var redirectedOutput *bufferedOutput
+
 var rootCmd = &cobra.Command{
 	Use:     "pub",
 	Short:   "Public.com Trading CLI",
@@ -20,6 +46,33 @@ var rootCmd = &cobra.Command{
 
 func init() {
 	rootCmd.PersistentFlags().BoolVarP(&jsonOutput, "json", "j", false, "Output in JSON format")
+	rootCmd.PersistentFlags().BoolVar(&wideOutput, "wide", false, "Show all available table columns (timestamps, fees, sizes, prices)")
+	rootCmd.PersistentFlags().BoolVar(&verboseOutput, "verbose", false, "Show full response bodies in errors and diagnostic warnings")
+	rootCmd.PersistentFlags().BoolVar(&timingsOutput, "timings", false, "Report API request count and durations when the command finishes (a timings object in --json output)")
+	rootCmd.PersistentFlags().BoolVar(&noColorOutput, "no-color", false, "Disable colored output (as does setting NO_COLOR)")
+	rootCmd.PersistentFlags().Var(&outputTo, "output-to", "Send the command's output to stdout, a file, or an http(s) URL (POSTed when the command succeeds)")
+	rootCmd.PersistentFlags().Var(&outputHeader, "output-header", `Header for --output-to URL posts, as "Name: value" (repeatable)`)
+
+	cobra.OnInitialize(func() {
+		api.VerboseErrors = verboseOutput
+		if verboseOutput {
+			auth.CacheWarnings = os.Stderr
+		}
+		if timingsOutput {
+			api.RequestTimings = &api.Timings{}
+		}
+		// Children without their own writer inherit the root's, so this
+		// redirects every command's primary output; errors stay on stderr.
+		// JSON output is also held for --timings, to merge the report in.
+		target := newOutputTarget(&outputTo, &outputHeader, jsonOutput)
+		if target == nil && timingsOutput && jsonOutput {
+			target = stdoutTarget{w: os.Stdout}
+		}
+		if target != nil {
+			redirectedOutput = &bufferedOutput{target: target}
+			rootCmd.SetOut(redirectedOutput)
+		}
+	})
 }
 
 // GetJSONMode returns whether JSON output mode is enabled.
@@ -27,8 +80,109 @@ func GetJSONMode() bool {
 	return jsonOutput
 }
 
+// GetWideMode returns whether tables should include every available column.
+func GetWideMode() bool {
+	return wideOutput
+}
+
+// ANSI sequences for highlighted terminal output.
+const (
+	ansiHighlight = "\033[1;33m"
+	ansiReset     = "\033[0m"
+)
+
+// GetColorMode reports whether output to w may use ANSI colors: w must be a
+// terminal, and neither --no-color nor the NO_COLOR environment variable set.
+func GetColorMode(w io.Writer) bool {
+	if noColorOutput || os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	return writerWidth(w) > 0
+}
+
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	err := rootCmd.Execute()
+	timingsMerged := false
+	if err == nil && redirectedOutput != nil && api.RequestTimings != nil {
+		timingsMerged = mergeTimings(&redirectedOutput.Buffer, api.RequestTimings.Summary())
+	}
+	// Output of a failed command may be partial, so it is not sent on to a
+	// file or URL; stdout gets it as it would have unbuffered
+	if redirectedOutput != nil {
+		if _, toStdout := redirectedOutput.target.(stdoutTarget); err == nil || toStdout {
+			if deliverErr := redirectedOutput.deliver(); deliverErr != nil && err == nil {
+				err = deliverErr
+				_, _ = fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+			}
+		}
+	}
+	// Reported even when the command fails; a slow endpoint is often why
+	if api.RequestTimings != nil && !timingsMerged {
+		printTimings(os.Stderr, api.RequestTimings.Summary(), jsonOutput)
+	}
+	if err != nil {
 		os.Exit(1)
 	}
 }
+
+// streamOutput lets a command that runs until interrupted, such as --watch,
+// write as it goes instead of into the stdout buffer that --timings --json
+// holds for merging; the timings then go to stderr. --output-to is left
+// alone, since it only sends output once the command finishes.
+func streamOutput(cmd *cobra.Command) {
+	if redirectedOutput == nil {
+		return
+	}
+	target, ok := redirectedOutput.target.(stdoutTarget)
+	if !ok {
+		return
+	}
+	_, _ = target.w.Write(redirectedOutput.Bytes())
+	cmd.SetOut(target.w)
+	redirectedOutput = nil
+}
+
+// mergeTimings adds the --timings report to a JSON object result as its
+// "timings" field. Results that are not a single object, such as arrays, are
+// left alone and it returns false.
+func mergeTimings(buf *bytes.Buffer, summary api.TimingsSummary) bool {
+	result := bytes.TrimSpace(buf.Bytes())
+	if len(result) == 0 || result[0] != '{' || !json.Valid(result) {
+		return false
+	}
+	timings, err := json.MarshalIndent(summary, "  ", "  ")
+	if err != nil {
+		return false
+	}
+
+	body := bytes.TrimRight(result[:len(result)-1], " \t\r\n")
+	var merged bytes.Buffer
+	merged.Write(body)
+	if len(body) > 1 {
+		merged.WriteByte(',')
+	}
+	merged.WriteString("\n  \"timings\": ")
+	merged.Write(timings)
+	merged.WriteString("\n}\n")
+
+	buf.Reset()
+	_, _ = buf.Write(merged.Bytes())
+	return true
+}
+
+// printTimings writes the --timings report to stderr when it could not be
+// merged into the result: in JSON mode a {"timings": ...} object, otherwise a
+// footer line per endpoint.
+func printTimings(w io.Writer, summary api.TimingsSummary, jsonMode bool) {
+	if jsonMode {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(map[string]any{"timings": summary})
+		return
+	}
+
+	_, _ = fmt.Fprintf(w, "Timings: %d API call(s), %.0fms total\n", summary.Calls, summary.TotalMs)
+	for _, e := range summary.Endpoints {
+		_, _ = fmt.Fprintf(w, "  %-50s %3dx  %7.0fms total  %7.0fms max\n", e.Endpoint, e.Count, e.TotalMs, e.MaxMs)
+	}
+}
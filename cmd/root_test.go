@@ -2,9 +2,14 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jonandersen/public-cli/internal/api"
 )
 
 func TestRootCmd_JSONFlagExists(t *testing.T) {
@@ -51,3 +56,89 @@ func TestRootCmd_Version(t *testing.T) {
 	output := out.String()
 	assert.Contains(t, output, "pub version")
 }
+
+func TestRootCmd_TimingsFlagExists(t *testing.T) {
+	flag := rootCmd.PersistentFlags().Lookup("timings")
+
+	assert.NotNil(t, flag, "--timings flag should exist")
+	assert.Equal(t, "false", flag.DefValue)
+}
+
+func TestPrintTimings(t *testing.T) {
+	summary := api.TimingsSummary{
+		Calls:   3,
+		TotalMs: 120,
+		Endpoints: []api.EndpointTiming{
+			{Endpoint: "POST /userapigateway/marketdata/{id}/quotes", Count: 2, TotalMs: 100, MaxMs: 70},
+			{Endpoint: "GET /userapigateway/trading/account", Count: 1, TotalMs: 20, MaxMs: 20},
+		},
+	}
+
+	var out bytes.Buffer
+	printTimings(&out, summary, false)
+	assert.Contains(t, out.String(), "Timings: 3 API call(s), 120ms total")
+	assert.Contains(t, out.String(), "POST /userapigateway/marketdata/{id}/quotes")
+	assert.Contains(t, out.String(), "2x")
+
+	out.Reset()
+	printTimings(&out, summary, true)
+	var result struct {
+		Timings api.TimingsSummary `json:"timings"`
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &result))
+	assert.Equal(t, summary, result.Timings)
+}
+
+func TestStreamOutput(t *testing.T) {
+	defer func() { redirectedOutput = nil }()
+
+	// --timings --json holds stdout; a --watch command writes through
+	var stdout bytes.Buffer
+	redirectedOutput = &bufferedOutput{target: stdoutTarget{w: &stdout}}
+	cmd := newTestCmd()
+	cmd.SetOut(redirectedOutput)
+	streamOutput(cmd)
+	_, _ = cmd.OutOrStdout().Write([]byte("{}\n"))
+	assert.Equal(t, "{}\n", stdout.String())
+	assert.Nil(t, redirectedOutput, "the timings then go to stderr")
+
+	// --output-to still collects everything
+	file := &bufferedOutput{target: fileTarget{path: "out.json"}}
+	redirectedOutput = file
+	cmd = newTestCmd()
+	cmd.SetOut(file)
+	streamOutput(cmd)
+	assert.Same(t, file, redirectedOutput)
+	assert.Same(t, file, cmd.OutOrStdout())
+}
+
+func TestMergeTimings(t *testing.T) {
+	summary := api.TimingsSummary{
+		Calls:     1,
+		TotalMs:   20,
+		Endpoints: []api.EndpointTiming{{Endpoint: "GET /userapigateway/trading/account", Count: 1, TotalMs: 20, MaxMs: 20}},
+	}
+
+	buf := bytes.NewBufferString("{\n  \"orderId\": \"abc\",\n  \"status\": \"placed\"\n}\n")
+	require.True(t, mergeTimings(buf, summary))
+	var result struct {
+		OrderID string             `json:"orderId"`
+		Status  string             `json:"status"`
+		Timings api.TimingsSummary `json:"timings"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+	assert.Equal(t, "abc", result.OrderID)
+	assert.Equal(t, summary, result.Timings)
+	assert.Less(t, strings.Index(buf.String(), "orderId"), strings.Index(buf.String(), "timings"), "the result's fields keep their order")
+
+	buf = bytes.NewBufferString("{}\n")
+	require.True(t, mergeTimings(buf, summary))
+	assert.True(t, json.Valid(buf.Bytes()))
+
+	// Only a single object can take the field
+	for _, notObject := range []string{"[{\"a\": 1}]\n", "{\"a\": 1}\n{\"a\": 2}\n", "Symbol  Last\n", ""} {
+		buf = bytes.NewBufferString(notObject)
+		assert.False(t, mergeTimings(buf, summary), notObject)
+		assert.Equal(t, notObject, buf.String())
+	}
+}
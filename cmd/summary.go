@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/jonandersen/public-cli/internal/money"
+	"github.com/jonandersen/public-cli/internal/output"
+)
+
+// Outcome values for opResult.Status.
+const (
+	opSucceeded = "succeeded"
+	opFailed    = "failed"
+)
+
+// opResult is the outcome of one item in a bulk operation such as cancelling
+// several orders.
+type opResult struct {
+	ID     string `json:"id"`
+	Symbol string `json:"symbol,omitempty"`
+	Status string `json:"status"`
+	Value  string `json:"value,omitempty"` // estimated value, empty when unknown
+	Error  string `json:"error,omitempty"`
+}
+
+// opSummary collects the results of a bulk operation so it ends with one
+// parseable outcome instead of a stream of individual lines. Bulk order
+// cancellation is the only fan-out command so far; later ones should report
+// through it too.
+type opSummary struct {
+	Operation  string     `json:"operation"`
+	Succeeded  int        `json:"succeeded"`
+	Failed     int        `json:"failed"`
+	TotalValue string     `json:"totalValue"` // sum of Value over succeeded items
+	Results    []opResult `json:"results"`
+
+	total money.Money
+}
+
+// newOpSummary starts an empty summary for the named operation.
+func newOpSummary(operation string) *opSummary {
+	return &opSummary{Operation: operation, Results: []opResult{}}
+}
+
+// succeed records a successful item. value may be empty when it is unknown.
+func (s *opSummary) succeed(id, symbol, value string) {
+	s.Succeeded++
+	if m, err := money.Parse(value); err == nil {
+		s.total = s.total.Add(m)
+	}
+	s.Results = append(s.Results, opResult{ID: id, Symbol: symbol, Status: opSucceeded, Value: value})
+}
+
+// fail records a failed item and the error that caused it.
+func (s *opSummary) fail(id, symbol, value string, err error) {
+	s.Failed++
+	s.Results = append(s.Results, opResult{ID: id, Symbol: symbol, Status: opFailed, Value: value, Error: err.Error()})
+}
+
+// err returns a non-nil error when any item failed, so the command exits
+// non-zero after the summary has been printed.
+func (s *opSummary) err() error {
+	if s.Failed == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s: %d of %d failed", s.Operation, s.Failed, len(s.Results))
+}
+
+// write prints the summary as a JSON object or as a count line followed by a
+// table of each item's result.
+func (s *opSummary) write(w io.Writer, jsonMode bool) error {
+	s.TotalValue = s.total.String()
+
+	if jsonMode {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(s)
+	}
+
+	_, _ = fmt.Fprintf(w, "\n%s: %d succeeded, %d failed, est. value $%s\n\n", s.Operation, s.Succeeded, s.Failed, s.TotalValue)
+
+	headers := []string{"ID", "Symbol", "Result", "Value", "Error"}
+	rows := make([][]string, 0, len(s.Results))
+	for _, r := range s.Results {
+		value := "-"
+		if r.Value != "" {
+			value = "$" + r.Value
+		}
+		rows = append(rows, []string{r.ID, r.Symbol, r.Status, value, r.Error})
+	}
+	return output.New(w, false).Table(headers, rows)
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpSummary_Counts(t *testing.T) {
+	s := newOpSummary("Cancel orders")
+	s.succeed("a", "AAPL", "0.10")
+	s.succeed("b", "MSFT", "")
+	s.fail("c", "TSLA", "99.00", errors.New("rejected"))
+	s.succeed("d", "", "0.20")
+
+	assert.Equal(t, 3, s.Succeeded)
+	assert.Equal(t, 1, s.Failed)
+	require.Error(t, s.err())
+	assert.Equal(t, "Cancel orders: 1 of 4 failed", s.err().Error())
+
+	var out bytes.Buffer
+	require.NoError(t, s.write(&out, false))
+
+	// Failed items don't count towards the value
+	output := out.String()
+	assert.Contains(t, output, "Cancel orders: 3 succeeded, 1 failed, est. value $0.30")
+	assert.Contains(t, output, "rejected")
+	assert.Contains(t, output, "$99.00")
+}
+
+func TestOpSummary_JSON(t *testing.T) {
+	s := newOpSummary("Cancel orders")
+	s.succeed("a", "AAPL", "1750.00")
+
+	var out bytes.Buffer
+	require.NoError(t, s.write(&out, true))
+	assert.NoError(t, s.err())
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(out.Bytes(), &got))
+	assert.Equal(t, "Cancel orders", got["operation"])
+	assert.Equal(t, float64(1), got["succeeded"])
+	assert.Equal(t, float64(0), got["failed"])
+	assert.Equal(t, "1750.00", got["totalValue"])
+
+	results := got["results"].([]any)
+	require.Len(t, results, 1)
+	assert.Equal(t, map[string]any{"id": "a", "symbol": "AAPL", "status": "succeeded", "value": "1750.00"}, results[0])
+}
+
+func TestOpSummary_EmptyJSONHasResultsArray(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, newOpSummary("Cancel orders").write(&out, true))
+	assert.Contains(t, out.String(), `"results": []`)
+}
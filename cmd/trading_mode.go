@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// defaultPlaceQuestion is asked before an order is placed in confirm mode
+// unless confirm_prompt sets other wording.
+const defaultPlaceQuestion = "Place this order"
+
+// placeOrderQuestion returns the confirm_prompt wording, or def when it is
+// not set. A trailing question mark is dropped, since the prompt adds one.
+func placeOrderQuestion(custom, def string) string {
+	custom = strings.TrimRight(strings.TrimSpace(custom), "?")
+	if custom == "" {
+		return def
+	}
+	return custom
+}
+
+// confirmTrade decides whether an order or cancel may go ahead once it has
+// been previewed. prompt is set for trading_enabled: confirm: the user is then
+// asked on stderr and must answer y, with or without --yes. Otherwise --yes
+// (skipConfirm) is required. noun names the action in errors and question is
+// the prompt, without the question mark.
+//
+// The prompt is refused under --json (jsonMode), which skips the preview, and
+// when the output is buffered, by --output-to or by --timings with --json: the
+// preview would not be written until the command finishes. Either way the
+// user would be asked to confirm an order they cannot see.
+func confirmTrade(cmd *cobra.Command, prompt, skipConfirm, jsonMode bool, noun, question string) error {
+	if prompt {
+		if jsonMode {
+			return fmt.Errorf("--json cannot be used while trading_enabled is confirm: the %s preview is not shown in JSON mode", noun)
+		}
+		if buffered, ok := cmd.OutOrStdout().(*bufferedOutput); ok {
+			return fmt.Errorf("%s cannot be used while trading_enabled is confirm: the %s preview would not be shown before the prompt", buffered.flag(), noun)
+		}
+		if !askYesNo(bufio.NewReader(cmd.InOrStdin()), cmd.ErrOrStderr(), question) {
+			return fmt.Errorf("%s not confirmed (trading_enabled is confirm, so every %s is confirmed at the prompt)", noun, noun)
+		}
+		return nil
+	}
+	if !skipConfirm {
+		return fmt.Errorf("%s requires confirmation (use --yes to confirm)", noun)
+	}
+	return nil
+}
@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfirmTrade(t *testing.T) {
+	run := func(prompt, skipConfirm bool, answer string) (string, error) {
+		cmd := newTestCmd()
+		var errOut bytes.Buffer
+		cmd.SetErr(&errOut)
+		cmd.SetIn(strings.NewReader(answer))
+		err := confirmTrade(cmd, prompt, skipConfirm, false, "order", "Place this order")
+		return errOut.String(), err
+	}
+
+	// Without the confirm mode --yes decides, and nothing is asked
+	asked, err := run(false, true, "")
+	assert.NoError(t, err)
+	assert.Empty(t, asked)
+	_, err = run(false, false, "y\n")
+	assert.EqualError(t, err, "order requires confirmation (use --yes to confirm)")
+
+	// In confirm mode only the answer counts
+	asked, err = run(true, true, "y\n")
+	assert.NoError(t, err)
+	assert.Equal(t, "Place this order? [y/N] ", asked)
+	_, err = run(true, false, "yes\n")
+	assert.NoError(t, err)
+	_, err = run(true, true, "n\n")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "order not confirmed")
+	_, err = run(true, true, "")
+	assert.Error(t, err, "end of input declines")
+
+	// --json skips the preview, so nothing is asked
+	cmd := newTestCmd()
+	var errOut bytes.Buffer
+	cmd.SetErr(&errOut)
+	cmd.SetIn(strings.NewReader("y\n"))
+	err = confirmTrade(cmd, true, true, true, "order", "Place this order")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--json cannot be used while trading_enabled is confirm")
+	assert.Empty(t, errOut.String())
+	assert.NoError(t, confirmTrade(cmd, false, true, true, "order", "Place this order"), "--yes needs no preview")
+
+	// A preview buffered for --output-to is never shown, so nothing is asked
+	cmd = newTestCmd()
+	errOut.Reset()
+	cmd.SetOut(&bufferedOutput{})
+	cmd.SetErr(&errOut)
+	cmd.SetIn(strings.NewReader("y\n"))
+	err = confirmTrade(cmd, true, true, false, "cancel", "Cancel this order")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--output-to cannot be used while trading_enabled is confirm")
+	assert.Empty(t, errOut.String())
+	cmd.SetOut(&bufferedOutput{target: stdoutTarget{w: &bytes.Buffer{}}})
+	err = confirmTrade(cmd, true, true, false, "cancel", "Cancel this order")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--timings with --json cannot be used while trading_enabled is confirm")
+	cmd.SetOut(&bufferedOutput{})
+	assert.NoError(t, confirmTrade(cmd, false, true, false, "cancel", "Cancel this order"), "--yes needs no preview")
+}
+
+func TestPlaceOrderQuestion(t *testing.T) {
+	assert.Equal(t, "Place this order", placeOrderQuestion("", defaultPlaceQuestion))
+	assert.Equal(t, "Place this order", placeOrderQuestion("  ", defaultPlaceQuestion))
+	assert.Equal(t, "Checked the journal", placeOrderQuestion("Checked the journal?", defaultPlaceQuestion))
+}
+
+func TestOrderCmd_ConfirmModeIgnoresYes(t *testing.T) {
+	placed := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if r.URL.Path == "/userapigateway/trading/test-account/order" {
+			placed++
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"orderId": req["orderId"]})
+	}))
+	defer server.Close()
+
+	opts := orderOptions{
+		baseURL:        server.URL,
+		authToken:      "test-token",
+		accountID:      "test-account",
+		tradingEnabled: true,
+		promptConfirm:  true,
+	}
+	args := []string{"AAPL", "--quantity", "10", "--no-preflight", "--yes"}
+
+	cmd := newOrderBuyCmd(opts)
+	var errOut bytes.Buffer
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&errOut)
+	cmd.SetIn(strings.NewReader("n\n"))
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "order not confirmed")
+	assert.Contains(t, errOut.String(), "Place this order? [y/N]")
+	assert.Zero(t, placed, "a declined order is not sent")
+
+	cmd = newOrderBuyCmd(opts)
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetIn(strings.NewReader("y\n"))
+	cmd.SetArgs(args)
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), "Order placed successfully!")
+	assert.Equal(t, 1, placed)
+
+	// confirm_prompt changes the wording, not the answer
+	opts.confirmPrompt = "Journal updated? Place it?"
+	cmd = newOrderBuyCmd(opts)
+	errOut.Reset()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&errOut)
+	cmd.SetIn(strings.NewReader("y\n"))
+	cmd.SetArgs(args)
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, errOut.String(), "Journal updated? Place it? [y/N]")
+	assert.Equal(t, 2, placed)
+}
+
+func TestOrderCmd_ConfirmModeRefusesJSON(t *testing.T) {
+	placed := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/userapigateway/trading/test-account/order" {
+			placed++
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"orderId": "unused"})
+	}))
+	defer server.Close()
+
+	cmd := newOrderBuyCmd(orderOptions{
+		baseURL:        server.URL,
+		authToken:      "test-token",
+		accountID:      "test-account",
+		tradingEnabled: true,
+		promptConfirm:  true,
+		jsonMode:       true,
+	})
+	var out, errOut bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&errOut)
+	cmd.SetIn(strings.NewReader("y\n"))
+	cmd.SetArgs([]string{"AAPL", "--quantity", "10", "--no-preflight", "--yes"})
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--json cannot be used while trading_enabled is confirm")
+	assert.NotContains(t, errOut.String(), "[y/N]", "an order that was not previewed is not asked about")
+	assert.Empty(t, out.String())
+	assert.Zero(t, placed)
+}
+
+func TestOrderSubmitCmd_ConfirmModeRejectsStdin(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	cmd := newOrderSubmitCmd(orderOptions{
+		baseURL:        server.URL,
+		authToken:      "test-token",
+		accountID:      "test-account",
+		tradingEnabled: true,
+		promptConfirm:  true,
+	})
+	var errOut bytes.Buffer
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&errOut)
+	cmd.SetIn(strings.NewReader(`{"instrument": {"symbol": "AAPL", "type": "EQUITY"}, "orderSide": "BUY", "orderType": "MARKET", "expiration": {"timeInForce": "DAY"}, "quantity": "10"}`))
+	cmd.SetArgs([]string{"--input", "-"})
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--input - cannot be used while trading_enabled is confirm")
+	assert.NotContains(t, errOut.String(), "[y/N]")
+	assert.Zero(t, requests)
+}
+
+func TestOrderCancelCmd_ConfirmMode(t *testing.T) {
+	cancelled := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		cancelled++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	opts := orderOptions{
+		baseURL:        server.URL,
+		authToken:      "test-token",
+		accountID:      "test-account",
+		tradingEnabled: true,
+		promptConfirm:  true,
+	}
+
+	cmd := newOrderCancelCmd(opts)
+	var errOut bytes.Buffer
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&errOut)
+	cmd.SetIn(strings.NewReader("\n"))
+	cmd.SetArgs([]string{"912710f1-1a45-4ef0-88a7-cd513781933d", "--yes"})
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cancel not confirmed")
+	assert.Contains(t, errOut.String(), "Cancel this order? [y/N]")
+	assert.Zero(t, cancelled)
+
+	cmd = newOrderCancelCmd(opts)
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetIn(strings.NewReader("y\n"))
+	cmd.SetArgs([]string{"912710f1-1a45-4ef0-88a7-cd513781933d"})
+	require.NoError(t, cmd.Execute(), "the prompt stands in for --yes")
+	assert.Equal(t, 1, cancelled)
+}
@@ -2,16 +2,33 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
 
+	"github.com/jonandersen/public-cli/internal/api"
 	"github.com/jonandersen/public-cli/internal/config"
 	"github.com/jonandersen/public-cli/internal/keyring"
 	"github.com/jonandersen/public-cli/internal/tui"
 )
 
+// validateUIAccount checks that accountID is one of the given accounts.
+func validateUIAccount(accounts []api.Account, accountID string) error {
+	ids := make([]string, 0, len(accounts))
+	for _, acc := range accounts {
+		if acc.AccountID == accountID {
+			return nil
+		}
+		ids = append(ids, acc.AccountID)
+	}
+	return fmt.Errorf("unknown account: %s (available: %s)", accountID, strings.Join(ids, ", "))
+}
+
 func init() {
+	var accountID string
+	var viewName string
+
 	uiCmd := &cobra.Command{
 		Use:   "ui",
 		Short: "Interactive terminal UI",
@@ -23,13 +40,27 @@ live data updates, and views for:
   - Watchlist: Track symbols with live quotes
   - Orders: View and manage open orders
   - Trade: Buy and sell securities
+  - Options: Browse option chains with greeks
+  - History: Review past transactions
+
+Use --view to open on a tab other than Portfolio: ` + strings.Join(tui.ViewNames(), ", ") + `.
 
 Keyboard shortcuts:
-  1-4     Switch between views
+  1-6     Switch between views
   ↑/↓     Navigate positions
   r       Refresh data
-  q/esc   Quit the application`,
+  q/esc   Quit the application
+
+Examples:
+  pub ui                   # Open on the default account
+  pub ui --account ACCT    # Open on a specific account
+  pub ui --view options    # Open on the options chain`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			view, err := tui.ParseView(viewName)
+			if err != nil {
+				return fmt.Errorf("invalid --view: %w", err)
+			}
+
 			// Load CLI config
 			cfg, err := config.Load(config.ConfigPath())
 			if err != nil {
@@ -45,12 +76,27 @@ Keyboard shortcuts:
 			// Create keyring store
 			store := keyring.NewEnvStore(keyring.NewSystemStore())
 
-			p := tea.NewProgram(tui.New(cfg, uiCfg, store), tea.WithAltScreen())
+			// Start on the requested account after checking it exists
+			if accountID != "" {
+				switch msg := tui.FetchAccounts(cfg, store)().(type) {
+				case tui.AccountsErrorMsg:
+					return msg.Err
+				case tui.AccountsLoadedMsg:
+					if err := validateUIAccount(msg.Accounts, accountID); err != nil {
+						return err
+					}
+				}
+				cfg.AccountUUID = accountID
+			}
+
+			p := tea.NewProgram(tui.New(cfg, uiCfg, store).WithView(view), tea.WithAltScreen())
 			_, err = p.Run()
 			return err
 		},
 	}
 
+	uiCmd.Flags().StringVarP(&accountID, "account", "a", "", "Account ID to open (uses default if not specified)")
+	uiCmd.Flags().StringVar(&viewName, "view", "portfolio", "Tab to open on: "+strings.Join(tui.ViewNames(), ", "))
 	uiCmd.SilenceUsage = true
 	rootCmd.AddCommand(uiCmd)
 }
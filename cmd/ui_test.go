@@ -5,6 +5,9 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jonandersen/public-cli/internal/api"
 )
 
 func TestUICommandExists(t *testing.T) {
@@ -33,3 +36,35 @@ func TestUICommandDescription(t *testing.T) {
 	assert.Equal(t, "ui", uiCmd.Use)
 	assert.Contains(t, uiCmd.Short, "Interactive")
 }
+
+func TestValidateUIAccount(t *testing.T) {
+	accounts := []api.Account{
+		{AccountID: "acc-1"},
+		{AccountID: "acc-2"},
+	}
+
+	require.NoError(t, validateUIAccount(accounts, "acc-2"))
+
+	err := validateUIAccount(accounts, "acc-3")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown account: acc-3")
+	assert.Contains(t, err.Error(), "acc-1, acc-2")
+}
+
+func TestUICommand_InvalidView(t *testing.T) {
+	var uiCmd *cobra.Command
+	for _, c := range rootCmd.Commands() {
+		if c.Name() == "ui" {
+			uiCmd = c
+			break
+		}
+	}
+	require.NotNil(t, uiCmd)
+	require.NoError(t, uiCmd.Flags().Set("view", "charts"))
+	defer func() { _ = uiCmd.Flags().Set("view", "portfolio") }()
+
+	err := uiCmd.RunE(uiCmd, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid --view")
+	assert.Contains(t, err.Error(), "portfolio, watchlist, orders, trade, options, history")
+}
@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jonandersen/public-cli/internal/tui"
+)
+
+// watchlistOptions holds dependencies for the watchlist command.
+type watchlistOptions struct {
+	uiConfigPath string
+	jsonMode     bool
+}
+
+// newWatchlistCmd creates the watchlist command with the given options.
+func newWatchlistCmd(opts watchlistOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "watchlist",
+		Short: "Arrange the TUI watchlist",
+		Long: `Arrange the watchlist shown in 'pub ui'. The order is saved in ui.yaml, so
+it is the same in every session. In the TUI, K and J (or shift+up and
+shift+down) move the selected symbol.
+
+Examples:
+  pub watchlist sort                 # Alphabetical
+  pub watchlist sort --by added      # Back to the order symbols were added
+  pub watchlist move TSLA --up       # One place up
+  pub watchlist move TSLA --down --steps 3  # Three places down`,
+	}
+
+	var by string
+	sortCmd := &cobra.Command{
+		Use:   "sort",
+		Short: "Sort the watchlist and save the order",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.jsonMode = opts.jsonMode || GetJSONMode()
+			return runWatchlistSort(cmd, opts, by)
+		},
+	}
+	sortCmd.Flags().StringVar(&by, "by", tui.WatchlistSortAlpha, "Sort order: alpha or added")
+
+	var up, down bool
+	var steps int
+	moveCmd := &cobra.Command{
+		Use:   "move SYMBOL",
+		Short: "Move a symbol up or down the watchlist",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.jsonMode = opts.jsonMode || GetJSONMode()
+			if up == down {
+				return fmt.Errorf("use exactly one of --up or --down")
+			}
+			if steps < 1 {
+				return fmt.Errorf("invalid --steps %d: must be at least 1", steps)
+			}
+			delta := steps
+			if up {
+				delta = -steps
+			}
+			return runWatchlistMove(cmd, opts, args[0], delta)
+		},
+	}
+	moveCmd.Flags().BoolVar(&up, "up", false, "Move the symbol up")
+	moveCmd.Flags().BoolVar(&down, "down", false, "Move the symbol down")
+	moveCmd.Flags().IntVar(&steps, "steps", 1, "Places to move the symbol")
+
+	cmd.AddCommand(sortCmd, moveCmd)
+	cmd.SilenceUsage = true
+	sortCmd.SilenceUsage = true
+	moveCmd.SilenceUsage = true
+
+	return cmd
+}
+
+func runWatchlistSort(cmd *cobra.Command, opts watchlistOptions, by string) error {
+	uiCfg, err := tui.LoadConfigFrom(opts.uiConfigPath)
+	if err != nil {
+		return fmt.Errorf("failed to load UI config: %w", err)
+	}
+	if err := uiCfg.SortWatchlist(strings.ToLower(by)); err != nil {
+		return fmt.Errorf("invalid --by %q: use %s or %s", by, tui.WatchlistSortAlpha, tui.WatchlistSortAdded)
+	}
+	if err := tui.SaveConfigTo(opts.uiConfigPath, uiCfg); err != nil {
+		return fmt.Errorf("failed to save watchlist: %w", err)
+	}
+	return printWatchlist(cmd, opts, uiCfg.Watchlist)
+}
+
+func runWatchlistMove(cmd *cobra.Command, opts watchlistOptions, symbol string, delta int) error {
+	uiCfg, err := tui.LoadConfigFrom(opts.uiConfigPath)
+	if err != nil {
+		return fmt.Errorf("failed to load UI config: %w", err)
+	}
+	moved, _, err := tui.MoveSymbol(uiCfg.Watchlist, strings.ToUpper(symbol), delta)
+	if err != nil {
+		return err
+	}
+	uiCfg.SetWatchlist(moved)
+	if err := tui.SaveConfigTo(opts.uiConfigPath, uiCfg); err != nil {
+		return fmt.Errorf("failed to save watchlist: %w", err)
+	}
+	return printWatchlist(cmd, opts, uiCfg.Watchlist)
+}
+
+// printWatchlist prints the saved watchlist order, one numbered symbol per
+// line, or as a JSON array.
+func printWatchlist(cmd *cobra.Command, opts watchlistOptions, symbols []string) error {
+	if opts.jsonMode {
+		if symbols == nil {
+			symbols = []string{}
+		}
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(symbols)
+	}
+
+	out := cmd.OutOrStdout()
+	if len(symbols) == 0 {
+		_, _ = fmt.Fprintln(out, "Watchlist is empty")
+		return nil
+	}
+	for i, symbol := range symbols {
+		_, _ = fmt.Fprintf(out, "%3d. %s\n", i+1, symbol)
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(newWatchlistCmd(watchlistOptions{
+		uiConfigPath: tui.ConfigPath(),
+	}))
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jonandersen/public-cli/internal/tui"
+)
+
+func runWatchlistCmd(t *testing.T, opts watchlistOptions, args ...string) (string, error) {
+	t.Helper()
+	cmd := newWatchlistCmd(opts)
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func writeTestWatchlist(t *testing.T, symbols ...string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "ui.yaml")
+	cfg := &tui.UIConfig{GreeksPrecision: 3}
+	cfg.SetWatchlist(symbols)
+	require.NoError(t, tui.SaveConfigTo(path, cfg))
+	return path
+}
+
+func TestWatchlistSortCmd(t *testing.T) {
+	path := writeTestWatchlist(t, "TSLA", "AAPL", "NVDA")
+	opts := watchlistOptions{uiConfigPath: path}
+
+	out, err := runWatchlistCmd(t, opts, "sort")
+	require.NoError(t, err)
+	assert.Equal(t, "  1. AAPL\n  2. NVDA\n  3. TSLA\n", out)
+
+	cfg, err := tui.LoadConfigFrom(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"AAPL", "NVDA", "TSLA"}, cfg.Watchlist)
+	assert.Equal(t, 3, cfg.GreeksPrecision, "other UI settings are preserved")
+
+	_, err = runWatchlistCmd(t, opts, "sort", "--by", "added")
+	require.NoError(t, err)
+	cfg, err = tui.LoadConfigFrom(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"TSLA", "AAPL", "NVDA"}, cfg.Watchlist)
+}
+
+func TestWatchlistSortCmd_InvalidBy(t *testing.T) {
+	_, err := runWatchlistCmd(t, watchlistOptions{uiConfigPath: writeTestWatchlist(t, "AAPL")}, "sort", "--by", "price")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid --by "price": use alpha or added`)
+}
+
+func TestWatchlistMoveCmd(t *testing.T) {
+	path := writeTestWatchlist(t, "AAPL", "MSFT", "TSLA", "NVDA")
+	opts := watchlistOptions{uiConfigPath: path, jsonMode: true}
+
+	out, err := runWatchlistCmd(t, opts, "move", "nvda", "--up", "--steps", "2")
+	require.NoError(t, err)
+	var symbols []string
+	require.NoError(t, json.Unmarshal([]byte(out), &symbols))
+	assert.Equal(t, []string{"AAPL", "NVDA", "MSFT", "TSLA"}, symbols)
+
+	_, err = runWatchlistCmd(t, opts, "move", "AAPL", "--down")
+	require.NoError(t, err)
+	cfg, err := tui.LoadConfigFrom(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"NVDA", "AAPL", "MSFT", "TSLA"}, cfg.Watchlist)
+
+	// Moving never changes the added order
+	assert.Equal(t, []string{"AAPL", "MSFT", "TSLA", "NVDA"}, cfg.WatchlistAdded)
+}
+
+func TestWatchlistMoveCmd_Errors(t *testing.T) {
+	opts := watchlistOptions{uiConfigPath: writeTestWatchlist(t, "AAPL", "MSFT")}
+
+	_, err := runWatchlistCmd(t, opts, "move", "AAPL")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "use exactly one of --up or --down")
+
+	_, err = runWatchlistCmd(t, opts, "move", "AAPL", "--down", "--steps", "0")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid --steps 0")
+
+	_, err = runWatchlistCmd(t, opts, "move", "GME", "--up")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "GME is not in the watchlist")
+}
@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jonandersen/public-cli/internal/auth"
+	"github.com/jonandersen/public-cli/internal/config"
+	"github.com/jonandersen/public-cli/internal/keyring"
+)
+
+// whoamiOptions holds dependencies for the whoami command.
+type whoamiOptions struct {
+	configPath     string
+	tokenCachePath string
+	store          keyring.Store
+	jsonMode       bool
+}
+
+// whoamiResult describes the identity the CLI is currently acting as.
+type whoamiResult struct {
+	ConfigPath       string `json:"configPath"`
+	BaseURL          string `json:"baseUrl"`
+	AccountID        string `json:"accountId,omitempty"`
+	TradingEnabled   bool   `json:"tradingEnabled"`
+	TradingMode      string `json:"tradingMode"` // disabled, confirm, or yolo
+	SecretConfigured bool   `json:"secretConfigured"`
+	TokenValid       bool   `json:"tokenValid"`
+	TokenExpiresAt   string `json:"tokenExpiresAt,omitempty"`
+}
+
+// newWhoamiCmd creates the whoami command with the given options.
+func newWhoamiCmd(opts whoamiOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "whoami",
+		Short: "Show the active configuration and account",
+		Long: `Show who the CLI is acting as right now: the config file in use, the API
+base URL, the default account, whether trading is enabled, and whether a
+valid token is cached. The token itself is never printed.
+
+No API calls are made.
+
+Examples:
+  pub whoami          # Human-readable summary
+  pub whoami --json   # JSON output`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWhoami(cmd, opts)
+		},
+	}
+
+	cmd.SilenceUsage = true
+
+	return cmd
+}
+
+func runWhoami(cmd *cobra.Command, opts whoamiOptions) error {
+	cfg, err := config.Load(opts.configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	result := whoamiResult{
+		ConfigPath:     opts.configPath,
+		BaseURL:        cfg.APIBaseURL,
+		AccountID:      cfg.AccountUUID,
+		TradingEnabled: cfg.TradingEnabled(),
+		TradingMode:    cfg.Trading.String(),
+	}
+
+	if _, err := opts.store.Get(keyring.ServiceName, keyring.KeySecretKey); err == nil {
+		result.SecretConfigured = true
+	}
+
+	if token, err := auth.LoadToken(opts.tokenCachePath); err == nil && token.IsValid() {
+		result.TokenValid = true
+		result.TokenExpiresAt = time.Unix(token.ExpiresAt, 0).UTC().Format(time.RFC3339)
+	}
+
+	if opts.jsonMode {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	}
+
+	out := cmd.OutOrStdout()
+	_, _ = fmt.Fprintf(out, "Config:   %s\n", result.ConfigPath)
+	_, _ = fmt.Fprintf(out, "API:      %s\n", result.BaseURL)
+	if result.AccountID != "" {
+		_, _ = fmt.Fprintf(out, "Account:  %s\n", result.AccountID)
+	} else {
+		_, _ = fmt.Fprintln(out, "Account:  Not set")
+	}
+	if result.TradingMode == string(config.TradingConfirm) {
+		_, _ = fmt.Fprintln(out, "Trading:  ENABLED (every order confirmed at a prompt)")
+	} else if result.TradingEnabled {
+		_, _ = fmt.Fprintln(out, "Trading:  ENABLED")
+	} else {
+		_, _ = fmt.Fprintln(out, "Trading:  DISABLED")
+	}
+	if result.SecretConfigured {
+		_, _ = fmt.Fprintln(out, "Secret:   Configured")
+	} else {
+		_, _ = fmt.Fprintln(out, "Secret:   Not configured (run: pub configure)")
+	}
+	if result.TokenValid {
+		_, _ = fmt.Fprintf(out, "Token:    Valid until %s\n", result.TokenExpiresAt)
+	} else {
+		_, _ = fmt.Fprintln(out, "Token:    None cached (fetched on next command)")
+	}
+
+	return nil
+}
+
+func init() {
+	opts := whoamiOptions{
+		configPath:     config.ConfigPath(),
+		tokenCachePath: auth.TokenCachePath(),
+		store:          keyring.NewEnvStore(keyring.NewSystemStore()),
+	}
+	whoamiCmd := newWhoamiCmd(opts)
+	whoamiCmd.PreRun = func(cmd *cobra.Command, args []string) {
+		opts.jsonMode = GetJSONMode()
+	}
+	whoamiCmd.RunE = func(cmd *cobra.Command, args []string) error {
+		return runWhoami(cmd, opts)
+	}
+	rootCmd.AddCommand(whoamiCmd)
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jonandersen/public-cli/internal/auth"
+	"github.com/jonandersen/public-cli/internal/keyring"
+)
+
+func TestWhoamiCmd_Configured(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	tokenPath := filepath.Join(tmpDir, ".tokens.json")
+
+	require.NoError(t, os.WriteFile(configPath, []byte(
+		"account_uuid: 12345678-1234-1234-1234-123456789012\ntrading_enabled: true\n"), 0600))
+	require.NoError(t, auth.SaveToken(tokenPath, &auth.Token{
+		AccessToken: "secret-token",
+		ExpiresAt:   time.Now().Add(time.Hour).Unix(),
+	}))
+
+	store := keyring.NewMockStore().WithData(keyring.ServiceName, keyring.KeySecretKey, "secret")
+	cmd := newWhoamiCmd(whoamiOptions{
+		configPath:     configPath,
+		tokenCachePath: tokenPath,
+		store:          store,
+	})
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{})
+
+	require.NoError(t, cmd.Execute())
+
+	output := out.String()
+	assert.Contains(t, output, configPath)
+	assert.Contains(t, output, "12345678-1234-1234-1234-123456789012")
+	assert.Contains(t, output, "Trading:  ENABLED")
+	assert.Contains(t, output, "Secret:   Configured")
+	assert.Contains(t, output, "Token:    Valid until")
+	assert.NotContains(t, output, "secret-token")
+}
+
+func TestWhoamiCmd_JSONUnconfigured(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	cmd := newWhoamiCmd(whoamiOptions{
+		configPath:     filepath.Join(tmpDir, "config.yaml"),
+		tokenCachePath: filepath.Join(tmpDir, ".tokens.json"),
+		store:          keyring.NewMockStore(),
+		jsonMode:       true,
+	})
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{})
+
+	require.NoError(t, cmd.Execute())
+
+	var result whoamiResult
+	require.NoError(t, json.Unmarshal(out.Bytes(), &result))
+	assert.Equal(t, "https://api.public.com", result.BaseURL)
+	assert.Empty(t, result.AccountID)
+	assert.False(t, result.TradingEnabled)
+	assert.Equal(t, "disabled", result.TradingMode)
+	assert.False(t, result.SecretConfigured)
+	assert.False(t, result.TokenValid)
+}
+
+func TestWhoamiCmd_ConfirmMode(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("trading_enabled: confirm\n"), 0600))
+
+	cmd := newWhoamiCmd(whoamiOptions{
+		configPath:     configPath,
+		tokenCachePath: filepath.Join(tmpDir, ".tokens.json"),
+		store:          keyring.NewMockStore(),
+	})
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{})
+
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), "Trading:  ENABLED (every order confirmed at a prompt)")
+}
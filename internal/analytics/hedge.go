@@ -0,0 +1,14 @@
+package analytics
+
+import "math"
+
+// ContractMultiplier is the number of shares controlled by one equity option contract.
+const ContractMultiplier = 100
+
+// HedgeShares returns the signed share count that neutralizes the delta of an
+// option position: delta × 100 × contracts, negated and rounded to whole
+// shares. Contracts are signed like Leg.Quantity, so a negative result means
+// sell (short) shares and a positive result means buy them.
+func HedgeShares(delta, contracts float64) int64 {
+	return int64(math.Round(-delta * ContractMultiplier * contracts))
+}
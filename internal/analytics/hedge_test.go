@@ -0,0 +1,29 @@
+package analytics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHedgeShares(t *testing.T) {
+	tests := []struct {
+		name      string
+		delta     float64
+		contracts float64
+		want      int64
+	}{
+		{"long call sells shares", 0.52, 1, -52},
+		{"short call buys shares", 0.52, -1, 52},
+		{"long put buys shares", -0.48, 1, 48},
+		{"several contracts", 0.305, 3, -92},
+		{"rounds to whole shares", 0.456, 1, -46},
+		{"zero delta", 0, 5, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, HedgeShares(tt.delta, tt.contracts))
+		})
+	}
+}
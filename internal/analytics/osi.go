@@ -0,0 +1,82 @@
+// Package analytics provides option symbol parsing and payoff math used for
+// pre-trade risk checks.
+package analytics
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Option types.
+const (
+	Call = "CALL"
+	Put  = "PUT"
+)
+
+// OSI is a parsed OSI option symbol.
+type OSI struct {
+	Underlying string
+	Expiration time.Time
+	Type       string // Call or Put
+	Strike     float64
+}
+
+// ParseOSI parses an OSI option symbol such as AAPL250117C00175000:
+// the underlying root, a YYMMDD expiration, C or P, and the strike times 1000
+// as eight digits.
+func ParseOSI(symbol string) (OSI, error) {
+	symbol = strings.ToUpper(strings.TrimSpace(symbol))
+	if len(symbol) < 16 {
+		return OSI{}, fmt.Errorf("invalid OSI symbol %q: too short", symbol)
+	}
+
+	tail := symbol[len(symbol)-15:]
+	root := strings.TrimSpace(symbol[:len(symbol)-15])
+	if root == "" {
+		return OSI{}, fmt.Errorf("invalid OSI symbol %q: missing underlying", symbol)
+	}
+
+	expiration, err := time.Parse("060102", tail[:6])
+	if err != nil {
+		return OSI{}, fmt.Errorf("invalid OSI symbol %q: bad expiration date", symbol)
+	}
+
+	var optType string
+	switch tail[6] {
+	case 'C':
+		optType = Call
+	case 'P':
+		optType = Put
+	default:
+		return OSI{}, fmt.Errorf("invalid OSI symbol %q: expected C or P", symbol)
+	}
+
+	strike, err := strconv.ParseInt(tail[7:], 10, 64)
+	if err != nil {
+		return OSI{}, fmt.Errorf("invalid OSI symbol %q: bad strike", symbol)
+	}
+
+	return OSI{
+		Underlying: root,
+		Expiration: expiration,
+		Type:       optType,
+		Strike:     float64(strike) / 1000,
+	}, nil
+}
+
+// BuildOSI formats an OSI option symbol from its parts. It is the inverse of
+// ParseOSI: BuildOSI("AAPL", 2025-01-17, Call, 175) is AAPL250117C00175000.
+func BuildOSI(underlying string, expiration time.Time, optType string, strike float64) string {
+	cp := "C"
+	if strings.EqualFold(optType, Put) {
+		cp = "P"
+	}
+	return fmt.Sprintf("%s%s%s%08d",
+		strings.ToUpper(strings.TrimSpace(underlying)),
+		expiration.Format("060102"),
+		cp,
+		int64(math.Round(strike*1000)))
+}
@@ -0,0 +1,70 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOSI(t *testing.T) {
+	tests := []struct {
+		name   string
+		symbol string
+		want   OSI
+	}{
+		{
+			name:   "call",
+			symbol: "AAPL250117C00175000",
+			want:   OSI{Underlying: "AAPL", Expiration: time.Date(2025, 1, 17, 0, 0, 0, 0, time.UTC), Type: Call, Strike: 175},
+		},
+		{
+			name:   "put with fractional strike",
+			symbol: "F250221P00012500",
+			want:   OSI{Underlying: "F", Expiration: time.Date(2025, 2, 21, 0, 0, 0, 0, time.UTC), Type: Put, Strike: 12.5},
+		},
+		{
+			name:   "lowercase",
+			symbol: "spy251219c00600000",
+			want:   OSI{Underlying: "SPY", Expiration: time.Date(2025, 12, 19, 0, 0, 0, 0, time.UTC), Type: Call, Strike: 600},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseOSI(tt.symbol)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseOSI_Invalid(t *testing.T) {
+	tests := []string{
+		"AAPL",
+		"250117C00175000",
+		"AAPL251317C00175000",
+		"AAPL250117X00175000",
+		"AAPL250117C0017500X",
+	}
+
+	for _, symbol := range tests {
+		t.Run(symbol, func(t *testing.T) {
+			_, err := ParseOSI(symbol)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestBuildOSI(t *testing.T) {
+	exp := time.Date(2025, 1, 17, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, "AAPL250117C00175000", BuildOSI("aapl", exp, Call, 175))
+	assert.Equal(t, "F250117P00012500", BuildOSI("F", exp, Put, 12.5))
+	assert.Equal(t, "SPX250117P04567500", BuildOSI("SPX", exp, Put, 4567.5))
+
+	// Round-trips through ParseOSI
+	parsed, err := ParseOSI(BuildOSI("MSFT", exp, Put, 402.5))
+	require.NoError(t, err)
+	assert.Equal(t, OSI{Underlying: "MSFT", Expiration: exp, Type: Put, Strike: 402.5}, parsed)
+}
@@ -0,0 +1,160 @@
+package analytics
+
+import (
+	"math"
+	"sort"
+)
+
+// Leg is one option position in a strategy.
+type Leg struct {
+	Type     string  // Call or Put
+	Strike   float64 // Strike price
+	Quantity float64 // Signed contracts: positive long, negative short
+}
+
+// PayoffAt returns the per-share value of the legs at expiration for the
+// given underlying price, excluding premium.
+func PayoffAt(legs []Leg, price float64) float64 {
+	var total float64
+	for _, leg := range legs {
+		switch leg.Type {
+		case Call:
+			total += leg.Quantity * math.Max(price-leg.Strike, 0)
+		case Put:
+			total += leg.Quantity * math.Max(leg.Strike-price, 0)
+		}
+	}
+	return total
+}
+
+// callSlope returns how fast the payoff changes per dollar above the highest strike.
+func callSlope(legs []Leg) float64 {
+	var slope float64
+	for _, leg := range legs {
+		if leg.Type == Call {
+			slope += leg.Quantity
+		}
+	}
+	return slope
+}
+
+// pricePoints returns the prices where the payoff can reach an extreme:
+// zero and every strike.
+func pricePoints(legs []Leg) []float64 {
+	points := []float64{0}
+	for _, leg := range legs {
+		points = append(points, leg.Strike)
+	}
+	sort.Float64s(points)
+	return points
+}
+
+// payoffRange returns the lowest and highest payoff at expiration, and
+// whether the payoff is unbounded below or above as the price rises.
+func payoffRange(legs []Leg) (low, high float64, unboundedLow, unboundedHigh bool) {
+	low, high = math.Inf(1), math.Inf(-1)
+	for _, p := range pricePoints(legs) {
+		v := PayoffAt(legs, p)
+		low = math.Min(low, v)
+		high = math.Max(high, v)
+	}
+	slope := callSlope(legs)
+	return low, high, slope < 0, slope > 0
+}
+
+// MaxLoss returns the largest per-share loss at expiration given the net
+// premium (positive for a credit received, negative for a debit paid).
+// unlimited is true when losses grow without bound as the price rises.
+func MaxLoss(legs []Leg, premium float64) (loss float64, unlimited bool) {
+	low, _, unboundedLow, _ := payoffRange(legs)
+	if unboundedLow {
+		return math.Inf(1), true
+	}
+	return math.Max(0, -(low + premium)), false
+}
+
+// MaxProfit returns the largest per-share profit at expiration given the net
+// premium (positive for a credit received, negative for a debit paid).
+// unlimited is true when profits grow without bound as the price rises.
+func MaxProfit(legs []Leg, premium float64) (profit float64, unlimited bool) {
+	_, high, _, unboundedHigh := payoffRange(legs)
+	if unboundedHigh {
+		return math.Inf(1), true
+	}
+	return math.Max(0, high+premium), false
+}
+
+// Breakevens returns the underlying prices, ascending, where the profit at
+// expiration including the net premium crosses zero. The payoff is linear
+// between strikes, so each crossing is found exactly.
+func Breakevens(legs []Leg, premium float64) []float64 {
+	profit := func(price float64) float64 { return PayoffAt(legs, price) + premium }
+	crosses := func(a, b float64) bool { return (a <= 0 && b > 0) || (a > 0 && b <= 0) }
+
+	var prices []float64
+	add := func(price float64) {
+		if n := len(prices); n == 0 || math.Abs(prices[n-1]-price) > 1e-9 {
+			prices = append(prices, price)
+		}
+	}
+
+	points := pricePoints(legs)
+	for i := 1; i < len(points); i++ {
+		a, b := points[i-1], points[i]
+		fa, fb := profit(a), profit(b)
+		if a == b || !crosses(fa, fb) {
+			continue
+		}
+		if fa == 0 {
+			add(a)
+		} else {
+			add(a + fa*(b-a)/(fa-fb))
+		}
+	}
+
+	// Above the highest strike the profit changes by callSlope per dollar
+	top := points[len(points)-1]
+	if slope := callSlope(legs); slope != 0 {
+		if root := top - profit(top)/slope; root > top {
+			add(root)
+		}
+	}
+	return prices
+}
+
+// NetPremium interprets an unsigned limit price as a credit or a debit based
+// on the strategy's shape: if the legs can never pay out at expiration the
+// position must be opened for a credit, otherwise the limit is a debit.
+// A negative limit is always treated as a credit.
+func NetPremium(legs []Leg, limit float64) float64 {
+	if limit < 0 {
+		return -limit
+	}
+	_, high, _, unboundedHigh := payoffRange(legs)
+	if !unboundedHigh && high <= 0 {
+		return limit
+	}
+	return -limit
+}
+
+// Net directions returned by NetDirection.
+const (
+	Debit  = "DEBIT"
+	Credit = "CREDIT"
+)
+
+// NetDirection reports whether the legs must be opened for a debit or a
+// credit, judged from their payoff at expiration: a position that can never
+// lose before premium is worth paying for, and one that can never gain must
+// be paid to take on. It returns "" when the payoff can go either way, as
+// with risk reversals and ratio spreads, or is zero everywhere.
+func NetDirection(legs []Leg) string {
+	low, high, unboundedLow, unboundedHigh := payoffRange(legs)
+	switch {
+	case low >= 0 && !unboundedLow && (high > 0 || unboundedHigh):
+		return Debit
+	case high <= 0 && !unboundedHigh && (low < 0 || unboundedLow):
+		return Credit
+	}
+	return ""
+}
@@ -0,0 +1,196 @@
+package analytics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayoffAt(t *testing.T) {
+	// Bull call spread 175/180
+	legs := []Leg{
+		{Type: Call, Strike: 175, Quantity: 1},
+		{Type: Call, Strike: 180, Quantity: -1},
+	}
+
+	assert.Equal(t, 0.0, PayoffAt(legs, 170))
+	assert.Equal(t, 2.5, PayoffAt(legs, 177.5))
+	assert.Equal(t, 5.0, PayoffAt(legs, 200))
+}
+
+func TestMaxLoss(t *testing.T) {
+	tests := []struct {
+		name          string
+		legs          []Leg
+		premium       float64
+		wantLoss      float64
+		wantUnlimited bool
+	}{
+		{
+			name:     "long call loses the debit",
+			legs:     []Leg{{Type: Call, Strike: 175, Quantity: 1}},
+			premium:  -2.50,
+			wantLoss: 2.50,
+		},
+		{
+			name: "bull call spread loses the debit",
+			legs: []Leg{
+				{Type: Call, Strike: 175, Quantity: 1},
+				{Type: Call, Strike: 180, Quantity: -1},
+			},
+			premium:  -2.00,
+			wantLoss: 2.00,
+		},
+		{
+			name: "iron condor loses width minus credit",
+			legs: []Leg{
+				{Type: Put, Strike: 160, Quantity: 1},
+				{Type: Put, Strike: 165, Quantity: -1},
+				{Type: Call, Strike: 185, Quantity: -1},
+				{Type: Call, Strike: 190, Quantity: 1},
+			},
+			premium:  1.20,
+			wantLoss: 3.80,
+		},
+		{
+			name:     "short put loses strike minus credit",
+			legs:     []Leg{{Type: Put, Strike: 50, Quantity: -1}},
+			premium:  1.00,
+			wantLoss: 49.00,
+		},
+		{
+			name:          "short call is unlimited",
+			legs:          []Leg{{Type: Call, Strike: 50, Quantity: -1}},
+			premium:       1.00,
+			wantUnlimited: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loss, unlimited := MaxLoss(tt.legs, tt.premium)
+			assert.Equal(t, tt.wantUnlimited, unlimited)
+			if !tt.wantUnlimited {
+				assert.InDelta(t, tt.wantLoss, loss, 0.0001)
+			}
+		})
+	}
+}
+
+func TestNetPremium(t *testing.T) {
+	debitSpread := []Leg{
+		{Type: Call, Strike: 175, Quantity: 1},
+		{Type: Call, Strike: 180, Quantity: -1},
+	}
+	creditSpread := []Leg{
+		{Type: Put, Strike: 165, Quantity: -1},
+		{Type: Put, Strike: 160, Quantity: 1},
+	}
+
+	assert.Equal(t, -2.50, NetPremium(debitSpread, 2.50))
+	assert.Equal(t, 1.20, NetPremium(creditSpread, 1.20))
+	assert.Equal(t, 1.20, NetPremium(debitSpread, -1.20))
+}
+
+func TestNetDirection(t *testing.T) {
+	tests := []struct {
+		name string
+		legs []Leg
+		want string
+	}{
+		{"bull call vertical", []Leg{{Type: Call, Strike: 175, Quantity: 1}, {Type: Call, Strike: 180, Quantity: -1}}, Debit},
+		{"bear call vertical", []Leg{{Type: Call, Strike: 175, Quantity: -1}, {Type: Call, Strike: 180, Quantity: 1}}, Credit},
+		{"bull put vertical", []Leg{{Type: Put, Strike: 165, Quantity: -1}, {Type: Put, Strike: 160, Quantity: 1}}, Credit},
+		{"long straddle", []Leg{{Type: Call, Strike: 100, Quantity: 1}, {Type: Put, Strike: 100, Quantity: 1}}, Debit},
+		{"short strangle", []Leg{{Type: Call, Strike: 110, Quantity: -1}, {Type: Put, Strike: 90, Quantity: -1}}, Credit},
+		{"risk reversal", []Leg{{Type: Call, Strike: 110, Quantity: 1}, {Type: Put, Strike: 90, Quantity: -1}}, ""},
+		{"call ratio spread", []Leg{{Type: Call, Strike: 100, Quantity: 1}, {Type: Call, Strike: 110, Quantity: -2}}, ""},
+		{"calendar", []Leg{{Type: Call, Strike: 100, Quantity: 1}, {Type: Call, Strike: 100, Quantity: -1}}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NetDirection(tt.legs))
+		})
+	}
+}
+
+func TestMaxProfit(t *testing.T) {
+	bullCall := []Leg{
+		{Type: Call, Strike: 175, Quantity: 1},
+		{Type: Call, Strike: 180, Quantity: -1},
+	}
+	profit, unlimited := MaxProfit(bullCall, -2.00)
+	assert.InDelta(t, 3.00, profit, 1e-9)
+	assert.False(t, unlimited)
+
+	_, unlimited = MaxProfit([]Leg{{Type: Call, Strike: 175, Quantity: 1}}, -2.50)
+	assert.True(t, unlimited)
+
+	profit, unlimited = MaxProfit([]Leg{{Type: Put, Strike: 50, Quantity: -1}}, 1.00)
+	assert.InDelta(t, 1.00, profit, 1e-9)
+	assert.False(t, unlimited)
+}
+
+func TestBreakevens(t *testing.T) {
+	tests := []struct {
+		name    string
+		legs    []Leg
+		premium float64
+		want    []float64
+	}{
+		{
+			name:    "long call",
+			legs:    []Leg{{Type: Call, Strike: 175, Quantity: 1}},
+			premium: -2.50,
+			want:    []float64{177.50},
+		},
+		{
+			name: "bull call spread",
+			legs: []Leg{
+				{Type: Call, Strike: 175, Quantity: 1},
+				{Type: Call, Strike: 180, Quantity: -1},
+			},
+			premium: -2.00,
+			want:    []float64{177},
+		},
+		{
+			name: "iron condor",
+			legs: []Leg{
+				{Type: Put, Strike: 160, Quantity: 1},
+				{Type: Put, Strike: 165, Quantity: -1},
+				{Type: Call, Strike: 185, Quantity: -1},
+				{Type: Call, Strike: 190, Quantity: 1},
+			},
+			premium: 1.20,
+			want:    []float64{163.80, 186.20},
+		},
+		{
+			name: "long straddle",
+			legs: []Leg{
+				{Type: Call, Strike: 100, Quantity: 1},
+				{Type: Put, Strike: 100, Quantity: 1},
+			},
+			premium: -6.00,
+			want:    []float64{94, 106},
+		},
+		{
+			name: "spread that cannot profit",
+			legs: []Leg{
+				{Type: Call, Strike: 175, Quantity: 1},
+				{Type: Call, Strike: 180, Quantity: -1},
+			},
+			premium: -6.00,
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Breakevens(tt.legs, tt.premium)
+			assert.Len(t, got, len(tt.want))
+			for i := range tt.want {
+				assert.InDelta(t, tt.want[i], got[i], 1e-9)
+			}
+		})
+	}
+}
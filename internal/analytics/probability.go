@@ -0,0 +1,137 @@
+package analytics
+
+import (
+	"math"
+	"sort"
+)
+
+// StrikeProbability is the chance that the underlying finishes above Strike
+// at expiration. StdDev is one standard deviation of the log price at
+// expiration (implied volatility × √years to expiration), or 0 when unknown.
+type StrikeProbability struct {
+	Strike float64
+	Above  float64
+	StdDev float64
+}
+
+// ITMFromDelta returns an option's delta as the chance it finishes in the
+// money. Delta is only a proxy for the risk-neutral probability (it runs a
+// little high for calls and low for puts), so treat the result as a rough
+// estimate.
+func ITMFromDelta(delta float64) float64 {
+	return math.Min(1, math.Abs(delta))
+}
+
+// AboveFromDelta returns the chance the underlying finishes above the strike
+// of a call or put with the given delta: the call's ITM chance, or one minus
+// the put's.
+func AboveFromDelta(optType string, delta float64) float64 {
+	if optType == Put {
+		return 1 - ITMFromDelta(delta)
+	}
+	return ITMFromDelta(delta)
+}
+
+// ProbabilityOfProfit estimates the chance the legs finish with a profit,
+// premium included (positive for a credit received, negative for a debit
+// paid). above must hold at least one strike; see survivalCurve for how
+// prices between and beyond them are filled in.
+func ProbabilityOfProfit(legs []Leg, premium float64, above []StrikeProbability) float64 {
+	survival := survivalCurve(above)
+	profit := func(price float64) float64 { return PayoffAt(legs, price) + premium }
+
+	points := append(pricePoints(legs), Breakevens(legs, premium)...)
+	sort.Float64s(points)
+
+	var p float64
+	for i := 1; i < len(points); i++ {
+		a, b := points[i-1], points[i]
+		if b > a && profit((a+b)/2) > 0 {
+			p += survival(a) - survival(b)
+		}
+	}
+	if top := points[len(points)-1]; profit(top+1) > 0 {
+		p += survival(top)
+	}
+	return math.Max(0, math.Min(1, p))
+}
+
+// survivalCurve returns the chance of finishing above a price. Each known
+// strike's chance is turned into a normal score, which is interpolated in log
+// price between strikes. Beyond the outermost strikes the score moves one unit
+// per StdDev of log price, as under a lognormal model; without a StdDev the
+// chance stays at the nearest strike's. Several values for one strike, say
+// from a call and a put, are averaged.
+func survivalCurve(above []StrikeProbability) func(float64) float64 {
+	type anchor struct {
+		logStrike, score, stdDev float64
+	}
+	byStrike := make(map[float64][]StrikeProbability)
+	for _, sp := range above {
+		if sp.Strike > 0 {
+			byStrike[sp.Strike] = append(byStrike[sp.Strike], sp)
+		}
+	}
+	anchors := make([]anchor, 0, len(byStrike))
+	for strike, sps := range byStrike {
+		var p, sd float64
+		var sds int
+		for _, sp := range sps {
+			p += sp.Above
+			if sp.StdDev > 0 {
+				sd += sp.StdDev
+				sds++
+			}
+		}
+		if sds > 0 {
+			sd /= float64(sds)
+		}
+		anchors = append(anchors, anchor{math.Log(strike), normalScore(p / float64(len(sps))), sd})
+	}
+	sort.Slice(anchors, func(i, j int) bool { return anchors[i].logStrike < anchors[j].logStrike })
+
+	return func(price float64) float64 {
+		if price <= 0 {
+			return 1
+		}
+		if len(anchors) == 0 {
+			return 0.5
+		}
+		x := math.Log(price)
+		first, last := anchors[0], anchors[len(anchors)-1]
+		switch {
+		case x <= first.logStrike:
+			return normalCDF(first.score + tailScore(first.logStrike-x, first.stdDev))
+		case x >= last.logStrike:
+			return normalCDF(last.score - tailScore(x-last.logStrike, last.stdDev))
+		}
+		for i := 1; i < len(anchors); i++ {
+			lo, hi := anchors[i-1], anchors[i]
+			if x <= hi.logStrike {
+				frac := (x - lo.logStrike) / (hi.logStrike - lo.logStrike)
+				return normalCDF(lo.score + frac*(hi.score-lo.score))
+			}
+		}
+		return normalCDF(last.score)
+	}
+}
+
+// tailScore is how far the normal score moves over dist of log price beyond
+// the outermost strike, or 0 when the standard deviation is unknown.
+func tailScore(dist, stdDev float64) float64 {
+	if stdDev <= 0 {
+		return 0
+	}
+	return dist / stdDev
+}
+
+// normalScore returns the standard normal quantile of p, clamped away from 0
+// and 1 so deep in- or out-of-the-money deltas stay finite.
+func normalScore(p float64) float64 {
+	p = math.Max(1e-6, math.Min(1-1e-6, p))
+	return math.Sqrt2 * math.Erfinv(2*p-1)
+}
+
+func normalCDF(z float64) float64 {
+	return 0.5 * (1 + math.Erf(z/math.Sqrt2))
+}
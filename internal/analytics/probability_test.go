@@ -0,0 +1,99 @@
+package analytics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestITMFromDelta(t *testing.T) {
+	assert.InDelta(t, 0.55, ITMFromDelta(0.55), 1e-9)
+	assert.InDelta(t, 0.30, ITMFromDelta(-0.30), 1e-9)
+	assert.InDelta(t, 1.00, ITMFromDelta(1.02), 1e-9)
+}
+
+func TestAboveFromDelta(t *testing.T) {
+	assert.InDelta(t, 0.55, AboveFromDelta(Call, 0.55), 1e-9)
+	assert.InDelta(t, 0.70, AboveFromDelta(Put, -0.30), 1e-9)
+}
+
+func TestProbabilityOfProfit(t *testing.T) {
+	tests := []struct {
+		name    string
+		legs    []Leg
+		premium float64
+		above   []StrikeProbability
+		want    float64
+	}{
+		{
+			name:    "long call without volatility keeps its delta past the strike",
+			legs:    []Leg{{Type: Call, Strike: 175, Quantity: 1}},
+			premium: -2.50,
+			above:   []StrikeProbability{{Strike: 175, Above: 0.55}},
+			want:    0.55,
+		},
+		{
+			name: "bull call spread interpolates at the breakeven",
+			legs: []Leg{
+				{Type: Call, Strike: 175, Quantity: 1},
+				{Type: Call, Strike: 180, Quantity: -1},
+			},
+			premium: -2.00,
+			above:   []StrikeProbability{{Strike: 175, Above: 0.60}, {Strike: 180, Above: 0.40}},
+			want:    0.519522,
+		},
+		{
+			name: "iron condor profits between its breakevens",
+			legs: []Leg{
+				{Type: Put, Strike: 160, Quantity: 1},
+				{Type: Put, Strike: 165, Quantity: -1},
+				{Type: Call, Strike: 185, Quantity: -1},
+				{Type: Call, Strike: 190, Quantity: 1},
+			},
+			premium: 1.20,
+			above: []StrikeProbability{
+				{Strike: 160, Above: 0.85},
+				{Strike: 165, Above: 0.80},
+				{Strike: 185, Above: 0.20},
+				{Strike: 190, Above: 0.12},
+			},
+			want: 0.634536,
+		},
+		{
+			name:    "short put extends below the strike with volatility",
+			legs:    []Leg{{Type: Put, Strike: 50, Quantity: -1}},
+			premium: 1.00,
+			above:   []StrikeProbability{{Strike: 50, Above: 0.70, StdDev: 0.10}},
+			want:    0.766212,
+		},
+		{
+			name: "short straddle averages call and put at one strike",
+			legs: []Leg{
+				{Type: Call, Strike: 100, Quantity: -1},
+				{Type: Put, Strike: 100, Quantity: -1},
+			},
+			premium: 5.00,
+			above: []StrikeProbability{
+				{Strike: 100, Above: 0.52, StdDev: 0.07},
+				{Strike: 100, Above: 0.48, StdDev: 0.07},
+			},
+			want: 0.525247,
+		},
+		{
+			name: "spread that cannot profit",
+			legs: []Leg{
+				{Type: Call, Strike: 175, Quantity: 1},
+				{Type: Call, Strike: 180, Quantity: -1},
+			},
+			premium: -6.00,
+			above:   []StrikeProbability{{Strike: 175, Above: 0.60}, {Strike: 180, Above: 0.40}},
+			want:    0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, ProbabilityOfProfit(tt.legs, tt.premium, tt.above), 1e-6)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"strings"
@@ -23,13 +24,33 @@ type Client struct {
 	TokenRefresher TokenRefresher // Optional: called on 401 to get fresh token
 }
 
+// sharedTransport is used by every Client so that connections (and TLS
+// sessions) are reused across the many short-lived clients the CLI and TUI
+// create. The stdlib default keeps only 2 idle connections per host, which is
+// too few when the TUI fires several requests at once.
+var sharedTransport = &http.Transport{
+	Proxy: http.ProxyFromEnvironment,
+	DialContext: (&net.Dialer{
+		Timeout:   10 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}).DialContext,
+	ForceAttemptHTTP2:     true,
+	MaxIdleConns:          100,
+	MaxIdleConnsPerHost:   16,
+	IdleConnTimeout:       90 * time.Second,
+	TLSHandshakeTimeout:   10 * time.Second,
+	ExpectContinueTimeout: 1 * time.Second,
+}
+
 // NewClient creates a new API client with the given base URL and auth token.
+// Clients share one connection pool, so NewClient is cheap to call per request.
 func NewClient(baseURL, authToken string) *Client {
 	return &Client{
 		BaseURL:   strings.TrimSuffix(baseURL, "/"),
 		AuthToken: authToken,
 		HTTPClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout:   30 * time.Second,
+			Transport: timingTransport{base: sharedTransport},
 		},
 	}
 }
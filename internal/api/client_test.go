@@ -3,9 +3,11 @@ package api
 import (
 	"context"
 	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -27,6 +29,29 @@ func TestNewClient_DefaultTimeout(t *testing.T) {
 	assert.Equal(t, 30*time.Second, client.HTTPClient.Timeout)
 }
 
+func TestNewClient_ReusesConnections(t *testing.T) {
+	var conns int64
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt64(&conns, 1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	for i := 0; i < 5; i++ {
+		resp, err := NewClient(server.URL, "test-token").Get(context.Background(), "/")
+		require.NoError(t, err)
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}
+
+	assert.Equal(t, int64(1), atomic.LoadInt64(&conns))
+}
+
 func TestClient_Get_Success(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, http.MethodGet, r.Method)
@@ -234,3 +259,47 @@ func TestClient_Get_Timeout(t *testing.T) {
 
 	assert.Error(t, err)
 }
+
+// benchmarkNewClientPerRequest creates a new client for every request, as the
+// CLI commands and TUI do, and reports new TCP connections per request.
+func benchmarkNewClientPerRequest(b *testing.B, newClient func(baseURL string) *Client) {
+	var conns int64
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt64(&conns, 1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		resp, err := newClient(server.URL).Get(context.Background(), "/")
+		if err != nil {
+			b.Fatal(err)
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}
+	b.StopTimer()
+
+	b.ReportMetric(float64(atomic.LoadInt64(&conns))/float64(b.N), "conns/op")
+}
+
+func BenchmarkNewClient_SharedTransport(b *testing.B) {
+	benchmarkNewClientPerRequest(b, func(baseURL string) *Client {
+		return NewClient(baseURL, "test-token")
+	})
+}
+
+func BenchmarkNewClient_FreshTransport(b *testing.B) {
+	benchmarkNewClientPerRequest(b, func(baseURL string) *Client {
+		// A transport per client never sees its connections again
+		c := NewClient(baseURL, "test-token")
+		c.HTTPClient = &http.Client{Timeout: 30 * time.Second, Transport: &http.Transport{DisableKeepAlives: true}}
+		return c
+	})
+}
@@ -1,12 +1,25 @@
 package api
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"regexp"
+	"strings"
 )
 
+// VerboseErrors includes the full response body in decode errors instead of
+// a truncated snippet. It is set from the global --verbose flag.
+var VerboseErrors bool
+
+// decodeSnippetLen is the number of body bytes included in decode errors.
+const decodeSnippetLen = 200
+
+// sensitiveFieldPattern matches JSON string fields whose names suggest credentials.
+var sensitiveFieldPattern = regexp.MustCompile(`(?i)("[^"]*(token|secret|password)[^"]*"\s*:\s*)"[^"]*"`)
+
 // APIError represents an error response from the Public.com API.
 type APIError struct {
 	StatusCode int
@@ -38,11 +51,65 @@ func (e *APIError) IsForbidden() bool {
 	return e.StatusCode == http.StatusForbidden
 }
 
+// transientRejections are message fragments of order rejections that are
+// expected to clear on their own, such as a momentary trading halt.
+var transientRejections = []string{
+	"halt",
+	"temporarily",
+	"try again",
+	"not available at this time",
+	"market data unavailable",
+}
+
+// permanentRejections override transientRejections; retrying these cannot succeed.
+var permanentRejections = []string{
+	"insufficient",
+	"buying power",
+	"not tradable",
+	"invalid",
+}
+
+// IsTransientRejection reports whether the error is a business-level order
+// rejection that may succeed if resubmitted shortly, as opposed to one caused
+// by the order itself or the account (e.g. insufficient buying power). Only
+// the rejection message counts: a 429 or 503 may arrive after the order was
+// accepted, so resubmitting it could place a duplicate.
+func (e *APIError) IsTransientRejection() bool {
+	msg := strings.ToLower(e.Message)
+	for _, p := range permanentRejections {
+		if strings.Contains(msg, p) {
+			return false
+		}
+	}
+	for _, t := range transientRejections {
+		if strings.Contains(msg, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsInvalidSymbol reports whether the error says the order's symbol is not
+// recognized, e.g. "Provided symbol 'XYZ' is not valid".
+func (e *APIError) IsInvalidSymbol() bool {
+	msg := strings.ToLower(e.Message)
+	if !strings.Contains(msg, "symbol") {
+		return false
+	}
+	for _, p := range []string{"not valid", "invalid", "unknown", "not found"} {
+		if strings.Contains(msg, p) {
+			return true
+		}
+	}
+	return false
+}
+
 // errorResponse represents the JSON structure of API error responses.
+// Code is raw because the API sends it as either a string or a number.
 type errorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message"`
-	Code    string `json:"code"`
+	Error   string          `json:"error"`
+	Message string          `json:"message"`
+	Code    json.RawMessage `json:"code"`
 }
 
 // CheckResponse checks the API response for errors.
@@ -53,13 +120,18 @@ func CheckResponse(resp *http.Response) error {
 		return nil
 	}
 
+	body, _ := io.ReadAll(resp.Body)
+	return ParseAPIError(resp.StatusCode, body)
+}
+
+// ParseAPIError builds an APIError from an error status code and the raw
+// response body, for callers that have already read the body.
+func ParseAPIError(statusCode int, body []byte) *APIError {
 	apiErr := &APIError{
-		StatusCode: resp.StatusCode,
+		StatusCode: statusCode,
 	}
 
-	// Try to parse error body
-	body, err := io.ReadAll(resp.Body)
-	if err != nil || len(body) == 0 {
+	if len(body) == 0 {
 		return apiErr
 	}
 
@@ -75,15 +147,57 @@ func CheckResponse(resp *http.Response) error {
 	} else if errResp.Message != "" {
 		apiErr.Message = errResp.Message
 	}
-	apiErr.Code = errResp.Code
+	apiErr.Code = strings.Trim(string(errResp.Code), `"`)
 
 	return apiErr
 }
 
+// DecodeError is returned when a response body cannot be decoded into the
+// expected type. It carries the target type name and a redacted body snippet
+// so schema changes upstream are easy to diagnose.
+type DecodeError struct {
+	Target string
+	Body   string
+	Err    error
+}
+
+// Error implements the error interface.
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("failed to decode response as %s: %v (body: %s)", e.Target, e.Err, e.Body)
+}
+
+// Unwrap returns the underlying JSON error.
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// NewDecodeError builds a DecodeError for the given body and target.
+// Credential-like fields are redacted and the body is truncated unless
+// VerboseErrors is set.
+func NewDecodeError(body []byte, target interface{}, err error) *DecodeError {
+	snippet := sensitiveFieldPattern.ReplaceAllString(string(body), `${1}"[REDACTED]"`)
+	if !VerboseErrors && len(snippet) > decodeSnippetLen {
+		snippet = snippet[:decodeSnippetLen] + "..."
+	}
+	if snippet == "" {
+		snippet = "<empty>"
+	}
+
+	return &DecodeError{
+		Target: strings.TrimLeft(fmt.Sprintf("%T", target), "*"),
+		Body:   snippet,
+		Err:    err,
+	}
+}
+
 // DecodeJSON decodes a JSON response body into the given target.
 func DecodeJSON(resp *http.Response, target interface{}) error {
-	if err := json.NewDecoder(resp.Body).Decode(target); err != nil {
-		return fmt.Errorf("failed to decode response: %w", err)
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if err := json.NewDecoder(bytes.NewReader(body)).Decode(target); err != nil {
+		return NewDecodeError(body, target, err)
 	}
 	return nil
 }
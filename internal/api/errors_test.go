@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"io"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -178,6 +179,42 @@ func TestDecodeJSON_InvalidJSON(t *testing.T) {
 	assert.Contains(t, err.Error(), "failed to decode response")
 }
 
+func TestDecodeJSON_ErrorIncludesSnippet(t *testing.T) {
+	type Quote struct {
+		Last string `json:"last"`
+	}
+
+	resp := &http.Response{
+		StatusCode: 200,
+		Body:       io.NopCloser(bytes.NewReader([]byte(`{"last": 175.5, "accessToken": "secret-value"}`))),
+	}
+
+	var quote Quote
+	err := DecodeJSON(resp, &quote)
+	require.Error(t, err)
+
+	var decodeErr *DecodeError
+	require.ErrorAs(t, err, &decodeErr)
+	assert.Equal(t, "api.Quote", decodeErr.Target)
+	assert.Contains(t, err.Error(), "failed to decode response as api.Quote")
+	assert.Contains(t, err.Error(), `"last": 175.5`)
+	assert.Contains(t, err.Error(), `"accessToken": "[REDACTED]"`)
+	assert.NotContains(t, err.Error(), "secret-value")
+}
+
+func TestNewDecodeError_TruncatesBody(t *testing.T) {
+	body := []byte(strings.Repeat("x", 500))
+
+	err := NewDecodeError(body, &map[string]any{}, assert.AnError)
+	assert.Len(t, err.Body, decodeSnippetLen+len("..."))
+
+	VerboseErrors = true
+	defer func() { VerboseErrors = false }()
+
+	err = NewDecodeError(body, &map[string]any{}, assert.AnError)
+	assert.Len(t, err.Body, 500)
+}
+
 func TestDecodeJSON_EmptyBody(t *testing.T) {
 	resp := &http.Response{
 		StatusCode: 200,
@@ -189,3 +226,49 @@ func TestDecodeJSON_EmptyBody(t *testing.T) {
 
 	assert.Error(t, err)
 }
+
+func TestAPIError_IsTransientRejection(t *testing.T) {
+	tests := []struct {
+		name string
+		err  APIError
+		want bool
+	}{
+		{"halt", APIError{StatusCode: 400, Message: "Symbol is halted"}, true},
+		{"temporarily", APIError{StatusCode: 400, Message: "Order entry temporarily unavailable"}, true},
+		{"service unavailable", APIError{StatusCode: 503}, false},
+		{"rate limited", APIError{StatusCode: 429}, false},
+		{"buying power", APIError{StatusCode: 400, Message: "Insufficient buying power"}, false},
+		{"permanent wins", APIError{StatusCode: 400, Message: "Invalid quantity, try again"}, false},
+		{"unknown", APIError{StatusCode: 400, Message: "Order rejected"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.err.IsTransientRejection())
+		})
+	}
+}
+
+func TestParseAPIError(t *testing.T) {
+	apiErr := ParseAPIError(400, []byte(`{"message":"bad","code":"E1"}`))
+	assert.Equal(t, 400, apiErr.StatusCode)
+	assert.Equal(t, "bad", apiErr.Message)
+	assert.Equal(t, "E1", apiErr.Code)
+
+	apiErr = ParseAPIError(502, []byte("<html>"))
+	assert.Equal(t, 502, apiErr.StatusCode)
+	assert.Empty(t, apiErr.Message)
+}
+
+func TestParseAPIError_NumericCode(t *testing.T) {
+	apiErr := ParseAPIError(400, []byte(`{"code":140,"message":"Provided symbol 'XYZ' is not valid"}`))
+	assert.Equal(t, "140", apiErr.Code)
+	assert.Equal(t, "Provided symbol 'XYZ' is not valid", apiErr.Message)
+}
+
+func TestAPIError_IsInvalidSymbol(t *testing.T) {
+	assert.True(t, (&APIError{StatusCode: 400, Message: "Provided symbol 'XYZ' is not valid"}).IsInvalidSymbol())
+	assert.True(t, (&APIError{StatusCode: 404, Message: "Unknown symbol"}).IsInvalidSymbol())
+	assert.False(t, (&APIError{StatusCode: 400, Message: "Invalid quantity"}).IsInvalidSymbol())
+	assert.False(t, (&APIError{StatusCode: 400, Message: "Symbol is halted"}).IsInvalidSymbol())
+}
@@ -0,0 +1,123 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// GetInstruments lists tradable instruments, optionally filtered by type
+// (EQUITY, CRYPTO, ...) and trading status (BUY_AND_SELL, ...).
+func (c *Client) GetInstruments(ctx context.Context, typeFilter, tradingFilter string) (*InstrumentsResponse, error) {
+	params := url.Values{}
+	if typeFilter != "" {
+		params.Set("typeFilter", strings.ToUpper(typeFilter))
+	}
+	if tradingFilter != "" {
+		params.Set("tradingFilter", strings.ToUpper(tradingFilter))
+	}
+
+	path := "/userapigateway/trading/instruments"
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	resp, err := c.Get(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch instruments: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != 200 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("API error: %d - %s", resp.StatusCode, string(respBody))
+	}
+
+	var instResp InstrumentsResponse
+	if err := DecodeJSON(resp, &instResp); err != nil {
+		return nil, err
+	}
+
+	return &instResp, nil
+}
+
+// maxSuggestionDistance is the largest edit distance a symbol suggestion may have.
+const maxSuggestionDistance = 2
+
+// SuggestSymbols ranks candidates by edit distance to symbol and returns at
+// most limit of them. Ties prefer candidates sharing a prefix with symbol,
+// then alphabetical order.
+func SuggestSymbols(symbol string, candidates []string, limit int) []string {
+	symbol = strings.ToUpper(strings.TrimSpace(symbol))
+	if symbol == "" || limit <= 0 {
+		return nil
+	}
+
+	type scored struct {
+		symbol string
+		dist   int
+		prefix bool
+	}
+	// Short symbols would match almost anything at the full distance.
+	maxDist := min(maxSuggestionDistance, len(symbol)-1)
+
+	var matches []scored
+	seen := make(map[string]bool)
+	for _, c := range candidates {
+		c = strings.ToUpper(c)
+		if c == symbol || seen[c] {
+			continue
+		}
+		seen[c] = true
+
+		dist := editDistance(symbol, c)
+		if dist > maxDist {
+			continue
+		}
+		prefix := strings.HasPrefix(c, symbol) || strings.HasPrefix(symbol, c)
+		matches = append(matches, scored{symbol: c, dist: dist, prefix: prefix})
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].dist != matches[j].dist {
+			return matches[i].dist < matches[j].dist
+		}
+		if matches[i].prefix != matches[j].prefix {
+			return matches[i].prefix
+		}
+		return matches[i].symbol < matches[j].symbol
+	})
+
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	result := make([]string, len(matches))
+	for i, m := range matches {
+		result[i] = m.symbol
+	}
+	return result
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetInstruments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/userapigateway/trading/instruments", r.URL.Path)
+		assert.Equal(t, "EQUITY", r.URL.Query().Get("typeFilter"))
+		assert.Equal(t, "BUY_AND_SELL", r.URL.Query().Get("tradingFilter"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"instruments":[{"instrument":{"symbol":"AAPL","type":"EQUITY"},"trading":"BUY_AND_SELL"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	resp, err := client.GetInstruments(context.Background(), "equity", "buy_and_sell")
+	require.NoError(t, err)
+	require.Len(t, resp.Instruments, 1)
+	assert.Equal(t, "AAPL", resp.Instruments[0].Instrument.Symbol)
+}
+
+func TestClient_GetInstruments_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	_, err := client.GetInstruments(context.Background(), "", "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "API error: 500")
+}
+
+func TestSuggestSymbols(t *testing.T) {
+	candidates := []string{"AAPL", "AAP", "AMZN", "APPL", "MSFT", "aapl", "XYZ"}
+
+	assert.Equal(t, []string{"AAPL", "AAP", "APPL"}, SuggestSymbols("AAPLE", candidates, 3))
+	assert.Equal(t, []string{"AAP", "APPL"}, SuggestSymbols("aapl", candidates, 5), "exact match is excluded")
+	assert.Equal(t, []string{"MSFT"}, SuggestSymbols("MSFTT", candidates, 3))
+	assert.Empty(t, SuggestSymbols("QQQQQQ", candidates, 3))
+	assert.Empty(t, SuggestSymbols("", candidates, 3))
+}
+
+func TestEditDistance(t *testing.T) {
+	assert.Equal(t, 0, editDistance("AAPL", "AAPL"))
+	assert.Equal(t, 1, editDistance("AAPL", "AAP"))
+	assert.Equal(t, 2, editDistance("AAPL", "APLA"))
+	assert.Equal(t, 4, editDistance("", "MSFT"))
+}
@@ -55,8 +55,8 @@ func (c *Client) GetOptionExpirations(ctx context.Context, accountID, symbol str
 	}
 
 	var expResp OptionExpirationsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&expResp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := DecodeJSON(resp, &expResp); err != nil {
+		return nil, err
 	}
 
 	return &expResp, nil
@@ -89,8 +89,8 @@ func (c *Client) GetOptionChain(ctx context.Context, accountID, symbol, expirati
 	}
 
 	var chainResp OptionChainResponse
-	if err := json.NewDecoder(resp.Body).Decode(&chainResp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := DecodeJSON(resp, &chainResp); err != nil {
+		return nil, err
 	}
 
 	return &chainResp, nil
@@ -121,8 +121,8 @@ func (c *Client) GetOptionGreeks(ctx context.Context, accountID string, osiSymbo
 	}
 
 	var greeksResp GreeksResponse
-	if err := json.NewDecoder(resp.Body).Decode(&greeksResp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := DecodeJSON(resp, &greeksResp); err != nil {
+		return nil, err
 	}
 
 	return &greeksResp, nil
@@ -146,9 +146,64 @@ func (c *Client) GetInstrument(ctx context.Context, symbol, instType string) (*I
 	}
 
 	var instResp InstrumentResponse
-	if err := json.NewDecoder(resp.Body).Decode(&instResp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := DecodeJSON(resp, &instResp); err != nil {
+		return nil, err
 	}
 
 	return &instResp, nil
 }
+
+// PreflightSingleLeg estimates the cost and buying power of a single-leg
+// options order without placing it.
+func (c *Client) PreflightSingleLeg(ctx context.Context, accountID string, req OptionsPreflightRequest) (*OptionsPreflightResponse, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode preflight request: %w", err)
+	}
+
+	path := fmt.Sprintf("/userapigateway/trading/%s/preflight/single-leg", accountID)
+	resp, err := c.Post(ctx, path, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to call preflight: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != 200 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("preflight API error: %d - %s", resp.StatusCode, string(respBody))
+	}
+
+	var preflightResp OptionsPreflightResponse
+	if err := DecodeJSON(resp, &preflightResp); err != nil {
+		return nil, err
+	}
+
+	return &preflightResp, nil
+}
+
+// PlaceOptionsOrder submits a single-leg options order.
+func (c *Client) PlaceOptionsOrder(ctx context.Context, accountID string, req OptionsOrderRequest) (*OrderResponse, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	path := fmt.Sprintf("/userapigateway/trading/%s/order", accountID)
+	resp, err := c.Post(ctx, path, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to place order: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != 200 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("API error: %d - %s", resp.StatusCode, string(respBody))
+	}
+
+	var orderResp OrderResponse
+	if err := DecodeJSON(resp, &orderResp); err != nil {
+		return nil, err
+	}
+
+	return &orderResp, nil
+}
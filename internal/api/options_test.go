@@ -245,3 +245,77 @@ func TestClient_GetInstrument_CryptoType(t *testing.T) {
 	assert.Equal(t, "CRYPTO", resp.Instrument.Type)
 	assert.Equal(t, "DISABLED", resp.OptionTrading)
 }
+
+func TestClient_PreflightSingleLeg_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/userapigateway/trading/test-account/preflight/single-leg", r.URL.Path)
+
+		var req OptionsPreflightRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "AAPL250117C00175000", req.Instrument.Symbol)
+		assert.Equal(t, "OPTION", req.Instrument.Type)
+		assert.Equal(t, "OPEN", req.OpenCloseIndicator)
+
+		resp := OptionsPreflightResponse{EstimatedCost: "250.65", BuyingPowerRequirement: "250.65"}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	resp, err := client.PreflightSingleLeg(context.Background(), "test-account", OptionsPreflightRequest{
+		Instrument:         OrderInstrument{Symbol: "AAPL250117C00175000", Type: "OPTION"},
+		OrderSide:          "BUY",
+		OrderType:          "LIMIT",
+		Expiration:         OrderExpiration{TimeInForce: "DAY"},
+		Quantity:           "1",
+		LimitPrice:         "2.50",
+		OpenCloseIndicator: "OPEN",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "250.65", resp.EstimatedCost)
+}
+
+func TestClient_PreflightSingleLeg_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"message":"insufficient buying power"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	_, err := client.PreflightSingleLeg(context.Background(), "test-account", OptionsPreflightRequest{})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "preflight API error: 400")
+}
+
+func TestClient_PlaceOptionsOrder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/userapigateway/trading/test-account/order", r.URL.Path)
+
+		var req OptionsOrderRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "CLOSE", req.OpenCloseIndicator)
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(OrderResponse{OrderID: req.OrderID})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	resp, err := client.PlaceOptionsOrder(context.Background(), "test-account", OptionsOrderRequest{
+		OrderID:            "order-1",
+		Instrument:         OrderInstrument{Symbol: "AAPL250117C00175000", Type: "OPTION"},
+		OrderSide:          "SELL",
+		OrderType:          "LIMIT",
+		Quantity:           "1",
+		LimitPrice:         "2.50",
+		OpenCloseIndicator: "CLOSE",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "order-1", resp.OrderID)
+}
@@ -2,7 +2,6 @@ package api
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 )
@@ -22,8 +21,8 @@ func (c *Client) GetPortfolio(ctx context.Context, accountID string) (*Portfolio
 	}
 
 	var portfolio Portfolio
-	if err := json.NewDecoder(resp.Body).Decode(&portfolio); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := DecodeJSON(resp, &portfolio); err != nil {
+		return nil, err
 	}
 
 	return &portfolio, nil
@@ -6,6 +6,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/jonandersen/public-cli/internal/analytics"
 )
 
 // GetQuotes retrieves quotes for the given instruments.
@@ -29,9 +34,156 @@ func (c *Client) GetQuotes(ctx context.Context, accountID string, instruments []
 	}
 
 	var quotesResp QuotesResponse
-	if err := json.NewDecoder(resp.Body).Decode(&quotesResp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := DecodeJSON(resp, &quotesResp); err != nil {
+		return nil, err
 	}
 
 	return quotesResp.Quotes, nil
 }
+
+// IsHalted reports whether q says trading in its instrument is halted,
+// through either its outcome or its trading status.
+func IsHalted(q Quote) bool {
+	for _, field := range []string{q.Outcome, q.Status} {
+		if strings.Contains(strings.ToUpper(field), "HALT") {
+			return true
+		}
+	}
+	return false
+}
+
+// resolved caches ResolveInstruments results by raw input. A raw symbol
+// always resolves the same way, so entries live for the whole run.
+var resolved sync.Map // string -> QuoteInstrument
+
+// ResolveInstruments turns user-typed symbols into quote instruments, in
+// order. Each symbol is trimmed, stripped of a leading "$", and uppercased;
+// one that parses as an OSI option symbol is typed OPTION and anything else
+// is EQUITY, with class-share separators normalized (BRK-B and BRK/B become
+// BRK.B). CRYPTO is never inferred, since the quote API documents only
+// EQUITY, OPTION, and INDEX; callers opt in with InstrumentsAs. Results are
+// cached per raw input, so large watchlists and repeated refreshes resolve
+// each symbol once. It is safe for concurrent use.
+func ResolveInstruments(symbols []string) []QuoteInstrument {
+	instruments := make([]QuoteInstrument, 0, len(symbols))
+	for _, raw := range symbols {
+		if inst, ok := resolved.Load(raw); ok {
+			instruments = append(instruments, inst.(QuoteInstrument))
+			continue
+		}
+		inst := resolveInstrument(raw)
+		resolved.Store(raw, inst)
+		instruments = append(instruments, inst)
+	}
+	return instruments
+}
+
+// classShare matches a share class written with a dash or slash, e.g. BRK-B.
+var classShare = regexp.MustCompile(`^([A-Z]{1,5})[-/]([A-Z])$`)
+
+// resolveInstrument normalizes and types a single symbol, uncached.
+func resolveInstrument(raw string) QuoteInstrument {
+	symbol := normalizeSymbol(raw)
+	if _, err := analytics.ParseOSI(symbol); err == nil {
+		return QuoteInstrument{Symbol: symbol, Type: "OPTION"}
+	}
+	return QuoteInstrument{Symbol: classShareSymbol(symbol), Type: "EQUITY"}
+}
+
+// InstrumentsAs types every symbol as instType instead of inferring it, for
+// symbols the inference gets wrong, such as crypto. Symbols are
+// normalized as in ResolveInstruments: a USD pair is quoted under its base
+// for CRYPTO and class-share separators are normalized for EQUITY.
+func InstrumentsAs(symbols []string, instType string) []QuoteInstrument {
+	instruments := make([]QuoteInstrument, 0, len(symbols))
+	for _, raw := range symbols {
+		symbol := normalizeSymbol(raw)
+		switch instType {
+		case "CRYPTO":
+			if base, ok := cryptoPairBase(symbol); ok {
+				symbol = base
+			}
+		case "EQUITY":
+			symbol = classShareSymbol(symbol)
+		}
+		instruments = append(instruments, QuoteInstrument{Symbol: symbol, Type: instType})
+	}
+	return instruments
+}
+
+// normalizeSymbol trims a user-typed symbol, drops a leading "$", and
+// uppercases it.
+func normalizeSymbol(raw string) string {
+	return strings.ToUpper(strings.TrimPrefix(strings.TrimSpace(raw), "$"))
+}
+
+// cryptoPairBase returns the base of a USD pair such as BTC-USD or ETH/USD.
+func cryptoPairBase(symbol string) (string, bool) {
+	for _, sep := range []string{"-", "/"} {
+		if base, ok := strings.CutSuffix(symbol, sep+"USD"); ok && base != "" {
+			return base, true
+		}
+	}
+	return "", false
+}
+
+// classShareSymbol writes a class share with a dot: BRK-B and BRK/B become
+// BRK.B. Other symbols are returned unchanged.
+func classShareSymbol(symbol string) string {
+	if m := classShare.FindStringSubmatch(symbol); m != nil {
+		return m[1] + "." + m[2]
+	}
+	return symbol
+}
+
+// maxQuotesPerRequest caps the instruments sent in one quotes request, so a
+// large watchlist is fetched in several smaller requests.
+const maxQuotesPerRequest = 50
+
+// QuoteSymbols fetches quotes for user-typed symbols, resolving each with
+// ResolveInstruments. Duplicates are requested once and large lists are
+// split into requests of at most maxQuotesPerRequest instruments. The result
+// is keyed by the symbols as passed in, so "brk-b" finds the quote for
+// BRK.B; symbols the API returned nothing for are absent.
+func (c *Client) QuoteSymbols(ctx context.Context, accountID string, symbols []string) (map[string]Quote, error) {
+	return c.QuoteInstruments(ctx, accountID, symbols, ResolveInstruments(symbols))
+}
+
+// QuoteInstruments is QuoteSymbols with the instruments already resolved:
+// resolved[i] is the instrument quoted for symbols[i].
+func (c *Client) QuoteInstruments(ctx context.Context, accountID string, symbols []string, resolved []QuoteInstrument) (map[string]Quote, error) {
+	var unique []QuoteInstrument
+	seen := make(map[QuoteInstrument]bool)
+	for _, inst := range resolved {
+		if !seen[inst] {
+			seen[inst] = true
+			unique = append(unique, inst)
+		}
+	}
+
+	// The API may report a finer type than was requested (ETF for EQUITY),
+	// so quotes are matched by symbol when the exact instrument is missing.
+	byInstrument := make(map[QuoteInstrument]Quote, len(unique))
+	bySymbol := make(map[string]Quote, len(unique))
+	for start := 0; start < len(unique); start += maxQuotesPerRequest {
+		chunk := unique[start:min(start+maxQuotesPerRequest, len(unique))]
+		quotes, err := c.GetQuotes(ctx, accountID, chunk)
+		if err != nil {
+			return nil, err
+		}
+		for _, q := range quotes {
+			byInstrument[QuoteInstrument{Symbol: q.Instrument.Symbol, Type: q.Instrument.Type}] = q
+			bySymbol[q.Instrument.Symbol] = q
+		}
+	}
+
+	result := make(map[string]Quote, len(symbols))
+	for i, inst := range resolved {
+		if q, ok := byInstrument[inst]; ok {
+			result[symbols[i]] = q
+		} else if q, ok := bySymbol[inst.Symbol]; ok {
+			result[symbols[i]] = q
+		}
+	}
+	return result, nil
+}
@@ -3,9 +3,11 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -227,3 +229,142 @@ func TestClient_GetQuotes_InvalidJSON(t *testing.T) {
 	assert.Contains(t, err.Error(), "failed to decode response")
 	assert.Nil(t, quotes)
 }
+
+func TestResolveInstruments(t *testing.T) {
+	got := ResolveInstruments([]string{"aapl", " $msft ", "AAPL250117C00175000", "spy250321p00500000", "BRK.B"})
+	assert.Equal(t, []QuoteInstrument{
+		{Symbol: "AAPL", Type: "EQUITY"},
+		{Symbol: "MSFT", Type: "EQUITY"},
+		{Symbol: "AAPL250117C00175000", Type: "OPTION"},
+		{Symbol: "SPY250321P00500000", Type: "OPTION"},
+		{Symbol: "BRK.B", Type: "EQUITY"},
+	}, got)
+
+	cached, ok := resolved.Load(" $msft ")
+	require.True(t, ok)
+	assert.Equal(t, QuoteInstrument{Symbol: "MSFT", Type: "EQUITY"}, cached)
+
+	assert.Empty(t, ResolveInstruments(nil))
+}
+
+func TestResolveInstruments_Inference(t *testing.T) {
+	// Crypto pairs are not inferred; they need InstrumentsAs CRYPTO
+	got := ResolveInstruments([]string{"btc-usd", "ETH/USD", "BTC", "ABC-USD", "brk-b", "BRK/A"})
+	assert.Equal(t, []QuoteInstrument{
+		{Symbol: "BTC-USD", Type: "EQUITY"},
+		{Symbol: "ETH/USD", Type: "EQUITY"},
+		{Symbol: "BTC", Type: "EQUITY"},
+		{Symbol: "ABC-USD", Type: "EQUITY"},
+		{Symbol: "BRK.B", Type: "EQUITY"},
+		{Symbol: "BRK.A", Type: "EQUITY"},
+	}, got)
+}
+
+func TestInstrumentsAs(t *testing.T) {
+	assert.Equal(t, []QuoteInstrument{
+		{Symbol: "BTC", Type: "CRYPTO"},
+		{Symbol: "ETH", Type: "CRYPTO"},
+		{Symbol: "SOL", Type: "CRYPTO"},
+	}, InstrumentsAs([]string{"btc", "ETH-USD", " $sol/usd "}, "CRYPTO"))
+
+	assert.Equal(t, []QuoteInstrument{
+		{Symbol: "BRK.B", Type: "EQUITY"},
+		{Symbol: "BTC-USD", Type: "EQUITY"},
+	}, InstrumentsAs([]string{"brk-b", "BTC-USD"}, "EQUITY"))
+}
+
+func TestClient_QuoteSymbols(t *testing.T) {
+	var requests [][]QuoteInstrument
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req QuoteRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		mu.Lock()
+		requests = append(requests, req.Instruments)
+		mu.Unlock()
+
+		var resp QuotesResponse
+		for _, inst := range req.Instruments {
+			if inst.Symbol == "SPY" {
+				inst.Type = "ETF" // the API reports a finer type than requested
+			}
+			resp.Quotes = append(resp.Quotes, Quote{Instrument: inst, Outcome: "SUCCESS", Last: "1.00"})
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "token")
+	quotes, err := client.QuoteSymbols(context.Background(), "acc", []string{"aapl", "AAPL", "brk-b", "btc-usd", "SPY"})
+	require.NoError(t, err)
+
+	require.Len(t, requests, 1)
+	assert.Len(t, requests[0], 4, "duplicates are requested once")
+	assert.Len(t, quotes, 5)
+	assert.Equal(t, "AAPL", quotes["aapl"].Instrument.Symbol)
+	assert.Equal(t, quotes["aapl"], quotes["AAPL"])
+	assert.Equal(t, "BRK.B", quotes["brk-b"].Instrument.Symbol)
+	assert.Equal(t, QuoteInstrument{Symbol: "BTC-USD", Type: "EQUITY"}, quotes["btc-usd"].Instrument)
+	assert.Equal(t, "ETF", quotes["SPY"].Instrument.Type)
+}
+
+func TestClient_QuoteSymbols_Chunks(t *testing.T) {
+	var sizes []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req QuoteRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		sizes = append(sizes, len(req.Instruments))
+		var resp QuotesResponse
+		for _, inst := range req.Instruments {
+			resp.Quotes = append(resp.Quotes, Quote{Instrument: inst, Outcome: "SUCCESS"})
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	symbols := make([]string, 2*maxQuotesPerRequest+1)
+	for i := range symbols {
+		symbols[i] = fmt.Sprintf("SYM%d", i)
+	}
+	quotes, err := NewClient(server.URL, "token").QuoteSymbols(context.Background(), "acc", symbols)
+	require.NoError(t, err)
+
+	assert.Equal(t, []int{maxQuotesPerRequest, maxQuotesPerRequest, 1}, sizes)
+	assert.Len(t, quotes, len(symbols))
+}
+
+func TestClient_QuoteSymbols_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("unauthorized"))
+	}))
+	defer server.Close()
+
+	quotes, err := NewClient(server.URL, "token").QuoteSymbols(context.Background(), "acc", []string{"AAPL"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "API error: 401")
+	assert.Nil(t, quotes)
+}
+
+func TestResolveInstruments_Concurrent(t *testing.T) {
+	symbols := []string{"aapl", "goog", "TSLA250117C00400000"}
+	want := ResolveInstruments(symbols)
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Equal(t, want, ResolveInstruments(symbols))
+		}()
+	}
+	wg.Wait()
+}
+
+func TestIsHalted(t *testing.T) {
+	assert.False(t, IsHalted(Quote{Outcome: "SUCCESS"}))
+	assert.False(t, IsHalted(Quote{Outcome: "FAILURE"}))
+	assert.True(t, IsHalted(Quote{Outcome: "SUCCESS", Status: "HALTED"}))
+	assert.True(t, IsHalted(Quote{Outcome: "SUCCESS", Status: "trading_halt"}))
+	assert.True(t, IsHalted(Quote{Outcome: "HALTED"}))
+}
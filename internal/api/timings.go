@@ -0,0 +1,133 @@
+package api
+
+import (
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RequestTimings, when non-nil, records the duration of every request made by
+// any Client. It is nil unless --timings is set, so recording costs a single
+// nil check otherwise.
+var RequestTimings *Timings
+
+// Timings collects per-request durations. It is safe for concurrent use, as
+// several commands fire requests in parallel.
+type Timings struct {
+	mu    sync.Mutex
+	calls []Timing
+}
+
+// Timing is one recorded request. Duration runs until the response headers
+// arrive, which is what the API's latency is made of; body reads are not
+// included.
+type Timing struct {
+	Endpoint string
+	Status   int
+	Duration time.Duration
+}
+
+// EndpointTiming aggregates the requests made to one endpoint.
+type EndpointTiming struct {
+	Endpoint string  `json:"endpoint"`
+	Count    int     `json:"count"`
+	TotalMs  float64 `json:"totalMs"`
+	MaxMs    float64 `json:"maxMs"`
+}
+
+// TimingsSummary is the per-command timing report.
+type TimingsSummary struct {
+	Calls     int              `json:"calls"`
+	TotalMs   float64          `json:"totalMs"`
+	Endpoints []EndpointTiming `json:"endpoints"`
+}
+
+// Calls returns a copy of the recorded requests in the order they completed.
+func (t *Timings) Calls() []Timing {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]Timing(nil), t.calls...)
+}
+
+// Summary groups the recorded requests by endpoint, slowest total first.
+func (t *Timings) Summary() TimingsSummary {
+	calls := t.Calls()
+
+	summary := TimingsSummary{Calls: len(calls), Endpoints: []EndpointTiming{}}
+	byEndpoint := make(map[string]*EndpointTiming)
+	for _, c := range calls {
+		ms := durationMs(c.Duration)
+		summary.TotalMs += ms
+		e, ok := byEndpoint[c.Endpoint]
+		if !ok {
+			e = &EndpointTiming{Endpoint: c.Endpoint}
+			byEndpoint[c.Endpoint] = e
+		}
+		e.Count++
+		e.TotalMs += ms
+		if ms > e.MaxMs {
+			e.MaxMs = ms
+		}
+	}
+	for _, e := range byEndpoint {
+		summary.Endpoints = append(summary.Endpoints, *e)
+	}
+	sort.Slice(summary.Endpoints, func(i, j int) bool {
+		a, b := summary.Endpoints[i], summary.Endpoints[j]
+		if a.TotalMs != b.TotalMs {
+			return a.TotalMs > b.TotalMs
+		}
+		return a.Endpoint < b.Endpoint
+	})
+	return summary
+}
+
+func (t *Timings) record(c Timing) {
+	t.mu.Lock()
+	t.calls = append(t.calls, c)
+	t.mu.Unlock()
+}
+
+func durationMs(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+// idSegment matches path segments that identify a resource, such as account
+// and order IDs, so requests to the same endpoint are grouped together.
+var idSegment = regexp.MustCompile(`^([0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}|[0-9]+)$`)
+
+// timingEndpoint names the endpoint of req as "METHOD /path", with ID
+// segments replaced by {id} and the query dropped.
+func timingEndpoint(req *http.Request) string {
+	segments := strings.Split(req.URL.Path, "/")
+	for i, s := range segments {
+		if idSegment.MatchString(s) {
+			segments[i] = "{id}"
+		}
+	}
+	return req.Method + " " + strings.Join(segments, "/")
+}
+
+// timingTransport records each round trip into RequestTimings.
+type timingTransport struct {
+	base http.RoundTripper
+}
+
+func (t timingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	timings := RequestTimings
+	if timings == nil {
+		return t.base.RoundTrip(req)
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	c := Timing{Endpoint: timingEndpoint(req), Duration: time.Since(start)}
+	if resp != nil {
+		c.Status = resp.StatusCode
+	}
+	timings.record(c)
+	return resp, err
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimingTransport_RecordsRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	RequestTimings = &Timings{}
+	defer func() { RequestTimings = nil }()
+
+	client := NewClient(server.URL, "test-token")
+	for _, path := range []string{
+		"/trading/12345678-1234-1234-1234-123456789012/order/912710f1-1a45-4ef0-88a7-cd513781933d",
+		"/trading/12345678-1234-1234-1234-123456789012/order/00000000-0000-0000-0000-000000000001?x=1",
+		"/missing",
+	} {
+		resp, err := client.Get(context.Background(), path)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+	}
+
+	calls := RequestTimings.Calls()
+	require.Len(t, calls, 3)
+	assert.Equal(t, "GET /trading/{id}/order/{id}", calls[0].Endpoint)
+	assert.Equal(t, http.StatusOK, calls[0].Status)
+	assert.Equal(t, http.StatusNotFound, calls[2].Status)
+
+	summary := RequestTimings.Summary()
+	assert.Equal(t, 3, summary.Calls)
+	require.Len(t, summary.Endpoints, 2)
+	counts := map[string]int{}
+	for _, e := range summary.Endpoints {
+		counts[e.Endpoint] = e.Count
+	}
+	assert.Equal(t, map[string]int{"GET /trading/{id}/order/{id}": 2, "GET /missing": 1}, counts)
+}
+
+func TestTimingTransport_DisabledRecordsNothing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	RequestTimings = nil
+
+	resp, err := NewClient(server.URL, "test-token").Get(context.Background(), "/quotes")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Nil(t, RequestTimings)
+}
+
+func TestTimingsSummary_SortsByTotal(t *testing.T) {
+	timings := &Timings{}
+	timings.record(Timing{Endpoint: "GET /fast", Duration: 5 * time.Millisecond})
+	timings.record(Timing{Endpoint: "GET /slow", Duration: 40 * time.Millisecond})
+	timings.record(Timing{Endpoint: "GET /fast", Duration: 10 * time.Millisecond})
+
+	summary := timings.Summary()
+
+	assert.Equal(t, 3, summary.Calls)
+	assert.Equal(t, 55.0, summary.TotalMs)
+	assert.Equal(t, []EndpointTiming{
+		{Endpoint: "GET /slow", Count: 1, TotalMs: 40, MaxMs: 40},
+		{Endpoint: "GET /fast", Count: 2, TotalMs: 15, MaxMs: 10},
+	}, summary.Endpoints)
+}
+
+func TestTimingsSummary_Empty(t *testing.T) {
+	summary := (&Timings{}).Summary()
+	assert.Equal(t, 0, summary.Calls)
+	assert.NotNil(t, summary.Endpoints)
+}
@@ -2,11 +2,19 @@ package auth
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"time"
+
+	"github.com/jonandersen/public-cli/internal/config"
 )
 
+// ErrInvalidCache is returned by LoadToken when the cache file exists but does
+// not hold a usable token, e.g. after a partial write or a format change.
+var ErrInvalidCache = errors.New("invalid token cache")
+
 // tokenCache is the JSON structure for the cached token file.
 type tokenCache struct {
 	AccessToken string `json:"access_token"`
@@ -41,7 +49,8 @@ func SaveToken(path string, token *Token) error {
 }
 
 // LoadToken reads a token from the cache file.
-// Returns an error if the file doesn't exist or contains invalid JSON.
+// Returns an error if the file doesn't exist, and ErrInvalidCache if it
+// contains invalid JSON or no access token.
 func LoadToken(path string) (*Token, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -50,7 +59,10 @@ func LoadToken(path string) (*Token, error) {
 
 	var cache tokenCache
 	if err := json.Unmarshal(data, &cache); err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCache, err)
+	}
+	if cache.AccessToken == "" {
+		return nil, fmt.Errorf("%w: no access token", ErrInvalidCache)
 	}
 
 	return &Token{
@@ -69,15 +81,7 @@ func DeleteToken(path string) error {
 	return err
 }
 
-// TokenCachePath returns the path to the token cache file.
-// Uses XDG_CONFIG_HOME if set, otherwise ~/.config/pub.
+// TokenCachePath returns the path to the token cache file in config.ConfigDir.
 func TokenCachePath() string {
-	var configDir string
-	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
-		configDir = filepath.Join(xdg, "pub")
-	} else {
-		home, _ := os.UserHomeDir()
-		configDir = filepath.Join(home, ".config", "pub")
-	}
-	return filepath.Join(configDir, ".token_cache")
+	return filepath.Join(config.ConfigDir(), ".token_cache")
 }
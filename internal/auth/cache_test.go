@@ -8,6 +8,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/jonandersen/public-cli/internal/config"
 )
 
 func TestToken_IsValid(t *testing.T) {
@@ -135,7 +137,17 @@ func TestLoadToken_InvalidJSON(t *testing.T) {
 	token, err := LoadToken(cachePath)
 
 	assert.Nil(t, token)
-	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrInvalidCache)
+}
+
+func TestLoadToken_NoAccessToken(t *testing.T) {
+	cachePath := filepath.Join(t.TempDir(), ".token_cache")
+	require.NoError(t, os.WriteFile(cachePath, []byte(`{"expires_at":9999999999}`), 0600))
+
+	token, err := LoadToken(cachePath)
+
+	assert.Nil(t, token)
+	assert.ErrorIs(t, err, ErrInvalidCache)
 }
 
 func TestLoadToken_EmptyFile(t *testing.T) {
@@ -166,8 +178,8 @@ func TestTokenCachePath(t *testing.T) {
 	t.Run("without XDG_CONFIG_HOME", func(t *testing.T) {
 		_ = os.Unsetenv("XDG_CONFIG_HOME")
 		path := TokenCachePath()
-		home, _ := os.UserHomeDir()
-		assert.Equal(t, filepath.Join(home, ".config", "pub", ".token_cache"), path)
+		// Shares the config directory with config.yaml on every platform
+		assert.Equal(t, filepath.Join(config.ConfigDir(), ".token_cache"), path)
 	})
 }
 
@@ -2,9 +2,16 @@ package auth
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
 	"os"
 )
 
+// CacheWarnings receives a warning when an unreadable token cache is
+// discarded. It is nil (silent) unless --verbose is set.
+var CacheWarnings io.Writer
+
 // GetToken returns a valid access token, refreshing if necessary.
 // It first tries to load a cached token. If the cached token is valid,
 // it returns immediately. If the token is expired, missing, or corrupted,
@@ -23,6 +30,15 @@ func GetTokenWithRefresh(ctx context.Context, cachePath, baseURL, secretKey stri
 		if err == nil && token.IsValid() {
 			return token, nil
 		}
+		if errors.Is(err, ErrInvalidCache) {
+			// Drop the bad cache so it cannot fail again; a fresh exchange
+			// replaces it. Other read errors, such as permissions, leave the
+			// file alone: it may be fine once they are fixed.
+			if CacheWarnings != nil {
+				_, _ = fmt.Fprintf(CacheWarnings, "Warning: discarding unreadable token cache %s: %v\n", cachePath, err)
+			}
+			_ = DeleteToken(cachePath)
+		}
 	}
 
 	// Token missing, expired, corrupted, or force refresh - exchange for new one
@@ -1,8 +1,10 @@
 package auth
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -141,6 +143,85 @@ func TestGetToken_CorruptedCache(t *testing.T) {
 	assert.Equal(t, "recovered-token", token.AccessToken)
 }
 
+func TestGetToken_GarbageCacheIsDiscarded(t *testing.T) {
+	tests := []struct {
+		name  string
+		cache string
+	}{
+		{"binary garbage", "\x00\xff{\"access_tok"},
+		{"unknown format", fmt.Sprintf(`{"token":"old","expires_at":%d}`, time.Now().Unix()+3600)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cachePath := filepath.Join(t.TempDir(), ".token_cache")
+			require.NoError(t, os.WriteFile(cachePath, []byte(tt.cache), 0600))
+
+			var exchanges int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				exchanges++
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(TokenResponse{AccessToken: "fresh-token"})
+			}))
+			defer server.Close()
+
+			var warnings bytes.Buffer
+			CacheWarnings = &warnings
+			defer func() { CacheWarnings = nil }()
+
+			token, err := GetToken(context.Background(), cachePath, server.URL, "secret-key")
+			require.NoError(t, err)
+			assert.Equal(t, "fresh-token", token.AccessToken)
+			assert.Contains(t, warnings.String(), "discarding unreadable token cache")
+
+			// The re-exchanged token replaced the bad cache
+			cached, err := LoadToken(cachePath)
+			require.NoError(t, err)
+			assert.Equal(t, "fresh-token", cached.AccessToken)
+
+			_, err = GetToken(context.Background(), cachePath, server.URL, "secret-key")
+			require.NoError(t, err)
+			assert.Equal(t, 1, exchanges)
+		})
+	}
+}
+
+func TestGetToken_UnreadableCacheIsKept(t *testing.T) {
+	// A read error that is not corruption, here a directory in the cache's
+	// place, must not delete anything
+	cachePath := filepath.Join(t.TempDir(), ".token_cache")
+	require.NoError(t, os.MkdirAll(cachePath, 0700))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(TokenResponse{AccessToken: "fresh-token"})
+	}))
+	defer server.Close()
+
+	token, err := GetToken(context.Background(), cachePath, server.URL, "secret-key")
+	require.NoError(t, err)
+	assert.Equal(t, "fresh-token", token.AccessToken)
+	assert.DirExists(t, cachePath)
+}
+
+func TestGetToken_MissingCacheDoesNotWarn(t *testing.T) {
+	cachePath := filepath.Join(t.TempDir(), ".token_cache")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(TokenResponse{AccessToken: "fresh-token"})
+	}))
+	defer server.Close()
+
+	var warnings bytes.Buffer
+	CacheWarnings = &warnings
+	defer func() { CacheWarnings = nil }()
+
+	_, err := GetToken(context.Background(), cachePath, server.URL, "secret-key")
+	require.NoError(t, err)
+	assert.Empty(t, warnings.String())
+}
+
 func TestGetToken_ContextCancellation(t *testing.T) {
 	tmpDir := t.TempDir()
 	cachePath := filepath.Join(tmpDir, ".token_cache")
@@ -7,6 +7,9 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
@@ -17,6 +20,10 @@ var uuidRegex = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4
 const (
 	DefaultAPIBaseURL           = "https://api.public.com"
 	DefaultTokenValidityMinutes = 60
+
+	// DefaultFXRateURL is the exchange rate source used by
+	// 'pub account portfolio --currency' when fx_rate_url is not set.
+	DefaultFXRateURL = "https://api.frankfurter.app/latest?from=USD&to={currency}"
 )
 
 // Config holds the CLI configuration.
@@ -24,20 +31,124 @@ type Config struct {
 	AccountUUID          string `yaml:"account_uuid"`
 	APIBaseURL           string `yaml:"api_base_url"`
 	TokenValidityMinutes int    `yaml:"token_validity_minutes"`
-	TradingEnabled       bool   `yaml:"trading_enabled"`
+
+	// Trading is whether orders may be placed and how they are confirmed.
+	// The key keeps its old name; true and false still load as yolo and
+	// disabled.
+	Trading TradingMode `yaml:"trading_enabled"`
+
+	// MaxShares rejects equity orders for more shares than this unless
+	// --force is passed; 0 disables the cap.
+	MaxShares float64 `yaml:"max_shares,omitempty"`
+
+	// MaxContracts rejects options orders for more contracts than this, per
+	// leg or in total, unless --force is passed; 0 disables the cap.
+	MaxContracts int `yaml:"max_contracts,omitempty"`
+
+	// FXRateURL is where portfolio --currency fetches USD exchange rates;
+	// {currency} is replaced with the target code. Empty uses
+	// DefaultFXRateURL.
+	FXRateURL string `yaml:"fx_rate_url,omitempty"`
+
+	// RequireReason rejects orders placed without a --reason, so every trade
+	// in the journal says why it was made.
+	RequireReason bool `yaml:"require_reason,omitempty"`
+
+	// RefreshIntervalSeconds is how often 'pub ui' refreshes its data; 0
+	// uses the 30 second default.
+	RefreshIntervalSeconds int `yaml:"refresh_interval_seconds,omitempty"`
+
+	// ConfirmPrompt replaces the "Place this order" question asked before
+	// an order is placed when trading_enabled is confirm. Empty keeps the
+	// default.
+	ConfirmPrompt string `yaml:"confirm_prompt,omitempty"`
+}
+
+// TradingMode is the trading_enabled setting, from no trading at all to
+// orders placed with --yes alone.
+type TradingMode string
+
+const (
+	// TradingDisabled blocks every order and cancel command. An empty
+	// TradingMode is read the same way.
+	TradingDisabled TradingMode = "disabled"
+	// TradingConfirm allows trading but asks for every order and cancel at
+	// an interactive prompt, even with --yes.
+	TradingConfirm TradingMode = "confirm"
+	// TradingYolo allows trading and lets --yes skip the prompt.
+	TradingYolo TradingMode = "yolo"
+)
+
+// ParseTradingMode parses a trading_enabled value: disabled, confirm, or
+// yolo, or a boolean from older configs (true is yolo, false is disabled).
+func ParseTradingMode(value string) (TradingMode, error) {
+	switch mode := TradingMode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case TradingDisabled, TradingConfirm, TradingYolo:
+		return mode, nil
+	}
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return "", fmt.Errorf("trading_enabled must be disabled, confirm, or yolo (true and false also work), got %q", value)
+	}
+	if enabled {
+		return TradingYolo, nil
+	}
+	return TradingDisabled, nil
+}
+
+// Enabled reports whether orders may be placed at all.
+func (m TradingMode) Enabled() bool {
+	return m == TradingConfirm || m == TradingYolo
+}
+
+// String returns the mode as written in the config; empty is disabled.
+func (m TradingMode) String() string {
+	if m == "" {
+		return string(TradingDisabled)
+	}
+	return string(m)
+}
+
+// UnmarshalYAML reads a mode name or an old boolean. A blank value leaves
+// the mode empty, which is disabled.
+func (m *TradingMode) UnmarshalYAML(node *yaml.Node) error {
+	if node.Value == "" {
+		*m = ""
+		return nil
+	}
+	mode, err := ParseTradingMode(node.Value)
+	if err != nil {
+		return err
+	}
+	*m = mode
+	return nil
+}
+
+// MarshalYAML writes the mode name, so an empty mode is saved as disabled.
+func (m TradingMode) MarshalYAML() (any, error) {
+	return m.String(), nil
 }
 
 // ErrTradingDisabled is returned when a trading operation is attempted but trading is disabled.
 var ErrTradingDisabled = fmt.Errorf("trading is disabled - run 'pub configure' and enable trading to place orders")
 
+// TradingEnabled reports whether orders may be placed.
+func (c *Config) TradingEnabled() bool {
+	return c.Trading.Enabled()
+}
+
 // CheckTrading returns ErrTradingDisabled if trading is not enabled.
 func (c *Config) CheckTrading() error {
-	if !c.TradingEnabled {
+	if !c.TradingEnabled() {
 		return ErrTradingDisabled
 	}
 	return nil
 }
 
+// ErrReasonRequired is returned when an order is placed without a --reason
+// while require_reason is set.
+var ErrReasonRequired = fmt.Errorf("a --reason is required for every order - require_reason is set in config")
+
 // DefaultConfig returns a Config with default values.
 func DefaultConfig() *Config {
 	return &Config{
@@ -73,6 +184,23 @@ func (c *Config) Validate() error {
 		errs = append(errs, fmt.Errorf("token_validity_minutes must be positive"))
 	}
 
+	if c.MaxShares < 0 {
+		errs = append(errs, fmt.Errorf("max_shares must not be negative"))
+	}
+	if c.MaxContracts < 0 {
+		errs = append(errs, fmt.Errorf("max_contracts must not be negative"))
+	}
+	if c.RefreshIntervalSeconds < 0 {
+		errs = append(errs, fmt.Errorf("refresh_interval_seconds must not be negative"))
+	}
+
+	if c.FXRateURL != "" {
+		parsed, err := url.Parse(c.FXRateURL)
+		if err != nil || parsed.Host == "" || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+			errs = append(errs, fmt.Errorf("fx_rate_url must be a valid http or https URL"))
+		}
+	}
+
 	return errors.Join(errs...)
 }
 
@@ -120,14 +248,48 @@ func Save(path string, cfg *Config) error {
 	return os.WriteFile(path, data, 0600)
 }
 
-// ConfigDir returns the configuration directory path.
-// Uses XDG_CONFIG_HOME if set, otherwise ~/.config/pub.
+// ConfigDir returns the configuration directory path. Every file the CLI
+// keeps - config.yaml, ui.yaml, the token cache, and portfolio snapshots -
+// lives here so setting one env var moves all of them.
+//
+// XDG_CONFIG_HOME wins on every platform. Otherwise the directory is
+// %APPDATA%\pub on Windows and ~/Library/Application Support/pub on macOS,
+// unless ~/.config/pub already exists from an older version, and
+// ~/.config/pub elsewhere.
 func ConfigDir() string {
-	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+	home, _ := os.UserHomeDir()
+	return resolveConfigDir(runtime.GOOS, os.Getenv, home, dirExists)
+}
+
+// resolveConfigDir implements ConfigDir with the platform, environment, and
+// filesystem injected so each OS can be tested anywhere.
+func resolveConfigDir(goos string, getenv func(string) string, home string, exists func(string) bool) string {
+	if xdg := getenv("XDG_CONFIG_HOME"); xdg != "" {
 		return filepath.Join(xdg, "pub")
 	}
-	home, _ := os.UserHomeDir()
-	return filepath.Join(home, ".config", "pub")
+
+	legacy := filepath.Join(home, ".config", "pub")
+	switch goos {
+	case "windows":
+		if exists(legacy) {
+			return legacy
+		}
+		if appData := getenv("APPDATA"); appData != "" {
+			return filepath.Join(appData, "pub")
+		}
+		return filepath.Join(home, "AppData", "Roaming", "pub")
+	case "darwin":
+		if exists(legacy) {
+			return legacy
+		}
+		return filepath.Join(home, "Library", "Application Support", "pub")
+	}
+	return legacy
+}
+
+func dirExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
 }
 
 // ConfigPath returns the full path to the config file.
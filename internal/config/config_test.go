@@ -3,6 +3,8 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
 )
 
@@ -19,6 +21,9 @@ func TestLoad_NonExistent(t *testing.T) {
 	if cfg.TokenValidityMinutes != DefaultTokenValidityMinutes {
 		t.Errorf("TokenValidityMinutes = %d, want %d", cfg.TokenValidityMinutes, DefaultTokenValidityMinutes)
 	}
+	if cfg.TradingEnabled() {
+		t.Error("TradingEnabled() = true without a config file, want false")
+	}
 }
 
 func TestLoad_ValidConfig(t *testing.T) {
@@ -162,8 +167,8 @@ func TestDefaultConfig(t *testing.T) {
 	if cfg.AccountUUID != "" {
 		t.Errorf("AccountUUID = %q, want empty", cfg.AccountUUID)
 	}
-	if cfg.TradingEnabled != false {
-		t.Errorf("TradingEnabled = %v, want false (safe default)", cfg.TradingEnabled)
+	if cfg.TradingEnabled() {
+		t.Errorf("TradingEnabled = %v, want false (safe default)", cfg.TradingEnabled())
 	}
 }
 
@@ -181,7 +186,7 @@ func TestCheckTrading_Disabled(t *testing.T) {
 
 func TestCheckTrading_Enabled(t *testing.T) {
 	cfg := DefaultConfig()
-	cfg.TradingEnabled = true
+	cfg.Trading = TradingYolo
 
 	err := cfg.CheckTrading()
 	if err != nil {
@@ -204,7 +209,7 @@ func TestLoad_TradingEnabled(t *testing.T) {
 		t.Fatalf("Load() error = %v, want nil", err)
 	}
 
-	if !cfg.TradingEnabled {
+	if !cfg.TradingEnabled() {
 		t.Error("TradingEnabled = false, want true")
 	}
 }
@@ -216,7 +221,7 @@ func TestSave_TradingEnabled(t *testing.T) {
 	cfg := &Config{
 		APIBaseURL:           DefaultAPIBaseURL,
 		TokenValidityMinutes: DefaultTokenValidityMinutes,
-		TradingEnabled:       true,
+		Trading:              TradingYolo,
 	}
 
 	if err := Save(configPath, cfg); err != nil {
@@ -228,7 +233,7 @@ func TestSave_TradingEnabled(t *testing.T) {
 		t.Fatalf("Load() after Save() error = %v", err)
 	}
 
-	if !loaded.TradingEnabled {
+	if !loaded.TradingEnabled() {
 		t.Error("TradingEnabled = false after load, want true")
 	}
 }
@@ -244,6 +249,9 @@ func TestConfigDir_WithXDG(t *testing.T) {
 }
 
 func TestConfigDir_WithoutXDG(t *testing.T) {
+	if runtime.GOOS == "windows" || runtime.GOOS == "darwin" {
+		t.Skip("platform defaults are covered by TestResolveConfigDir")
+	}
 	t.Setenv("XDG_CONFIG_HOME", "")
 	dir := ConfigDir()
 
@@ -254,6 +262,42 @@ func TestConfigDir_WithoutXDG(t *testing.T) {
 	}
 }
 
+func TestResolveConfigDir(t *testing.T) {
+	home := filepath.Join("/home", "ana")
+	env := func(vars map[string]string) func(string) string {
+		return func(key string) string { return vars[key] }
+	}
+	none := func(string) bool { return false }
+	legacyExists := func(path string) bool { return path == filepath.Join(home, ".config", "pub") }
+
+	tests := []struct {
+		name   string
+		goos   string
+		env    map[string]string
+		exists func(string) bool
+		want   string
+	}{
+		{"linux default", "linux", nil, none, filepath.Join(home, ".config", "pub")},
+		{"linux xdg", "linux", map[string]string{"XDG_CONFIG_HOME": "/xdg"}, none, filepath.Join("/xdg", "pub")},
+		{"windows appdata", "windows", map[string]string{"APPDATA": "/appdata"}, none, filepath.Join("/appdata", "pub")},
+		{"windows without appdata", "windows", nil, none, filepath.Join(home, "AppData", "Roaming", "pub")},
+		{"windows legacy dir", "windows", map[string]string{"APPDATA": "/appdata"}, legacyExists, filepath.Join(home, ".config", "pub")},
+		{"windows xdg wins", "windows", map[string]string{"APPDATA": "/appdata", "XDG_CONFIG_HOME": "/xdg"}, none, filepath.Join("/xdg", "pub")},
+		{"macos default", "darwin", nil, none, filepath.Join(home, "Library", "Application Support", "pub")},
+		{"macos legacy dir", "darwin", nil, legacyExists, filepath.Join(home, ".config", "pub")},
+		{"macos xdg wins", "darwin", map[string]string{"XDG_CONFIG_HOME": "/xdg"}, legacyExists, filepath.Join("/xdg", "pub")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := resolveConfigDir(tt.goos, env(tt.env), home, tt.exists)
+			if got != tt.want {
+				t.Errorf("resolveConfigDir() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestConfigPath_WithXDG(t *testing.T) {
 	t.Setenv("XDG_CONFIG_HOME", "/custom/config")
 	path := ConfigPath()
@@ -265,6 +309,9 @@ func TestConfigPath_WithXDG(t *testing.T) {
 }
 
 func TestConfigPath_WithoutXDG(t *testing.T) {
+	if runtime.GOOS == "windows" || runtime.GOOS == "darwin" {
+		t.Skip("platform defaults are covered by TestResolveConfigDir")
+	}
 	t.Setenv("XDG_CONFIG_HOME", "")
 	path := ConfigPath()
 
@@ -377,6 +424,43 @@ func TestValidate_InvalidTokenValidityMinutes(t *testing.T) {
 	}
 }
 
+func TestValidate_NegativeOrderCaps(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.MaxShares = -1
+	cfg.MaxContracts = -5
+	cfg.RefreshIntervalSeconds = -1
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("Validate() error = nil, want errors for negative caps")
+	}
+	for _, want := range []string{"max_shares", "max_contracts", "refresh_interval_seconds"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Validate() error = %q, want it to mention %s", err, want)
+		}
+	}
+
+	cfg.MaxShares, cfg.MaxContracts, cfg.RefreshIntervalSeconds = 500, 10, 60
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() error = %v, want nil", err)
+	}
+}
+
+func TestValidate_FXRateURL(t *testing.T) {
+	cfg := DefaultConfig()
+	for _, bad := range []string{"not a url", "ftp://rates.example.com", "/latest"} {
+		cfg.FXRateURL = bad
+		if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "fx_rate_url") {
+			t.Errorf("Validate() with fx_rate_url %q error = %v, want it to mention fx_rate_url", bad, err)
+		}
+	}
+
+	cfg.FXRateURL = "https://rates.example.com/latest?base=USD&symbols={currency}"
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() error = %v, want nil", err)
+	}
+}
+
 func TestValidate_MultipleErrors(t *testing.T) {
 	cfg := &Config{
 		AccountUUID:          "invalid-uuid",
@@ -404,3 +488,81 @@ func searchString(s, substr string) bool {
 	}
 	return false
 }
+
+func TestParseTradingMode(t *testing.T) {
+	tests := []struct {
+		value string
+		want  TradingMode
+	}{
+		{"disabled", TradingDisabled},
+		{"confirm", TradingConfirm},
+		{"YOLO", TradingYolo},
+		{"true", TradingYolo},
+		{"false", TradingDisabled},
+	}
+	for _, tt := range tests {
+		got, err := ParseTradingMode(tt.value)
+		if err != nil {
+			t.Errorf("ParseTradingMode(%q) error = %v", tt.value, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseTradingMode(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+
+	if _, err := ParseTradingMode("sometimes"); err == nil {
+		t.Error("ParseTradingMode(sometimes) error = nil, want an error")
+	}
+}
+
+func TestLoad_TradingMode(t *testing.T) {
+	tests := []struct {
+		content     string
+		want        TradingMode
+		wantEnabled bool
+	}{
+		{"trading_enabled: confirm\n", TradingConfirm, true},
+		{"trading_enabled: yolo\n", TradingYolo, true},
+		{"trading_enabled: false\n", TradingDisabled, false},
+		{"trading_enabled:\n", "", false},
+		{"account_uuid: \"\"\n", "", false},
+	}
+	for _, tt := range tests {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		if err := os.WriteFile(path, []byte(tt.content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		cfg, err := Load(path)
+		if err != nil {
+			t.Errorf("Load(%q) error = %v", tt.content, err)
+			continue
+		}
+		if cfg.Trading != tt.want || cfg.TradingEnabled() != tt.wantEnabled {
+			t.Errorf("Load(%q) Trading = %q (enabled %v), want %q (enabled %v)",
+				tt.content, string(cfg.Trading), cfg.TradingEnabled(), string(tt.want), tt.wantEnabled)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("trading_enabled: sometimes\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("Load() error = nil for an unknown trading mode, want an error")
+	}
+}
+
+func TestSave_TradingModeName(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := Save(path, DefaultConfig()); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "trading_enabled: disabled") {
+		t.Errorf("saved config = %q, want trading_enabled: disabled", data)
+	}
+}
@@ -0,0 +1,110 @@
+// Package marketdate parses user-supplied dates and counts days to
+// expiration on the US/Eastern market calendar, so that a date means the
+// same trading day no matter where the CLI runs.
+package marketdate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Layout is the YYYY-MM-DD format used for expirations and date flags.
+const Layout = "2006-01-02"
+
+// Eastern is the time zone US equity and option markets trade in.
+var Eastern = loadEastern()
+
+func loadEastern() *time.Location {
+	if loc, err := time.LoadLocation("America/New_York"); err == nil {
+		return loc
+	}
+	// Without tzdata, EST is right for most of the options calendar and
+	// only off by an hour around midnight during daylight saving time.
+	return time.FixedZone("EST", -5*60*60)
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "sun": time.Sunday,
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+}
+
+// Today returns midnight Eastern of the market date containing now.
+func Today(now time.Time) time.Time {
+	y, m, d := now.In(Eastern).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, Eastern)
+}
+
+// Parse parses a date relative to now and returns midnight Eastern of that
+// day. Accepted forms are YYYY-MM-DD, "today", "tomorrow", "+Nd"/"-Nd",
+// "+Nw"/"-Nw", and "next-<weekday>" (e.g. next-friday), which is the first
+// such weekday strictly after today.
+func Parse(s string, now time.Time) (time.Time, error) {
+	v := strings.ToLower(strings.TrimSpace(s))
+	today := Today(now)
+
+	switch {
+	case v == "today":
+		return today, nil
+	case v == "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	case strings.HasPrefix(v, "next-"):
+		wd, ok := weekdays[strings.TrimPrefix(v, "next-")]
+		if !ok {
+			break
+		}
+		days := (int(wd)-int(today.Weekday())+6)%7 + 1
+		return today.AddDate(0, 0, days), nil
+	case strings.HasPrefix(v, "+") || strings.HasPrefix(v, "-"):
+		if len(v) < 3 {
+			break
+		}
+		n, err := strconv.Atoi(v[1 : len(v)-1])
+		if err != nil || n < 0 {
+			break
+		}
+		if v[0] == '-' {
+			n = -n
+		}
+		switch v[len(v)-1] {
+		case 'd':
+			return today.AddDate(0, 0, n), nil
+		case 'w':
+			return today.AddDate(0, 0, 7*n), nil
+		}
+	default:
+		if t, err := time.ParseInLocation(Layout, v, Eastern); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q: expected YYYY-MM-DD, today, tomorrow, +Nd, or next-<weekday>", s)
+}
+
+// Normalize parses s like Parse and formats the result as YYYY-MM-DD.
+func Normalize(s string, now time.Time) (string, error) {
+	t, err := Parse(s, now)
+	if err != nil {
+		return "", err
+	}
+	return t.Format(Layout), nil
+}
+
+// DaysUntil returns the number of calendar days from the Eastern market
+// date of now until the YYYY-MM-DD expiration. Counting whole dates rather
+// than elapsed hours keeps the result stable across the day and across
+// daylight saving changes. It returns 0 if expiration cannot be parsed.
+func DaysUntil(expiration string, now time.Time) int {
+	exp, err := time.Parse(Layout, expiration)
+	if err != nil {
+		return 0
+	}
+	y, m, d := now.In(Eastern).Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	return int(exp.Sub(today).Hours() / 24)
+}
@@ -0,0 +1,93 @@
+package marketdate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	// Wednesday 2025-01-15, 10:00 Eastern.
+	now := time.Date(2025, 1, 15, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"2025-03-21", "2025-03-21"},
+		{"today", "2025-01-15"},
+		{"TODAY", "2025-01-15"},
+		{"tomorrow", "2025-01-16"},
+		{"+30d", "2025-02-14"},
+		{"-1d", "2025-01-14"},
+		{"+2w", "2025-01-29"},
+		{"next-friday", "2025-01-17"},
+		{"next-fri", "2025-01-17"},
+		{"next-wednesday", "2025-01-22"},
+		{"next-monday", "2025-01-20"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := Parse(tt.in, now)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.Format(Layout))
+			assert.Equal(t, Eastern, got.Location())
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	now := time.Date(2025, 1, 15, 15, 0, 0, 0, time.UTC)
+	for _, in := range []string{"", "01/17/2025", "2025-13-01", "next-someday", "+d", "+3x", "+-3d", "yesterday"} {
+		_, err := Parse(in, now)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestParse_UsesEasternDate(t *testing.T) {
+	// 02:30 UTC on the 16th is still the evening of the 15th in New York.
+	now := time.Date(2025, 1, 16, 2, 30, 0, 0, time.UTC)
+
+	got, err := Normalize("today", now)
+	require.NoError(t, err)
+	assert.Equal(t, "2025-01-15", got)
+}
+
+func TestDaysUntil_TimezoneBoundaries(t *testing.T) {
+	tests := []struct {
+		name string
+		now  time.Time
+		want int
+	}{
+		{"morning eastern", time.Date(2025, 1, 15, 9, 30, 0, 0, Eastern), 2},
+		{"late evening eastern", time.Date(2025, 1, 15, 23, 59, 0, 0, Eastern), 2},
+		{"after utc midnight still the 15th in eastern", time.Date(2025, 1, 16, 3, 30, 0, 0, time.UTC), 2},
+		{"eastern midnight", time.Date(2025, 1, 16, 0, 0, 0, 0, Eastern), 1},
+		{"from tokyo", time.Date(2025, 1, 16, 13, 0, 0, 0, time.FixedZone("JST", 9*60*60)), 2},
+		{"expiration day", time.Date(2025, 1, 17, 15, 59, 0, 0, Eastern), 0},
+		{"after expiration", time.Date(2025, 1, 18, 12, 0, 0, 0, Eastern), -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, DaysUntil("2025-01-17", tt.now))
+		})
+	}
+}
+
+func TestDaysUntil_AcrossDaylightSaving(t *testing.T) {
+	// US clocks spring forward on 2025-03-09, making that day 23 hours long.
+	now := time.Date(2025, 3, 8, 23, 0, 0, 0, Eastern)
+	assert.Equal(t, 13, DaysUntil("2025-03-21", now))
+
+	// And fall back on 2025-11-02.
+	now = time.Date(2025, 11, 1, 0, 30, 0, 0, Eastern)
+	assert.Equal(t, 6, DaysUntil("2025-11-07", now))
+}
+
+func TestDaysUntil_Invalid(t *testing.T) {
+	assert.Equal(t, 0, DaysUntil("not-a-date", time.Now()))
+}
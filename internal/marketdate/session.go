@@ -0,0 +1,36 @@
+package marketdate
+
+import "time"
+
+// Session is a US equity trading session.
+type Session string
+
+// Trading sessions, in Eastern time.
+const (
+	SessionPre     Session = "pre"     // 4:00-9:30
+	SessionRegular Session = "regular" // 9:30-16:00
+	SessionPost    Session = "post"    // 16:00-20:00
+	SessionClosed  Session = "closed"  // overnight and weekends
+)
+
+// SessionAt returns the trading session in progress at t. Exchange holidays
+// and early closes are not known, so they classify like ordinary weekdays.
+func SessionAt(t time.Time) Session {
+	et := t.In(Eastern)
+	if wd := et.Weekday(); wd == time.Saturday || wd == time.Sunday {
+		return SessionClosed
+	}
+	minute := et.Hour()*60 + et.Minute()
+	switch {
+	case minute < 4*60:
+		return SessionClosed
+	case minute < 9*60+30:
+		return SessionPre
+	case minute < 16*60:
+		return SessionRegular
+	case minute < 20*60:
+		return SessionPost
+	default:
+		return SessionClosed
+	}
+}
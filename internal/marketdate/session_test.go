@@ -0,0 +1,33 @@
+package marketdate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessionAt(t *testing.T) {
+	tests := []struct {
+		name string
+		at   time.Time
+		want Session
+	}{
+		// January: Eastern is UTC-5.
+		{"overnight", time.Date(2025, 1, 15, 8, 59, 0, 0, time.UTC), SessionClosed},
+		{"pre-market open", time.Date(2025, 1, 15, 9, 0, 0, 0, time.UTC), SessionPre},
+		{"regular open", time.Date(2025, 1, 15, 14, 30, 0, 0, time.UTC), SessionRegular},
+		{"last regular minute", time.Date(2025, 1, 15, 20, 59, 0, 0, time.UTC), SessionRegular},
+		{"after hours", time.Date(2025, 1, 15, 21, 0, 0, 0, time.UTC), SessionPost},
+		{"after hours end", time.Date(2025, 1, 16, 1, 0, 0, 0, time.UTC), SessionClosed},
+		{"saturday midday", time.Date(2025, 1, 18, 17, 0, 0, 0, time.UTC), SessionClosed},
+		// July: Eastern is UTC-4.
+		{"summer regular open", time.Date(2025, 7, 15, 13, 30, 0, 0, time.UTC), SessionRegular},
+		{"summer pre-market", time.Date(2025, 7, 15, 13, 29, 0, 0, time.UTC), SessionPre},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, SessionAt(tt.at))
+		})
+	}
+}
@@ -0,0 +1,243 @@
+// Package money provides a fixed-point decimal type for prices, fees, and
+// order costs so that sums like 0.1+0.2 come out exact.
+package money
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Scale is the number of decimal places a Money value stores exactly.
+const Scale = 6
+
+var unit = int64Pow10(Scale)
+
+// Money is a decimal amount stored as an integer count of millionths.
+// The zero value is $0.
+type Money struct {
+	micros int64
+}
+
+// Zero is the zero amount.
+var Zero = Money{}
+
+// Parse parses a decimal string such as "175.25", "-0.01", or "$1,250.00".
+// Digits beyond Scale decimal places are rounded half away from zero.
+// An empty string parses as zero.
+func Parse(s string) (Money, error) {
+	orig := s
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Zero, nil
+	}
+
+	neg := false
+	switch s[0] {
+	case '-':
+		neg = true
+		s = s[1:]
+	case '+':
+		s = s[1:]
+	}
+	s = strings.TrimPrefix(s, "$")
+	s = strings.ReplaceAll(s, ",", "")
+
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	if intPart == "" && fracPart == "" {
+		return Zero, fmt.Errorf("invalid amount %q", orig)
+	}
+	if !isDigits(intPart) || !isDigits(fracPart) {
+		return Zero, fmt.Errorf("invalid amount %q", orig)
+	}
+	if len(intPart) > 12 {
+		return Zero, fmt.Errorf("amount %q out of range", orig)
+	}
+
+	var micros int64
+	for _, c := range intPart {
+		micros = micros*10 + int64(c-'0')
+	}
+	micros *= unit
+
+	roundUp := false
+	for i, c := range fracPart {
+		d := int64(c - '0')
+		if i < Scale {
+			micros += d * int64Pow10(Scale-1-i)
+		} else if i == Scale {
+			roundUp = d >= 5
+			break
+		}
+	}
+	if roundUp {
+		micros++
+	}
+
+	if neg {
+		micros = -micros
+	}
+	return Money{micros: micros}, nil
+}
+
+// MustParse is like Parse but panics on error. It is intended for constants
+// and tests.
+func MustParse(s string) Money {
+	m, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// FromInt returns the whole amount n.
+func FromInt(n int64) Money {
+	return Money{micros: n * unit}
+}
+
+// Sum parses and adds each non-empty string, skipping values that fail to
+// parse. It is meant for API fee breakdowns where missing fields are empty.
+func Sum(values ...string) Money {
+	var total Money
+	for _, v := range values {
+		if m, err := Parse(v); err == nil {
+			total = total.Add(m)
+		}
+	}
+	return total
+}
+
+// Add returns m + o.
+func (m Money) Add(o Money) Money {
+	return Money{micros: m.micros + o.micros}
+}
+
+// Sub returns m - o.
+func (m Money) Sub(o Money) Money {
+	return Money{micros: m.micros - o.micros}
+}
+
+// Neg returns -m.
+func (m Money) Neg() Money {
+	return Money{micros: -m.micros}
+}
+
+// MulInt returns m * n.
+func (m Money) MulInt(n int64) Money {
+	return Money{micros: m.micros * n}
+}
+
+// Mul returns m * o, rounded half away from zero to Scale decimal places.
+// Use it for price times a (possibly fractional) quantity.
+func (m Money) Mul(o Money) Money {
+	p := new(big.Int).Mul(big.NewInt(m.micros), big.NewInt(o.micros))
+	return Money{micros: roundDiv(p, unit)}
+}
+
+// Floor returns the largest multiple of step that is at most m, e.g. a price
+// rounded down to its tick. A non-positive step returns m unchanged.
+func (m Money) Floor(step Money) Money {
+	if step.micros <= 0 {
+		return m
+	}
+	q := m.micros / step.micros
+	if m.micros%step.micros != 0 && m.micros < 0 {
+		q--
+	}
+	return Money{micros: q * step.micros}
+}
+
+// Ceil returns the smallest multiple of step that is at least m. A
+// non-positive step returns m unchanged.
+func (m Money) Ceil(step Money) Money {
+	if step.micros <= 0 {
+		return m
+	}
+	return m.Neg().Floor(step).Neg()
+}
+
+// Sign returns -1, 0, or +1 depending on the sign of m.
+func (m Money) Sign() int {
+	switch {
+	case m.micros < 0:
+		return -1
+	case m.micros > 0:
+		return 1
+	}
+	return 0
+}
+
+// IsZero reports whether m is exactly zero.
+func (m Money) IsZero() bool {
+	return m.micros == 0
+}
+
+// Cmp compares m and o and returns -1, 0, or +1.
+func (m Money) Cmp(o Money) int {
+	return m.Sub(o).Sign()
+}
+
+// Float64 returns m as a float64 for display math such as percentages.
+func (m Money) Float64() float64 {
+	return float64(m.micros) / float64(unit)
+}
+
+// StringFixed formats m with exactly places decimal places, rounding half
+// away from zero.
+func (m Money) StringFixed(places int) string {
+	if places < 0 {
+		places = 0
+	}
+	if places > Scale {
+		places = Scale
+	}
+
+	div := int64Pow10(Scale - places)
+	v := roundDiv(big.NewInt(m.micros), div)
+
+	sign := ""
+	if v < 0 {
+		sign = "-"
+		v = -v
+	}
+	if places == 0 {
+		return fmt.Sprintf("%s%d", sign, v)
+	}
+	p := int64Pow10(places)
+	return fmt.Sprintf("%s%d.%0*d", sign, v/p, places, v%p)
+}
+
+// String formats m with two decimal places, e.g. "1750.03".
+func (m Money) String() string {
+	return m.StringFixed(2)
+}
+
+// roundDiv returns n / d rounded half away from zero.
+func roundDiv(n *big.Int, d int64) int64 {
+	q, r := new(big.Int).QuoRem(n, big.NewInt(d), new(big.Int))
+	if new(big.Int).Abs(r).Int64()*2 >= d {
+		if n.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return q.Int64()
+}
+
+func int64Pow10(n int) int64 {
+	p := int64(1)
+	for i := 0; i < n; i++ {
+		p *= 10
+	}
+	return p
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
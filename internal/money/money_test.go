@@ -0,0 +1,92 @@
+package money
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdd_NoFloatDrift(t *testing.T) {
+	sum := MustParse("0.1").Add(MustParse("0.2"))
+	assert.Equal(t, MustParse("0.3"), sum)
+	assert.Equal(t, "0.30", sum.String())
+
+	pennies := MustParse("0.01").Add(MustParse("0.02"))
+	assert.Equal(t, MustParse("0.03"), pennies)
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"", "0.000000"},
+		{"175", "175.000000"},
+		{"175.25", "175.250000"},
+		{"-0.01", "-0.010000"},
+		{"+3.5", "3.500000"},
+		{".5", "0.500000"},
+		{"$1,250.00", "1250.000000"},
+		{"0.0000004", "0.000000"},
+		{"0.0000005", "0.000001"},
+		{"-0.0000005", "-0.000001"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			m, err := Parse(tt.in)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, m.StringFixed(6))
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, in := range []string{"abc", "1.2.3", "-", ".", "1e5", "12a"} {
+		_, err := Parse(in)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestMul(t *testing.T) {
+	assert.Equal(t, "1752.50", MustParse("175.25").Mul(MustParse("10")).String())
+	assert.Equal(t, "17.53", MustParse("175.25").Mul(MustParse("0.1")).String())
+	assert.Equal(t, "-350.50", MustParse("-175.25").MulInt(2).String())
+
+	// Large prices times large quantities must not overflow the intermediate product.
+	assert.Equal(t, "5000000000.00", MustParse("500000").Mul(MustParse("10000")).String())
+}
+
+func TestStringFixed_Rounding(t *testing.T) {
+	assert.Equal(t, "0.01", MustParse("0.005").String())
+	assert.Equal(t, "-0.01", MustParse("-0.005").String())
+	assert.Equal(t, "0.00", MustParse("0.004").String())
+	assert.Equal(t, "2", MustParse("1.5").StringFixed(0))
+	assert.Equal(t, "1.2346", MustParse("1.23456").StringFixed(4))
+}
+
+func TestSum(t *testing.T) {
+	assert.Equal(t, "0.06", Sum("0.01", "", "0.02", "bogus", "0.03").String())
+	assert.True(t, Sum().IsZero())
+}
+
+func TestCmpAndSign(t *testing.T) {
+	assert.Equal(t, -1, MustParse("1.00").Cmp(MustParse("1.01")))
+	assert.Equal(t, 0, MustParse("1.0").Cmp(FromInt(1)))
+	assert.Equal(t, 1, MustParse("2").Sub(FromInt(1)).Sign())
+	assert.Equal(t, -1, FromInt(1).Neg().Sign())
+	assert.InDelta(t, 1.25, MustParse("1.25").Float64(), 1e-9)
+}
+
+func TestFloorAndCeil(t *testing.T) {
+	nickel := MustParse("0.05")
+	assert.Equal(t, "1.45", MustParse("1.475").Floor(nickel).String())
+	assert.Equal(t, "1.50", MustParse("1.475").Ceil(nickel).String())
+	assert.Equal(t, "1.50", MustParse("1.50").Floor(nickel).String())
+	assert.Equal(t, "1.50", MustParse("1.50").Ceil(nickel).String())
+	assert.Equal(t, "-0.05", MustParse("-0.01").Floor(nickel).String())
+	assert.Equal(t, "0.00", MustParse("-0.01").Ceil(nickel).String())
+	assert.Equal(t, "0.1234", MustParse("0.12345").Floor(MustParse("0.0001")).StringFixed(4))
+	assert.Equal(t, "1.23", MustParse("1.23").Floor(Zero).String())
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"slices"
 	"strings"
 	"text/tabwriter"
 )
@@ -12,6 +13,9 @@ import (
 type Formatter struct {
 	Writer   io.Writer
 	JSONMode bool
+	// Wide keeps the detail columns that WideTable otherwise drops from text
+	// tables to fit the terminal.
+	Wide bool
 }
 
 // New creates a new Formatter with the specified writer and JSON mode.
@@ -31,6 +35,43 @@ func (f *Formatter) Table(headers []string, rows [][]string) error {
 	return f.tableAsText(headers, rows)
 }
 
+// WithWide sets Wide and returns the formatter.
+func (f *Formatter) WithWide(wide bool) *Formatter {
+	f.Wide = wide
+	return f
+}
+
+// WideTable is like Table, but the columns named in wideOnly are detail
+// columns: text output drops them unless Wide is set. JSON output always
+// includes every column.
+func (f *Formatter) WideTable(headers []string, rows [][]string, wideOnly ...string) error {
+	if f.JSONMode || f.Wide || len(wideOnly) == 0 {
+		return f.Table(headers, rows)
+	}
+
+	var keep []int
+	for i, h := range headers {
+		if !slices.Contains(wideOnly, h) {
+			keep = append(keep, i)
+		}
+	}
+	pick := func(cells []string) []string {
+		out := make([]string, 0, len(keep))
+		for _, i := range keep {
+			if i < len(cells) {
+				out = append(out, cells[i])
+			}
+		}
+		return out
+	}
+
+	narrowRows := make([][]string, len(rows))
+	for i, row := range rows {
+		narrowRows[i] = pick(row)
+	}
+	return f.tableAsText(pick(headers), narrowRows)
+}
+
 // tableAsText renders a table with aligned columns.
 func (f *Formatter) tableAsText(headers []string, rows [][]string) error {
 	tw := tabwriter.NewWriter(f.Writer, 0, 0, 2, ' ', 0)
@@ -180,3 +180,26 @@ func TestNewFormatter(t *testing.T) {
 	f2 := New(&buf, true)
 	assert.True(t, f2.JSONMode)
 }
+
+func TestFormatter_WideTable(t *testing.T) {
+	headers := []string{"ID", "Fees", "Amount"}
+	rows := [][]string{{"txn-1", "0.02", "100.00"}}
+
+	var buf bytes.Buffer
+	f := New(&buf, false)
+	require.NoError(t, f.WideTable(headers, rows, "Fees"))
+	assert.NotContains(t, buf.String(), "Fees")
+	assert.NotContains(t, buf.String(), "0.02")
+	assert.Contains(t, buf.String(), "Amount")
+	assert.Contains(t, buf.String(), "100.00")
+
+	buf.Reset()
+	require.NoError(t, New(&buf, false).WithWide(true).WideTable(headers, rows, "Fees"))
+	assert.Contains(t, buf.String(), "Fees")
+	assert.Contains(t, buf.String(), "0.02")
+
+	// JSON keeps every column regardless of Wide
+	buf.Reset()
+	require.NoError(t, New(&buf, true).WideTable(headers, rows, "Fees"))
+	assert.Contains(t, buf.String(), `"Fees": "0.02"`)
+}
@@ -12,6 +12,28 @@ import (
 // UIConfig holds TUI-specific configuration separate from CLI config.
 type UIConfig struct {
 	Watchlist []string `yaml:"watchlist,omitempty"`
+
+	// WatchlistAdded is the watchlist in the order symbols were added, kept
+	// so 'pub watchlist sort --by added' can undo other orderings. Update it
+	// through SetWatchlist.
+	WatchlistAdded []string `yaml:"watchlist_added,omitempty"`
+
+	// ATMTolerancePct overrides the ATM band in the options chain, as a
+	// percentage of the underlying price (default 1%).
+	ATMTolerancePct float64 `yaml:"atm_tolerance_pct,omitempty"`
+
+	// GreeksPrecision overrides the decimals shown for greeks and IV in the
+	// options chain (default 2, max 6).
+	GreeksPrecision int `yaml:"greeks_precision,omitempty"`
+
+	// MinPositionValue hides positions worth less than this many dollars
+	// from the portfolio table. Totals still include them.
+	MinPositionValue float64 `yaml:"min_position_value,omitempty"`
+
+	// ShowQuoteSession adds a watchlist column with the trading session and
+	// Eastern time of each quote's last trade, marking quotes from an
+	// earlier day as stale.
+	ShowQuoteSession bool `yaml:"show_quote_session,omitempty"`
 }
 
 // ConfigPath returns the path to the TUI config file.
@@ -21,8 +43,14 @@ func ConfigPath() string {
 
 // LoadConfig loads the TUI config from disk.
 func LoadConfig() (*UIConfig, error) {
+	return LoadConfigFrom(ConfigPath())
+}
+
+// LoadConfigFrom loads the TUI config from path. A missing file is an empty
+// config.
+func LoadConfigFrom(path string) (*UIConfig, error) {
 	cfg := &UIConfig{}
-	data, err := os.ReadFile(ConfigPath())
+	data, err := os.ReadFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return cfg, nil
@@ -37,7 +65,11 @@ func LoadConfig() (*UIConfig, error) {
 
 // SaveConfig saves the TUI config to disk.
 func SaveConfig(cfg *UIConfig) error {
-	path := ConfigPath()
+	return SaveConfigTo(ConfigPath(), cfg)
+}
+
+// SaveConfigTo saves the TUI config to path, creating its directory.
+func SaveConfigTo(path string, cfg *UIConfig) error {
 	dir := filepath.Dir(path)
 	if err := os.MkdirAll(dir, 0700); err != nil {
 		return err
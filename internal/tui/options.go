@@ -3,6 +3,7 @@ package tui
 import (
 	"context"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 	"time"
@@ -11,9 +12,12 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/jonandersen/public-cli/internal/analytics"
 	"github.com/jonandersen/public-cli/internal/api"
 	"github.com/jonandersen/public-cli/internal/config"
 	"github.com/jonandersen/public-cli/internal/keyring"
+	"github.com/jonandersen/public-cli/internal/marketdate"
+	"github.com/jonandersen/public-cli/pkg/publicapi"
 )
 
 // OptionsState represents the current state of the options view.
@@ -46,6 +50,16 @@ const (
 	GreeksDisplayExpanded                          // All Greeks: Delta, Gamma, Theta, Vega, Rho, IV
 )
 
+// DefaultATMTolerancePct is the default ATM band as a percentage of the underlying price.
+const DefaultATMTolerancePct = 1.0
+
+// DefaultGreeksPrecision is the default number of decimals shown for greeks.
+// IV is shown as a percentage with one decimal fewer.
+const DefaultGreeksPrecision = 2
+
+// MaxGreeksPrecision is the largest supported greeks precision.
+const MaxGreeksPrecision = 6
+
 // OptionsModel holds the state for the options view.
 type OptionsModel struct {
 	State       OptionsState
@@ -71,6 +85,20 @@ type OptionsModel struct {
 	Height      int
 	OptionsBP   string
 
+	// ATMTolerancePct is how close a strike must be to the underlying price,
+	// as a percentage of that price, to be marked ATM
+	ATMTolerancePct float64
+
+	// GreeksPrecision is the number of decimals shown for greeks
+	GreeksPrecision int
+
+	// ShowHedge shows the delta-hedge share count for the option under the cursor
+	ShowHedge bool
+
+	// PinnedStrike is the strike the cursors return to when the chain
+	// reloads, matched by value; 0 means nothing is pinned
+	PinnedStrike float64
+
 	// Detail panel
 	ShowDetailPanel bool
 	SelectedOption  *api.OptionQuote
@@ -89,11 +117,13 @@ func NewOptionsModel() *OptionsModel {
 	ti.Focus()
 
 	return &OptionsModel{
-		State:       OptionsStateIdle,
-		Focus:       OptionsFocusSymbol,
-		SymbolInput: ti,
-		Greeks:      make(map[string]api.GreeksData),
-		Height:      10,
+		State:           OptionsStateIdle,
+		Focus:           OptionsFocusSymbol,
+		SymbolInput:     ti,
+		Greeks:          make(map[string]api.GreeksData),
+		Height:          10,
+		ATMTolerancePct: DefaultATMTolerancePct,
+		GreeksPrecision: DefaultGreeksPrecision,
 	}
 }
 
@@ -153,8 +183,12 @@ func (m *OptionsModel) Update(msg tea.Msg, cfg *config.Config, store keyring.Sto
 		m.CallsCursor = 0
 		m.PutsCursor = 0
 		m.Focus = OptionsFocusCalls
-		// Try to select ATM option
-		m.selectATMOption()
+		// Return to the pinned strike, else try to select ATM option
+		if m.PinnedStrike > 0 {
+			m.selectStrike(m.PinnedStrike)
+		} else {
+			m.selectATMOption()
+		}
 		// Fetch greeks for visible options
 		return m, m.fetchVisibleGreeks(cfg, store)
 
@@ -206,6 +240,9 @@ func (m *OptionsModel) handleIdleKeys(msg tea.KeyMsg, cfg *config.Config, store
 	case "enter":
 		symbol := strings.ToUpper(strings.TrimSpace(m.SymbolInput.Value()))
 		if symbol != "" {
+			if symbol != m.Symbol {
+				m.PinnedStrike = 0
+			}
 			m.Symbol = symbol
 			m.State = OptionsStateLoadingExpirations
 			return m, FetchOptionExpirations(symbol, cfg, store)
@@ -262,10 +299,14 @@ func (m *OptionsModel) handleExpirationKeys(msg tea.KeyMsg, cfg *config.Config,
 func (m *OptionsModel) handleChainKeys(msg tea.KeyMsg, cfg *config.Config, store keyring.Store) (*OptionsModel, tea.Cmd) {
 	// If detail panel is open, handle it first
 	if m.ShowDetailPanel {
-		if msg.String() == "esc" || msg.String() == "enter" {
+		switch msg.String() {
+		case "esc", "enter":
 			m.ShowDetailPanel = false
 			m.SelectedOption = nil
-			return m, nil
+		case "t":
+			if m.SelectedOption != nil {
+				return m, tradeOption(*m.SelectedOption)
+			}
 		}
 		return m, nil
 	}
@@ -329,6 +370,30 @@ func (m *OptionsModel) handleChainKeys(msg tea.KeyMsg, cfg *config.Config, store
 		}
 		return m, nil
 
+	case "h":
+		// Toggle the delta-neutral hedge hint
+		m.ShowHedge = !m.ShowHedge
+		return m, nil
+
+	case "P":
+		// Pin the strike under the cursor, or unpin it
+		if opt := m.cursorOption(); opt != nil {
+			strike := parseStrikeFromOSI(opt.Instrument.Symbol)
+			if strike == m.PinnedStrike {
+				m.PinnedStrike = 0
+			} else {
+				m.PinnedStrike = strike
+			}
+		}
+		return m, nil
+
+	case "t":
+		// Trade the option under the cursor
+		if opt := m.cursorOption(); opt != nil {
+			return m, tradeOption(*opt)
+		}
+		return m, nil
+
 	case "enter":
 		// Show detail panel for selected option
 		if m.Chain != nil {
@@ -366,6 +431,18 @@ func (m *OptionsModel) handleChainKeys(msg tea.KeyMsg, cfg *config.Config, store
 	return m, nil
 }
 
+// tradeOption returns a command that opens the trade form for opt, with the
+// limit price prefilled from its ask.
+func tradeOption(opt api.OptionQuote) tea.Cmd {
+	limit := ""
+	if ask, err := strconv.ParseFloat(opt.Ask, 64); err == nil && ask > 0 {
+		limit = opt.Ask
+	}
+	return func() tea.Msg {
+		return OptionTradeMsg{Symbol: opt.Instrument.Symbol, LimitPrice: limit}
+	}
+}
+
 func (m *OptionsModel) selectATMOption() {
 	if m.Chain == nil || m.Quote == nil {
 		return
@@ -400,6 +477,28 @@ func (m *OptionsModel) selectATMOption() {
 	}
 }
 
+// selectStrike moves both cursors to the row whose strike is closest to
+// strike, which is the exact row whenever that strike is still listed.
+func (m *OptionsModel) selectStrike(strike float64) {
+	if m.Chain == nil {
+		return
+	}
+	m.CallsCursor = closestStrikeIndex(m.Chain.Calls, strike)
+	m.PutsCursor = closestStrikeIndex(m.Chain.Puts, strike)
+}
+
+// closestStrikeIndex returns the index of the option whose strike is closest
+// to strike, or 0 for an empty list.
+func closestStrikeIndex(options []api.OptionQuote, strike float64) int {
+	best, minDiff := 0, math.Inf(1)
+	for i, opt := range options {
+		if diff := abs(parseStrikeFromOSI(opt.Instrument.Symbol) - strike); diff < minDiff {
+			best, minDiff = i, diff
+		}
+	}
+	return best
+}
+
 func parseStrikeFromOSI(osi string) float64 {
 	// OSI format: AAPL250117C00185000
 	// Last 8 chars are strike * 1000 (3 decimal places)
@@ -421,6 +520,14 @@ func abs(x float64) float64 {
 	return x
 }
 
+// isATM reports whether strike is within tolPct percent of the underlying price.
+func isATM(strike, underlying, tolPct float64) bool {
+	if underlying <= 0 {
+		return false
+	}
+	return abs(strike-underlying) <= underlying*tolPct/100
+}
+
 func (m *OptionsModel) fetchVisibleGreeks(cfg *config.Config, store keyring.Store) tea.Cmd {
 	if m.Chain == nil {
 		return nil
@@ -609,6 +716,12 @@ func (m *OptionsModel) renderChain() string {
 	// Render puts table
 	b.WriteString(m.renderOptionsTable("PUTS", m.Chain.Puts, m.PutsCursor, m.Focus == OptionsFocusPuts))
 
+	if m.ShowHedge {
+		b.WriteString("\n")
+		b.WriteString(m.renderHedgeHint())
+		b.WriteString("\n")
+	}
+
 	// Updated time
 	b.WriteString("\n")
 	b.WriteString(LabelStyle.Render(fmt.Sprintf("Updated: %s", m.LastUpdated.Format("3:04:05 PM"))))
@@ -616,6 +729,56 @@ func (m *OptionsModel) renderChain() string {
 	return b.String()
 }
 
+// cursorOption returns the option under the cursor of the focused side, or nil.
+func (m *OptionsModel) cursorOption() *api.OptionQuote {
+	if m.Chain == nil {
+		return nil
+	}
+	switch m.Focus {
+	case OptionsFocusCalls:
+		if m.CallsCursor >= 0 && m.CallsCursor < len(m.Chain.Calls) {
+			return &m.Chain.Calls[m.CallsCursor]
+		}
+	case OptionsFocusPuts:
+		if m.PutsCursor >= 0 && m.PutsCursor < len(m.Chain.Puts) {
+			return &m.Chain.Puts[m.PutsCursor]
+		}
+	}
+	return nil
+}
+
+// hedgeHint describes the shares to trade to delta-hedge one contract of opt,
+// long and short, or returns "" when its delta has not loaded.
+func (m *OptionsModel) hedgeHint(opt *api.OptionQuote) string {
+	delta, err := strconv.ParseFloat(m.Greeks[opt.Instrument.Symbol].Delta, 64)
+	if err != nil {
+		return ""
+	}
+	shares := analytics.HedgeShares(delta, 1)
+	if shares == 0 {
+		return "no hedge needed (delta 0)"
+	}
+	trade, opposite := "buy", "sell"
+	if shares < 0 {
+		trade, opposite = "sell", "buy"
+		shares = -shares
+	}
+	return fmt.Sprintf("long 1: %s %d shares, short 1: %s %d shares", trade, shares, opposite, shares)
+}
+
+// renderHedgeHint renders the delta hedge for the option under the cursor.
+func (m *OptionsModel) renderHedgeHint() string {
+	opt := m.cursorOption()
+	if opt == nil {
+		return ""
+	}
+	hint := m.hedgeHint(opt)
+	if hint == "" {
+		hint = "delta unavailable"
+	}
+	return LabelStyle.Render(fmt.Sprintf("Hedge %.2f: ", parseStrikeFromOSI(opt.Instrument.Symbol))) + ValueStyle.Render(hint)
+}
+
 func (m *OptionsModel) renderDetailPanel() string {
 	var b strings.Builder
 
@@ -652,9 +815,11 @@ func (m *OptionsModel) renderDetailPanel() string {
 	b.WriteString(ValueStyle.Render(opt.Instrument.Symbol))
 	b.WriteString("\n")
 
-	// Row 2: Bid/Ask with spread
+	// Row 2: Bid/Ask with size and spread
 	b.WriteString(LabelStyle.Render("Bid/Ask:    "))
-	b.WriteString(ValueStyle.Render(fmt.Sprintf("$%s / $%s (spread: $%.2f)", formatOptPrice(opt.Bid), formatOptPrice(opt.Ask), spread)))
+	b.WriteString(ValueStyle.Render(fmt.Sprintf("$%s x %s / $%s x %s (spread: $%.2f)",
+		formatOptPrice(opt.Bid), publicapi.FormatVolume(int64(opt.BidSize)),
+		formatOptPrice(opt.Ask), publicapi.FormatVolume(int64(opt.AskSize)), spread)))
 	b.WriteString("\n")
 
 	// Row 3: Volume and OI
@@ -666,23 +831,32 @@ func (m *OptionsModel) renderDetailPanel() string {
 
 	// Row 4: Delta and IV
 	b.WriteString(LabelStyle.Render("Delta:      "))
-	b.WriteString(ValueStyle.Render(fmt.Sprintf("%-14s", formatGreek(greeks.Delta))))
+	b.WriteString(ValueStyle.Render(fmt.Sprintf("%-14s", formatGreek(greeks.Delta, m.GreeksPrecision))))
 	b.WriteString(LabelStyle.Render("Implied Vol:   "))
-	b.WriteString(ValueStyle.Render(formatIV(greeks.ImpliedVolatility)))
+	b.WriteString(ValueStyle.Render(formatIV(greeks.ImpliedVolatility, m.GreeksPrecision)))
 	b.WriteString("\n")
 
 	// Row 5: Gamma and Theta
 	b.WriteString(LabelStyle.Render("Gamma:      "))
-	b.WriteString(ValueStyle.Render(fmt.Sprintf("%-14s", formatGreek(greeks.Gamma))))
+	b.WriteString(ValueStyle.Render(fmt.Sprintf("%-14s", formatGreek(greeks.Gamma, m.GreeksPrecision))))
 	b.WriteString(LabelStyle.Render("Theta:         "))
-	b.WriteString(ValueStyle.Render(formatGreek(greeks.Theta)))
+	b.WriteString(ValueStyle.Render(formatGreek(greeks.Theta, m.GreeksPrecision)))
 	b.WriteString("\n")
 
 	// Row 6: Vega and Rho
 	b.WriteString(LabelStyle.Render("Vega:       "))
-	b.WriteString(ValueStyle.Render(fmt.Sprintf("%-14s", formatGreek(greeks.Vega))))
+	b.WriteString(ValueStyle.Render(fmt.Sprintf("%-14s", formatGreek(greeks.Vega, m.GreeksPrecision))))
 	b.WriteString(LabelStyle.Render("Rho:           "))
-	b.WriteString(ValueStyle.Render(formatGreek(greeks.Rho)))
+	b.WriteString(ValueStyle.Render(formatGreek(greeks.Rho, m.GreeksPrecision)))
+
+	// Row 7: Delta hedge, when toggled on
+	if m.ShowHedge {
+		if hint := m.hedgeHint(opt); hint != "" {
+			b.WriteString("\n")
+			b.WriteString(LabelStyle.Render("Hedge:      "))
+			b.WriteString(ValueStyle.Render(hint))
+		}
+	}
 
 	return b.String()
 }
@@ -745,14 +919,17 @@ func (m *OptionsModel) renderOptionsTable(title string, options []api.OptionQuot
 			}
 		}
 
-		// Check if ATM
-		atmMarker := ""
+		// Mark ATM and pinned strikes
+		marker := ""
 		if m.Quote != nil {
 			price, _ := strconv.ParseFloat(m.Quote.Last, 64)
-			if abs(strike-price) < 2.5 {
-				atmMarker = " ATM"
+			if isATM(strike, price, m.ATMTolerancePct) {
+				marker = " ATM"
 			}
 		}
+		if m.PinnedStrike > 0 && strike == m.PinnedStrike {
+			marker += " PIN"
+		}
 
 		greeks := m.Greeks[opt.Instrument.Symbol]
 
@@ -763,13 +940,13 @@ func (m *OptionsModel) renderOptionsTable(title string, options []api.OptionQuot
 				strike,
 				formatOptPrice(opt.Bid),
 				formatOptPrice(opt.Ask),
-				formatGreek(greeks.Delta),
-				formatGreek(greeks.Gamma),
-				formatGreek(greeks.Theta),
-				formatGreek(greeks.Vega),
-				formatGreek(greeks.Rho),
-				formatIV(greeks.ImpliedVolatility),
-				atmMarker)
+				formatGreek(greeks.Delta, m.GreeksPrecision),
+				formatGreek(greeks.Gamma, m.GreeksPrecision),
+				formatGreek(greeks.Theta, m.GreeksPrecision),
+				formatGreek(greeks.Vega, m.GreeksPrecision),
+				formatGreek(greeks.Rho, m.GreeksPrecision),
+				formatIV(greeks.ImpliedVolatility, m.GreeksPrecision),
+				marker)
 		} else {
 			// Compact: Strike, Bid, Ask, Last, Vol, OI, Delta, Theta, IV
 			row = fmt.Sprintf("%-8.2f  %6s   %6s   %6s  %5d  %6d  %6s  %6s  %6s%s",
@@ -779,10 +956,10 @@ func (m *OptionsModel) renderOptionsTable(title string, options []api.OptionQuot
 				formatOptPrice(opt.Last),
 				opt.Volume,
 				opt.OpenInterest,
-				formatGreek(greeks.Delta),
-				formatGreek(greeks.Theta),
-				formatIV(greeks.ImpliedVolatility),
-				atmMarker)
+				formatGreek(greeks.Delta, m.GreeksPrecision),
+				formatGreek(greeks.Theta, m.GreeksPrecision),
+				formatIV(greeks.ImpliedVolatility, m.GreeksPrecision),
+				marker)
 		}
 
 		b.WriteString(style.Render(prefix + row))
@@ -819,6 +996,7 @@ func (m *OptionsModel) FooterKeys(keys []struct{ key, desc string }) []struct{ k
 	// Detail panel has its own keys
 	if m.ShowDetailPanel {
 		return []struct{ key, desc string }{
+			{"t", "trade"},
 			{"Esc", "close"},
 			{"Enter", "close"},
 		}
@@ -836,8 +1014,11 @@ func (m *OptionsModel) FooterKeys(keys []struct{ key, desc string }) []struct{ k
 	case OptionsStateChainLoaded:
 		keys = append(keys, struct{ key, desc string }{"↑/↓", "navigate"})
 		keys = append(keys, struct{ key, desc string }{"Enter", "details"})
+		keys = append(keys, struct{ key, desc string }{"t", "trade"})
 		keys = append(keys, struct{ key, desc string }{"c/p", "calls/puts"})
 		keys = append(keys, struct{ key, desc string }{"g", "toggle greeks"})
+		keys = append(keys, struct{ key, desc string }{"h", "hedge"})
+		keys = append(keys, struct{ key, desc string }{"P", "pin strike"})
 		keys = append(keys, struct{ key, desc string }{"e", "expiration"})
 		keys = append(keys, struct{ key, desc string }{"r", "refresh"})
 	case OptionsStateError:
@@ -850,12 +1031,7 @@ func (m *OptionsModel) FooterKeys(keys []struct{ key, desc string }) []struct{ k
 // Helper functions
 
 func calculateDTE(expiration string) int {
-	// Parse expiration date (format: 2025-01-17)
-	exp, err := time.Parse("2006-01-02", expiration)
-	if err != nil {
-		return 0
-	}
-	return int(time.Until(exp).Hours() / 24)
+	return marketdate.DaysUntil(expiration, time.Now())
 }
 
 func formatOptPrice(price string) string {
@@ -869,7 +1045,7 @@ func formatOptPrice(price string) string {
 	return fmt.Sprintf("%.2f", p)
 }
 
-func formatGreek(value string) string {
+func formatGreek(value string, precision int) string {
 	if value == "" {
 		return "-"
 	}
@@ -877,10 +1053,13 @@ func formatGreek(value string) string {
 	if err != nil {
 		return value
 	}
-	return fmt.Sprintf("%.2f", v)
+	return fmt.Sprintf("%.*f", precision, v)
 }
 
-func formatIV(value string) string {
+// formatIV formats implied volatility as a percentage. Since the percentage
+// already shifts two digits left of the decimal point, it uses one decimal
+// fewer than the greeks precision, and none at precision 1.
+func formatIV(value string, precision int) string {
 	if value == "" {
 		return "-"
 	}
@@ -888,11 +1067,17 @@ func formatIV(value string) string {
 	if err != nil {
 		return value
 	}
-	return fmt.Sprintf("%.1f%%", v*100)
+	return fmt.Sprintf("%.*f%%", max(precision-1, 0), v*100)
 }
 
 // Message types for options operations
 
+// OptionTradeMsg asks the app to open the trade form for an option contract.
+type OptionTradeMsg struct {
+	Symbol     string
+	LimitPrice string
+}
+
 // OptionExpirationsLoadedMsg is sent when expirations are loaded.
 type OptionExpirationsLoadedMsg struct {
 	Expirations []string
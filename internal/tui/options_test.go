@@ -0,0 +1,147 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jonandersen/public-cli/internal/api"
+)
+
+func TestIsATM(t *testing.T) {
+	tests := []struct {
+		name       string
+		strike     float64
+		underlying float64
+		tolPct     float64
+		want       bool
+	}{
+		{"exact match", 100, 100, 1, true},
+		{"within band", 100.5, 100, 1, true},
+		{"edge of band", 99, 100, 1, true},
+		{"outside band", 102, 100, 1, false},
+		{"cheap stock narrow band", 5.5, 5.2, 1, false},
+		{"cheap stock nearest strike", 5, 5.02, 1, true},
+		{"expensive stock wide band", 1500, 1510, 1, true},
+		{"no underlying price", 100, 0, 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isATM(tt.strike, tt.underlying, tt.tolPct))
+		})
+	}
+}
+
+func TestNewOptionsModel_DefaultATMTolerance(t *testing.T) {
+	m := NewOptionsModel()
+	assert.Equal(t, DefaultATMTolerancePct, m.ATMTolerancePct)
+}
+
+func TestFormatGreek_Precision(t *testing.T) {
+	assert.Equal(t, "0.00", formatGreek("0.0012", 2))
+	assert.Equal(t, "0.0012", formatGreek("0.0012", 4))
+	assert.Equal(t, "1.2%", formatIV("0.0123", 2))
+	assert.Equal(t, "1.230%", formatIV("0.0123", 4))
+	assert.Equal(t, "1.23%", formatIV("0.0123", 3))
+	assert.Equal(t, "1%", formatIV("0.0123", 1))
+}
+
+func TestOptionsModel_HedgeToggle(t *testing.T) {
+	m := NewOptionsModel()
+	m.State = OptionsStateChainLoaded
+	m.Focus = OptionsFocusCalls
+	m.Expirations = []string{"2025-01-17"}
+	m.Chain = &api.OptionChainResponse{
+		BaseSymbol: "AAPL",
+		Calls:      []api.OptionQuote{{Instrument: api.OptionInstrument{Symbol: "AAPL250117C00175000"}}},
+		Puts:       []api.OptionQuote{{Instrument: api.OptionInstrument{Symbol: "AAPL250117P00175000"}}},
+	}
+	m.Greeks = map[string]api.GreeksData{
+		"AAPL250117C00175000": {Delta: "0.52"},
+		"AAPL250117P00175000": {Delta: "-0.48"},
+	}
+
+	assert.NotContains(t, m.View(), "Hedge")
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("h")}, nil, nil)
+	require.True(t, m.ShowHedge)
+	assert.Contains(t, m.View(), "long 1: sell 52 shares, short 1: buy 52 shares")
+
+	m.Focus = OptionsFocusPuts
+	assert.Contains(t, m.View(), "long 1: buy 48 shares, short 1: sell 48 shares")
+
+	m.Greeks = nil
+	assert.Contains(t, m.View(), "delta unavailable")
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("h")}, nil, nil)
+	assert.False(t, m.ShowHedge)
+}
+
+func TestOptionsModel_PinStrike(t *testing.T) {
+	chain := func(strikes ...string) *api.OptionChainResponse {
+		c := &api.OptionChainResponse{BaseSymbol: "AAPL"}
+		for _, s := range strikes {
+			c.Calls = append(c.Calls, api.OptionQuote{Instrument: api.OptionInstrument{Symbol: "AAPL250117C" + s}})
+			c.Puts = append(c.Puts, api.OptionQuote{Instrument: api.OptionInstrument{Symbol: "AAPL250117P" + s}})
+		}
+		return c
+	}
+
+	m := NewOptionsModel()
+	m.State = OptionsStateChainLoaded
+	m.Focus = OptionsFocusCalls
+	m.Symbol = "AAPL"
+	m.Expirations = []string{"2025-01-17"}
+	m.Quote = &Quote{Last: "170.00"}
+	m.Chain = chain("00170000", "00175000", "00180000")
+	m.CallsCursor = 1
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("P")}, nil, nil)
+	require.Equal(t, 175.0, m.PinnedStrike)
+	assert.Contains(t, m.View(), "PIN")
+
+	// A refresh that adds strikes above and below lands back on 175, not on
+	// the old index or the ATM strike.
+	m.Update(OptionChainLoadedMsg{Chain: chain("00165000", "00170000", "00172500", "00175000", "00180000")}, nil, nil)
+	assert.Equal(t, 3, m.CallsCursor)
+	assert.Equal(t, 3, m.PutsCursor)
+
+	// Without the pinned strike listed, the nearest one is selected.
+	m.Update(OptionChainLoadedMsg{Chain: chain("00160000", "00177500", "00190000")}, nil, nil)
+	assert.Equal(t, 1, m.CallsCursor)
+
+	// P on a different strike repins; P on the pinned strike unpins.
+	m.Update(OptionChainLoadedMsg{Chain: chain("00170000", "00175000", "00180000")}, nil, nil)
+	m.CallsCursor = 2
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("P")}, nil, nil)
+	assert.Equal(t, 180.0, m.PinnedStrike)
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("P")}, nil, nil)
+	assert.Zero(t, m.PinnedStrike)
+	assert.NotContains(t, m.View(), "PIN")
+}
+
+func TestOptionsModel_TradeKey(t *testing.T) {
+	m := NewOptionsModel()
+	m.State = OptionsStateChainLoaded
+	m.Focus = OptionsFocusPuts
+	m.Expirations = []string{"2025-01-17"}
+	m.Chain = &api.OptionChainResponse{
+		BaseSymbol: "AAPL",
+		Calls:      []api.OptionQuote{{Instrument: api.OptionInstrument{Symbol: "AAPL250117C00175000"}, Ask: "3.20"}},
+		Puts:       []api.OptionQuote{{Instrument: api.OptionInstrument{Symbol: "AAPL250117P00175000"}, Ask: "0"}},
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")}, nil, nil)
+	require.NotNil(t, cmd)
+	assert.Equal(t, OptionTradeMsg{Symbol: "AAPL250117P00175000"}, cmd())
+
+	m.Focus = OptionsFocusCalls
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter}, nil, nil)
+	require.True(t, m.ShowDetailPanel)
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")}, nil, nil)
+	require.NotNil(t, cmd)
+	assert.Equal(t, OptionTradeMsg{Symbol: "AAPL250117C00175000", LimitPrice: "3.20"}, cmd())
+}
@@ -138,7 +138,7 @@ func (m *OrdersModel) Update(msg tea.Msg, cfg *config.Config, store keyring.Stor
 
 		case OrdersModeNormal:
 			switch msg.String() {
-			case "c", "x", "d":
+			case "c", "x":
 				// Cancel selected order
 				if len(m.Orders) > 0 {
 					idx := m.Table.Cursor()
@@ -34,6 +34,8 @@ type PortfolioModel struct {
 	Err         error
 	LastUpdated time.Time
 	Table       table.Model
+	MinValue    float64 // hide positions worth less than this from the table
+	Hidden      int     // positions hidden by MinValue
 }
 
 // NewPortfolioModel creates a new portfolio model.
@@ -96,8 +98,11 @@ func (m *PortfolioModel) Update(msg tea.Msg) (*PortfolioModel, tea.Cmd) {
 
 // updateTable updates the table rows from portfolio data.
 func (m *PortfolioModel) updateTable() {
-	rows := make([]table.Row, 0, len(m.Data.Positions))
-	for _, pos := range m.Data.Positions {
+	positions, hidden := publicapi.FilterPositionsByValue(m.Data.Positions, m.MinValue)
+	m.Hidden = hidden
+
+	rows := make([]table.Row, 0, len(positions))
+	for _, pos := range positions {
 		totalGainValue := pos.CostBasis.GainValue
 		totalGainPct := pos.CostBasis.GainPercentage
 		if totalGainValue == "" {
@@ -187,7 +192,11 @@ func (m *PortfolioModel) View() string {
 			b.WriteString(LabelStyle.Render("No positions"))
 		} else {
 			b.WriteString(SummaryStyle.Render("Positions"))
-			b.WriteString(LabelStyle.Render(fmt.Sprintf(" (%d)", len(p.Positions))))
+			if m.Hidden > 0 {
+				b.WriteString(LabelStyle.Render(fmt.Sprintf(" (%d, %d under $%.2f hidden)", len(p.Positions), m.Hidden, m.MinValue)))
+			} else {
+				b.WriteString(LabelStyle.Render(fmt.Sprintf(" (%d)", len(p.Positions))))
+			}
 			b.WriteString("\n")
 			b.WriteString(m.Table.View())
 		}
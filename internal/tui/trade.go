@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -15,9 +16,11 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/google/uuid"
 
+	"github.com/jonandersen/public-cli/internal/analytics"
 	"github.com/jonandersen/public-cli/internal/api"
 	"github.com/jonandersen/public-cli/internal/config"
 	"github.com/jonandersen/public-cli/internal/keyring"
+	"github.com/jonandersen/public-cli/internal/money"
 )
 
 // TradeState represents the current state of the trade view.
@@ -70,6 +73,36 @@ func (t TradeOrderType) String() string {
 	return "LIMIT"
 }
 
+// TradeOpenClose represents whether an options order opens or closes a position.
+type TradeOpenClose int
+
+const (
+	TradeOpen TradeOpenClose = iota
+	TradeClose
+)
+
+func (o TradeOpenClose) String() string {
+	if o == TradeOpen {
+		return "OPEN"
+	}
+	return "CLOSE"
+}
+
+// TradeTimeInForce represents how long an order stays working.
+type TradeTimeInForce int
+
+const (
+	TradeTimeInForceDay TradeTimeInForce = iota
+	TradeTimeInForceGTC
+)
+
+func (t TradeTimeInForce) String() string {
+	if t == TradeTimeInForceDay {
+		return "DAY"
+	}
+	return "GTC"
+}
+
 // TradeField represents the currently focused input field.
 type TradeField int
 
@@ -79,6 +112,8 @@ const (
 	TradeFieldOrderType
 	TradeFieldQuantity
 	TradeFieldLimitPrice
+	TradeFieldOpenClose
+	TradeFieldExpiration
 )
 
 // TradeModel holds the state for the trade view.
@@ -98,6 +133,15 @@ type TradeModel struct {
 	Side      TradeSide
 	OrderType TradeOrderType
 
+	// Options-only selections, shown when the symbol is an OSI option
+	OpenClose  TradeOpenClose
+	Expiration TradeTimeInForce
+
+	// Preflight cost estimate for an options order, fetched on review
+	Preflight        *api.OptionsPreflightResponse
+	PreflightErr     error
+	PreflightLoading bool
+
 	// Quote data for the symbol
 	Quote       *Quote
 	QuoteLoaded bool
@@ -119,7 +163,7 @@ type TradeModel struct {
 func NewTradeModel() *TradeModel {
 	symbolInput := textinput.New()
 	symbolInput.Placeholder = "AAPL"
-	symbolInput.CharLimit = 10
+	symbolInput.CharLimit = 21 // long enough for an OSI option symbol
 	symbolInput.Focus()
 
 	quantityInput := textinput.New()
@@ -175,6 +219,58 @@ func (m *TradeModel) SetSymbol(symbol string) {
 	m.State = TradeStateIdle
 }
 
+// TradeOrderParams pre-fills the trade form beyond the symbol.
+type TradeOrderParams struct {
+	Symbol     string
+	Side       TradeSide
+	OrderType  TradeOrderType
+	LimitPrice string
+}
+
+// SetOrder pre-fills the whole trade form (called when duplicating an order).
+// Focus lands on quantity, as with SetSymbol.
+func (m *TradeModel) SetOrder(p TradeOrderParams) {
+	m.reset()
+	m.SetSymbol(p.Symbol)
+	m.Side = p.Side
+	m.OrderType = p.OrderType
+	if p.OrderType == TradeOrderTypeLimit {
+		m.LimitPriceInput.SetValue(p.LimitPrice)
+	}
+}
+
+// tradeParamsFromOrder maps an existing order onto the trade form. The form
+// only supports market and limit orders, so stop-limit orders keep their limit
+// price and plain stop orders become market orders.
+func tradeParamsFromOrder(o Order) TradeOrderParams {
+	p := TradeOrderParams{
+		Symbol:    o.Instrument.Symbol,
+		Side:      TradeSideBuy,
+		OrderType: TradeOrderTypeMarket,
+	}
+	if strings.EqualFold(o.Side, "SELL") {
+		p.Side = TradeSideSell
+	}
+	switch strings.ToUpper(o.Type) {
+	case "LIMIT", "STOP_LIMIT":
+		p.OrderType = TradeOrderTypeLimit
+		p.LimitPrice = o.LimitPrice
+	}
+	return p
+}
+
+// IsOption reports whether the symbol is an OSI option contract. Options
+// orders are always limit orders sized in contracts.
+func (m *TradeModel) IsOption() bool {
+	_, err := analytics.ParseOSI(m.SymbolInput.Value())
+	return err == nil
+}
+
+// isLimit reports whether the order carries a limit price.
+func (m *TradeModel) isLimit() bool {
+	return m.OrderType == TradeOrderTypeLimit || m.IsOption()
+}
+
 // IsTextFieldFocused returns true if focus is on a text input field.
 func (m *TradeModel) IsTextFieldFocused() bool {
 	switch m.FocusedField {
@@ -237,6 +333,18 @@ func (m *TradeModel) Update(msg tea.Msg, cfg *config.Config, store keyring.Store
 		m.QuoteLoaded = false
 		return m, nil
 
+	case TradePreflightMsg:
+		m.PreflightLoading = false
+		m.Preflight = msg.Preflight
+		m.PreflightErr = nil
+		return m, nil
+
+	case TradePreflightErrorMsg:
+		m.PreflightLoading = false
+		m.Preflight = nil
+		m.PreflightErr = msg.Err
+		return m, nil
+
 	case TradeOrderPlacedMsg:
 		m.State = TradeStateSuccess
 		m.OrderID = msg.OrderID
@@ -289,43 +397,23 @@ func (m *TradeModel) Update(msg tea.Msg, cfg *config.Config, store keyring.Store
 			// If form is valid, show confirmation
 			if m.isFormValid() {
 				m.Mode = TradeModeConfirm
+				if m.IsOption() {
+					m.Preflight = nil
+					m.PreflightErr = nil
+					m.PreflightLoading = true
+					return m, FetchTradePreflight(m.optionsOrder(""), cfg, store)
+				}
 			}
 			return m, nil
 
 		case "left", "right":
-			// Toggle side or order type with arrow keys
-			switch m.FocusedField {
-			case TradeFieldSide:
-				if m.Side == TradeSideBuy {
-					m.Side = TradeSideSell
-				} else {
-					m.Side = TradeSideBuy
-				}
-			case TradeFieldOrderType:
-				if m.OrderType == TradeOrderTypeMarket {
-					m.OrderType = TradeOrderTypeLimit
-				} else {
-					m.OrderType = TradeOrderTypeMarket
-				}
-			}
+			// Toggle the focused selection with arrow keys
+			m.toggleFocused()
 			return m, nil
 
 		case " ":
-			// Space toggles side or order type
-			switch m.FocusedField {
-			case TradeFieldSide:
-				if m.Side == TradeSideBuy {
-					m.Side = TradeSideSell
-				} else {
-					m.Side = TradeSideBuy
-				}
-				return m, nil
-			case TradeFieldOrderType:
-				if m.OrderType == TradeOrderTypeMarket {
-					m.OrderType = TradeOrderTypeLimit
-				} else {
-					m.OrderType = TradeOrderTypeMarket
-				}
+			// Space toggles the focused selection
+			if m.toggleFocused() {
 				return m, nil
 			}
 
@@ -367,52 +455,72 @@ func (m *TradeModel) Update(msg tea.Msg, cfg *config.Config, store keyring.Store
 	return m, tea.Batch(cmds...)
 }
 
+// fields lists the form fields in tab order. Options orders swap the order
+// type for open/close and always take a limit price and expiration.
+func (m *TradeModel) fields() []TradeField {
+	if m.IsOption() {
+		return []TradeField{TradeFieldSymbol, TradeFieldSide, TradeFieldOpenClose, TradeFieldQuantity, TradeFieldLimitPrice, TradeFieldExpiration}
+	}
+	fields := []TradeField{TradeFieldSymbol, TradeFieldSide, TradeFieldOrderType, TradeFieldQuantity}
+	if m.OrderType == TradeOrderTypeLimit {
+		fields = append(fields, TradeFieldLimitPrice)
+	}
+	return fields
+}
+
 // nextField moves focus to the next field.
 func (m *TradeModel) nextField() {
 	m.blurAll()
-
-	switch m.FocusedField {
-	case TradeFieldSymbol:
-		m.FocusedField = TradeFieldSide
-	case TradeFieldSide:
-		m.FocusedField = TradeFieldOrderType
-	case TradeFieldOrderType:
-		m.FocusedField = TradeFieldQuantity
-	case TradeFieldQuantity:
-		if m.OrderType == TradeOrderTypeLimit {
-			m.FocusedField = TradeFieldLimitPrice
-		} else {
-			m.FocusedField = TradeFieldSymbol
-		}
-	case TradeFieldLimitPrice:
-		m.FocusedField = TradeFieldSymbol
-	}
-
+	fields := m.fields()
+	i := slices.Index(fields, m.FocusedField)
+	m.FocusedField = fields[(i+1)%len(fields)]
 	m.focusCurrent()
 }
 
 // prevField moves focus to the previous field.
 func (m *TradeModel) prevField() {
 	m.blurAll()
+	fields := m.fields()
+	i := slices.Index(fields, m.FocusedField)
+	if i <= 0 {
+		i = len(fields)
+	}
+	m.FocusedField = fields[i-1]
+	m.focusCurrent()
+}
 
+// toggleFocused flips the focused selection field. It reports false when the
+// focus is on a text input.
+func (m *TradeModel) toggleFocused() bool {
 	switch m.FocusedField {
-	case TradeFieldSymbol:
-		if m.OrderType == TradeOrderTypeLimit {
-			m.FocusedField = TradeFieldLimitPrice
+	case TradeFieldSide:
+		if m.Side == TradeSideBuy {
+			m.Side = TradeSideSell
 		} else {
-			m.FocusedField = TradeFieldQuantity
+			m.Side = TradeSideBuy
 		}
-	case TradeFieldSide:
-		m.FocusedField = TradeFieldSymbol
 	case TradeFieldOrderType:
-		m.FocusedField = TradeFieldSide
-	case TradeFieldQuantity:
-		m.FocusedField = TradeFieldOrderType
-	case TradeFieldLimitPrice:
-		m.FocusedField = TradeFieldQuantity
+		if m.OrderType == TradeOrderTypeMarket {
+			m.OrderType = TradeOrderTypeLimit
+		} else {
+			m.OrderType = TradeOrderTypeMarket
+		}
+	case TradeFieldOpenClose:
+		if m.OpenClose == TradeOpen {
+			m.OpenClose = TradeClose
+		} else {
+			m.OpenClose = TradeOpen
+		}
+	case TradeFieldExpiration:
+		if m.Expiration == TradeTimeInForceDay {
+			m.Expiration = TradeTimeInForceGTC
+		} else {
+			m.Expiration = TradeTimeInForceDay
+		}
+	default:
+		return false
 	}
-
-	m.focusCurrent()
+	return true
 }
 
 func (m *TradeModel) blurAll() {
@@ -441,6 +549,11 @@ func (m *TradeModel) reset() {
 	m.LimitPriceInput.SetValue("")
 	m.Side = TradeSideBuy
 	m.OrderType = TradeOrderTypeMarket
+	m.OpenClose = TradeOpen
+	m.Expiration = TradeTimeInForceDay
+	m.Preflight = nil
+	m.PreflightErr = nil
+	m.PreflightLoading = false
 	m.Quote = nil
 	m.QuoteLoaded = false
 	m.OrderID = ""
@@ -464,8 +577,12 @@ func (m *TradeModel) isFormValid() bool {
 	if err != nil || qtyVal <= 0 {
 		return false
 	}
+	// Options trade in whole contracts
+	if m.IsOption() && qtyVal != float64(int(qtyVal)) {
+		return false
+	}
 
-	if m.OrderType == TradeOrderTypeLimit {
+	if m.isLimit() {
 		price := strings.TrimSpace(m.LimitPriceInput.Value())
 		if price == "" {
 			return false
@@ -485,23 +602,23 @@ func (m *TradeModel) estimatedCost() string {
 	if qty == "" {
 		return "-"
 	}
-	qtyVal, err := strconv.ParseFloat(qty, 64)
-	if err != nil || qtyVal <= 0 {
+	qtyVal, err := money.Parse(qty)
+	if err != nil || qtyVal.Sign() <= 0 {
 		return "-"
 	}
 
-	var price float64
-	if m.OrderType == TradeOrderTypeLimit {
+	var price money.Money
+	if m.isLimit() {
 		priceStr := strings.TrimSpace(m.LimitPriceInput.Value())
 		if priceStr == "" {
 			return "-"
 		}
-		price, err = strconv.ParseFloat(priceStr, 64)
+		price, err = money.Parse(priceStr)
 		if err != nil {
 			return "-"
 		}
 	} else if m.Quote != nil && m.Quote.Last != "" {
-		price, err = strconv.ParseFloat(m.Quote.Last, 64)
+		price, err = money.Parse(m.Quote.Last)
 		if err != nil {
 			return "-"
 		}
@@ -509,8 +626,12 @@ func (m *TradeModel) estimatedCost() string {
 		return "-"
 	}
 
-	cost := qtyVal * price
-	return fmt.Sprintf("$%.2f", cost)
+	cost := price.Mul(qtyVal)
+	if m.IsOption() {
+		// Each contract covers 100 shares
+		cost = cost.Mul(money.FromInt(100))
+	}
+	return "$" + cost.String()
 }
 
 // View renders the trade view.
@@ -542,7 +663,12 @@ func (m *TradeModel) View() string {
 	}
 
 	// Show form
-	b.WriteString(SummaryStyle.Render("Place Order"))
+	isOption := m.IsOption()
+	if isOption {
+		b.WriteString(SummaryStyle.Render("Place Options Order"))
+	} else {
+		b.WriteString(SummaryStyle.Render("Place Order"))
+	}
 	b.WriteString("\n\n")
 
 	// Error message
@@ -561,6 +687,9 @@ func (m *TradeModel) View() string {
 		b.WriteString(LabelStyle.Render(" (fetching quote...)"))
 	} else if m.QuoteLoaded && m.Quote != nil {
 		b.WriteString(LabelStyle.Render(fmt.Sprintf(" $%s", m.Quote.Last)))
+		if api.IsHalted(*m.Quote) {
+			b.WriteString(ErrorStyle.Render(" HALTED"))
+		}
 	} else if m.FocusedField == TradeFieldSymbol {
 		b.WriteString(LabelStyle.Render(" (press 'w' for watchlist)"))
 	}
@@ -578,28 +707,44 @@ func (m *TradeModel) View() string {
 	b.WriteString(m.renderToggle([]string{"BUY", "SELL"}, int(m.Side), m.FocusedField == TradeFieldSide))
 	b.WriteString("\n\n")
 
-	// Order type toggle (MARKET/LIMIT) - label on its own line
-	typeStyle := LabelStyle
-	if m.FocusedField == TradeFieldOrderType {
-		typeStyle = ValueStyle
+	if isOption {
+		// Open/close toggle replaces the order type: options orders are limit only
+		posStyle := LabelStyle
+		if m.FocusedField == TradeFieldOpenClose {
+			posStyle = ValueStyle
+		}
+		b.WriteString(posStyle.Render("Position:"))
+		b.WriteString("\n")
+		b.WriteString(m.renderToggle([]string{"OPEN", "CLOSE"}, int(m.OpenClose), m.FocusedField == TradeFieldOpenClose))
+		b.WriteString("\n\n")
+	} else {
+		// Order type toggle (MARKET/LIMIT) - label on its own line
+		typeStyle := LabelStyle
+		if m.FocusedField == TradeFieldOrderType {
+			typeStyle = ValueStyle
+		}
+		b.WriteString(typeStyle.Render("Type:"))
+		b.WriteString("\n")
+		b.WriteString(m.renderToggle([]string{"MARKET", "LIMIT"}, int(m.OrderType), m.FocusedField == TradeFieldOrderType))
+		b.WriteString("\n\n")
 	}
-	b.WriteString(typeStyle.Render("Type:"))
-	b.WriteString("\n")
-	b.WriteString(m.renderToggle([]string{"MARKET", "LIMIT"}, int(m.OrderType), m.FocusedField == TradeFieldOrderType))
-	b.WriteString("\n\n")
 
 	// Quantity input - label on its own line
 	qtyStyle := LabelStyle
 	if m.FocusedField == TradeFieldQuantity {
 		qtyStyle = ValueStyle
 	}
-	b.WriteString(qtyStyle.Render("Quantity:"))
+	qtyLabel := "Quantity:"
+	if isOption {
+		qtyLabel = "Contracts:"
+	}
+	b.WriteString(qtyStyle.Render(qtyLabel))
 	b.WriteString("\n")
 	b.WriteString(m.renderTextInput(m.QuantityInput, m.FocusedField == TradeFieldQuantity))
 	b.WriteString("\n\n")
 
 	// Limit price input (only for limit orders) - label on its own line
-	if m.OrderType == TradeOrderTypeLimit {
+	if m.isLimit() {
 		priceStyle := LabelStyle
 		if m.FocusedField == TradeFieldLimitPrice {
 			priceStyle = ValueStyle
@@ -610,6 +755,18 @@ func (m *TradeModel) View() string {
 		b.WriteString("\n\n")
 	}
 
+	// Expiration toggle (options only) - label on its own line
+	if isOption {
+		expStyle := LabelStyle
+		if m.FocusedField == TradeFieldExpiration {
+			expStyle = ValueStyle
+		}
+		b.WriteString(expStyle.Render("Expires:"))
+		b.WriteString("\n")
+		b.WriteString(m.renderToggle([]string{"DAY", "GTC"}, int(m.Expiration), m.FocusedField == TradeFieldExpiration))
+		b.WriteString("\n\n")
+	}
+
 	// Estimated cost
 	b.WriteString(LabelStyle.Render("Est. Cost:  "))
 	cost := m.estimatedCost()
@@ -697,23 +854,44 @@ func (m *TradeModel) renderConfirmation() string {
 	}
 	b.WriteString(" ")
 	b.WriteString(ValueStyle.Render(qty))
-	b.WriteString(" shares of ")
-	b.WriteString(ValueStyle.Render(symbol))
+	if m.IsOption() {
+		b.WriteString(" contracts of ")
+		b.WriteString(ValueStyle.Render(symbol))
+		b.WriteString(" to " + strings.ToLower(m.OpenClose.String()))
+	} else {
+		b.WriteString(" shares of ")
+		b.WriteString(ValueStyle.Render(symbol))
+	}
 	b.WriteString("\n\n")
 
+	orderType := m.OrderType.String()
+	if m.IsOption() {
+		orderType = TradeOrderTypeLimit.String()
+	}
 	b.WriteString(LabelStyle.Render("Order Type: "))
-	b.WriteString(ValueStyle.Render(m.OrderType.String()))
+	b.WriteString(ValueStyle.Render(orderType))
 	b.WriteString("\n")
 
-	if m.OrderType == TradeOrderTypeLimit {
+	if m.isLimit() {
 		b.WriteString(LabelStyle.Render("Limit Price: "))
 		b.WriteString(ValueStyle.Render("$" + m.LimitPriceInput.Value()))
 		b.WriteString("\n")
 	}
 
+	if m.IsOption() {
+		b.WriteString(LabelStyle.Render("Expires:    "))
+		b.WriteString(ValueStyle.Render(m.Expiration.String()))
+		b.WriteString("\n")
+	}
+
 	b.WriteString(LabelStyle.Render("Est. Cost:  "))
 	b.WriteString(ValueStyle.Render(m.estimatedCost()))
-	b.WriteString("\n\n")
+	b.WriteString("\n")
+
+	if m.IsOption() {
+		b.WriteString(m.renderPreflight())
+	}
+	b.WriteString("\n")
 
 	if m.State == TradeStateSubmitting {
 		b.WriteString(LabelStyle.Render("Submitting order..."))
@@ -727,6 +905,74 @@ func (m *TradeModel) renderConfirmation() string {
 	return b.String()
 }
 
+// renderPreflight shows the broker's cost estimate for an options order.
+func (m *TradeModel) renderPreflight() string {
+	var b strings.Builder
+	switch {
+	case m.PreflightLoading:
+		b.WriteString(LabelStyle.Render("Estimating cost..."))
+		b.WriteString("\n")
+	case m.PreflightErr != nil:
+		b.WriteString(LabelStyle.Render(fmt.Sprintf("Cost estimate: unavailable (%v)", m.PreflightErr)))
+		b.WriteString("\n")
+	case m.Preflight != nil:
+		b.WriteString(LabelStyle.Render("Order Value: "))
+		b.WriteString(ValueStyle.Render("$" + m.Preflight.OrderValue))
+		b.WriteString("\n")
+		b.WriteString(LabelStyle.Render("Commission:  "))
+		b.WriteString(ValueStyle.Render("$" + m.Preflight.EstimatedCommission))
+		b.WriteString("\n")
+		b.WriteString(LabelStyle.Render("Total Cost:  "))
+		b.WriteString(ValueStyle.Render("$" + m.Preflight.EstimatedCost))
+		b.WriteString("\n")
+		b.WriteString(LabelStyle.Render("Buying Power: "))
+		b.WriteString(ValueStyle.Render("$" + m.Preflight.BuyingPowerRequirement))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// optionsOrder builds the single-leg options order described by the form.
+func (m *TradeModel) optionsOrder(orderID string) api.OptionsOrderRequest {
+	return api.OptionsOrderRequest{
+		OrderID: orderID,
+		Instrument: api.OrderInstrument{
+			Symbol: strings.ToUpper(strings.TrimSpace(m.SymbolInput.Value())),
+			Type:   "OPTION",
+		},
+		OrderSide:          m.Side.String(),
+		OrderType:          TradeOrderTypeLimit.String(),
+		Expiration:         api.OrderExpiration{TimeInForce: m.Expiration.String()},
+		Quantity:           strings.TrimSpace(m.QuantityInput.Value()),
+		LimitPrice:         strings.TrimSpace(m.LimitPriceInput.Value()),
+		OpenCloseIndicator: m.OpenClose.String(),
+	}
+}
+
+// equityOrder builds the stock order described by the form. The instrument
+// is resolved as FetchTradeQuote resolves it, so the order is for the
+// instrument the form was quoted as.
+func (m *TradeModel) equityOrder(orderID string) map[string]any {
+	inst := api.ResolveInstruments([]string{m.SymbolInput.Value()})[0]
+	order := map[string]any{
+		"orderId": orderID,
+		"instrument": map[string]string{
+			"symbol": inst.Symbol,
+			"type":   inst.Type,
+		},
+		"orderSide": m.Side.String(),
+		"orderType": m.OrderType.String(),
+		"expiration": map[string]string{
+			"timeInForce": "DAY",
+		},
+		"quantity": m.QuantityInput.Value(),
+	}
+	if m.OrderType == TradeOrderTypeLimit {
+		order["limitPrice"] = m.LimitPriceInput.Value()
+	}
+	return order
+}
+
 // Message types for trade operations
 
 // TradeQuoteMsg is sent when a quote is fetched for the trade form.
@@ -750,6 +996,16 @@ type TradeOrderErrorMsg struct {
 	Err error
 }
 
+// TradePreflightMsg is sent when the cost estimate for an options order arrives.
+type TradePreflightMsg struct {
+	Preflight *api.OptionsPreflightResponse
+}
+
+// TradePreflightErrorMsg is sent when the options cost estimate fails.
+type TradePreflightErrorMsg struct {
+	Err error
+}
+
 // FetchTradeQuote returns a command that fetches a quote for the trade form.
 func FetchTradeQuote(symbol string, cfg *config.Config, store keyring.Store) tea.Cmd {
 	return func() tea.Msg {
@@ -766,9 +1022,7 @@ func FetchTradeQuote(symbol string, cfg *config.Config, store keyring.Store) tea
 		defer cancel()
 
 		reqBody := QuoteRequest{
-			Instruments: []QuoteInstrument{
-				{Symbol: strings.ToUpper(symbol), Type: "EQUITY"},
-			},
+			Instruments: api.ResolveInstruments([]string{symbol}),
 		}
 		body, err := json.Marshal(reqBody)
 		if err != nil {
@@ -798,7 +1052,7 @@ func FetchTradeQuote(symbol string, cfg *config.Config, store keyring.Store) tea
 		}
 
 		quote := quotesResp.Quotes[0]
-		if quote.Outcome != "SUCCESS" {
+		if quote.Outcome != "SUCCESS" && !api.IsHalted(quote) {
 			return TradeQuoteErrorMsg{Err: fmt.Errorf("invalid symbol: %s", symbol)}
 		}
 
@@ -806,6 +1060,66 @@ func FetchTradeQuote(symbol string, cfg *config.Config, store keyring.Store) tea
 	}
 }
 
+// FetchTradePreflight returns a command that estimates the cost of an options
+// order without placing it.
+func FetchTradePreflight(order api.OptionsOrderRequest, cfg *config.Config, store keyring.Store) tea.Cmd {
+	return func() tea.Msg {
+		if cfg.AccountUUID == "" {
+			return TradePreflightErrorMsg{Err: fmt.Errorf("no account configured")}
+		}
+
+		token, err := api.GetAuthToken(store, cfg.APIBaseURL, false)
+		if err != nil {
+			return TradePreflightErrorMsg{Err: err}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		client := api.NewClient(cfg.APIBaseURL, token)
+		preflight, err := client.PreflightSingleLeg(ctx, cfg.AccountUUID, api.OptionsPreflightRequest{
+			Instrument:         order.Instrument,
+			OrderSide:          order.OrderSide,
+			OrderType:          order.OrderType,
+			Expiration:         order.Expiration,
+			Quantity:           order.Quantity,
+			LimitPrice:         order.LimitPrice,
+			OpenCloseIndicator: order.OpenCloseIndicator,
+		})
+		if err != nil {
+			return TradePreflightErrorMsg{Err: err}
+		}
+		return TradePreflightMsg{Preflight: preflight}
+	}
+}
+
+// checkOrderLimits applies the guards every CLI order path enforces:
+// max_shares or max_contracts, require_reason, and the trading halt check.
+// The form has no --force or --reason, so an order that trips them has to be
+// placed from the CLI.
+func (m *TradeModel) checkOrderLimits(cfg *config.Config) error {
+	if cfg.RequireReason {
+		return fmt.Errorf("require_reason is set in config - place this order from the CLI with --reason")
+	}
+	if m.Quote != nil && api.IsHalted(*m.Quote) {
+		return fmt.Errorf("trading in %s is halted - place the order from the CLI with --force to queue it anyway",
+			strings.ToUpper(strings.TrimSpace(m.SymbolInput.Value())))
+	}
+	qty, err := strconv.ParseFloat(strings.TrimSpace(m.QuantityInput.Value()), 64)
+	if err != nil {
+		return nil
+	}
+	limit, unit, key := cfg.MaxShares, "shares", "max_shares"
+	if m.IsOption() {
+		limit, unit, key = float64(cfg.MaxContracts), "contracts", "max_contracts"
+	}
+	if limit > 0 && qty > limit {
+		return fmt.Errorf("order of %s %s exceeds %s %s - place it from the CLI with --force to override",
+			strconv.FormatFloat(qty, 'f', -1, 64), unit, key, strconv.FormatFloat(limit, 'f', -1, 64))
+	}
+	return nil
+}
+
 // PlaceOrder returns a command that places an order.
 func PlaceOrder(m *TradeModel, cfg *config.Config, store keyring.Store) tea.Cmd {
 	return func() tea.Msg {
@@ -813,10 +1127,14 @@ func PlaceOrder(m *TradeModel, cfg *config.Config, store keyring.Store) tea.Cmd
 			return TradeOrderErrorMsg{Err: fmt.Errorf("no account configured")}
 		}
 
-		if !cfg.TradingEnabled {
+		if !cfg.TradingEnabled() {
 			return TradeOrderErrorMsg{Err: fmt.Errorf("trading is disabled - enable in config")}
 		}
 
+		if err := m.checkOrderLimits(cfg); err != nil {
+			return TradeOrderErrorMsg{Err: err}
+		}
+
 		token, err := api.GetAuthToken(store, cfg.APIBaseURL, false)
 		if err != nil {
 			return TradeOrderErrorMsg{Err: err}
@@ -828,25 +1146,15 @@ func PlaceOrder(m *TradeModel, cfg *config.Config, store keyring.Store) tea.Cmd
 		symbol := strings.ToUpper(strings.TrimSpace(m.SymbolInput.Value()))
 		orderID := uuid.New().String()
 
-		orderReq := map[string]any{
-			"orderId": orderID,
-			"instrument": map[string]string{
-				"symbol": symbol,
-				"type":   "EQUITY",
-			},
-			"orderSide": m.Side.String(),
-			"orderType": m.OrderType.String(),
-			"expiration": map[string]string{
-				"timeInForce": "DAY",
-			},
-			"quantity": m.QuantityInput.Value(),
-		}
-
-		if m.OrderType == TradeOrderTypeLimit {
-			orderReq["limitPrice"] = m.LimitPriceInput.Value()
+		if m.IsOption() {
+			client := api.NewClient(cfg.APIBaseURL, token)
+			if _, err := client.PlaceOptionsOrder(ctx, cfg.AccountUUID, m.optionsOrder(orderID)); err != nil {
+				return TradeOrderErrorMsg{Err: err}
+			}
+			return TradeOrderPlacedMsg{OrderID: orderID, Symbol: symbol}
 		}
 
-		body, err := json.Marshal(orderReq)
+		body, err := json.Marshal(m.equityOrder(orderID))
 		if err != nil {
 			return TradeOrderErrorMsg{Err: fmt.Errorf("failed to encode request: %w", err)}
 		}
@@ -1,10 +1,15 @@
 package tui
 
 import (
+	"errors"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jonandersen/public-cli/internal/api"
+	"github.com/jonandersen/public-cli/internal/config"
 )
 
 func TestNewTradeModel(t *testing.T) {
@@ -145,6 +150,17 @@ func TestTradeViewHidesHintWhenQuoteLoaded(t *testing.T) {
 	assert.Contains(t, view, "$150.00")
 }
 
+func TestTradeViewShowsHaltedBadge(t *testing.T) {
+	m := NewTradeModel()
+	m.QuoteLoaded = true
+	m.Quote = &Quote{Outcome: "SUCCESS", Status: "HALTED", Last: "12.40"}
+
+	view := m.View()
+
+	assert.Contains(t, view, "$12.40")
+	assert.Contains(t, view, "HALTED")
+}
+
 func TestTradeViewShowsAssetSelectorWhenOpen(t *testing.T) {
 	m := NewTradeModel()
 	m.AssetSelector = NewAssetSelectorModel(AssetSelectorModeWatchlist)
@@ -154,3 +170,163 @@ func TestTradeViewShowsAssetSelectorWhenOpen(t *testing.T) {
 
 	assert.Contains(t, view, "Select Asset")
 }
+
+func TestTradeModelOptionForm(t *testing.T) {
+	m := NewTradeModel()
+	m.SetSymbol("aapl250117c00175000")
+
+	require.True(t, m.IsOption())
+	view := m.View()
+	assert.Contains(t, view, "Place Options Order")
+	assert.Contains(t, view, "Contracts:")
+	assert.Contains(t, view, "Position:")
+	assert.Contains(t, view, "Limit Price:")
+	assert.Contains(t, view, "Expires:")
+	assert.NotContains(t, view, "Type:")
+
+	// Tab order skips the order type and ends on expiration
+	var order []TradeField
+	for range 6 {
+		m.nextField()
+		order = append(order, m.FocusedField)
+	}
+	assert.Equal(t, []TradeField{
+		TradeFieldLimitPrice, TradeFieldExpiration, TradeFieldSymbol,
+		TradeFieldSide, TradeFieldOpenClose, TradeFieldQuantity,
+	}, order)
+	m.prevField()
+	assert.Equal(t, TradeFieldOpenClose, m.FocusedField)
+
+	m.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}, testConfig(), testStore())
+	assert.Equal(t, TradeClose, m.OpenClose)
+}
+
+func TestTradeModelOptionValidationAndCost(t *testing.T) {
+	m := NewTradeModel()
+	m.SetSymbol("AAPL250117C00175000")
+	m.QuantityInput.SetValue("2")
+
+	// Options always need a limit price, even with the MARKET type selected
+	assert.False(t, m.isFormValid())
+	m.LimitPriceInput.SetValue("1.50")
+	assert.True(t, m.isFormValid())
+	assert.Equal(t, "$300.00", m.estimatedCost())
+
+	m.QuantityInput.SetValue("1.5")
+	assert.False(t, m.isFormValid())
+}
+
+func TestTradeModelOptionConfirmShowsPreflight(t *testing.T) {
+	m := NewTradeModel()
+	m.SetSymbol("AAPL250117C00175000")
+	m.QuantityInput.SetValue("2")
+	m.LimitPriceInput.SetValue("1.50")
+	m.FocusedField = TradeFieldQuantity
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter}, testConfig(), testStore())
+	require.Equal(t, TradeModeConfirm, m.Mode)
+	assert.NotNil(t, cmd)
+	assert.True(t, m.PreflightLoading)
+	view := m.View()
+	assert.Contains(t, view, "contracts of")
+	assert.Contains(t, view, "to open")
+	assert.Contains(t, view, "Estimating cost...")
+
+	m, _ = m.Update(TradePreflightMsg{Preflight: &api.OptionsPreflightResponse{
+		OrderValue:             "300.00",
+		EstimatedCommission:    "0.00",
+		EstimatedCost:          "300.13",
+		BuyingPowerRequirement: "300.13",
+	}}, testConfig(), testStore())
+	assert.False(t, m.PreflightLoading)
+	assert.Contains(t, m.View(), "$300.13")
+
+	m, _ = m.Update(TradePreflightErrorMsg{Err: errors.New("preflight API error: 500 - boom")}, testConfig(), testStore())
+	assert.Contains(t, m.View(), "Cost estimate: unavailable")
+}
+
+func TestTradeModelOptionsOrder(t *testing.T) {
+	m := NewTradeModel()
+	m.SetSymbol("AAPL250117P00175000")
+	m.Side = TradeSideSell
+	m.OpenClose = TradeClose
+	m.Expiration = TradeTimeInForceGTC
+	m.QuantityInput.SetValue("3")
+	m.LimitPriceInput.SetValue("2.05")
+
+	assert.Equal(t, api.OptionsOrderRequest{
+		OrderID:            "order-1",
+		Instrument:         api.OrderInstrument{Symbol: "AAPL250117P00175000", Type: "OPTION"},
+		OrderSide:          "SELL",
+		OrderType:          "LIMIT",
+		Expiration:         api.OrderExpiration{TimeInForce: "GTC"},
+		Quantity:           "3",
+		LimitPrice:         "2.05",
+		OpenCloseIndicator: "CLOSE",
+	}, m.optionsOrder("order-1"))
+}
+
+func TestTradeModelEquityOrder(t *testing.T) {
+	m := NewTradeModel()
+	m.SetSymbol("brk-b")
+	m.OrderType = TradeOrderTypeLimit
+	m.QuantityInput.SetValue("3")
+	m.LimitPriceInput.SetValue("410.00")
+
+	order := m.equityOrder("order-1")
+	assert.Equal(t, map[string]string{"symbol": "BRK.B", "type": "EQUITY"}, order["instrument"])
+	assert.Equal(t, "LIMIT", order["orderType"])
+	assert.Equal(t, "410.00", order["limitPrice"])
+
+	// The order is for the instrument the form quotes
+	for _, symbol := range []string{"brk-b", "BTC-USD", "$aapl"} {
+		m.SetSymbol(symbol)
+		inst := api.ResolveInstruments([]string{symbol})[0]
+		assert.Equal(t, map[string]string{"symbol": inst.Symbol, "type": inst.Type}, m.equityOrder("order-1")["instrument"])
+	}
+}
+
+func TestTradeModelCheckOrderLimits(t *testing.T) {
+	m := NewTradeModel()
+	m.SetSymbol("AAPL")
+	m.QuantityInput.SetValue("150")
+
+	assert.NoError(t, m.checkOrderLimits(&config.Config{}))
+	assert.NoError(t, m.checkOrderLimits(&config.Config{MaxShares: 150}))
+
+	err := m.checkOrderLimits(&config.Config{MaxShares: 100})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "order of 150 shares exceeds max_shares 100")
+
+	// Options are held to max_contracts instead
+	m.SetSymbol("AAPL250117P00175000")
+	m.QuantityInput.SetValue("5")
+	assert.NoError(t, m.checkOrderLimits(&config.Config{MaxShares: 1}))
+	err = m.checkOrderLimits(&config.Config{MaxContracts: 2})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "order of 5 contracts exceeds max_contracts 2")
+
+	err = m.checkOrderLimits(&config.Config{RequireReason: true})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "require_reason is set in config")
+
+	// A halted quote blocks the order; the CLI has --force for it
+	m.QuantityInput.SetValue("1")
+	m.Quote = &Quote{Outcome: "SUCCESS", Status: "HALTED", Last: "2.50"}
+	err = m.checkOrderLimits(&config.Config{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "trading in AAPL250117P00175000 is halted")
+	assert.Contains(t, err.Error(), "--force")
+}
+
+func TestTradeModelEquityTabOrderUnchanged(t *testing.T) {
+	m := NewTradeModel()
+	m.SetSymbol("AAPL")
+	require.False(t, m.IsOption())
+
+	m.nextField()
+	assert.Equal(t, TradeFieldSymbol, m.FocusedField)
+	m.OrderType = TradeOrderTypeLimit
+	m.prevField()
+	assert.Equal(t, TradeFieldLimitPrice, m.FocusedField)
+}
@@ -28,6 +28,39 @@ const (
 	ViewHistory
 )
 
+// viewNames maps the names accepted by ParseView to views, in tab order.
+var viewNames = []struct {
+	name string
+	view View
+}{
+	{"portfolio", ViewPortfolio},
+	{"watchlist", ViewWatchlist},
+	{"orders", ViewOrders},
+	{"trade", ViewTrade},
+	{"options", ViewOptions},
+	{"history", ViewHistory},
+}
+
+// ViewNames returns the names accepted by ParseView, in tab order.
+func ViewNames() []string {
+	names := make([]string, len(viewNames))
+	for i, v := range viewNames {
+		names[i] = v.name
+	}
+	return names
+}
+
+// ParseView returns the view with the given name, such as "orders".
+func ParseView(name string) (View, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, v := range viewNames {
+		if v.name == name {
+			return v.view, nil
+		}
+	}
+	return ViewPortfolio, fmt.Errorf("unknown view %q (valid views: %s)", name, strings.Join(ViewNames(), ", "))
+}
+
 // Model is the main bubbletea model for the TUI.
 type Model struct {
 	currentView View
@@ -56,6 +89,8 @@ type Model struct {
 	selectedAccountID string
 	accountPickerOpen bool
 	accountCursor     int
+	accountsLoading   bool  // a FetchAccounts request is in flight
+	accountsErr       error // last account loading failure; retried on tick or [a]
 
 	// Toolbar navigation
 	toolbarFocused bool
@@ -63,20 +98,55 @@ type Model struct {
 
 // New creates a new TUI model.
 func New(cfg *config.Config, uiCfg *UIConfig, store keyring.Store) Model {
+	options := NewOptionsModel()
+	if uiCfg.ATMTolerancePct > 0 {
+		options.ATMTolerancePct = uiCfg.ATMTolerancePct
+	}
+	if uiCfg.GreeksPrecision > 0 && uiCfg.GreeksPrecision <= MaxGreeksPrecision {
+		options.GreeksPrecision = uiCfg.GreeksPrecision
+	}
+
+	portfolio := NewPortfolioModel()
+	if uiCfg.MinPositionValue > 0 {
+		portfolio.MinValue = uiCfg.MinPositionValue
+	}
+
+	watchlist := NewWatchlistModel(uiCfg.Watchlist)
+	if uiCfg.ShowQuoteSession {
+		watchlist.SetShowSession(true)
+	}
+
 	return Model{
 		currentView:       ViewPortfolio,
 		cfg:               cfg,
 		uiCfg:             uiCfg,
 		store:             store,
-		portfolio:         NewPortfolioModel(),
-		watchlist:         NewWatchlistModel(uiCfg.Watchlist),
+		portfolio:         portfolio,
+		watchlist:         watchlist,
 		orders:            NewOrdersModel(),
 		trade:             NewTradeModel(),
-		options:           NewOptionsModel(),
+		options:           options,
 		history:           NewHistoryModel(),
-		refreshInterval:   30 * time.Second,
+		refreshInterval:   refreshInterval(cfg),
 		selectedAccountID: cfg.AccountUUID,
+		accountsLoading:   true,
+	}
+}
+
+// refreshInterval is how often the TUI refreshes: refresh_interval_seconds
+// from config, or 30 seconds when it is not set.
+func refreshInterval(cfg *config.Config) time.Duration {
+	if cfg.RefreshIntervalSeconds > 0 {
+		return time.Duration(cfg.RefreshIntervalSeconds) * time.Second
 	}
+	return 30 * time.Second
+}
+
+// WithView returns the model opened on view v instead of Portfolio. Init
+// fetches whatever the view needs.
+func (m Model) WithView(v View) Model {
+	m.currentView = v
+	return m
 }
 
 // Init implements tea.Model.
@@ -89,6 +159,16 @@ func (m Model) Init() tea.Cmd {
 	if len(m.watchlist.Symbols) > 0 {
 		cmds = append(cmds, FetchWatchlistQuotes(m.watchlist.Symbols, m.cfg, m.store))
 	}
+
+	// Load the starting view when it is not covered above
+	switch m.currentView {
+	case ViewOrders:
+		cmds = append(cmds, FetchOrders(m.cfg, m.store))
+	case ViewTrade:
+		cmds = append(cmds, m.trade.FocusSymbol())
+	case ViewHistory:
+		cmds = append(cmds, FetchHistory(m.cfg, m.store))
+	}
 	return tea.Batch(cmds...)
 }
 
@@ -123,17 +203,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 				return m, nil
 			case "enter":
-				if len(m.accounts) > 0 && m.accountCursor < len(m.accounts) {
-					newAccountID := m.accounts[m.accountCursor].AccountID
-					if newAccountID != m.selectedAccountID {
-						m.selectedAccountID = newAccountID
-						m.cfg.AccountUUID = newAccountID
-						m.accountPickerOpen = false
-						// Refresh data for the new account
-						return m, m.refreshCurrentView()
-					}
+				return m.selectAccount(m.accountCursor)
+			case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+				// Quick select: jump straight to the Nth account
+				n := int(msg.Runes[0] - '0')
+				if n <= len(m.accounts) {
+					m.accountCursor = n - 1
+					return m.selectAccount(n - 1)
 				}
-				m.accountPickerOpen = false
 				return m, nil
 			case "q", "ctrl+c":
 				return m, tea.Quit
@@ -308,6 +385,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Let watchlist handle it in the default case below
 			if m.currentView == ViewWatchlist && m.watchlist.Mode == WatchlistModeNormal {
 				// Fall through to default case
+			} else if len(m.accounts) == 0 && m.accountsErr != nil {
+				// Account loading failed - retry now instead of waiting for the tick
+				if !m.accountsLoading {
+					m.accountsLoading = true
+					return m, FetchAccounts(m.cfg, m.store)
+				}
+				return m, nil
 			} else if len(m.accounts) > 0 {
 				// Open account picker if we have accounts
 				m.accountPickerOpen = true
@@ -392,6 +476,24 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					cmds = append(cmds, cmd)
 				}
 			case ViewOrders:
+				switch msg.String() {
+				case "n":
+					// New order: jump to trade with a blank form
+					m.trade.reset()
+					m.trade.SetWatchlistData(m.watchlist.Symbols, m.watchlist.Quotes)
+					m.currentView = ViewTrade
+					return m, m.trade.FocusSymbol()
+				case "d":
+					// Duplicate the selected order into the trade form
+					if order := m.orders.SelectedOrder(); order != nil {
+						params := tradeParamsFromOrder(*order)
+						m.trade.SetOrder(params)
+						m.trade.SetWatchlistData(m.watchlist.Symbols, m.watchlist.Quotes)
+						m.currentView = ViewTrade
+						return m, FetchTradeQuote(params.Symbol, m.cfg, m.store)
+					}
+					return m, nil
+				}
 				m.orders, cmd, _ = m.orders.Update(msg, m.cfg, m.store)
 				if cmd != nil {
 					cmds = append(cmds, cmd)
@@ -434,10 +536,22 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.orders, cmd, _ = m.orders.Update(msg, m.cfg, m.store)
 		cmds = append(cmds, cmd)
 
-	case TradeQuoteMsg, TradeQuoteErrorMsg, TradeOrderPlacedMsg, TradeOrderErrorMsg:
+	case TradeQuoteMsg, TradeQuoteErrorMsg, TradeOrderPlacedMsg, TradeOrderErrorMsg, TradePreflightMsg, TradePreflightErrorMsg:
 		m.trade, cmd = m.trade.Update(msg, m.cfg, m.store)
 		cmds = append(cmds, cmd)
 
+	case OptionTradeMsg:
+		// Jump to trade from the options chain
+		m.trade.SetOrder(TradeOrderParams{
+			Symbol:     msg.Symbol,
+			Side:       TradeSideBuy,
+			OrderType:  TradeOrderTypeLimit,
+			LimitPrice: msg.LimitPrice,
+		})
+		m.trade.SetWatchlistData(m.watchlist.Symbols, m.watchlist.Quotes)
+		m.currentView = ViewTrade
+		cmds = append(cmds, FetchTradeQuote(msg.Symbol, m.cfg, m.store))
+
 	case AssetSelectedMsg, AssetSelectorCancelledMsg:
 		// Route asset selector messages to the appropriate view
 		if m.currentView == ViewTrade && m.trade.ShowAssetSelector {
@@ -457,6 +571,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds = append(cmds, cmd)
 
 	case AccountsLoadedMsg:
+		m.accountsLoading = false
+		m.accountsErr = nil
 		m.accounts = msg.Accounts
 		// If no account is selected but we have accounts, select the first one
 		if m.selectedAccountID == "" && len(m.accounts) > 0 {
@@ -465,8 +581,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case AccountsErrorMsg:
-		// Silently ignore account loading errors - we still have the default account
-		_ = msg.Err
+		// Keep the default account usable; the header shows the failure and the
+		// next tick (or [a]) retries
+		m.accountsLoading = false
+		m.accountsErr = msg.Err
 
 	case ToolbarFocusMsg:
 		// Child view requested toolbar focus
@@ -481,6 +599,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		} else if m.currentView == ViewOrders && m.orders.State != OrdersStateLoading {
 			cmds = append(cmds, FetchOrders(m.cfg, m.store))
 		}
+		if m.accountsErr != nil && !m.accountsLoading {
+			m.accountsLoading = true
+			cmds = append(cmds, FetchAccounts(m.cfg, m.store))
+		}
 		cmds = append(cmds, m.tickCmd())
 	}
 
@@ -601,10 +723,24 @@ func (m Model) renderHeader() string {
 	} else if len(m.accounts) > 0 {
 		accountIndicator = "[a] Select account"
 	}
+	accountsFailed := m.accountsErr != nil && len(m.accounts) == 0
+	if accountsFailed {
+		status := "retry accounts"
+		if m.accountsLoading {
+			status = "retrying accounts..."
+		}
+		if m.selectedAccountID == "" {
+			accountIndicator = "[a] " + status
+		} else {
+			accountIndicator += " ! " + status
+		}
+	}
 
 	accountStyle := lipgloss.NewStyle().Padding(0, 1).Foreground(ColorMuted)
 	if m.accountPickerOpen {
 		accountStyle = accountStyle.Foreground(ColorPrimary).Bold(true)
+	} else if accountsFailed {
+		accountStyle = accountStyle.Foreground(ColorRed)
 	}
 	accountStr := accountStyle.Render(accountIndicator)
 
@@ -661,6 +797,7 @@ func (m Model) renderFooter() string {
 	if m.accountPickerOpen {
 		keys = []struct{ key, desc string }{
 			{"↑/↓", "navigate"},
+			{"1-9", "quick select"},
 			{"enter", "select"},
 			{"esc", "close"},
 			{"q", "quit"},
@@ -727,6 +864,7 @@ func (m Model) renderFooter() string {
 			keys = append(keys, struct{ key, desc string }{"↑/↓", "navigate"})
 			keys = append(keys, struct{ key, desc string }{"a", "add"})
 			keys = append(keys, struct{ key, desc string }{"d", "delete"})
+			keys = append(keys, struct{ key, desc string }{"K/J", "move"})
 			keys = append(keys, struct{ key, desc string }{"enter", "trade"})
 			keys = append(keys, struct{ key, desc string }{"esc", "toolbar"})
 			keys = append(keys, struct{ key, desc string }{"r", "refresh"})
@@ -746,6 +884,8 @@ func (m Model) renderFooter() string {
 		case OrdersModeNormal:
 			keys = append(keys, struct{ key, desc string }{"↑/↓", "navigate"})
 			keys = append(keys, struct{ key, desc string }{"c", "cancel order"})
+			keys = append(keys, struct{ key, desc string }{"n", "new order"})
+			keys = append(keys, struct{ key, desc string }{"d", "duplicate"})
 			keys = append(keys, struct{ key, desc string }{"esc", "toolbar"})
 			keys = append(keys, struct{ key, desc string }{"r", "refresh"})
 		case OrdersModeCanceling:
@@ -821,6 +961,23 @@ func (m Model) refreshCurrentView() tea.Cmd {
 	return tea.Batch(cmds...)
 }
 
+// selectAccount makes the account at index i active and closes the picker.
+// Data is refreshed only when the selection actually changes.
+func (m Model) selectAccount(i int) (tea.Model, tea.Cmd) {
+	m.accountPickerOpen = false
+	if i < 0 || i >= len(m.accounts) {
+		return m, nil
+	}
+	newAccountID := m.accounts[i].AccountID
+	if newAccountID == m.selectedAccountID {
+		return m, nil
+	}
+	m.selectedAccountID = newAccountID
+	m.cfg.AccountUUID = newAccountID
+	// Refresh data for the new account
+	return m, m.refreshCurrentView()
+}
+
 // renderAccountPicker renders the account picker in the content area.
 func (m Model) renderAccountPicker() string {
 	var b strings.Builder
@@ -835,7 +992,12 @@ func (m Model) renderAccountPicker() string {
 			accType = acc.BrokerageAccountType
 		}
 
-		line := fmt.Sprintf("%-40s %s", acc.AccountID, accType)
+		// Number the first nine accounts for quick selection
+		index := "   "
+		if i < 9 {
+			index = fmt.Sprintf("%d. ", i+1)
+		}
+		line := fmt.Sprintf("%s%-40s %s", index, acc.AccountID, accType)
 
 		if i == m.accountCursor {
 			// Selected row
@@ -862,6 +1024,8 @@ func (m Model) renderAccountPicker() string {
 	b.WriteString("\n")
 	b.WriteString(KeyStyle.Render("↑/↓"))
 	b.WriteString(LabelStyle.Render(" navigate  "))
+	b.WriteString(KeyStyle.Render("1-9"))
+	b.WriteString(LabelStyle.Render(" quick select  "))
 	b.WriteString(KeyStyle.Render("enter"))
 	b.WriteString(LabelStyle.Render(" select  "))
 	b.WriteString(KeyStyle.Render("esc"))
@@ -4,10 +4,13 @@ import (
 	"testing"
 	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/jonandersen/public-cli/internal/config"
 	"github.com/jonandersen/public-cli/internal/keyring"
+	"github.com/jonandersen/public-cli/internal/marketdate"
 )
 
 func testConfig() *config.Config {
@@ -37,6 +40,12 @@ func TestNew(t *testing.T) {
 	m := New(testConfig(), testUIConfig(), testStore())
 	assert.Equal(t, ViewPortfolio, m.currentView)
 	assert.Equal(t, PortfolioStateLoading, m.portfolio.State)
+	assert.Equal(t, 30*time.Second, m.refreshInterval)
+
+	cfg := testConfig()
+	cfg.RefreshIntervalSeconds = 90
+	m = New(cfg, testUIConfig(), testStore())
+	assert.Equal(t, 90*time.Second, m.refreshInterval)
 }
 
 func TestModelInit(t *testing.T) {
@@ -46,6 +55,46 @@ func TestModelInit(t *testing.T) {
 	assert.NotNil(t, cmd)
 }
 
+func TestParseView(t *testing.T) {
+	tests := []struct {
+		name string
+		want View
+	}{
+		{"portfolio", ViewPortfolio},
+		{"watchlist", ViewWatchlist},
+		{"orders", ViewOrders},
+		{"trade", ViewTrade},
+		{"options", ViewOptions},
+		{"History", ViewHistory},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseView(tt.name)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := ParseView("chart")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown view "chart"`)
+	assert.Contains(t, err.Error(), "portfolio, watchlist, orders, trade, options, history")
+}
+
+func TestModelWithView(t *testing.T) {
+	m := New(testConfig(), testUIConfig(), testStore()).WithView(ViewTrade)
+	assert.Equal(t, ViewTrade, m.currentView)
+	assert.NotNil(t, m.Init())
+	assert.Equal(t, TradeFieldSymbol, m.trade.FocusedField)
+	assert.True(t, m.trade.SymbolInput.Focused())
+
+	m = New(testConfig(), testUIConfig(), testStore()).WithView(ViewOptions)
+	m.width = 80
+	m.height = 24
+	m.ready = true
+	assert.Contains(t, m.View(), "Options Chain")
+}
+
 func TestModelView(t *testing.T) {
 	m := New(testConfig(), testUIConfig(), testStore())
 	m.width = 80
@@ -123,6 +172,27 @@ func TestModelViewWithPositions(t *testing.T) {
 	assert.Contains(t, view, "Positions")
 }
 
+func TestPortfolioMinPositionValue(t *testing.T) {
+	m := New(testConfig(), &UIConfig{MinPositionValue: 5}, testStore())
+	m.width = 120
+	m.height = 30
+	m.ready = true
+	m.portfolio.State = PortfolioStateLoaded
+	m.portfolio.Data = Portfolio{
+		Positions: []Position{
+			{Instrument: Instrument{Symbol: "AAPL"}, Quantity: "10", CurrentValue: "1500.00"},
+			{Instrument: Instrument{Symbol: "DUST"}, Quantity: "0.01", CurrentValue: "0.42"},
+		},
+	}
+	m.portfolio.updateTable()
+
+	assert.Len(t, m.portfolio.Table.Rows(), 1)
+	assert.Equal(t, 1, m.portfolio.Hidden)
+	view := m.View()
+	assert.Contains(t, view, "1 under $5.00 hidden")
+	assert.NotContains(t, view, "DUST")
+}
+
 func TestWatchlistViewEmpty(t *testing.T) {
 	m := New(testConfig(), testUIConfig(), testStore())
 	m.width = 80
@@ -239,6 +309,95 @@ func TestWatchlistFooterKeys(t *testing.T) {
 	assert.Contains(t, view, "delete")
 }
 
+func TestAccountPickerQuickSelect(t *testing.T) {
+	m := New(testConfig(), testUIConfig(), testStore())
+	m.width = 100
+	m.height = 24
+	m.ready = true
+	m.accounts = []Account{
+		{AccountID: "test-account-123", AccountType: "BROKERAGE"},
+		{AccountID: "ira-account-456", AccountType: "ROTH_IRA"},
+	}
+	m.selectedAccountID = "test-account-123"
+	m.accountPickerOpen = true
+
+	view := m.View()
+	assert.Contains(t, view, "1. ")
+	assert.Contains(t, view, "2. ")
+	assert.Contains(t, view, "quick select")
+
+	// Out-of-range number is ignored
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("3")})
+	m = updated.(Model)
+	assert.Nil(t, cmd)
+	assert.True(t, m.accountPickerOpen)
+	assert.Equal(t, "test-account-123", m.selectedAccountID)
+
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("2")})
+	m = updated.(Model)
+	assert.NotNil(t, cmd)
+	assert.False(t, m.accountPickerOpen)
+	assert.Equal(t, 1, m.accountCursor)
+	assert.Equal(t, "ira-account-456", m.selectedAccountID)
+	assert.Equal(t, "ira-account-456", m.cfg.AccountUUID)
+}
+
+func TestAccountPickerEnterSameAccount(t *testing.T) {
+	m := New(testConfig(), testUIConfig(), testStore())
+	m.accounts = []Account{{AccountID: "test-account-123"}}
+	m.selectedAccountID = "test-account-123"
+	m.accountPickerOpen = true
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	assert.Nil(t, cmd)
+	assert.False(t, m.accountPickerOpen)
+}
+
+func TestAccountsErrorRetriesOnTick(t *testing.T) {
+	m := New(testConfig(), testUIConfig(), testStore())
+	m.width = 140
+	m.height = 24
+	m.ready = true
+
+	updated, _ := m.Update(AccountsErrorMsg{Err: assert.AnError})
+	m = updated.(Model)
+	assert.Equal(t, assert.AnError, m.accountsErr)
+	assert.False(t, m.accountsLoading)
+	assert.Equal(t, "test-account-123", m.selectedAccountID, "default account stays usable")
+	assert.Contains(t, m.View(), "retry accounts")
+
+	updated, cmd := m.Update(TickMsg(time.Now()))
+	m = updated.(Model)
+	assert.NotNil(t, cmd)
+	assert.True(t, m.accountsLoading)
+	assert.Contains(t, m.View(), "retrying accounts")
+
+	updated, _ = m.Update(AccountsLoadedMsg{Accounts: []Account{{AccountID: "test-account-123"}}})
+	m = updated.(Model)
+	assert.NoError(t, m.accountsErr)
+	assert.False(t, m.accountsLoading)
+	assert.NotContains(t, m.View(), "retry")
+}
+
+func TestAccountsErrorManualRetry(t *testing.T) {
+	m := New(testConfig(), testUIConfig(), testStore())
+	m.accountsLoading = false
+	m.accountsErr = assert.AnError
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	m = updated.(Model)
+	assert.NotNil(t, cmd)
+	assert.True(t, m.accountsLoading)
+	assert.False(t, m.accountPickerOpen)
+
+	// A second press while the retry is in flight does nothing
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	m = updated.(Model)
+	assert.Nil(t, cmd)
+	assert.True(t, m.accountsLoading)
+}
+
 func TestNewLoadsWatchlist(t *testing.T) {
 	uiCfg := testUIConfigWithWatchlist()
 	m := New(testConfig(), uiCfg, testStore())
@@ -271,6 +430,46 @@ func TestUpdateWatchlistTable(t *testing.T) {
 	assert.Equal(t, "-", rows[1][1])
 }
 
+func TestUpdateWatchlistTable_ShowSession(t *testing.T) {
+	uiCfg := testUIConfig()
+	uiCfg.ShowQuoteSession = true
+	m := New(testConfig(), uiCfg, testStore())
+	m.watchlist.Symbols = []string{"AAPL", "MSFT", "TSLA"}
+	// Wednesday 2025-01-15, 17:30 Eastern.
+	m.watchlist.LastUpdated = time.Date(2025, 1, 15, 22, 30, 0, 0, time.UTC)
+	m.watchlist.Quotes = map[string]Quote{
+		"AAPL": {Outcome: "SUCCESS", Last: "150.00", LastTimestamp: "2025-01-15T22:15:00Z"},
+		"MSFT": {Outcome: "SUCCESS", Last: "410.00", LastTimestamp: "2025-01-14T20:59:00Z"},
+	}
+	m.watchlist.updateTable()
+
+	cols := m.watchlist.Table.Columns()
+	assert.Equal(t, "Session", cols[len(cols)-1].Title)
+	rows := m.watchlist.Table.Rows()
+	require.Len(t, rows, 3)
+	assert.Equal(t, "post 17:15", rows[0][5])
+	assert.Equal(t, "stale 01-14 15:59", rows[1][5])
+	assert.Equal(t, "-", rows[2][5])
+}
+
+func TestUpdateWatchlistTable_Halted(t *testing.T) {
+	m := New(testConfig(), testUIConfig(), testStore())
+	m.watchlist.Symbols = []string{"AAPL", "XYZ"}
+	m.watchlist.Quotes = map[string]Quote{
+		"AAPL": {Outcome: "SUCCESS", Last: "150.00", Bid: "149.95", Ask: "150.05"},
+		"XYZ":  {Outcome: "SUCCESS", Status: "HALTED", Last: "12.40"},
+	}
+	m.watchlist.updateTable()
+
+	rows := m.watchlist.Table.Rows()
+	require.Len(t, rows, 2)
+	assert.Equal(t, "$149.95", rows[0][2])
+	assert.Equal(t, "XYZ", rows[1][0])
+	assert.Equal(t, "$12.40", rows[1][1])
+	assert.Equal(t, "HALTED", rows[1][2])
+	assert.Equal(t, "HALTED", rows[1][3])
+}
+
 func TestPortfolioModel(t *testing.T) {
 	pm := NewPortfolioModel()
 	assert.Equal(t, PortfolioStateLoading, pm.State)
@@ -402,6 +601,80 @@ func TestTradeModelIsTextFieldFocused(t *testing.T) {
 	assert.True(t, tm.IsTextFieldFocused())
 }
 
+func TestTradeModelSetOrder(t *testing.T) {
+	tm := NewTradeModel()
+	tm.QuantityInput.SetValue("99")
+	tm.SetOrder(TradeOrderParams{
+		Symbol:     "msft",
+		Side:       TradeSideSell,
+		OrderType:  TradeOrderTypeLimit,
+		LimitPrice: "410.50",
+	})
+
+	assert.Equal(t, "MSFT", tm.SymbolInput.Value())
+	assert.Equal(t, TradeSideSell, tm.Side)
+	assert.Equal(t, TradeOrderTypeLimit, tm.OrderType)
+	assert.Equal(t, "410.50", tm.LimitPriceInput.Value())
+	assert.Empty(t, tm.QuantityInput.Value(), "quantity is re-entered, not copied")
+	assert.Equal(t, TradeFieldQuantity, tm.FocusedField)
+}
+
+func TestTradeParamsFromOrder(t *testing.T) {
+	limit := tradeParamsFromOrder(Order{
+		Instrument: Instrument{Symbol: "AAPL"},
+		Side:       "SELL",
+		Type:       "STOP_LIMIT",
+		LimitPrice: "180.00",
+		StopPrice:  "181.00",
+	})
+	assert.Equal(t, "AAPL", limit.Symbol)
+	assert.Equal(t, TradeSideSell, limit.Side)
+	assert.Equal(t, TradeOrderTypeLimit, limit.OrderType)
+	assert.Equal(t, "180.00", limit.LimitPrice)
+
+	stop := tradeParamsFromOrder(Order{Instrument: Instrument{Symbol: "AAPL"}, Side: "BUY", Type: "STOP", StopPrice: "170.00"})
+	assert.Equal(t, TradeSideBuy, stop.Side)
+	assert.Equal(t, TradeOrderTypeMarket, stop.OrderType)
+	assert.Empty(t, stop.LimitPrice)
+}
+
+func TestOrdersNewAndDuplicateKeys(t *testing.T) {
+	m := New(testConfig(), testUIConfig(), testStore())
+	m.currentView = ViewOrders
+	m.orders.State = OrdersStateLoaded
+	m.orders.Orders = []Order{{
+		OrderID:    "order-1",
+		Instrument: Instrument{Symbol: "TSLA", Type: "EQUITY"},
+		Side:       "SELL",
+		Type:       "LIMIT",
+		Status:     "NEW",
+		Quantity:   "5",
+		LimitPrice: "250.00",
+	}}
+	m.orders.updateTable()
+
+	// d pre-fills the trade form from the selected order and fetches a quote
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	m = updated.(Model)
+	assert.NotNil(t, cmd)
+	assert.Equal(t, ViewTrade, m.currentView)
+	assert.Equal(t, OrdersModeNormal, m.orders.Mode, "d no longer opens the cancel prompt")
+	assert.Equal(t, "TSLA", m.trade.SymbolInput.Value())
+	assert.Equal(t, TradeSideSell, m.trade.Side)
+	assert.Equal(t, TradeOrderTypeLimit, m.trade.OrderType)
+	assert.Equal(t, "250.00", m.trade.LimitPriceInput.Value())
+
+	// n opens a blank trade form
+	m.currentView = ViewOrders
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	m = updated.(Model)
+	assert.Equal(t, ViewTrade, m.currentView)
+	assert.Empty(t, m.trade.SymbolInput.Value())
+	assert.Equal(t, TradeSideBuy, m.trade.Side)
+	assert.Equal(t, TradeOrderTypeMarket, m.trade.OrderType)
+	assert.Equal(t, TradeFieldSymbol, m.trade.FocusedField)
+}
+
 func TestTradeModelFocusSymbol(t *testing.T) {
 	tm := NewTradeModel()
 	// Move focus away from symbol
@@ -508,11 +781,11 @@ func TestOptionsModelSetHeight(t *testing.T) {
 }
 
 func TestOptionsModelHelpers(t *testing.T) {
-	// Test calculateDTE - use a date 30 days in the future
-	// DTE rounds down based on hours, so it may be 28-30
-	futureDate := time.Now().AddDate(0, 0, 30).Format("2006-01-02")
-	dte := calculateDTE(futureDate)
-	assert.True(t, dte >= 28 && dte <= 30, "DTE should be approximately 30 days")
+	// Test calculateDTE - counts whole market (US/Eastern) dates, so a date
+	// 30 days out is exactly 30 regardless of the time of day
+	futureDate := marketdate.Today(time.Now()).AddDate(0, 0, 30).Format("2006-01-02")
+	assert.Equal(t, 30, calculateDTE(futureDate))
+	assert.Equal(t, 0, calculateDTE("bogus"))
 
 	// Test formatOptPrice
 	assert.Equal(t, "1.50", formatOptPrice("1.5"))
@@ -520,12 +793,12 @@ func TestOptionsModelHelpers(t *testing.T) {
 	assert.Equal(t, "-", formatOptPrice("0"))
 
 	// Test formatGreek
-	assert.Equal(t, "0.50", formatGreek("0.5"))
-	assert.Equal(t, "-", formatGreek(""))
+	assert.Equal(t, "0.50", formatGreek("0.5", 2))
+	assert.Equal(t, "-", formatGreek("", 2))
 
 	// Test formatIV
-	assert.Equal(t, "50.0%", formatIV("0.5"))
-	assert.Equal(t, "-", formatIV(""))
+	assert.Equal(t, "50.0%", formatIV("0.5", 2))
+	assert.Equal(t, "-", formatIV("", 2))
 
 	// Test parseStrikeFromOSI
 	strike := parseStrikeFromOSI("AAPL260117C00185000")
@@ -542,3 +815,47 @@ func TestOptionsViewSwitch(t *testing.T) {
 	view := m.View()
 	assert.Contains(t, view, "Options")
 }
+
+func TestModelOptionTradeMsgOpensTradeView(t *testing.T) {
+	m := New(testConfig(), testUIConfig(), testStore())
+	m.currentView = ViewOptions
+
+	updated, cmd := m.Update(OptionTradeMsg{Symbol: "AAPL250117C00175000", LimitPrice: "3.20"})
+	model := updated.(Model)
+
+	assert.Equal(t, ViewTrade, model.currentView)
+	assert.NotNil(t, cmd)
+	assert.Equal(t, "AAPL250117C00175000", model.trade.SymbolInput.Value())
+	assert.Equal(t, "3.20", model.trade.LimitPriceInput.Value())
+	assert.Equal(t, TradeOrderTypeLimit, model.trade.OrderType)
+	assert.True(t, model.trade.IsOption())
+}
+
+func TestWatchlistMoveSelected(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	uiCfg := &UIConfig{Watchlist: []string{"AAPL", "GOOGL", "MSFT"}}
+	m := New(testConfig(), uiCfg, testStore())
+	m.ready = true
+	m.currentView = ViewWatchlist
+	m.watchlist.State = WatchlistStateLoaded
+	m.watchlist.updateTable()
+	m.watchlist.Table.SetCursor(2)
+
+	var cmd tea.Cmd
+	m.watchlist, cmd, _ = m.watchlist.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'K'}}, uiCfg)
+	require.NotNil(t, cmd)
+	assert.Equal(t, []string{"AAPL", "MSFT", "GOOGL"}, m.watchlist.Symbols)
+	assert.Equal(t, "MSFT", m.watchlist.SelectedSymbol())
+
+	assert.IsType(t, WatchlistSavedMsg{}, cmd())
+	saved, err := LoadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"AAPL", "MSFT", "GOOGL"}, saved.Watchlist)
+
+	// Already at the bottom after moving down: nothing to save
+	m.watchlist, _, _ = m.watchlist.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'J'}}, uiCfg)
+	_, cmd, _ = m.watchlist.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'J'}}, uiCfg)
+	assert.Nil(t, cmd)
+	assert.Equal(t, []string{"AAPL", "GOOGL", "MSFT"}, m.watchlist.Symbols)
+}
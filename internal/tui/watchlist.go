@@ -1,11 +1,8 @@
 package tui
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
 	"strings"
 	"time"
 
@@ -16,6 +13,7 @@ import (
 	"github.com/jonandersen/public-cli/internal/api"
 	"github.com/jonandersen/public-cli/internal/config"
 	"github.com/jonandersen/public-cli/internal/keyring"
+	"github.com/jonandersen/public-cli/internal/marketdate"
 	"github.com/jonandersen/public-cli/pkg/publicapi"
 )
 
@@ -48,10 +46,12 @@ type WatchlistModel struct {
 	Mode         WatchlistMode
 	AddInput     textinput.Model
 	DeleteSymbol string
+	ShowSession  bool // adds a column with each quote's trading session
 }
 
-// NewWatchlistModel creates a new watchlist model.
-func NewWatchlistModel(symbols []string) *WatchlistModel {
+// watchlistColumns returns the watchlist table columns, with the session
+// column when showSession is set.
+func watchlistColumns(showSession bool) []table.Column {
 	cols := []table.Column{
 		{Title: "Symbol", Width: 10},
 		{Title: "Last", Width: 12},
@@ -59,9 +59,16 @@ func NewWatchlistModel(symbols []string) *WatchlistModel {
 		{Title: "Ask", Width: 10},
 		{Title: "Volume", Width: 14},
 	}
+	if showSession {
+		cols = append(cols, table.Column{Title: "Session", Width: 18})
+	}
+	return cols
+}
 
+// NewWatchlistModel creates a new watchlist model.
+func NewWatchlistModel(symbols []string) *WatchlistModel {
 	t := table.New(
-		table.WithColumns(cols),
+		table.WithColumns(watchlistColumns(false)),
 		table.WithFocused(true),
 		table.WithHeight(10),
 	)
@@ -193,6 +200,10 @@ func (m *WatchlistModel) Update(msg tea.Msg, uiCfg *UIConfig) (*WatchlistModel,
 					}
 				}
 				return m, nil, true
+			case "K", "shift+up":
+				return m, m.moveSelected(-1, uiCfg), true
+			case "J", "shift+down":
+				return m, m.moveSelected(1, uiCfg), true
 			}
 		}
 	}
@@ -206,32 +217,95 @@ func (m *WatchlistModel) Update(msg tea.Msg, uiCfg *UIConfig) (*WatchlistModel,
 	return m, nil, false
 }
 
+// moveSelected moves the selected symbol delta rows, keeps it selected, and
+// saves the new order. It does nothing at either end of the list.
+func (m *WatchlistModel) moveSelected(delta int, uiCfg *UIConfig) tea.Cmd {
+	symbol := m.SelectedSymbol()
+	if symbol == "" {
+		return nil
+	}
+	moved, to, err := MoveSymbol(m.Symbols, symbol, delta)
+	if err != nil || to == m.Table.Cursor() {
+		return nil
+	}
+	m.Symbols = moved
+	m.updateTable()
+	m.Table.SetCursor(to)
+	return m.saveWatchlist(uiCfg)
+}
+
 // updateTable updates the table rows from watchlist data.
 func (m *WatchlistModel) updateTable() {
 	rows := make([]table.Row, 0, len(m.Symbols))
 	for _, sym := range m.Symbols {
 		quote, hasQuote := m.Quotes[sym]
-		if hasQuote && quote.Outcome == "SUCCESS" {
-			rows = append(rows, table.Row{
+		var row table.Row
+		if hasQuote && api.IsHalted(quote) {
+			// There is no live market during a halt, so the bid and ask
+			// columns carry the badge instead.
+			row = table.Row{
+				sym,
+				"$" + quote.Last,
+				"HALTED",
+				"HALTED",
+				publicapi.FormatVolume(quote.Volume),
+			}
+			if m.ShowSession {
+				row = append(row, quoteSessionLabel(quote, m.LastUpdated))
+			}
+		} else if hasQuote && quote.Outcome == "SUCCESS" {
+			row = table.Row{
 				sym,
 				"$" + quote.Last,
 				"$" + quote.Bid,
 				"$" + quote.Ask,
 				publicapi.FormatVolume(quote.Volume),
-			})
+			}
+			if m.ShowSession {
+				row = append(row, quoteSessionLabel(quote, m.LastUpdated))
+			}
 		} else {
-			rows = append(rows, table.Row{
+			row = table.Row{
 				sym,
 				"-",
 				"-",
 				"-",
 				"-",
-			})
+			}
+			if m.ShowSession {
+				row = append(row, "-")
+			}
 		}
+		rows = append(rows, row)
 	}
 	m.Table.SetRows(rows)
 }
 
+// SetShowSession toggles the session column and rebuilds the table.
+func (m *WatchlistModel) SetShowSession(show bool) {
+	m.ShowSession = show
+	// Clear rows first: the table renders every column of every row, so
+	// rows must never be shorter than the column set.
+	m.Table.SetRows(nil)
+	m.Table.SetColumns(watchlistColumns(show))
+	m.updateTable()
+}
+
+// quoteSessionLabel describes when q last traded relative to now: the
+// session and Eastern time, marked stale when the trade is from an earlier
+// market date.
+func quoteSessionLabel(q Quote, now time.Time) string {
+	ts, err := time.Parse(time.RFC3339, q.LastTimestamp)
+	if err != nil {
+		return "-"
+	}
+	et := ts.In(marketdate.Eastern)
+	if marketdate.Today(ts).Before(marketdate.Today(now)) {
+		return "stale " + et.Format("01-02 15:04")
+	}
+	return fmt.Sprintf("%s %s", marketdate.SessionAt(ts), et.Format("15:04"))
+}
+
 // View renders the watchlist view.
 func (m *WatchlistModel) View() string {
 	var b strings.Builder
@@ -298,7 +372,7 @@ func (m *WatchlistModel) SelectedSymbol() string {
 // saveWatchlist returns a command to save the watchlist config.
 func (m *WatchlistModel) saveWatchlist(uiCfg *UIConfig) tea.Cmd {
 	return func() tea.Msg {
-		uiCfg.Watchlist = m.Symbols
+		uiCfg.SetWatchlist(m.Symbols)
 		if err := SaveConfig(uiCfg); err != nil {
 			return WatchlistErrorMsg{Err: fmt.Errorf("failed to save watchlist: %w", err)}
 		}
@@ -325,42 +399,10 @@ func FetchWatchlistQuotes(symbols []string, cfg *config.Config, store keyring.St
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 
-		// Build request
-		instruments := make([]QuoteInstrument, 0, len(symbols))
-		for _, sym := range symbols {
-			instruments = append(instruments, QuoteInstrument{
-				Symbol: strings.ToUpper(sym),
-				Type:   "EQUITY",
-			})
-		}
-
-		reqBody := QuoteRequest{Instruments: instruments}
-		body, err := json.Marshal(reqBody)
-		if err != nil {
-			return WatchlistErrorMsg{Err: fmt.Errorf("failed to encode request: %w", err)}
-		}
-
 		client := api.NewClient(cfg.APIBaseURL, token)
-		path := fmt.Sprintf("/userapigateway/marketdata/%s/quotes", cfg.AccountUUID)
-		resp, err := client.Post(ctx, path, bytes.NewReader(body))
+		quotes, err := client.QuoteSymbols(ctx, cfg.AccountUUID, symbols)
 		if err != nil {
-			return WatchlistErrorMsg{Err: fmt.Errorf("failed to fetch quotes: %w", err)}
-		}
-		defer func() { _ = resp.Body.Close() }()
-
-		if resp.StatusCode != 200 {
-			respBody, _ := io.ReadAll(resp.Body)
-			return WatchlistErrorMsg{Err: fmt.Errorf("API error: %d - %s", resp.StatusCode, string(respBody))}
-		}
-
-		var quotesResp QuotesResponse
-		if err := json.NewDecoder(resp.Body).Decode(&quotesResp); err != nil {
-			return WatchlistErrorMsg{Err: fmt.Errorf("failed to decode response: %w", err)}
-		}
-
-		quotes := make(map[string]Quote)
-		for _, q := range quotesResp.Quotes {
-			quotes[q.Instrument.Symbol] = q
+			return WatchlistErrorMsg{Err: err}
 		}
 
 		return WatchlistQuotesMsg{Quotes: quotes}
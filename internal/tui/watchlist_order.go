@@ -0,0 +1,71 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Watchlist sort orders accepted by SortWatchlist.
+const (
+	WatchlistSortAlpha = "alpha"
+	WatchlistSortAdded = "added"
+)
+
+// SetWatchlist replaces the watchlist with symbols and keeps WatchlistAdded in
+// step: symbols new to the list are appended to it and removed ones dropped.
+// A config written before WatchlistAdded existed is seeded from the current
+// watchlist order first.
+func (c *UIConfig) SetWatchlist(symbols []string) {
+	added := c.WatchlistAdded
+	if len(added) == 0 {
+		added = c.Watchlist
+	}
+
+	var kept []string
+	for _, s := range added {
+		if slices.Contains(symbols, s) && !slices.Contains(kept, s) {
+			kept = append(kept, s)
+		}
+	}
+	for _, s := range symbols {
+		if !slices.Contains(kept, s) {
+			kept = append(kept, s)
+		}
+	}
+
+	c.Watchlist = slices.Clone(symbols)
+	c.WatchlistAdded = kept
+}
+
+// SortWatchlist reorders the watchlist alphabetically (alpha) or back into the
+// order symbols were added (added).
+func (c *UIConfig) SortWatchlist(by string) error {
+	switch by {
+	case WatchlistSortAlpha:
+		sorted := slices.Clone(c.Watchlist)
+		slices.Sort(sorted)
+		c.SetWatchlist(sorted)
+	case WatchlistSortAdded:
+		// SetWatchlist seeds and prunes the added order; applying it again
+		// makes it the watchlist order
+		c.SetWatchlist(c.Watchlist)
+		c.Watchlist = slices.Clone(c.WatchlistAdded)
+	default:
+		return fmt.Errorf("invalid sort order %q: use %s or %s", by, WatchlistSortAlpha, WatchlistSortAdded)
+	}
+	return nil
+}
+
+// MoveSymbol returns a copy of symbols with symbol moved delta places (negative
+// is up), stopping at either end, and its new index.
+func MoveSymbol(symbols []string, symbol string, delta int) ([]string, int, error) {
+	from := slices.Index(symbols, symbol)
+	if from < 0 {
+		return nil, 0, fmt.Errorf("%s is not in the watchlist", symbol)
+	}
+	to := max(0, min(len(symbols)-1, from+delta))
+
+	moved := slices.Delete(slices.Clone(symbols), from, from+1)
+	moved = slices.Insert(moved, to, symbol)
+	return moved, to, nil
+}
@@ -0,0 +1,85 @@
+package tui
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetWatchlist_TracksAddedOrder(t *testing.T) {
+	cfg := &UIConfig{Watchlist: []string{"MSFT", "AAPL"}}
+
+	// Seeded from the existing order, new symbols appended
+	cfg.SetWatchlist([]string{"AAPL", "MSFT", "TSLA"})
+	assert.Equal(t, []string{"AAPL", "MSFT", "TSLA"}, cfg.Watchlist)
+	assert.Equal(t, []string{"MSFT", "AAPL", "TSLA"}, cfg.WatchlistAdded)
+
+	// Removed symbols drop out; re-adding goes to the end
+	cfg.SetWatchlist([]string{"TSLA", "AAPL"})
+	cfg.SetWatchlist([]string{"TSLA", "AAPL", "MSFT"})
+	assert.Equal(t, []string{"AAPL", "TSLA", "MSFT"}, cfg.WatchlistAdded)
+}
+
+func TestSortWatchlist(t *testing.T) {
+	cfg := &UIConfig{}
+	cfg.SetWatchlist([]string{"TSLA", "AAPL", "NVDA"})
+
+	require.NoError(t, cfg.SortWatchlist(WatchlistSortAlpha))
+	assert.Equal(t, []string{"AAPL", "NVDA", "TSLA"}, cfg.Watchlist)
+
+	require.NoError(t, cfg.SortWatchlist(WatchlistSortAdded))
+	assert.Equal(t, []string{"TSLA", "AAPL", "NVDA"}, cfg.Watchlist)
+
+	err := cfg.SortWatchlist("price")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid sort order "price"`)
+}
+
+func TestSortWatchlist_AddedWithoutHistory(t *testing.T) {
+	// Configs written before WatchlistAdded keep their current order
+	cfg := &UIConfig{Watchlist: []string{"TSLA", "AAPL"}}
+
+	require.NoError(t, cfg.SortWatchlist(WatchlistSortAdded))
+	assert.Equal(t, []string{"TSLA", "AAPL"}, cfg.Watchlist)
+}
+
+func TestMoveSymbol(t *testing.T) {
+	symbols := []string{"AAPL", "MSFT", "TSLA", "NVDA"}
+
+	moved, to, err := MoveSymbol(symbols, "TSLA", -1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"AAPL", "TSLA", "MSFT", "NVDA"}, moved)
+	assert.Equal(t, 1, to)
+	assert.Equal(t, []string{"AAPL", "MSFT", "TSLA", "NVDA"}, symbols, "input is not modified")
+
+	moved, to, err = MoveSymbol(symbols, "MSFT", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"AAPL", "TSLA", "NVDA", "MSFT"}, moved)
+	assert.Equal(t, 3, to)
+
+	moved, to, err = MoveSymbol(symbols, "AAPL", -1)
+	require.NoError(t, err)
+	assert.Equal(t, symbols, moved)
+	assert.Equal(t, 0, to)
+
+	_, _, err = MoveSymbol(symbols, "GME", 1)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "GME is not in the watchlist")
+}
+
+func TestConfigFromPath_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "ui.yaml")
+
+	cfg, err := LoadConfigFrom(path)
+	require.NoError(t, err)
+	assert.Empty(t, cfg.Watchlist)
+
+	cfg.SetWatchlist([]string{"AAPL", "MSFT"})
+	require.NoError(t, SaveConfigTo(path, cfg))
+
+	loaded, err := LoadConfigFrom(path)
+	require.NoError(t, err)
+	assert.Equal(t, cfg, loaded)
+}
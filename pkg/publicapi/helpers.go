@@ -3,6 +3,8 @@ package publicapi
 
 import (
 	"fmt"
+	"math"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -54,3 +56,74 @@ func FormatVolume(vol int64) string {
 
 	return result.String()
 }
+
+// FilterPositionsByValue drops positions whose absolute current value is
+// below minValue and reports how many were dropped. Positions with an
+// unparseable value are kept. A minValue of zero or less keeps everything.
+func FilterPositionsByValue(positions []Position, minValue float64) ([]Position, int) {
+	if minValue <= 0 {
+		return positions, 0
+	}
+
+	visible := make([]Position, 0, len(positions))
+	for _, pos := range positions {
+		value, err := strconv.ParseFloat(pos.CurrentValue, 64)
+		if err == nil && math.Abs(value) < minValue {
+			continue
+		}
+		visible = append(visible, pos)
+	}
+	return visible, len(positions) - len(visible)
+}
+
+// PositionSortKeys lists the keys accepted by SortPositions.
+var PositionSortKeys = []string{"symbol", "value", "day-pct", "total-pct", "quantity"}
+
+// SortPositions sorts positions in place by one of PositionSortKeys. Numeric
+// keys parse the API's string fields; positions with an empty or unparseable
+// value sort last in either direction. Ties keep their original order, and an
+// empty key leaves the API order unchanged. The TUI has no sort yet; it
+// lives here so one can reuse it.
+func SortPositions(positions []Position, key string, desc bool) error {
+	if key == "" {
+		return nil
+	}
+
+	var field func(Position) string
+	switch key {
+	case "symbol":
+		sort.SliceStable(positions, func(i, j int) bool {
+			a := strings.ToUpper(positions[i].Instrument.Symbol)
+			b := strings.ToUpper(positions[j].Instrument.Symbol)
+			if desc {
+				return a > b
+			}
+			return a < b
+		})
+		return nil
+	case "value":
+		field = func(p Position) string { return p.CurrentValue }
+	case "day-pct":
+		field = func(p Position) string { return p.PositionDailyGain.GainPercentage }
+	case "total-pct":
+		field = func(p Position) string { return p.CostBasis.GainPercentage }
+	case "quantity":
+		field = func(p Position) string { return p.Quantity }
+	default:
+		return fmt.Errorf("invalid sort key %q: must be one of %s", key, strings.Join(PositionSortKeys, ", "))
+	}
+
+	sort.SliceStable(positions, func(i, j int) bool {
+		a, errA := strconv.ParseFloat(field(positions[i]), 64)
+		b, errB := strconv.ParseFloat(field(positions[j]), 64)
+		switch {
+		case errA != nil || errB != nil:
+			return errA == nil && errB != nil
+		case desc:
+			return a > b
+		default:
+			return a < b
+		}
+	})
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestFormatGainLoss(t *testing.T) {
@@ -107,3 +108,96 @@ func TestFormatVolume(t *testing.T) {
 		})
 	}
 }
+
+func TestFilterPositionsByValue(t *testing.T) {
+	positions := []Position{
+		{Instrument: Instrument{Symbol: "AAPL"}, CurrentValue: "1750.00"},
+		{Instrument: Instrument{Symbol: "DUST"}, CurrentValue: "0.42"},
+		{Instrument: Instrument{Symbol: "SHORT"}, CurrentValue: "-3.00"},
+		{Instrument: Instrument{Symbol: "BIGSHORT"}, CurrentValue: "-500.00"},
+		{Instrument: Instrument{Symbol: "ODD"}, CurrentValue: ""},
+	}
+
+	visible, hidden := FilterPositionsByValue(positions, 5)
+	assert.Equal(t, 2, hidden)
+	var symbols []string
+	for _, p := range visible {
+		symbols = append(symbols, p.Instrument.Symbol)
+	}
+	assert.Equal(t, []string{"AAPL", "BIGSHORT", "ODD"}, symbols)
+
+	visible, hidden = FilterPositionsByValue(positions, 0)
+	assert.Equal(t, 0, hidden)
+	assert.Len(t, visible, len(positions))
+}
+
+func TestSortPositions(t *testing.T) {
+	base := []Position{
+		{
+			Instrument:        Instrument{Symbol: "msft"},
+			Quantity:          "5",
+			CurrentValue:      "2000.00",
+			PositionDailyGain: Gain{GainPercentage: "-1.50"},
+			CostBasis:         CostBasis{GainPercentage: "12.00"},
+		},
+		{
+			Instrument:        Instrument{Symbol: "AAPL"},
+			Quantity:          "10",
+			CurrentValue:      "1750.00",
+			PositionDailyGain: Gain{GainPercentage: "0.75"},
+			CostBasis:         CostBasis{GainPercentage: "0"},
+		},
+		{
+			Instrument:        Instrument{Symbol: "ZERO"},
+			Quantity:          "0",
+			CurrentValue:      "0",
+			PositionDailyGain: Gain{GainPercentage: "0"},
+			CostBasis:         CostBasis{GainPercentage: "-20.00"},
+		},
+		{
+			Instrument: Instrument{Symbol: "EMPTY"},
+		},
+	}
+
+	symbols := func(positions []Position) []string {
+		var out []string
+		for _, p := range positions {
+			out = append(out, p.Instrument.Symbol)
+		}
+		return out
+	}
+
+	tests := []struct {
+		key  string
+		desc bool
+		want []string
+	}{
+		{"", false, []string{"msft", "AAPL", "ZERO", "EMPTY"}},
+		{"symbol", false, []string{"AAPL", "EMPTY", "msft", "ZERO"}},
+		{"symbol", true, []string{"ZERO", "msft", "EMPTY", "AAPL"}},
+		{"value", false, []string{"ZERO", "AAPL", "msft", "EMPTY"}},
+		{"value", true, []string{"msft", "AAPL", "ZERO", "EMPTY"}},
+		{"day-pct", false, []string{"msft", "ZERO", "AAPL", "EMPTY"}},
+		{"day-pct", true, []string{"AAPL", "ZERO", "msft", "EMPTY"}},
+		{"total-pct", false, []string{"ZERO", "AAPL", "msft", "EMPTY"}},
+		{"total-pct", true, []string{"msft", "AAPL", "ZERO", "EMPTY"}},
+		{"quantity", false, []string{"ZERO", "msft", "AAPL", "EMPTY"}},
+		{"quantity", true, []string{"AAPL", "msft", "ZERO", "EMPTY"}},
+	}
+
+	for _, tt := range tests {
+		name := tt.key
+		if tt.desc {
+			name += " desc"
+		}
+		t.Run(name, func(t *testing.T) {
+			positions := append([]Position(nil), base...)
+			require.NoError(t, SortPositions(positions, tt.key, tt.desc))
+			assert.Equal(t, tt.want, symbols(positions))
+		})
+	}
+
+	err := SortPositions(append([]Position(nil), base...), "price", false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid sort key "price"`)
+}
@@ -110,6 +110,7 @@ type QuotesResponse struct {
 type Quote struct {
 	Instrument    QuoteInstrument `json:"instrument"`
 	Outcome       string          `json:"outcome"`
+	Status        string          `json:"status,omitempty"` // trading status when reported, e.g. HALTED
 	Last          string          `json:"last"`
 	LastTimestamp string          `json:"lastTimestamp"`
 	Bid           string          `json:"bid"`